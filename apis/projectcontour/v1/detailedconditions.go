@@ -144,12 +144,21 @@ const (
 	// ConditionTypeCORSError describes an error condition related to CORS.
 	ConditionTypeCORSError = "CORSError"
 
+	// ConditionTypeExternalProcessingError describes an error condition related to external processing.
+	ConditionTypeExternalProcessingError = "ExternalProcessingError"
+
 	// ConditionTypeIPFilterError describes an error condition related to IP filters.
 	ConditionTypeIPFilterError = "IPFilterError"
 
 	// ConditionTypeJWTVerificationError describes an error condition related to JWT verification.
 	ConditionTypeJWTVerificationError = "JWTVerificationError"
 
+	// ConditionTypeOAuth2Error describes an error condition related to OAuth2.
+	ConditionTypeOAuth2Error = "OAuth2Error"
+
+	// ConditionTypeBasicAuthError describes an error condition related to basic authentication.
+	ConditionTypeBasicAuthError = "BasicAuthError"
+
 	// ConditionTypeIncludeError describes an error condition with
 	// inclusion of another HTTPProxy resource.
 	ConditionTypeIncludeError = "IncludeError"
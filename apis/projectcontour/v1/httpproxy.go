@@ -311,6 +311,116 @@ type AuthorizationPolicy struct {
 	Context map[string]string `json:"context,omitempty"`
 }
 
+// ExternalProcessing configures an external processing server that
+// can inspect and modify client requests and upstream responses.
+type ExternalProcessing struct {
+	// ExtensionServiceRef specifies the extension resource that implements
+	// the Envoy external processing gRPC API.
+	//
+	// +optional
+	ExtensionServiceRef ExtensionServiceReference `json:"extensionRef,omitempty"`
+
+	// ResponseTimeout configures maximum time to wait for each message
+	// from the external processing server.
+	// Timeout durations are expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// The string "infinity" is also a valid input and specifies no timeout.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+|infinity|infinite)$`
+	ResponseTimeout string `json:"responseTimeout,omitempty"`
+
+	// If FailOpen is true, the client request is forwarded to the upstream
+	// service, and the response returned to the client, even if the external
+	// processing server fails to respond.
+	//
+	// +optional
+	FailOpen bool `json:"failOpen,omitempty"`
+
+	// ProcessingMode sets which parts of requests and responses
+	// are sent to the external processing server. This mode will
+	// be used unless overridden by individual routes.
+	//
+	// +optional
+	ProcessingMode *ProcessingMode `json:"processingMode,omitempty"`
+}
+
+// HeaderSendMode defines how headers are sent to the external processing server.
+// +kubebuilder:validation:Enum=Default;Send;Skip
+type HeaderSendMode string
+
+const (
+	// Use the default behavior of the external processing filter,
+	// which sends headers.
+	HeaderSendModeDefault HeaderSendMode = "Default"
+	// Send headers to the external processing server.
+	HeaderSendModeSend HeaderSendMode = "Send"
+	// Do not send headers to the external processing server.
+	HeaderSendModeSkip HeaderSendMode = "Skip"
+)
+
+// BodySendMode defines how bodies are sent to the external processing server.
+// +kubebuilder:validation:Enum=None;Streamed;Buffered;BufferedPartial
+type BodySendMode string
+
+const (
+	// Do not send the body to the external processing server.
+	BodySendModeNone BodySendMode = "None"
+	// Stream the body to the external processing server in chunks
+	// as it arrives.
+	BodySendModeStreamed BodySendMode = "Streamed"
+	// Buffer the whole body and send it to the external processing
+	// server in a single message.
+	BodySendModeBuffered BodySendMode = "Buffered"
+	// Buffer the body up to the buffer limit and send what was
+	// buffered to the external processing server.
+	BodySendModeBufferedPartial BodySendMode = "BufferedPartial"
+)
+
+// ProcessingMode sets which parts of requests and responses are
+// sent to the external processing server.
+type ProcessingMode struct {
+	// RequestHeaderMode sets how request headers are sent.
+	// Defaults to Default.
+	//
+	// +optional
+	RequestHeaderMode HeaderSendMode `json:"requestHeaderMode,omitempty"`
+
+	// ResponseHeaderMode sets how response headers are sent.
+	// Defaults to Default.
+	//
+	// +optional
+	ResponseHeaderMode HeaderSendMode `json:"responseHeaderMode,omitempty"`
+
+	// RequestBodyMode sets how request bodies are sent.
+	// Defaults to None.
+	//
+	// +optional
+	RequestBodyMode BodySendMode `json:"requestBodyMode,omitempty"`
+
+	// ResponseBodyMode sets how response bodies are sent.
+	// Defaults to None.
+	//
+	// +optional
+	ResponseBodyMode BodySendMode `json:"responseBodyMode,omitempty"`
+}
+
+// ExternalProcessingPolicy modifies how client requests and upstream
+// responses are sent to the external processing server.
+type ExternalProcessingPolicy struct {
+	// When true, this field disables external processing
+	// for the scope of the policy.
+	//
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ProcessingMode overrides the processing mode set on the
+	// virtual host for the scope of the policy.
+	//
+	// +optional
+	ProcessingMode *ProcessingMode `json:"processingMode,omitempty"`
+}
+
 // VirtualHost appears at most once. If it is present, the object is considered
 // to be a "root".
 type VirtualHost struct {
@@ -337,6 +447,13 @@ type VirtualHost struct {
 	//
 	// +optional
 	Authorization *AuthorizationServer `json:"authorization,omitempty"`
+	// This field configures an extension service that client requests
+	// and upstream responses for this virtual host are sent to for
+	// external processing. External processing can only be configured
+	// on virtual hosts that have TLS enabled.
+	//
+	// +optional
+	ExternalProcessing *ExternalProcessing `json:"externalProcessing,omitempty"`
 	// Specifies the cross-origin policy to apply to the VirtualHost.
 	// +optional
 	CORSPolicy *CORSPolicy `json:"corsPolicy,omitempty"`
@@ -347,6 +464,19 @@ type VirtualHost struct {
 	// +optional
 	JWTProviders []JWTProvider `json:"jwtProviders,omitempty"`
 
+	// OAuth2 configures an OAuth2 authorization code flow that logs
+	// browser users in with an external authorization server. OAuth2
+	// can only be configured on virtual hosts that have TLS enabled.
+	// +optional
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+
+	// BasicAuth configures HTTP basic authentication for requests to
+	// this virtual host, using the users listed in an htpasswd Secret.
+	// BasicAuth can only be configured on virtual hosts that have TLS
+	// enabled.
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+
 	// IPAllowFilterPolicy is a list of ipv4/6 filter rules for which matching
 	// requests should be allowed. All other requests will be denied.
 	// Only one of IPAllowFilterPolicy and IPDenyFilterPolicy can be defined.
@@ -438,6 +568,150 @@ type RemoteJWKS struct {
 	DNSLookupFamily string `json:"dnsLookupFamily,omitempty"`
 }
 
+// OAuth2 defines an OAuth2 authorization code flow for a virtual host.
+// Requests without a valid session are redirected to the authorization
+// endpoint, and the authorization code returned to the redirect path is
+// exchanged for an access token at the token endpoint.
+type OAuth2 struct {
+	// TokenEndpoint is the URI of the authorization server's token
+	// endpoint. It must use the http or https scheme.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TokenEndpoint string `json:"tokenEndpoint"`
+
+	// How long to wait for a response from the token endpoint.
+	// If not specified, a default of 1s applies.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	TokenEndpointTimeout string `json:"tokenEndpointTimeout,omitempty"`
+
+	// AuthorizationEndpoint is the URI of the authorization server's
+	// authorization endpoint, where users are redirected to log in.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	AuthorizationEndpoint string `json:"authorizationEndpoint"`
+
+	// ClientID is the client identifier registered with the
+	// authorization server.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef is the name of a Secret in the namespace of the
+	// HTTPProxy. The Secret must contain the client secret in the
+	// "client-secret" key, and the key used to sign session cookies
+	// in the "hmac-secret" key.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ClientSecretRef string `json:"clientSecretRef"`
+
+	// RedirectPath is the path the authorization server redirects
+	// users to after they log in. It must not be used by any route.
+	// If not specified, "/oauth2/callback" is used.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	RedirectPath string `json:"redirectPath,omitempty"`
+
+	// SignoutPath is the path that clears the session cookies.
+	// If not specified, "/oauth2/signout" is used.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	SignoutPath string `json:"signoutPath,omitempty"`
+
+	// Scopes to request from the authorization server. If not
+	// specified, the "user" scope is requested.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ForwardBearerToken sets whether the access token is forwarded
+	// to the upstream service in the Authorization header.
+	// +optional
+	ForwardBearerToken bool `json:"forwardBearerToken,omitempty"`
+
+	// PassThroughMatchers are header conditions that let requests
+	// skip the OAuth2 flow. A request that matches any of the
+	// conditions is forwarded without authentication.
+	// +optional
+	PassThroughMatchers []HeaderMatchCondition `json:"passThroughMatchers,omitempty"`
+}
+
+// FaultInjectionPolicy defines the faults injected into requests for a route.
+// At least one of Delay or Abort must be specified.
+type FaultInjectionPolicy struct {
+	// Delay holds requests for a fixed duration before they are
+	// forwarded upstream.
+	// +optional
+	Delay *FaultDelay `json:"delay,omitempty"`
+
+	// Abort responds to requests with an HTTP status code instead
+	// of forwarding them upstream.
+	// +optional
+	Abort *FaultAbort `json:"abort,omitempty"`
+
+	// Headers restricts fault injection to requests that match all
+	// of the header conditions. If empty, faults apply to all requests.
+	// +optional
+	Headers []HeaderMatchCondition `json:"headers,omitempty"`
+}
+
+// FaultDelay defines a delay injected into requests.
+type FaultDelay struct {
+	// Duration of the delay, for example "500ms" or "2s".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	Duration string `json:"duration"`
+
+	// Percentage of requests that are delayed.
+	// If not specified, all requests are delayed.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// FaultAbort defines an abort injected into requests.
+type FaultAbort struct {
+	// StatusCode is the HTTP status code returned to aborted requests.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusCode int `json:"statusCode"`
+
+	// Percentage of requests that are aborted.
+	// If not specified, all requests are aborted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// BasicAuth defines HTTP basic authentication for a virtual host.
+type BasicAuth struct {
+	// SecretName is the name of an Opaque Secret in the namespace of the
+	// HTTPProxy. The Secret must contain htpasswd formatted users in the
+	// "auth" key. Only SHA password hashes ("{SHA}") are supported.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// BasicAuthPolicy modifies how basic authentication applies to a route.
+type BasicAuthPolicy struct {
+	// When true, this field disables basic authentication
+	// for the scope of the policy.
+	//
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// SecretName is the name of an Opaque Secret in the namespace of the
+	// HTTPProxy that defines the route. When set, the htpasswd users in the
+	// Secret's "auth" key replace the users of the virtual host for the
+	// scope of the policy.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
 // TLS describes tls properties. The SNI names that will be matched on
 // are described in the HTTPProxy's Spec.VirtualHost.Fqdn field.
 type TLS struct {
@@ -548,11 +822,31 @@ type Route struct {
 	// not permitted when a `virtualhost.tls` block is present.
 	// +optional
 	PermitInsecure bool `json:"permitInsecure,omitempty"`
+	// DisableCompression disables response compression for this route.
+	// This is useful for routes serving content that is already compressed
+	// or that stream responses.
+	// +optional
+	DisableCompression bool `json:"disableCompression,omitempty"`
 	// AuthPolicy updates the authorization policy that was set
 	// on the root HTTPProxy object for client requests that
 	// match this route.
 	// +optional
 	AuthPolicy *AuthorizationPolicy `json:"authPolicy,omitempty"`
+	// ExternalProcessingPolicy updates the external processing policy
+	// that was set on the root HTTPProxy object for client requests
+	// that match this route.
+	// +optional
+	ExternalProcessingPolicy *ExternalProcessingPolicy `json:"externalProcessingPolicy,omitempty"`
+	// BasicAuthPolicy updates the basic authentication that was set
+	// on the root HTTPProxy object for client requests that match
+	// this route.
+	// +optional
+	BasicAuthPolicy *BasicAuthPolicy `json:"basicAuthPolicy,omitempty"`
+	// FaultInjectionPolicy injects delays or aborts into a share of the
+	// requests that match this route, to test how clients and services
+	// cope with failures.
+	// +optional
+	FaultInjectionPolicy *FaultInjectionPolicy `json:"faultInjectionPolicy,omitempty"`
 	// The timeout policy for this route.
 	// +optional
 	TimeoutPolicy *TimeoutPolicy `json:"timeoutPolicy,omitempty"`
@@ -565,6 +859,16 @@ type Route struct {
 	// The load balancing policy for this route.
 	// +optional
 	LoadBalancerPolicy *LoadBalancerPolicy `json:"loadBalancerPolicy,omitempty"`
+	// The session persistence policy for this route.
+	// Unlike the `Cookie` load balancing strategy, requests
+	// remain pinned to the same endpoint when the set of
+	// endpoints for the route changes.
+	// +optional
+	SessionPersistence *SessionPersistence `json:"sessionPersistence,omitempty"`
+	// The cross-origin policy to apply to this route.
+	// When set, it overrides the policy set on the VirtualHost.
+	// +optional
+	CORSPolicy *CORSPolicy `json:"corsPolicy,omitempty"`
 	// The policy for rewriting the path of the request URL
 	// after the request has been routed to a Service.
 	//
@@ -1009,6 +1313,13 @@ type Service struct {
 	// Name is the name of Kubernetes service to proxy traffic.
 	// Names defined here will be used to look up corresponding endpoints which contain the ips to route.
 	Name string `json:"name"`
+	// Kind is the kind of resource Name refers to. A Service is a
+	// Kubernetes Service, and a Backend is a Contour Backend listing
+	// static or DNS endpoints. If omitted, Service is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Service;Backend
+	Kind string `json:"kind,omitempty"`
 	// Port (defined as Integer) to proxy traffic to since a service can have multiple defined.
 	//
 	// +required
@@ -1033,16 +1344,47 @@ type Service struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	Weight int64 `json:"weight,omitempty"`
+	// Priority configures failover between the Services of a route.
+	// Traffic is sent to the Service with the lowest priority that has
+	// healthy endpoints, and only fails over to the Service with the next
+	// priority when it has none. Services default to priority 0.
+	// If any Service of a route sets a priority, every Service of the
+	// route must have a distinct priority, and Weight, Subset and
+	// per-Service header and cookie rewrite policies can not be used.
+	// Priority is not supported for mirror Services or TCPProxy Services.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority uint32 `json:"priority,omitempty"`
 	// UpstreamValidation defines how to verify the backend service's certificate
 	// +optional
 	UpstreamValidation *UpstreamValidation `json:"validation,omitempty"`
+	// ClientCertificate is the name of a Kubernetes secret containing the
+	// client certificate and private key presented to this Service when it
+	// is reached over TLS. It overrides the client certificate configured
+	// globally for Envoy. The name can be optionally prefixed with namespace
+	// "namespace/name". When cross-namespace reference is used,
+	// TLSCertificateDelegation resource must exist in the namespace to grant
+	// access to the secret.
+	// Max length should be the actual max possible length of a namespaced name (63 + 253 + 1 = 317)
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	ClientCertificate string `json:"clientCertificate,omitempty"`
 	// If Mirror is true the Service will receive a read only mirror of the traffic for this route.
 	// If Mirror is true, then fractional mirroring can be enabled by optionally setting the Weight
 	// field. Legal values for Weight are 1-100. Omitting the Weight field will result in 100% mirroring.
 	// NOTE: Setting Weight explicitly to 0 will unexpectedly result in 100% traffic mirroring. This
 	// occurs since we cannot distinguish omitted fields from those explicitly set to their default
 	// values
+	// More than one Service per route may be nominated as a mirror,
+	// each receiving its own share of the traffic.
 	Mirror bool `json:"mirror,omitempty"`
+	// MirrorFraction is the fraction of requests that is mirrored to
+	// this Service. It can only be set if Mirror is true and takes
+	// precedence over Weight. Unlike Weight, it can express fractions
+	// of less than one percent.
+	// +optional
+	MirrorFraction *MirrorFraction `json:"mirrorFraction,omitempty"`
 	// The policy for managing request headers during proxying.
 	// +optional
 	RequestHeadersPolicy *HeadersPolicy `json:"requestHeadersPolicy,omitempty"`
@@ -1056,6 +1398,33 @@ type Service struct {
 	// Slow start will gradually increase amount of traffic to a newly added endpoint.
 	// +optional
 	SlowStartPolicy *SlowStartPolicy `json:"slowStartPolicy,omitempty"`
+	// OutlierDetectionPolicy ejects endpoints that keep failing
+	// requests from the load balancing set (passive health checking).
+	// +optional
+	OutlierDetectionPolicy *OutlierDetectionPolicy `json:"outlierDetectionPolicy,omitempty"`
+	// Subset restricts the traffic sent to this Service to the endpoints
+	// whose Pods match the selector. Requests fail with a 503 if no
+	// endpoint matches. Requires subset load balancing to be enabled in
+	// the Contour configuration.
+	// +optional
+	Subset *SubsetSelector `json:"subset,omitempty"`
+}
+
+// MirrorFraction defines the fraction of requests that is mirrored to a Service,
+// computed as Numerator/Denominator.
+type MirrorFraction struct {
+	// Numerator is the number of requests out of every Denominator requests
+	// that are mirrored.
+	//
+	// +kubebuilder:validation:Minimum=0
+	Numerator int32 `json:"numerator"`
+	// Denominator is the size of the sample that Numerator is relative to.
+	// Defaults to 100, in which case Numerator is a percentage.
+	//
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	Denominator *int32 `json:"denominator,omitempty"`
 }
 
 // HTTPHealthCheckPolicy defines health checks on the upstream service.
@@ -1299,6 +1668,48 @@ type LoadBalancerPolicy struct {
 	RequestHashPolicies []RequestHashPolicy `json:"requestHashPolicies,omitempty"`
 }
 
+// SessionPersistenceType is the type of session persistence.
+// +kubebuilder:validation:Enum=Cookie;Header
+type SessionPersistenceType string
+
+const (
+	// CookieSessionPersistence pins sessions using a cookie
+	// that Envoy sets on the response.
+	CookieSessionPersistence SessionPersistenceType = "Cookie"
+
+	// HeaderSessionPersistence pins sessions using a header
+	// that Envoy sets on the response and that the client
+	// is expected to send on subsequent requests.
+	HeaderSessionPersistence SessionPersistenceType = "Header"
+)
+
+// SessionPersistence defines how requests from a client are
+// pinned to the same upstream endpoint.
+type SessionPersistence struct {
+	// Type defines how the session is identified.
+	// Valid values are `Cookie` and `Header`.
+	// If not specified, defaults to `Cookie`.
+	// +optional
+	Type SessionPersistenceType `json:"type,omitempty"`
+
+	// Name is the name of the cookie or header used
+	// to track the session.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// CookieTTL is the lifetime of the session cookie.
+	// If not specified, or set to 0s, a session cookie is used,
+	// which is discarded when the client ends the session.
+	// Only applies to the `Cookie` type.
+	// Duration is expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	CookieTTL string `json:"cookieTTL,omitempty"`
+}
+
 // HeadersPolicy defines how headers are managed during forwarding.
 // The `Host` header is treated specially and if set in a HTTP request
 // will be used as the SNI server name when forwarding over TLS. It is an
@@ -1514,5 +1925,54 @@ type SlowStartPolicy struct {
 	MinimumWeightPercent uint32 `json:"minWeightPercent"`
 }
 
-// +kubebuilder:validation:Enum=grpcroutes;tlsroutes;extensionservices;backendtlspolicies
+// OutlierDetectionPolicy defines how endpoints that keep failing requests
+// are temporarily ejected from the load balancing set.
+//
+// More info: https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/outlier
+type OutlierDetectionPolicy struct {
+	// ConsecutiveServerErrors is the number of consecutive 5xx responses,
+	// or local connection failures, after which an endpoint is ejected.
+	// Setting it to 0 disables ejection based on server errors.
+	// If not specified, the default is 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ConsecutiveServerErrors *uint32 `json:"consecutiveServerErrors,omitempty"`
+
+	// ConsecutiveGatewayErrors is the number of consecutive gateway errors
+	// (502, 503 and 504 responses, or local connection failures) after
+	// which an endpoint is ejected.
+	// If not specified, ejection based on gateway errors is disabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ConsecutiveGatewayErrors *uint32 `json:"consecutiveGatewayErrors,omitempty"`
+
+	// BaseEjectionTime is the base time that an endpoint is ejected for.
+	// The actual time is equal to the base time multiplied by the number
+	// of times the endpoint has been ejected.
+	// Duration is expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// If not specified, the default is 30s.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent is the maximum percentage of endpoints of the
+	// service that can be ejected at the same time.
+	// If not specified, the default is 10%.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// SubsetSelector selects a subset of the endpoints of a Service by the
+// labels of their Pods.
+type SubsetSelector struct {
+	// MatchLabels are the labels a Pod must have for its endpoints
+	// to be selected.
+	// +kubebuilder:validation:MinProperties=1
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+// +kubebuilder:validation:Enum=grpcroutes;tlsroutes;udproutes;extensionservices;backends;backendtlspolicies;xbackendtrafficpolicies
 type Feature string
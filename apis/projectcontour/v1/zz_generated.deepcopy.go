@@ -87,6 +87,36 @@ func (in *AuthorizationServerBufferSettings) DeepCopy() *AuthorizationServerBuff
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuthPolicy) DeepCopyInto(out *BasicAuthPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthPolicy.
+func (in *BasicAuthPolicy) DeepCopy() *BasicAuthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CORSPolicy) DeepCopyInto(out *CORSPolicy) {
 	*out = *in
@@ -283,6 +313,117 @@ func (in *ExtensionServiceReference) DeepCopy() *ExtensionServiceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalProcessing) DeepCopyInto(out *ExternalProcessing) {
+	*out = *in
+	out.ExtensionServiceRef = in.ExtensionServiceRef
+	if in.ProcessingMode != nil {
+		in, out := &in.ProcessingMode, &out.ProcessingMode
+		*out = new(ProcessingMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalProcessing.
+func (in *ExternalProcessing) DeepCopy() *ExternalProcessing {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalProcessing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalProcessingPolicy) DeepCopyInto(out *ExternalProcessingPolicy) {
+	*out = *in
+	if in.ProcessingMode != nil {
+		in, out := &in.ProcessingMode, &out.ProcessingMode
+		*out = new(ProcessingMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalProcessingPolicy.
+func (in *ExternalProcessingPolicy) DeepCopy() *ExternalProcessingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalProcessingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultAbort) DeepCopyInto(out *FaultAbort) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultAbort.
+func (in *FaultAbort) DeepCopy() *FaultAbort {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultAbort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultDelay) DeepCopyInto(out *FaultDelay) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultDelay.
+func (in *FaultDelay) DeepCopy() *FaultDelay {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultDelay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjectionPolicy) DeepCopyInto(out *FaultInjectionPolicy) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(FaultDelay)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(FaultAbort)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HeaderMatchCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjectionPolicy.
+func (in *FaultInjectionPolicy) DeepCopy() *FaultInjectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenericKeyDescriptor) DeepCopyInto(out *GenericKeyDescriptor) {
 	*out = *in
@@ -766,6 +907,81 @@ func (in *MatchCondition) DeepCopy() *MatchCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorFraction) DeepCopyInto(out *MirrorFraction) {
+	*out = *in
+	if in.Denominator != nil {
+		in, out := &in.Denominator, &out.Denominator
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorFraction.
+func (in *MirrorFraction) DeepCopy() *MirrorFraction {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorFraction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2) DeepCopyInto(out *OAuth2) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PassThroughMatchers != nil {
+		in, out := &in.PassThroughMatchers, &out.PassThroughMatchers
+		*out = make([]HeaderMatchCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2.
+func (in *OAuth2) DeepCopy() *OAuth2 {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionPolicy) DeepCopyInto(out *OutlierDetectionPolicy) {
+	*out = *in
+	if in.ConsecutiveServerErrors != nil {
+		in, out := &in.ConsecutiveServerErrors, &out.ConsecutiveServerErrors
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ConsecutiveGatewayErrors != nil {
+		in, out := &in.ConsecutiveGatewayErrors, &out.ConsecutiveGatewayErrors
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionPolicy.
+func (in *OutlierDetectionPolicy) DeepCopy() *OutlierDetectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PathRewritePolicy) DeepCopyInto(out *PathRewritePolicy) {
 	*out = *in
@@ -786,6 +1002,21 @@ func (in *PathRewritePolicy) DeepCopy() *PathRewritePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessingMode) DeepCopyInto(out *ProcessingMode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessingMode.
+func (in *ProcessingMode) DeepCopy() *ProcessingMode {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessingMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueryParameterHashOptions) DeepCopyInto(out *QueryParameterHashOptions) {
 	*out = *in
@@ -1055,6 +1286,21 @@ func (in *Route) DeepCopyInto(out *Route) {
 		*out = new(AuthorizationPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalProcessingPolicy != nil {
+		in, out := &in.ExternalProcessingPolicy, &out.ExternalProcessingPolicy
+		*out = new(ExternalProcessingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuthPolicy != nil {
+		in, out := &in.BasicAuthPolicy, &out.BasicAuthPolicy
+		*out = new(BasicAuthPolicy)
+		**out = **in
+	}
+	if in.FaultInjectionPolicy != nil {
+		in, out := &in.FaultInjectionPolicy, &out.FaultInjectionPolicy
+		*out = new(FaultInjectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TimeoutPolicy != nil {
 		in, out := &in.TimeoutPolicy, &out.TimeoutPolicy
 		*out = new(TimeoutPolicy)
@@ -1075,6 +1321,16 @@ func (in *Route) DeepCopyInto(out *Route) {
 		*out = new(LoadBalancerPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SessionPersistence != nil {
+		in, out := &in.SessionPersistence, &out.SessionPersistence
+		*out = new(SessionPersistence)
+		**out = **in
+	}
+	if in.CORSPolicy != nil {
+		in, out := &in.CORSPolicy, &out.CORSPolicy
+		*out = new(CORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PathRewritePolicy != nil {
 		in, out := &in.PathRewritePolicy, &out.PathRewritePolicy
 		*out = new(PathRewritePolicy)
@@ -1157,6 +1413,11 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(UpstreamValidation)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MirrorFraction != nil {
+		in, out := &in.MirrorFraction, &out.MirrorFraction
+		*out = new(MirrorFraction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RequestHeadersPolicy != nil {
 		in, out := &in.RequestHeadersPolicy, &out.RequestHeadersPolicy
 		*out = new(HeadersPolicy)
@@ -1179,6 +1440,16 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(SlowStartPolicy)
 		**out = **in
 	}
+	if in.OutlierDetectionPolicy != nil {
+		in, out := &in.OutlierDetectionPolicy, &out.OutlierDetectionPolicy
+		*out = new(OutlierDetectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subset != nil {
+		in, out := &in.Subset, &out.Subset
+		*out = new(SubsetSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
@@ -1191,6 +1462,21 @@ func (in *Service) DeepCopy() *Service {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionPersistence) DeepCopyInto(out *SessionPersistence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionPersistence.
+func (in *SessionPersistence) DeepCopy() *SessionPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlowStartPolicy) DeepCopyInto(out *SlowStartPolicy) {
 	*out = *in
@@ -1221,6 +1507,28 @@ func (in *SubCondition) DeepCopy() *SubCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubsetSelector) DeepCopyInto(out *SubsetSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubsetSelector.
+func (in *SubsetSelector) DeepCopy() *SubsetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubsetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPHealthCheckPolicy) DeepCopyInto(out *TCPHealthCheckPolicy) {
 	*out = *in
@@ -1464,6 +1772,11 @@ func (in *VirtualHost) DeepCopyInto(out *VirtualHost) {
 		*out = new(AuthorizationServer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalProcessing != nil {
+		in, out := &in.ExternalProcessing, &out.ExternalProcessing
+		*out = new(ExternalProcessing)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CORSPolicy != nil {
 		in, out := &in.CORSPolicy, &out.CORSPolicy
 		*out = new(CORSPolicy)
@@ -1481,6 +1794,16 @@ func (in *VirtualHost) DeepCopyInto(out *VirtualHost) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		**out = **in
+	}
 	if in.IPAllowFilterPolicy != nil {
 		in, out := &in.IPAllowFilterPolicy, &out.IPAllowFilterPolicy
 		*out = make([]IPFilterPolicy, len(*in))
@@ -0,0 +1,126 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+)
+
+// BackendEndpoint defines a network endpoint outside of the
+// cluster that will accept traffic.
+type BackendEndpoint struct {
+	// Address is the IP address or DNS hostname of the endpoint.
+	// Hostnames are resolved by Envoy.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Port (defined as Integer) to proxy traffic to. If omitted, the
+	// port that the HTTPProxy or route references the Backend with is
+	// used.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65536
+	// +kubebuilder:validation:ExclusiveMinimum=false
+	// +kubebuilder:validation:ExclusiveMaximum=true
+	Port int `json:"port,omitempty"`
+}
+
+// BackendTLS defines how to connect to the endpoints of a Backend
+// over TLS.
+type BackendTLS struct {
+	// SNI is the server name to send when connecting to the endpoints.
+	// If omitted, the subject name of the upstream validation is used.
+	//
+	// +optional
+	SNI string `json:"sni,omitempty"`
+
+	// UpstreamValidation defines how to verify the certificates of the
+	// endpoints.
+	//
+	// +optional
+	UpstreamValidation *contour_v1.UpstreamValidation `json:"validation,omitempty"`
+}
+
+// BackendSpec defines the desired state of a Backend resource.
+type BackendSpec struct {
+	// Endpoints specifies the set of endpoints that receive traffic.
+	// If every address is an IP address, the endpoints are used as
+	// is. Otherwise, Envoy periodically resolves the hostnames in DNS.
+	//
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []BackendEndpoint `json:"endpoints"`
+
+	// Protocol may be used to specify the protocol used to reach the
+	// endpoints. Values may be tls, h2 or h2c. If omitted, HTTP/1.1 is
+	// used.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=h2;h2c;tls
+	Protocol *string `json:"protocol,omitempty"`
+
+	// TLS defines how to connect to the endpoints over TLS. When set,
+	// protocol defaults to tls and may not be h2c.
+	//
+	// +optional
+	TLS *BackendTLS `json:"tls,omitempty"`
+}
+
+// BackendStatus defines the observed state of a Backend resource.
+type BackendStatus struct {
+	// Conditions contains the current status of the Backend resource.
+	//
+	// Contour will update a single condition, `Valid`, that is in normal-true polarity.
+	//
+	// Contour will not modify any other Conditions set in this block,
+	// in case some other controller wants to add a Condition.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []contour_v1.DetailedCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=backend;backends
+
+// Backend is the schema for the Contour backends API. A Backend
+// resource lists static or DNS endpoints outside of the cluster
+// that HTTPProxies and Gateway API routes can send traffic to.
+type Backend struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendSpec   `json:"spec,omitempty"`
+	Status BackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendList contains a list of Backend resources.
+type BackendList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata,omitempty"`
+	Items            []Backend `json:"items"`
+}
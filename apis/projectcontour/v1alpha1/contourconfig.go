@@ -56,7 +56,7 @@ type ContourConfigurationSpec struct {
 	// +optional
 	HTTPProxy *HTTPProxyConfig `json:"httpproxy,omitempty"`
 
-	// EnableExternalNameService allows processing of ExternalNameServices
+	// EnableExternalNameService allows processing of ExternalNameServices and Backends
 	//
 	// Contour's default is false for security reasons.
 	// +optional
@@ -67,6 +67,13 @@ type ContourConfigurationSpec struct {
 	// +optional
 	GlobalExternalAuthorization *contour_v1.AuthorizationServer `json:"globalExtAuth,omitempty"`
 
+	// GlobalExternalProcessing allows envoys external processing filter
+	// to be enabled for all virtual hosts. Virtual hosts that define their
+	// own external processing replace it, and routes can disable or
+	// override it with an external processing policy.
+	// +optional
+	GlobalExternalProcessing *contour_v1.ExternalProcessing `json:"globalExtProc,omitempty"`
+
 	// RateLimitService optionally holds properties of the Rate Limit Service
 	// to be used for global rate limiting.
 	// +optional
@@ -441,6 +448,81 @@ type EnvoyListenerConfig struct {
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	MaxConnectionsPerListener *uint32 `json:"maxConnectionsPerListener,omitempty"`
+
+	// Compression defines the response compression settings applied to
+	// HTTP listeners. When not set, responses are compressed with gzip
+	// using Envoy's default content types.
+	// +optional
+	Compression *EnvoyCompression `json:"compression,omitempty"`
+
+	// HTTP3 configures HTTP/3 (QUIC) listeners. When enabled, a UDP
+	// listener serving HTTP/3 is added alongside each HTTPS listener,
+	// using the same certificates.
+	// +optional
+	HTTP3 *EnvoyHTTP3 `json:"http3,omitempty"`
+}
+
+// EnvoyHTTP3 defines HTTP/3 (QUIC) listener settings.
+type EnvoyHTTP3 struct {
+	// Enabled adds an HTTP/3 listener on UDP for each HTTPS listener,
+	// and advertises it to clients with an alt-svc response header.
+	//
+	// Contour's default is false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AdvertisedPort is the UDP port advertised to clients in the
+	// alt-svc response header. This should be the port that HTTP/3
+	// clients reach Envoy on, for example the port of the Envoy Service.
+	// Gateway API listeners always advertise the port of the Gateway
+	// listener instead.
+	//
+	// Contour's default is 443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	AdvertisedPort *uint32 `json:"advertisedPort,omitempty"`
+}
+
+// CompressionAlgorithm is the name of a response compression algorithm.
+// +kubebuilder:validation:Enum=gzip;brotli;zstd
+type CompressionAlgorithm string
+
+const (
+	// GzipCompression compresses responses with gzip.
+	GzipCompression CompressionAlgorithm = "gzip"
+	// BrotliCompression compresses responses with brotli.
+	BrotliCompression CompressionAlgorithm = "brotli"
+	// ZstdCompression compresses responses with zstd.
+	ZstdCompression CompressionAlgorithm = "zstd"
+)
+
+// EnvoyCompression defines response compression settings for Envoy listeners.
+type EnvoyCompression struct {
+	// Algorithms is the list of compression algorithms enabled on the
+	// listeners, in order of preference. When a client accepts more than
+	// one of the enabled algorithms, the first matching one in this list
+	// is used.
+	//
+	// Values: `gzip`, `brotli`, `zstd`.
+	//
+	// Contour's default is `gzip` only.
+	// +optional
+	Algorithms []CompressionAlgorithm `json:"algorithms,omitempty"`
+
+	// MinContentLength is the minimum response length, in bytes, for a
+	// response to be compressed.
+	//
+	// Envoy's default is 30 bytes.
+	// +optional
+	MinContentLength *uint32 `json:"minContentLength,omitempty"`
+
+	// ContentTypes is the list of response content types eligible for
+	// compression.
+	//
+	// Contour's default is Envoy's default content types list.
+	// +optional
+	ContentTypes []string `json:"contentTypes,omitempty"`
 }
 
 // SocketOptions defines configurable socket options for Envoy listeners.
@@ -717,6 +799,53 @@ type ClusterParameters struct {
 	//
 	// +optional
 	UpstreamTLS *EnvoyTLS `json:"upstreamTLS,omitempty"`
+
+	// TopologyAwareRouting configures Envoy to prefer upstream
+	// endpoints in its own zone.
+	//
+	// +optional
+	TopologyAwareRouting *TopologyAwareRouting `json:"topologyAwareRouting,omitempty"`
+
+	// SubsetLoadBalancing configures HTTPProxy services to be able to
+	// select a subset of their endpoints by Pod label.
+	//
+	// +optional
+	SubsetLoadBalancing *SubsetLoadBalancing `json:"subsetLoadBalancing,omitempty"`
+}
+
+// SubsetLoadBalancing defines subset load balancing settings.
+type SubsetLoadBalancing struct {
+	// Enabled watches the metadata of Pods and publishes the labels used
+	// by HTTPProxy service subsets as endpoint metadata to Envoy.
+	// Requires EndpointSlices to be enabled.
+	//
+	// Requires the Contour service account to be able to watch Pods.
+	//
+	// Contour's default is false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// TopologyAwareRouting defines zone aware routing settings.
+type TopologyAwareRouting struct {
+	// Enabled publishes the region and zone of each endpoint to Envoy
+	// and enables Envoy zone aware routing, which sends as much traffic
+	// as possible to endpoints in the same zone as the Envoy instance.
+	// Envoy's own zone is set with the `--locality-node` or `--locality-zone`
+	// flags of `contour bootstrap`.
+	//
+	// Requires the Contour service account to be able to watch Nodes.
+	//
+	// Contour's default is false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinClusterSize is the minimum number of endpoints an upstream
+	// cluster needs for zone aware routing to be used.
+	//
+	// Envoy's default is 6.
+	// +optional
+	MinClusterSize *uint32 `json:"minClusterSize,omitempty"`
 }
 
 // HTTPProxyConfig defines parameters on HTTPProxy.
@@ -149,9 +149,51 @@ func (e *EnvoyConfig) Validate() error {
 		}
 	}
 
-	// Envoy TLS configuration
-	if e.Listener != nil && e.Listener.TLS != nil {
-		return e.Listener.TLS.Validate()
+	if e.Listener != nil {
+		// Envoy TLS configuration
+		if e.Listener.TLS != nil {
+			if err := e.Listener.TLS.Validate(); err != nil {
+				return err
+			}
+		}
+
+		// Envoy compression configuration
+		if e.Listener.Compression != nil {
+			if err := e.Listener.Compression.Validate(); err != nil {
+				return err
+			}
+		}
+
+		// Envoy HTTP/3 configuration
+		if e.Listener.HTTP3 != nil && e.Listener.HTTP3.AdvertisedPort != nil {
+			if port := *e.Listener.HTTP3.AdvertisedPort; port < 1 || port > 65535 {
+				return fmt.Errorf("invalid HTTP/3 advertised port %d", port)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate ensures that the compression algorithms are known and
+// not repeated.
+func (c *EnvoyCompression) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	seen := map[CompressionAlgorithm]bool{}
+	for _, a := range c.Algorithms {
+		switch a {
+		case GzipCompression, BrotliCompression, ZstdCompression:
+		default:
+			return fmt.Errorf("invalid compression algorithm %q", a)
+		}
+
+		if seen[a] {
+			return fmt.Errorf("duplicate compression algorithm %q", a)
+		}
+		seen[a] = true
 	}
 
 	return nil
@@ -179,6 +179,55 @@ func TestContourConfigurationSpecValidate(t *testing.T) {
 		require.Error(t, c.Validate())
 	})
 
+	t.Run("compression validation", func(t *testing.T) {
+		c := contour_v1alpha1.ContourConfigurationSpec{
+			Envoy: &contour_v1alpha1.EnvoyConfig{
+				Listener: &contour_v1alpha1.EnvoyListenerConfig{
+					Compression: &contour_v1alpha1.EnvoyCompression{},
+				},
+			},
+		}
+		require.NoError(t, c.Validate())
+
+		c.Envoy.Listener.Compression.Algorithms = []contour_v1alpha1.CompressionAlgorithm{
+			contour_v1alpha1.ZstdCompression,
+			contour_v1alpha1.BrotliCompression,
+			contour_v1alpha1.GzipCompression,
+		}
+		require.NoError(t, c.Validate())
+
+		c.Envoy.Listener.Compression.Algorithms = []contour_v1alpha1.CompressionAlgorithm{"deflate"}
+		require.Error(t, c.Validate())
+
+		c.Envoy.Listener.Compression.Algorithms = []contour_v1alpha1.CompressionAlgorithm{
+			contour_v1alpha1.GzipCompression,
+			contour_v1alpha1.GzipCompression,
+		}
+		require.Error(t, c.Validate())
+	})
+
+	t.Run("http3 validation", func(t *testing.T) {
+		c := contour_v1alpha1.ContourConfigurationSpec{
+			Envoy: &contour_v1alpha1.EnvoyConfig{
+				Listener: &contour_v1alpha1.EnvoyListenerConfig{
+					HTTP3: &contour_v1alpha1.EnvoyHTTP3{
+						Enabled: true,
+					},
+				},
+			},
+		}
+		require.NoError(t, c.Validate())
+
+		c.Envoy.Listener.HTTP3.AdvertisedPort = ptr.To(uint32(8443))
+		require.NoError(t, c.Validate())
+
+		c.Envoy.Listener.HTTP3.AdvertisedPort = ptr.To(uint32(0))
+		require.Error(t, c.Validate())
+
+		c.Envoy.Listener.HTTP3.AdvertisedPort = ptr.To(uint32(65536))
+		require.Error(t, c.Validate())
+	})
+
 	t.Run("gateway validation", func(t *testing.T) {
 		c := contour_v1alpha1.ContourConfigurationSpec{
 			Gateway: &contour_v1alpha1.GatewayConfig{},
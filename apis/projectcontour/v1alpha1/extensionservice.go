@@ -109,6 +109,12 @@ type ExtensionServiceSpec struct {
 	// If defined this overrides the global circuit breaker budget.
 	// +optional
 	CircuitBreakerPolicy *CircuitBreakers `json:"circuitBreakerPolicy,omitempty"`
+
+	// OutlierDetectionPolicy ejects extension service endpoints that
+	// keep failing requests from the load balancing set.
+	//
+	// +optional
+	OutlierDetectionPolicy *contour_v1.OutlierDetectionPolicy `json:"outlierDetectionPolicy,omitempty"`
 }
 
 // ExtensionServiceStatus defines the observed state of an
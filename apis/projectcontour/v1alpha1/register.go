@@ -23,6 +23,7 @@ var (
 	ExtensionServiceGVR     = GroupVersion.WithResource("extensionservices")
 	ContourConfigurationGVR = GroupVersion.WithResource("contourconfigurations")
 	ContourDeploymentGVR    = GroupVersion.WithResource("contourdeployments")
+	BackendGVR              = GroupVersion.WithResource("backends")
 )
 
 var (
@@ -45,6 +46,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ContourConfigurationList{},
 		&ContourDeployment{},
 		&ContourDeploymentList{},
+		&Backend{},
+		&BackendList{},
 	)
 
 	meta_v1.AddToGroupVersion(scheme, GroupVersion)
@@ -47,6 +47,152 @@ func (in AccessLogJSONFields) DeepCopy() AccessLogJSONFields {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backend) DeepCopyInto(out *Backend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
+func (in *Backend) DeepCopy() *Backend {
+	if in == nil {
+		return nil
+	}
+	out := new(Backend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Backend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendEndpoint) DeepCopyInto(out *BackendEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendEndpoint.
+func (in *BackendEndpoint) DeepCopy() *BackendEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendList) DeepCopyInto(out *BackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Backend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendList.
+func (in *BackendList) DeepCopy() *BackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]BackendEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BackendTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
+func (in *BackendSpec) DeepCopy() *BackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.DetailedCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
+func (in *BackendStatus) DeepCopy() *BackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLS) DeepCopyInto(out *BackendTLS) {
+	*out = *in
+	if in.UpstreamValidation != nil {
+		in, out := &in.UpstreamValidation, &out.UpstreamValidation
+		*out = new(v1.UpstreamValidation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLS.
+func (in *BackendTLS) DeepCopy() *BackendTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreakers) DeepCopyInto(out *CircuitBreakers) {
 	*out = *in
@@ -85,6 +231,16 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(EnvoyTLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologyAwareRouting != nil {
+		in, out := &in.TopologyAwareRouting, &out.TopologyAwareRouting
+		*out = new(TopologyAwareRouting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubsetLoadBalancing != nil {
+		in, out := &in.SubsetLoadBalancing, &out.SubsetLoadBalancing
+		*out = new(SubsetLoadBalancing)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterParameters.
@@ -204,6 +360,11 @@ func (in *ContourConfigurationSpec) DeepCopyInto(out *ContourConfigurationSpec)
 		*out = new(v1.AuthorizationServer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GlobalExternalProcessing != nil {
+		in, out := &in.GlobalExternalProcessing, &out.GlobalExternalProcessing
+		*out = new(v1.ExternalProcessing)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RateLimitService != nil {
 		in, out := &in.RateLimitService, &out.RateLimitService
 		*out = new(RateLimitServiceConfig)
@@ -489,6 +650,36 @@ func (in *DeploymentSettings) DeepCopy() *DeploymentSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyCompression) DeepCopyInto(out *EnvoyCompression) {
+	*out = *in
+	if in.Algorithms != nil {
+		in, out := &in.Algorithms, &out.Algorithms
+		*out = make([]CompressionAlgorithm, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinContentLength != nil {
+		in, out := &in.MinContentLength, &out.MinContentLength
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyCompression.
+func (in *EnvoyCompression) DeepCopy() *EnvoyCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyConfig) DeepCopyInto(out *EnvoyConfig) {
 	*out = *in
@@ -564,6 +755,26 @@ func (in *EnvoyConfig) DeepCopy() *EnvoyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyHTTP3) DeepCopyInto(out *EnvoyHTTP3) {
+	*out = *in
+	if in.AdvertisedPort != nil {
+		in, out := &in.AdvertisedPort, &out.AdvertisedPort
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyHTTP3.
+func (in *EnvoyHTTP3) DeepCopy() *EnvoyHTTP3 {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyHTTP3)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyListener) DeepCopyInto(out *EnvoyListener) {
 	*out = *in
@@ -632,6 +843,16 @@ func (in *EnvoyListenerConfig) DeepCopyInto(out *EnvoyListenerConfig) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(EnvoyCompression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP3 != nil {
+		in, out := &in.HTTP3, &out.HTTP3
+		*out = new(EnvoyHTTP3)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyListenerConfig.
@@ -833,6 +1054,11 @@ func (in *ExtensionServiceSpec) DeepCopyInto(out *ExtensionServiceSpec) {
 		*out = new(CircuitBreakers)
 		**out = **in
 	}
+	if in.OutlierDetectionPolicy != nil {
+		in, out := &in.OutlierDetectionPolicy, &out.OutlierDetectionPolicy
+		*out = new(v1.OutlierDetectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionServiceSpec.
@@ -1216,6 +1442,21 @@ func (in *SocketOptions) DeepCopy() *SocketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubsetLoadBalancing) DeepCopyInto(out *SubsetLoadBalancing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubsetLoadBalancing.
+func (in *SubsetLoadBalancing) DeepCopy() *SubsetLoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(SubsetLoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -1286,6 +1527,26 @@ func (in *TimeoutParameters) DeepCopy() *TimeoutParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyAwareRouting) DeepCopyInto(out *TopologyAwareRouting) {
+	*out = *in
+	if in.MinClusterSize != nil {
+		in, out := &in.MinClusterSize, &out.MinClusterSize
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAwareRouting.
+func (in *TopologyAwareRouting) DeepCopy() *TopologyAwareRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyAwareRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
 	*out = *in
@@ -14,7 +14,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/alecthomas/kingpin/v2"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/projectcontour/contour/internal/envoy"
 )
@@ -33,6 +39,9 @@ func registerBootstrap(app *kingpin.Application) (*kingpin.CmdClause, *envoy.Boo
 	bootstrap.Flag("envoy-cafile", "CA Filename for Envoy secure xDS gRPC communication.").Envar("ENVOY_CAFILE").StringVar(&config.GrpcCABundle)
 	bootstrap.Flag("envoy-cert-file", "Client certificate filename for Envoy secure xDS gRPC communication.").Envar("ENVOY_CERT_FILE").StringVar(&config.GrpcClientCert)
 	bootstrap.Flag("envoy-key-file", "Client key filename for Envoy secure xDS gRPC communication.").Envar("ENVOY_KEY_FILE").StringVar(&config.GrpcClientKey)
+	bootstrap.Flag("locality-node", "The node the Envoy container will run in, whose topology labels set the region and zone unless given explicitly.").Envar("ENVOY_LOCALITY_NODE").StringVar(&config.LocalityNode)
+	bootstrap.Flag("locality-region", "The region the Envoy container will run in.").Envar("ENVOY_LOCALITY_REGION").StringVar(&config.LocalityRegion)
+	bootstrap.Flag("locality-zone", "The zone the Envoy container will run in, enables zone aware routing.").Envar("ENVOY_LOCALITY_ZONE").StringVar(&config.LocalityZone)
 	bootstrap.Flag("namespace", "The namespace the Envoy container will run in.").Envar("CONTOUR_NAMESPACE").Default("projectcontour").StringVar(&config.Namespace)
 	bootstrap.Flag("overload-max-heap", "Defines the maximum heap size in bytes until overload manager stops accepting new connections.").Uint64Var(&config.MaximumHeapSizeBytes)
 	bootstrap.Flag("resources-dir", "Directory where configuration files will be written to.").StringVar(&config.ResourcesDir)
@@ -42,3 +51,23 @@ func registerBootstrap(app *kingpin.Application) (*kingpin.CmdClause, *envoy.Boo
 
 	return bootstrap, &config
 }
+
+// nodeLocality defaults the locality region and zone of config to the
+// topology labels of the Node named by config.LocalityNode. A DaemonSet
+// can't set a different zone flag per Pod, but can pass each Pod the
+// name of its Node.
+func nodeLocality(ctx context.Context, client kubernetes.Interface, config *envoy.BootstrapConfig) error {
+	node, err := client.CoreV1().Nodes().Get(ctx, config.LocalityNode, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %q: %w", config.LocalityNode, err)
+	}
+
+	if config.LocalityRegion == "" {
+		config.LocalityRegion = node.Labels[core_v1.LabelTopologyRegion]
+	}
+	if config.LocalityZone == "" {
+		config.LocalityZone = node.Labels[core_v1.LabelTopologyZone]
+	}
+
+	return nil
+}
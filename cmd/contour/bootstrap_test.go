@@ -0,0 +1,71 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/projectcontour/contour/internal/envoy"
+)
+
+func TestNodeLocality(t *testing.T) {
+	client := fake.NewSimpleClientset(&core_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node-a",
+			Labels: map[string]string{
+				core_v1.LabelTopologyRegion: "us-east-1",
+				core_v1.LabelTopologyZone:   "us-east-1a",
+			},
+		},
+	})
+
+	tests := map[string]struct {
+		config envoy.BootstrapConfig
+		want   envoy.BootstrapConfig
+	}{
+		"locality from node labels": {
+			config: envoy.BootstrapConfig{LocalityNode: "node-a"},
+			want: envoy.BootstrapConfig{
+				LocalityNode:   "node-a",
+				LocalityRegion: "us-east-1",
+				LocalityZone:   "us-east-1a",
+			},
+		},
+		"explicit zone takes precedence": {
+			config: envoy.BootstrapConfig{LocalityNode: "node-a", LocalityZone: "us-east-1b"},
+			want: envoy.BootstrapConfig{
+				LocalityNode:   "node-a",
+				LocalityRegion: "us-east-1",
+				LocalityZone:   "us-east-1b",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, nodeLocality(context.Background(), client, &tc.config))
+			assert.Equal(t, tc.want, tc.config)
+		})
+	}
+
+	config := envoy.BootstrapConfig{LocalityNode: "node-b"}
+	require.EqualError(t, nodeLocality(context.Background(), client, &config), `failed to get node "node-b": nodes "node-b" not found`)
+}
@@ -14,6 +14,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"os"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -77,7 +79,11 @@ func main() {
 
 	gatewayProvisioner, gatewayProvisionerConfig := registerGatewayProvisioner(app)
 
+	render, renderCtx := registerRender(app)
+
 	serve, serveCtx := registerServe(app)
+
+	validate, validateCtx := registerValidate(app)
 	version := app.Command("version", "Build information for Contour.")
 
 	args := os.Args[1:]
@@ -104,6 +110,15 @@ func main() {
 		if err := envoy.ValidAdminAddress(bootstrapCtx.AdminAddress); err != nil {
 			log.WithField("flag", "--admin-address").WithError(err).Fatal("failed to parse bootstrap args")
 		}
+		if bootstrapCtx.LocalityNode != "" {
+			coreClient, err := k8s.NewCoreClient("", true)
+			if err != nil {
+				log.WithError(err).Fatal("failed to create Kubernetes client")
+			}
+			if err := nodeLocality(context.Background(), coreClient, bootstrapCtx); err != nil {
+				log.WithField("flag", "--locality-node").WithError(err).Fatal("failed to look up node locality")
+			}
+		}
 		if err := envoy_v3.WriteBootstrap(bootstrapCtx); err != nil {
 			log.WithError(err).Fatal("failed to write bootstrap configuration")
 		}
@@ -149,6 +164,17 @@ func main() {
 			stream := client.RouteStream()
 			watchstream(log, stream, resource_v3.SecretType, resources, client.Nack, client.NodeID)
 		}
+	case render.FullCommand():
+		if err := doRender(renderCtx, log, os.Stdout); err != nil {
+			log.WithError(err).Fatal("failed to render configuration")
+		}
+	case validate.FullCommand():
+		if err := doValidate(validateCtx, log, os.Stdout); err != nil {
+			if errors.Is(err, errValidationFailed) {
+				os.Exit(1)
+			}
+			log.WithError(err).Fatal("failed to validate manifests")
+		}
 	case serve.FullCommand():
 		// Parse args a second time so cli flags are applied
 		// on top of any values sourced from -c's config file.
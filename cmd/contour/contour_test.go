@@ -55,6 +55,12 @@ func TestOptionFlagsAreSorted(t *testing.T) {
 	gatewayProvisioner, _ := registerGatewayProvisioner(app)
 	assertOptionFlagsAreSorted(t, gatewayProvisioner)
 
+	render, _ := registerRender(app)
+	assertOptionFlagsAreSorted(t, render)
+
+	validate, _ := registerValidate(app)
+	assertOptionFlagsAreSorted(t, validate)
+
 	serve, _ := registerServe(app)
 	assertOptionFlagsAreSorted(t, serve)
 }
@@ -0,0 +1,806 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_filter_http_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	resource_v3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	core_v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/contourconfig"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/xdscache"
+	"github.com/projectcontour/contour/pkg/config"
+)
+
+// registerRender registers the render subcommand and flags
+// with the Application provided.
+func registerRender(app *kingpin.Application) (*kingpin.CmdClause, *renderContext) {
+	ctx := &renderContext{}
+
+	render := app.Command("render", "Render the Envoy configuration for Kubernetes manifests without a cluster.")
+	render.Arg("paths", "Manifest files or directories to read, or - to read from stdin.").Required().StringsVar(&ctx.paths)
+
+	render.Flag("config-path", "Path to base configuration.").Short('c').PlaceHolder("/path/to/file").ExistingFileVar(&ctx.configFile)
+	render.Flag("namespace", "Namespace of manifests that do not set one.").Short('n').Default("default").StringVar(&ctx.namespace)
+	render.Flag("output", "Output format for the rendered configuration.").Short('o').Default("yaml").EnumVar(&ctx.output, "yaml", "json")
+
+	return render, ctx
+}
+
+// renderContext holds the configuration for the render subcommand.
+type renderContext struct {
+	// configFile is the path to a Contour configuration file.
+	configFile string
+
+	// namespace is the namespace given to namespaced objects
+	// whose manifests do not set one.
+	namespace string
+
+	// output is the output format, either yaml or json.
+	output string
+
+	// paths are the manifest files and directories to read.
+	paths []string
+}
+
+// renderedConfig is the output of the render subcommand.
+type renderedConfig struct {
+	Listeners        []json.RawMessage `json:"listeners"`
+	Routes           []json.RawMessage `json:"routes"`
+	Clusters         []json.RawMessage `json:"clusters"`
+	Endpoints        []json.RawMessage `json:"endpoints"`
+	ExtensionConfigs []json.RawMessage `json:"extensionConfigs"`
+	Secrets          []json.RawMessage `json:"secrets"`
+	Status           []renderedStatus  `json:"status"`
+}
+
+// renderedStatus is the status Contour computed for an object.
+type renderedStatus struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Status     any    `json:"status"`
+}
+
+// doRender runs the render subcommand, writing the rendered configuration to w.
+func doRender(ctx *renderContext, log logrus.FieldLogger, w io.Writer) error {
+	contourConfiguration, err := offlineConfig(ctx.configFile)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := k8s.NewContourScheme()
+	if err != nil {
+		return fmt.Errorf("unable to create scheme: %w", err)
+	}
+
+	objects, _, err := readManifests(log, scheme, ctx.namespace, ctx.paths)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderObjects(log, scheme, contourConfiguration, objects)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(rendered, "", "  ")
+	if err != nil {
+		return err
+	}
+	if ctx.output == "yaml" {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// offlineConfig returns the Contour configuration read from configFile,
+// overlaid on the defaults in the same way as contour serve does.
+func offlineConfig(configFile string) (contour_v1alpha1.ContourConfigurationSpec, error) {
+	ctx := newServeContext()
+
+	if configFile != "" {
+		f, err := os.Open(configFile)
+		if err != nil {
+			return contour_v1alpha1.ContourConfigurationSpec{}, err
+		}
+		defer f.Close()
+
+		params, err := config.Parse(f)
+		if err != nil {
+			return contour_v1alpha1.ContourConfigurationSpec{}, err
+		}
+
+		if err := params.Validate(); err != nil {
+			return contour_v1alpha1.ContourConfigurationSpec{}, fmt.Errorf("invalid Contour configuration: %w", err)
+		}
+
+		ctx.Config = *params
+	}
+
+	contourConfiguration, err := contourconfig.OverlayOnDefaults(ctx.convertToContourConfigurationSpec())
+	if err != nil {
+		return contour_v1alpha1.ContourConfigurationSpec{}, err
+	}
+
+	if err := contourConfiguration.Validate(); err != nil {
+		return contour_v1alpha1.ContourConfigurationSpec{}, err
+	}
+
+	return contourConfiguration, nil
+}
+
+// offlineBuild loads objects into a DAG builder configured like the one
+// contour serve uses and builds the DAG. It returns the DAG along with the
+// xDS resource caches, which have observed the DAG and the endpoints found
+// in objects. If ingressErrorSink is not nil, it is called with each
+// problem found with an Ingress.
+func offlineBuild(log logrus.FieldLogger, scheme *runtime.Scheme, contourConfiguration contour_v1alpha1.ContourConfigurationSpec, objects []client.Object, ingressErrorSink func(dag.IngressError)) (*dag.DAG, []xdscache.ResourceCache, error) {
+	reader := newManifestReader(scheme, objects)
+
+	s := &Server{
+		log:       log,
+		apiReader: reader,
+	}
+
+	timeouts, err := contourconfig.ParseTimeoutPolicy(contourConfiguration.Envoy.Timeouts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources, endpointHandler, err := s.getResourceCaches(contourConfiguration, timeouts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbc := newDAGBuilderConfig(contourConfiguration, timeouts.ConnectTimeout)
+	dbc.client = reader
+	dbc.ingressErrorSink = ingressErrorSink
+	builder := s.getDAGBuilder(dbc)
+
+	var endpoints []client.Object
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *core_v1.Endpoints, *discovery_v1.EndpointSlice, *core_v1.Node, *core_v1.Pod:
+			endpoints = append(endpoints, obj)
+		case *core_v1.Secret, *core_v1.ConfigMap, *core_v1.Service, *core_v1.Namespace,
+			*networking_v1.Ingress,
+			*contour_v1.HTTPProxy, *contour_v1.TLSCertificateDelegation,
+			*contour_v1alpha1.ExtensionService, *contour_v1alpha1.Backend,
+			*gatewayapi_v1.GatewayClass, *gatewayapi_v1.Gateway, *gatewayapi_v1.HTTPRoute, *gatewayapi_v1.GRPCRoute,
+			*gatewayapi_v1alpha2.TLSRoute, *gatewayapi_v1alpha2.TCPRoute, *gatewayapi_v1alpha2.UDPRoute,
+			*gatewayapi_v1beta1.ReferenceGrant, *gatewayapi_v1alpha3.BackendTLSPolicy, *gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
+			builder.Source.Insert(obj)
+		default:
+			log.WithField("kind", obj.GetObjectKind().GroupVersionKind().Kind).
+				WithField("name", k8s.NamespacedNameOf(obj)).
+				Debug("ignoring object not used by Contour")
+		}
+	}
+
+	latestDAG := builder.Build()
+	for _, r := range resources {
+		r.OnChange(latestDAG)
+	}
+
+	// Endpoints are handled after the DAG is built, so the
+	// translator knows which service clusters are in use.
+	endpointSlices := contourConfiguration.FeatureFlags.IsEndpointSliceEnabled()
+	topologyAwareRouting := contourConfiguration.Envoy.Cluster.TopologyAwareRouting
+	enableSubsetLoadBalancing := contourConfiguration.Envoy.Cluster.SubsetLoadBalancing != nil &&
+		contourConfiguration.Envoy.Cluster.SubsetLoadBalancing.Enabled
+
+	for _, obj := range endpoints {
+		switch obj := obj.(type) {
+		case *core_v1.Endpoints:
+			if !endpointSlices {
+				endpointHandler.OnAdd(obj, true)
+			}
+		case *discovery_v1.EndpointSlice:
+			if endpointSlices {
+				endpointHandler.OnAdd(obj, true)
+			}
+		case *core_v1.Node:
+			if endpointSlices && topologyAwareRouting != nil && topologyAwareRouting.Enabled {
+				endpointHandler.OnAdd(obj, true)
+			}
+		case *core_v1.Pod:
+			// Only the labels of Pods are needed for subset load balancing.
+			if endpointSlices && enableSubsetLoadBalancing {
+				pod := &meta_v1.PartialObjectMetadata{ObjectMeta: obj.ObjectMeta}
+				pod.SetGroupVersionKind(core_v1.SchemeGroupVersion.WithKind("Pod"))
+				endpointHandler.OnAdd(pod, true)
+			}
+		}
+	}
+
+	return latestDAG, resources, nil
+}
+
+// renderObjects builds the DAG for objects and returns the resulting
+// Envoy configuration along with the status of each object.
+func renderObjects(log logrus.FieldLogger, scheme *runtime.Scheme, contourConfiguration contour_v1alpha1.ContourConfigurationSpec, objects []client.Object) (*renderedConfig, error) {
+	latestDAG, caches, err := offlineBuild(log, scheme, contourConfiguration, objects, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &renderedConfig{
+		Listeners:        []json.RawMessage{},
+		Routes:           []json.RawMessage{},
+		Clusters:         []json.RawMessage{},
+		Endpoints:        []json.RawMessage{},
+		ExtensionConfigs: []json.RawMessage{},
+		Secrets:          []json.RawMessage{},
+		Status:           []renderedStatus{},
+	}
+	for _, c := range caches {
+		var messages *[]json.RawMessage
+
+		switch c.TypeURL() {
+		case resource_v3.ListenerType:
+			messages = &rendered.Listeners
+		case resource_v3.RouteType:
+			messages = &rendered.Routes
+		case resource_v3.ClusterType:
+			messages = &rendered.Clusters
+		case resource_v3.EndpointType:
+			messages = &rendered.Endpoints
+		case resource_v3.ExtensionConfigType:
+			messages = &rendered.ExtensionConfigs
+		case resource_v3.SecretType:
+			messages = &rendered.Secrets
+		default:
+			continue
+		}
+
+		for _, m := range c.Contents() {
+			if secret, ok := m.(*envoy_transport_socket_tls_v3.Secret); ok {
+				m = redactSecret(secret)
+			}
+			m = redactBasicAuth(m)
+
+			data, err := protojson.Marshal(m)
+			if err != nil {
+				return nil, err
+			}
+			*messages = append(*messages, data)
+		}
+	}
+
+	rendered.Status, err = renderStatus(scheme, latestDAG, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return rendered, nil
+}
+
+// redactSecret returns a copy of secret with its private data replaced,
+// so that rendered configuration can be shared safely.
+func redactSecret(secret *envoy_transport_socket_tls_v3.Secret) *envoy_transport_socket_tls_v3.Secret {
+	secret = proto.Clone(secret).(*envoy_transport_socket_tls_v3.Secret)
+	switch t := secret.Type.(type) {
+	case *envoy_transport_socket_tls_v3.Secret_TlsCertificate:
+		t.TlsCertificate.PrivateKey = redactedDataSource()
+	case *envoy_transport_socket_tls_v3.Secret_GenericSecret:
+		t.GenericSecret.Secret = redactedDataSource()
+	}
+
+	return secret
+}
+
+// redactBasicAuth returns a copy of m with the htpasswd users of every
+// basic_auth filter config it holds replaced. Contour serves these configs
+// over ECDS, but they are also looked for in listener filters and
+// per-route filter configs, embedded at any depth.
+func redactBasicAuth(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	redactBasicAuthUsers(m.ProtoReflect())
+	return m
+}
+
+// redactBasicAuthUsers replaces the htpasswd users of the basic_auth
+// filter configs in m and in the messages it holds, and reports whether
+// any were replaced.
+func redactBasicAuthUsers(m protoreflect.Message) bool {
+	switch msg := m.Interface().(type) {
+	case *envoy_filter_http_basic_auth_v3.BasicAuth:
+		msg.Users = redactedDataSource()
+		return true
+	case *envoy_filter_http_basic_auth_v3.BasicAuthPerRoute:
+		msg.Users = redactedDataSource()
+		return true
+	case *anypb.Any:
+		inner, err := msg.UnmarshalNew()
+		if err != nil || !redactBasicAuthUsers(inner.ProtoReflect()) {
+			return false
+		}
+		return msg.MarshalFrom(inner) == nil
+	}
+
+	redacted := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redacted = redactBasicAuthUsers(mv.Message()) || redacted
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					redacted = redactBasicAuthUsers(v.List().Get(i).Message()) || redacted
+				}
+			}
+		case fd.Message() != nil:
+			redacted = redactBasicAuthUsers(v.Message()) || redacted
+		}
+		return true
+	})
+
+	return redacted
+}
+
+// redactedDataSource returns the data source that stands in for
+// private data in rendered configuration.
+func redactedDataSource() *envoy_config_core_v3.DataSource {
+	return &envoy_config_core_v3.DataSource{
+		Specifier: &envoy_config_core_v3.DataSource_InlineString{
+			InlineString: "[redacted]",
+		},
+	}
+}
+
+// renderStatus returns the status computed for each object while
+// building latestDAG, sorted by kind, namespace and name.
+func renderStatus(scheme *runtime.Scheme, latestDAG *dag.DAG, objects []client.Object) ([]renderedStatus, error) {
+	updated, err := statusObjects(scheme, latestDAG, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []renderedStatus{}
+	for _, obj := range updated {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		statuses = append(statuses, renderedStatus{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			// Transition times only reflect when the command ran,
+			// drop them to keep the output stable.
+			Status: withoutTransitionTimes(u["status"]),
+		})
+	}
+
+	slices.SortFunc(statuses, func(a, b renderedStatus) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return statuses, nil
+}
+
+// statusObjects returns a copy of each of objects that had its status
+// computed while building latestDAG, with that status applied.
+func statusObjects(scheme *runtime.Scheme, latestDAG *dag.DAG, objects []client.Object) ([]client.Object, error) {
+	byKey := map[manifestKey]client.Object{}
+	for _, obj := range objects {
+		byKey[manifestKey{gvk: obj.GetObjectKind().GroupVersionKind(), name: client.ObjectKeyFromObject(obj)}] = obj
+	}
+
+	var updated []client.Object
+	for _, upd := range latestDAG.StatusCache.GetStatusUpdates() {
+		gvk, err := apiutil.GVKForObject(upd.Resource, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, ok := byKey[manifestKey{gvk: gvk, name: upd.NamespacedName}]
+		if !ok {
+			continue
+		}
+
+		updated = append(updated, upd.Mutator.Mutate(obj.DeepCopyObject().(client.Object)))
+	}
+
+	return updated, nil
+}
+
+// withoutTransitionTimes removes all lastTransitionTime fields from v.
+func withoutTransitionTimes(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, "lastTransitionTime")
+		for k := range v {
+			v[k] = withoutTransitionTimes(v[k])
+		}
+	case []any:
+		for i := range v {
+			v[i] = withoutTransitionTimes(v[i])
+		}
+	}
+	return v
+}
+
+// clusterScopedKinds are the kinds read by Contour that are not namespaced.
+var clusterScopedKinds = map[string]bool{
+	"GatewayClass": true,
+	"IngressClass": true,
+	"Namespace":    true,
+	"Node":         true,
+}
+
+// readManifests decodes the Kubernetes objects in the manifests found at
+// paths. A path can be a file, a directory, whose .yaml, .yml and .json
+// files are read recursively, or - for stdin. Objects of kinds not known
+// to Contour are skipped, namespaced objects without a namespace are put
+// in namespace. CustomResourceDefinitions found in the manifests are not
+// returned but their schema defaults are applied to the custom resources
+// they define, as the API server would. The file each object was read
+// from is also returned.
+func readManifests(log logrus.FieldLogger, scheme *runtime.Scheme, namespace string, paths []string) ([]client.Object, map[manifestKey]string, error) {
+	var items []manifestItem
+
+	read := func(source string, r io.Reader) error {
+		docs := utilyaml.NewYAMLReader(bufio.NewReader(r))
+		for {
+			doc, err := docs.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", source, err)
+			}
+
+			objs, err := manifestItems(doc)
+			if err != nil {
+				return fmt.Errorf("%s: %w", source, err)
+			}
+			for _, obj := range objs {
+				items = append(items, manifestItem{source: source, obj: obj})
+			}
+		}
+	}
+
+	for _, path := range paths {
+		if path == "-" {
+			if err := read("<stdin>", os.Stdin); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		var files []string
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// Files named explicitly are read whatever their extension.
+			if p == path || slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(p)) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := read(file, bytes.NewReader(data)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	schemas := map[schema.GroupVersionKind]*apiextensions_v1.JSONSchemaProps{}
+	for _, item := range items {
+		if item.gvk() != crdGVK {
+			continue
+		}
+		if err := addCRDSchemas(schemas, item.obj); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", item.source, err)
+		}
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+	seen := map[manifestKey]string{}
+	undefaulted := map[schema.GroupVersionKind]bool{}
+
+	for _, item := range items {
+		itemGVK := item.gvk()
+		if itemGVK == crdGVK {
+			continue
+		}
+
+		if s, ok := schemas[itemGVK]; ok {
+			if err := applyDefaults(item.obj, s); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", item.source, err)
+			}
+		} else if customResourceGroups[itemGVK.Group] && !undefaulted[itemGVK] {
+			undefaulted[itemGVK] = true
+			log.WithField("kind", itemGVK.String()).Warn("no CustomResourceDefinition found, defaults will not be applied")
+		}
+
+		data, err := json.Marshal(item.obj)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", item.source, err)
+		}
+
+		runtimeObj, gvk, err := decoder.Decode(data, nil, nil)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) {
+				log.WithField("source", item.source).Warnf("skipping object of unknown kind: %s", err)
+				continue
+			}
+			return nil, nil, fmt.Errorf("%s: %w", item.source, err)
+		}
+
+		obj, ok := runtimeObj.(client.Object)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: unsupported object of kind %q", item.source, gvk.Kind)
+		}
+
+		obj.GetObjectKind().SetGroupVersionKind(*gvk)
+		if obj.GetNamespace() == "" && !clusterScopedKinds[gvk.Kind] {
+			obj.SetNamespace(namespace)
+		}
+
+		key := manifestKey{gvk: *gvk, name: client.ObjectKeyFromObject(obj)}
+		if prev, ok := seen[key]; ok {
+			return nil, nil, fmt.Errorf("%s: %s %s is also defined in %s", item.source, gvk.Kind, key.name, prev)
+		}
+		seen[key] = item.source
+
+		objects = append(objects, obj)
+	}
+
+	return objects, seen, nil
+}
+
+// customResourceGroups are the API groups of the custom resources read
+// by Contour.
+var customResourceGroups = map[string]bool{
+	contour_v1.GroupName:    true,
+	gatewayapi_v1.GroupName: true,
+}
+
+var crdGVK = apiextensions_v1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+
+// manifestItem is an object read from a manifest, before decoding.
+type manifestItem struct {
+	source string
+	obj    map[string]any
+}
+
+func (m manifestItem) gvk() schema.GroupVersionKind {
+	apiVersion, _ := m.obj["apiVersion"].(string)
+	kind, _ := m.obj["kind"].(string)
+	return schema.FromAPIVersionAndKind(apiVersion, kind)
+}
+
+// addCRDSchemas adds the schema of each version of the
+// CustomResourceDefinition crd to schemas.
+func addCRDSchemas(schemas map[schema.GroupVersionKind]*apiextensions_v1.JSONSchemaProps, crd map[string]any) error {
+	var def apiextensions_v1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(crd, &def); err != nil {
+		return err
+	}
+
+	for _, version := range def.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: def.Spec.Group, Version: version.Name, Kind: def.Spec.Names.Kind}
+		schemas[gvk] = version.Schema.OpenAPIV3Schema
+	}
+
+	return nil
+}
+
+// applyDefaults sets the defaults declared by the schema s on the fields
+// missing from v, following the API server's defaulting of custom
+// resources.
+func applyDefaults(v any, s *apiextensions_v1.JSONSchemaProps) error {
+	if s == nil {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for name, prop := range s.Properties {
+			if _, ok := v[name]; ok || prop.Default == nil {
+				continue
+			}
+			var def any
+			if err := json.Unmarshal(prop.Default.Raw, &def); err != nil {
+				return fmt.Errorf("invalid default for %q: %w", name, err)
+			}
+			v[name] = def
+		}
+		for name, field := range v {
+			if prop, ok := s.Properties[name]; ok {
+				if err := applyDefaults(field, &prop); err != nil {
+					return err
+				}
+			} else if s.AdditionalProperties != nil {
+				if err := applyDefaults(field, s.AdditionalProperties.Schema); err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		if s.Items == nil {
+			return nil
+		}
+		for _, item := range v {
+			if err := applyDefaults(item, s.Items.Schema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// manifestItems returns the objects held by a YAML or JSON document,
+// expanding lists and dropping any status, since the status is for
+// Contour to compute.
+func manifestItems(doc []byte) ([]map[string]any, error) {
+	data, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	if len(obj) == 0 {
+		return nil, nil
+	}
+
+	var objs []map[string]any
+	if kind, _ := obj["kind"].(string); strings.HasSuffix(kind, "List") {
+		items, _ := obj["items"].([]any)
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				objs = append(objs, m)
+			}
+		}
+	} else {
+		objs = append(objs, obj)
+	}
+
+	for _, o := range objs {
+		delete(o, "status")
+	}
+
+	return objs, nil
+}
+
+// manifestKey identifies an object read from a manifest.
+type manifestKey struct {
+	gvk  schema.GroupVersionKind
+	name types.NamespacedName
+}
+
+// manifestReader is a client.Reader serving the objects read from
+// manifests, standing in for the API server in offline commands.
+type manifestReader struct {
+	scheme  *runtime.Scheme
+	objects map[manifestKey]client.Object
+}
+
+func newManifestReader(scheme *runtime.Scheme, objects []client.Object) *manifestReader {
+	r := &manifestReader{
+		scheme:  scheme,
+		objects: map[manifestKey]client.Object{},
+	}
+	for _, obj := range objects {
+		r.objects[manifestKey{gvk: obj.GetObjectKind().GroupVersionKind(), name: client.ObjectKeyFromObject(obj)}] = obj
+	}
+	return r
+}
+
+func (r *manifestReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return err
+	}
+
+	found, ok := r.objects[manifestKey{gvk: gvk, name: key}]
+	if !ok {
+		return api_errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(found.DeepCopyObject()).Elem())
+	return nil
+}
+
+func (r *manifestReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return errors.New("listing objects is not supported when reading manifests")
+}
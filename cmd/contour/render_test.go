@@ -0,0 +1,294 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/protobuf"
+)
+
+const renderManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: kuard
+spec:
+  ports:
+  - name: http
+    port: 80
+    targetPort: 8080
+---
+apiVersion: discovery.k8s.io/v1
+kind: EndpointSlice
+metadata:
+  name: kuard-abc
+  labels:
+    kubernetes.io/service-name: kuard
+addressType: IPv4
+endpoints:
+- addresses: ["10.0.0.1"]
+ports:
+- name: http
+  port: 8080
+  protocol: TCP
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: projectcontour.io/v1
+  kind: HTTPProxy
+  metadata:
+    name: kuard
+  spec:
+    virtualhost:
+      fqdn: kuard.example.com
+    routes:
+    - services:
+      - name: kuard
+        port: 80
+  status:
+    currentStatus: stale
+- apiVersion: projectcontour.io/v1
+  kind: HTTPProxy
+  metadata:
+    name: broken
+  spec:
+    virtualhost:
+      fqdn: broken.example.com
+    routes:
+    - services:
+      - name: missing
+        port: 80
+`
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(renderManifests), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+
+	var out bytes.Buffer
+	ctx := &renderContext{
+		namespace: "default",
+		output:    "json",
+		paths:     []string{dir},
+	}
+	require.NoError(t, doRender(ctx, fixture.NewTestLogger(t), &out))
+
+	var rendered struct {
+		Clusters []struct {
+			Name string `json:"name"`
+		} `json:"clusters"`
+		Endpoints []struct {
+			ClusterName string `json:"clusterName"`
+		} `json:"endpoints"`
+		Status []struct {
+			Name   string `json:"name"`
+			Status struct {
+				CurrentStatus string `json:"currentStatus"`
+			} `json:"status"`
+		} `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rendered))
+
+	require.Len(t, rendered.Clusters, 1)
+	assert.Equal(t, "default/kuard/80/da39a3ee5e", rendered.Clusters[0].Name)
+	require.Len(t, rendered.Endpoints, 1)
+	assert.Equal(t, "default/kuard/http", rendered.Endpoints[0].ClusterName)
+
+	require.Len(t, rendered.Status, 2)
+	assert.Equal(t, "broken", rendered.Status[0].Name)
+	assert.Equal(t, "invalid", rendered.Status[0].Status.CurrentStatus)
+	assert.Equal(t, "kuard", rendered.Status[1].Name)
+	assert.Equal(t, "valid", rendered.Status[1].Status.CurrentStatus)
+}
+
+func TestRenderRedactsBasicAuth(t *testing.T) {
+	const users = "admin:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"
+
+	manifests := fmt.Sprintf(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: tls
+type: kubernetes.io/tls
+data:
+  tls.crt: %s
+  tls.key: %s
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: users
+type: Opaque
+data:
+  auth: %s
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: kuard
+spec:
+  ports:
+  - name: http
+    port: 80
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: kuard
+spec:
+  virtualhost:
+    fqdn: kuard.example.com
+    tls:
+      secretName: tls
+    basicAuth:
+      secretName: users
+  routes:
+  - services:
+    - name: kuard
+      port: 80
+`,
+		base64.StdEncoding.EncodeToString([]byte(fixture.CERTIFICATE)),
+		base64.StdEncoding.EncodeToString([]byte(fixture.RSA_PRIVATE_KEY)),
+		base64.StdEncoding.EncodeToString([]byte(users)),
+	)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(manifests), 0o600))
+
+	var out bytes.Buffer
+	ctx := &renderContext{
+		namespace: "default",
+		output:    "json",
+		paths:     []string{dir},
+	}
+	require.NoError(t, doRender(ctx, fixture.NewTestLogger(t), &out))
+
+	var rendered struct {
+		ExtensionConfigs []struct {
+			Name        string `json:"name"`
+			TypedConfig struct {
+				Users struct {
+					InlineString string `json:"inlineString"`
+				} `json:"users"`
+			} `json:"typedConfig"`
+		} `json:"extensionConfigs"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rendered))
+
+	require.Len(t, rendered.ExtensionConfigs, 1)
+	assert.Equal(t, "envoy.filters.http.basic_auth/kuard.example.com", rendered.ExtensionConfigs[0].Name)
+	assert.Equal(t, "[redacted]", rendered.ExtensionConfigs[0].TypedConfig.Users.InlineString)
+
+	assert.NotContains(t, out.String(), "W6ph5Mm5Pz8GgiULbPgzG37mj9g")
+	assert.NotContains(t, out.String(), base64.StdEncoding.EncodeToString([]byte(users)))
+}
+
+func TestRedactBasicAuth(t *testing.T) {
+	users := &envoy_config_core_v3.DataSource{
+		Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+			InlineBytes: []byte("admin:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="),
+		},
+	}
+
+	route := &envoy_config_route_v3.RouteConfiguration{
+		Name: "https/kuard.example.com",
+		VirtualHosts: []*envoy_config_route_v3.VirtualHost{{
+			Name: "kuard.example.com",
+			Routes: []*envoy_config_route_v3.Route{{
+				TypedPerFilterConfig: map[string]*anypb.Any{
+					"envoy.filters.http.basic_auth": protobuf.MustMarshalAny(&envoy_filter_http_basic_auth_v3.BasicAuthPerRoute{
+						Users: users,
+					}),
+				},
+			}},
+		}},
+	}
+
+	redacted := redactBasicAuth(route).(*envoy_config_route_v3.RouteConfiguration)
+
+	perRoute := &envoy_filter_http_basic_auth_v3.BasicAuthPerRoute{}
+	require.NoError(t, redacted.VirtualHosts[0].Routes[0].TypedPerFilterConfig["envoy.filters.http.basic_auth"].UnmarshalTo(perRoute))
+	assert.Equal(t, "[redacted]", perRoute.Users.GetInlineString())
+
+	// The original is left untouched.
+	original := &envoy_filter_http_basic_auth_v3.BasicAuthPerRoute{}
+	require.NoError(t, route.VirtualHosts[0].Routes[0].TypedPerFilterConfig["envoy.filters.http.basic_auth"].UnmarshalTo(original))
+	assert.Equal(t, users.GetInlineBytes(), original.Users.GetInlineBytes())
+}
+
+func TestReadManifestsDuplicate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(renderManifests), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(renderManifests), 0o600))
+
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	_, _, err = readManifests(fixture.NewTestLogger(t), scheme, "default", []string{dir})
+	require.ErrorContains(t, err, "is also defined in")
+}
+
+func TestApplyDefaults(t *testing.T) {
+	s := &apiextensions_v1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions_v1.JSONSchemaProps{
+			"backendRefs": {
+				Type: "array",
+				Items: &apiextensions_v1.JSONSchemaPropsOrArray{
+					Schema: &apiextensions_v1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensions_v1.JSONSchemaProps{
+							"group":  {Type: "string", Default: &apiextensions_v1.JSON{Raw: []byte(`""`)}},
+							"kind":   {Type: "string", Default: &apiextensions_v1.JSON{Raw: []byte(`"Service"`)}},
+							"weight": {Type: "integer", Default: &apiextensions_v1.JSON{Raw: []byte(`1`)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	obj := map[string]any{
+		"backendRefs": []any{
+			map[string]any{"name": "kuard"},
+			map[string]any{"name": "other", "kind": "Backend", "weight": float64(5)},
+		},
+	}
+	require.NoError(t, applyDefaults(obj, s))
+
+	assert.Equal(t, map[string]any{
+		"backendRefs": []any{
+			map[string]any{"name": "kuard", "group": "", "kind": "Service", "weight": float64(1)},
+			map[string]any{"name": "other", "group": "", "kind": "Backend", "weight": float64(5)},
+		},
+	}, obj)
+}
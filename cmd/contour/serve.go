@@ -15,6 +15,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -29,6 +30,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	discovery_v1 "k8s.io/api/discovery/v1"
 	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,10 +44,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	controller_runtime_metrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	controller_runtime_metrics_server "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrl_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -61,6 +66,7 @@ import (
 	"github.com/projectcontour/contour/internal/leadership"
 	"github.com/projectcontour/contour/internal/metrics"
 	"github.com/projectcontour/contour/internal/timeout"
+	"github.com/projectcontour/contour/internal/webhook"
 	"github.com/projectcontour/contour/internal/xds"
 	contour_xds_v3 "github.com/projectcontour/contour/internal/xds/v3"
 	"github.com/projectcontour/contour/internal/xdscache"
@@ -134,7 +140,7 @@ func registerServe(app *kingpin.Application) (*kingpin.CmdClause, *serveContext)
 	serve.Flag("debug", "Enable debug logging.").Short('d').BoolVar(&ctx.Config.Debug)
 	serve.Flag("debug-http-address", "Address the debug http endpoint will bind to.").PlaceHolder("<ipaddr>").StringVar(&ctx.debugAddr)
 	serve.Flag("debug-http-port", "Port the debug http endpoint will bind to.").PlaceHolder("<port>").IntVar(&ctx.debugPort)
-	serve.Flag("disable-feature", "Do not start an informer for the specified resources.").PlaceHolder("<extensionservices,tlsroutes,grpcroutes,tcproutes,backendtlspolicies>").EnumsVar(&ctx.disabledFeatures, "extensionservices", "tlsroutes", "grpcroutes", "tcproutes", "backendtlspolicies")
+	serve.Flag("disable-feature", "Do not start an informer for the specified resources.").PlaceHolder("<extensionservices,backends,tlsroutes,grpcroutes,tcproutes,udproutes,backendtlspolicies,xbackendtrafficpolicies>").EnumsVar(&ctx.disabledFeatures, "extensionservices", "backends", "tlsroutes", "grpcroutes", "tcproutes", "udproutes", "backendtlspolicies", "xbackendtrafficpolicies")
 	serve.Flag("disable-leader-election", "Disable leader election mechanism.").BoolVar(&ctx.LeaderElection.Disable)
 
 	serve.Flag("envoy-http-access-log", "Envoy HTTP access log.").PlaceHolder("/path/to/file").StringVar(&ctx.httpAccessLog)
@@ -175,6 +181,9 @@ func registerServe(app *kingpin.Application) (*kingpin.CmdClause, *serveContext)
 	serve.Flag("use-proxy-protocol", "Use PROXY protocol for all listeners.").BoolVar(&ctx.useProxyProto)
 
 	serve.Flag("watch-namespaces", "Restrict contour to watch resources in these namespaces only.").PlaceHolder("<ns,ns>").StringVar(&ctx.watchNamespaces)
+	serve.Flag("webhook-address", "Address the HTTPProxy validating webhook will bind to.").PlaceHolder("<ipaddr>").StringVar(&ctx.webhookAddr)
+	serve.Flag("webhook-cert-dir", "Directory with the tls.crt and tls.key files for serving the HTTPProxy validating webhook. The webhook is only served if set.").PlaceHolder("/path/to/dir").StringVar(&ctx.webhookCertDir)
+	serve.Flag("webhook-port", "Port the HTTPProxy validating webhook will bind to.").PlaceHolder("<port>").IntVar(&ctx.webhookPort)
 
 	serve.Flag("xds-address", "xDS gRPC API address.").PlaceHolder("<ipaddr>").StringVar(&ctx.xdsAddr)
 	serve.Flag("xds-port", "xDS gRPC API port.").PlaceHolder("<port>").IntVar(&ctx.xdsPort)
@@ -189,6 +198,10 @@ type Server struct {
 	mgr               manager.Manager
 	registry          *prometheus.Registry
 	handlerCacheSyncs []cache.InformerSynced
+
+	// apiReader reads objects directly from the API server, since
+	// the manager's caches are not started until the manager is.
+	apiReader client.Reader
 }
 
 type EndpointsTranslator interface {
@@ -337,6 +350,7 @@ func NewServer(log logrus.FieldLogger, ctx *serveContext) (*Server, error) {
 		coreClient: coreClient,
 		mgr:        mgr,
 		registry:   registry,
+		apiReader:  mgr.GetAPIReader(),
 	}, nil
 }
 
@@ -357,9 +371,7 @@ func (s *Server) getConfig() (contour_v1alpha1.ContourConfigurationSpec, error)
 		contourConfig := &contour_v1alpha1.ContourConfiguration{}
 		key := client.ObjectKey{Namespace: contourNamespace, Name: s.ctx.contourConfigurationName}
 
-		// Using GetAPIReader() here because the manager's caches won't be started yet,
-		// so reads from the manager's client (which uses the caches for reads) will fail.
-		if err := s.mgr.GetAPIReader().Get(context.Background(), key, contourConfig); err != nil {
+		if err := s.apiReader.Get(context.Background(), key, contourConfig); err != nil {
 			return contour_v1alpha1.ContourConfigurationSpec{}, fmt.Errorf("error getting contour configuration %s: %v", key, err)
 		}
 
@@ -446,64 +458,16 @@ func (s *Server) doServe() error {
 		return err
 	}
 
-	listenerConfig := xdscache_v3.ListenerConfig{
-		UseProxyProto:                 *contourConfiguration.Envoy.Listener.UseProxyProto,
-		HTTPAccessLog:                 contourConfiguration.Envoy.HTTPListener.AccessLog,
-		HTTPSAccessLog:                contourConfiguration.Envoy.HTTPSListener.AccessLog,
-		AccessLogType:                 contourConfiguration.Envoy.Logging.AccessLogFormat,
-		AccessLogJSONFields:           contourConfiguration.Envoy.Logging.AccessLogJSONFields,
-		AccessLogLevel:                contourConfiguration.Envoy.Logging.AccessLogLevel,
-		AccessLogFormatString:         contourConfiguration.Envoy.Logging.AccessLogFormatString,
-		AccessLogFormatterExtensions:  contourConfiguration.Envoy.Logging.AccessLogFormatterExtensions(),
-		MinimumTLSVersion:             annotation.TLSVersion(contourConfiguration.Envoy.Listener.TLS.MinimumProtocolVersion, "1.2"),
-		MaximumTLSVersion:             annotation.TLSVersion(contourConfiguration.Envoy.Listener.TLS.MaximumProtocolVersion, "1.3"),
-		CipherSuites:                  contourConfiguration.Envoy.Listener.TLS.SanitizedCipherSuites(),
-		Timeouts:                      timeouts,
-		DefaultHTTPVersions:           parseDefaultHTTPVersions(contourConfiguration.Envoy.DefaultHTTPVersions),
-		AllowChunkedLength:            !*contourConfiguration.Envoy.Listener.DisableAllowChunkedLength,
-		MergeSlashes:                  !*contourConfiguration.Envoy.Listener.DisableMergeSlashes,
-		ServerHeaderTransformation:    contourConfiguration.Envoy.Listener.ServerHeaderTransformation,
-		XffNumTrustedHops:             *contourConfiguration.Envoy.Network.XffNumTrustedHops,
-		ConnectionBalancer:            contourConfiguration.Envoy.Listener.ConnectionBalancer,
-		MaxRequestsPerConnection:      contourConfiguration.Envoy.Listener.MaxRequestsPerConnection,
-		HTTP2MaxConcurrentStreams:     contourConfiguration.Envoy.Listener.HTTP2MaxConcurrentStreams,
-		PerConnectionBufferLimitBytes: contourConfiguration.Envoy.Listener.PerConnectionBufferLimitBytes,
-		SocketOptions:                 contourConfiguration.Envoy.Listener.SocketOptions,
-	}
-
-	if listenerConfig.TracingConfig, err = s.setupTracingService(contourConfiguration.Tracing); err != nil {
-		return err
-	}
-
-	if listenerConfig.RateLimitConfig, err = s.setupRateLimitService(contourConfiguration); err != nil {
-		return err
-	}
-
-	if listenerConfig.GlobalExternalAuthConfig, err = s.setupGlobalExternalAuthentication(contourConfiguration); err != nil {
+	resources, endpointHandler, err := s.getResourceCaches(contourConfiguration, timeouts)
+	if err != nil {
 		return err
 	}
 
 	contourMetrics := metrics.NewMetrics(s.registry)
 
-	// Endpoints updates are handled directly by the EndpointsTranslator/EndpointSliceTranslator due to the high update volume.
-	var endpointHandler EndpointsTranslator
-	if contourConfiguration.FeatureFlags.IsEndpointSliceEnabled() {
-		endpointHandler = xdscache_v3.NewEndpointSliceTranslator(s.log.WithField("context", "endpointslicetranslator"))
-	} else {
-		endpointHandler = xdscache_v3.NewEndpointsTranslator(s.log.WithField("context", "endpointstranslator"))
-	}
-
-	resources := []xdscache.ResourceCache{
-		xdscache_v3.NewListenerCache(listenerConfig, *contourConfiguration.Envoy.Metrics, *contourConfiguration.Envoy.Health, *contourConfiguration.Envoy.Network.EnvoyAdminPort),
-		xdscache_v3.NewSecretsCache(envoy_v3.StatsSecrets(contourConfiguration.Envoy.Metrics.TLS)),
-		&xdscache_v3.RouteCache{},
-		&xdscache_v3.ClusterCache{},
-		endpointHandler,
-		xdscache_v3.NewRuntimeCache(xdscache_v3.ConfigurableRuntimeSettings{
-			MaxRequestsPerIOCycle:     contourConfiguration.Envoy.Listener.MaxRequestsPerIOCycle,
-			MaxConnectionsPerListener: contourConfiguration.Envoy.Listener.MaxConnectionsPerListener,
-		}),
-	}
+	topologyAwareRouting := contourConfiguration.Envoy.Cluster.TopologyAwareRouting
+	enableSubsetLoadBalancing := contourConfiguration.Envoy.Cluster.SubsetLoadBalancing != nil &&
+		contourConfiguration.Envoy.Cluster.SubsetLoadBalancing.Enabled
 
 	// snapshotHandler triggers go-control-plane Snapshots based on
 	// the contents of the Contour xDS caches after the DAG is built.
@@ -525,62 +489,15 @@ func (s *Server) doServe() error {
 		s.log.WithField("context", "envoy-client-certificate").Infof("enabled client certificate with secret: %q", contourConfiguration.Envoy.ClientCertificate)
 	}
 
-	var ingressClassNames []string
-	if contourConfiguration.Ingress != nil {
-		ingressClassNames = contourConfiguration.Ingress.ClassNames
-	}
-
-	var clientCert *types.NamespacedName
-	var fallbackCert *types.NamespacedName
-	if contourConfiguration.Envoy.ClientCertificate != nil {
-		clientCert = &types.NamespacedName{Name: contourConfiguration.Envoy.ClientCertificate.Name, Namespace: contourConfiguration.Envoy.ClientCertificate.Namespace}
-	}
-	if contourConfiguration.HTTPProxy.FallbackCertificate != nil {
-		fallbackCert = &types.NamespacedName{Name: contourConfiguration.HTTPProxy.FallbackCertificate.Name, Namespace: contourConfiguration.HTTPProxy.FallbackCertificate.Namespace}
-	}
-
 	sh := k8s.NewStatusUpdateHandler(s.log.WithField("context", "StatusUpdateHandler"), s.mgr.GetClient(), contourMetrics)
 	if err := s.mgr.Add(sh); err != nil {
 		return err
 	}
 
-	var gatewayRef *types.NamespacedName
-
-	if contourConfiguration.Gateway != nil {
-		gatewayRef = &types.NamespacedName{
-			Namespace: contourConfiguration.Gateway.GatewayRef.Namespace,
-			Name:      contourConfiguration.Gateway.GatewayRef.Name,
-		}
-	}
-
-	builder := s.getDAGBuilder(dagBuilderConfig{
-		ingressClassNames:                  ingressClassNames,
-		rootNamespaces:                     contourConfiguration.HTTPProxy.RootNamespaces,
-		gatewayRef:                         gatewayRef,
-		disablePermitInsecure:              *contourConfiguration.HTTPProxy.DisablePermitInsecure,
-		enableExternalNameService:          *contourConfiguration.EnableExternalNameService,
-		dnsLookupFamily:                    contourConfiguration.Envoy.Cluster.DNSLookupFamily,
-		headersPolicy:                      contourConfiguration.Policy,
-		clientCert:                         clientCert,
-		fallbackCert:                       fallbackCert,
-		connectTimeout:                     timeouts.ConnectTimeout,
-		client:                             s.mgr.GetClient(),
-		metrics:                            contourMetrics,
-		httpAddress:                        contourConfiguration.Envoy.HTTPListener.Address,
-		httpPort:                           contourConfiguration.Envoy.HTTPListener.Port,
-		httpsAddress:                       contourConfiguration.Envoy.HTTPSListener.Address,
-		httpsPort:                          contourConfiguration.Envoy.HTTPSListener.Port,
-		globalExternalAuthorizationService: contourConfiguration.GlobalExternalAuthorization,
-		globalRateLimitService:             contourConfiguration.RateLimitService,
-		maxRequestsPerConnection:           contourConfiguration.Envoy.Cluster.MaxRequestsPerConnection,
-		perConnectionBufferLimitBytes:      contourConfiguration.Envoy.Cluster.PerConnectionBufferLimitBytes,
-		globalCircuitBreakerDefaults:       contourConfiguration.Envoy.Cluster.GlobalCircuitBreakerDefaults,
-		upstreamTLS: &dag.UpstreamTLS{
-			MinimumProtocolVersion: annotation.TLSVersion(contourConfiguration.Envoy.Cluster.UpstreamTLS.MinimumProtocolVersion, "1.2"),
-			MaximumProtocolVersion: annotation.TLSVersion(contourConfiguration.Envoy.Cluster.UpstreamTLS.MaximumProtocolVersion, "1.3"),
-			CipherSuites:           contourConfiguration.Envoy.Cluster.UpstreamTLS.SanitizedCipherSuites(),
-		},
-	})
+	dbc := newDAGBuilderConfig(contourConfiguration, timeouts.ConnectTimeout)
+	dbc.client = s.mgr.GetClient()
+	dbc.metrics = contourMetrics
+	builder := s.getDAGBuilder(dbc)
 
 	// Build the core Kubernetes event handler.
 	xdsCaches := xdscache.ObserversOf(resources)
@@ -588,9 +505,13 @@ func (s *Server) doServe() error {
 		xdsCaches = append(xdsCaches, snapshotHandler)
 	}
 
+	// latestDAG records each DAG for the debug service, after
+	// the xDS caches have been updated from it.
+	latestDAG := &debug.LatestDAG{}
+
 	observer := contour.NewRebuildMetricsObserver(
 		contourMetrics,
-		dag.ComposeObservers(xdsCaches...),
+		dag.ComposeObservers(append(xdsCaches, latestDAG)...),
 	)
 
 	hasSynced := func() bool {
@@ -622,6 +543,7 @@ func (s *Server) doServe() error {
 		"httpproxies":               &contour_v1.HTTPProxy{},
 		"tlscertificatedelegations": &contour_v1.TLSCertificateDelegation{},
 		"extensionservices":         &contour_v1alpha1.ExtensionService{},
+		"backends":                  &contour_v1alpha1.Backend{},
 		"services":                  &core_v1.Service{},
 		"ingresses":                 &networking_v1.Ingress{},
 	}
@@ -661,6 +583,29 @@ func (s *Server) doServe() error {
 		}); err != nil {
 			s.log.WithError(err).WithField("resource", "endpointslices").Fatal("failed to create informer")
 		}
+
+		// Node localities are only needed for topology aware routing.
+		if topologyAwareRouting != nil && topologyAwareRouting.Enabled {
+			if err := s.informOnResource(&core_v1.Node{}, &contour.EventRecorder{
+				Next:    endpointHandler,
+				Counter: contourMetrics.EventHandlerOperations,
+			}); err != nil {
+				s.log.WithError(err).WithField("resource", "nodes").Fatal("failed to create informer")
+			}
+		}
+
+		// Only the labels of Pods are needed for subset load balancing,
+		// so avoid caching entire Pods.
+		if enableSubsetLoadBalancing {
+			pods := &meta_v1.PartialObjectMetadata{}
+			pods.SetGroupVersionKind(core_v1.SchemeGroupVersion.WithKind("Pod"))
+			if err := s.informOnResource(pods, &contour.EventRecorder{
+				Next:    endpointHandler,
+				Counter: contourMetrics.EventHandlerOperations,
+			}); err != nil {
+				s.log.WithError(err).WithField("resource", "pods").Fatal("failed to create informer")
+			}
+		}
 	} else {
 		if err := s.informOnResource(&core_v1.Endpoints{}, &contour.EventRecorder{
 			Next:    endpointHandler,
@@ -686,7 +631,12 @@ func (s *Server) doServe() error {
 	}
 
 	// Create debug service and register with mgr.
-	if err := s.setupDebugService(*contourConfiguration.Debug, builder); err != nil {
+	if err := s.setupDebugService(*contourConfiguration.Debug, builder, latestDAG); err != nil {
+		return err
+	}
+
+	// Create the HTTPProxy validating webhook if required.
+	if err := s.setupWebhook(contourHandler); err != nil {
 		return err
 	}
 
@@ -695,8 +645,8 @@ func (s *Server) doServe() error {
 		log:               s.log.WithField("context", "loadBalancerStatusWriter"),
 		cache:             s.mgr.GetCache(),
 		lbStatus:          make(chan core_v1.LoadBalancerStatus, 1),
-		ingressClassNames: ingressClassNames,
-		gatewayRef:        gatewayRef,
+		ingressClassNames: dbc.ingressClassNames,
+		gatewayRef:        dbc.gatewayRef,
 		statusUpdater:     sh.Writer(),
 	}
 	if err := s.mgr.Add(lbsw); err != nil {
@@ -758,9 +708,7 @@ func (s *Server) getExtensionSvcConfig(name, namespace string) (xdscache_v3.Exte
 		Name:      name,
 	}
 
-	// Using GetAPIReader() here because the manager's caches won't be started yet,
-	// so reads from the manager's client (which uses the caches for reads) will fail.
-	if err := s.mgr.GetAPIReader().Get(context.Background(), key, extensionSvc); err != nil {
+	if err := s.apiReader.Get(context.Background(), key, extensionSvc); err != nil {
 		return xdscache_v3.ExtensionServiceConfig{}, fmt.Errorf("error getting extension service %s: %v", key, err)
 	}
 
@@ -886,7 +834,44 @@ func (s *Server) setupGlobalExternalAuthentication(contourConfiguration contour_
 	return globalExternalAuthConfig, nil
 }
 
-func (s *Server) setupDebugService(debugConfig contour_v1alpha1.DebugConfig, builder *dag.Builder) error {
+func (s *Server) setupGlobalExternalProcessing(contourConfiguration contour_v1alpha1.ContourConfigurationSpec) (*xdscache_v3.GlobalExternalProcessingConfig, error) {
+	if contourConfiguration.GlobalExternalProcessing == nil {
+		return nil, nil
+	}
+
+	// ensure the specified ExtensionService exists
+	extensionSvcConfig, err := s.getExtensionSvcConfig(contourConfiguration.GlobalExternalProcessing.ExtensionServiceRef.Name, contourConfiguration.GlobalExternalProcessing.ExtensionServiceRef.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response timeout of the global external processing
+	// configuration takes precedence over that of the
+	// ExtensionService.
+	if contourConfiguration.GlobalExternalProcessing.ResponseTimeout != "" {
+		extensionSvcConfig.Timeout, err = timeout.Parse(contourConfiguration.GlobalExternalProcessing.ResponseTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing global external processing response timeout: %v", err)
+		}
+	}
+
+	globalExternalProcessingConfig := &xdscache_v3.GlobalExternalProcessingConfig{
+		ExtensionServiceConfig: extensionSvcConfig,
+		FailOpen:               contourConfiguration.GlobalExternalProcessing.FailOpen,
+	}
+
+	if pm := contourConfiguration.GlobalExternalProcessing.ProcessingMode; pm != nil {
+		globalExternalProcessingConfig.ProcessingMode = &dag.ProcessingMode{
+			RequestHeaderMode:  dag.HeaderSendMode(pm.RequestHeaderMode),
+			ResponseHeaderMode: dag.HeaderSendMode(pm.ResponseHeaderMode),
+			RequestBodyMode:    dag.BodySendMode(pm.RequestBodyMode),
+			ResponseBodyMode:   dag.BodySendMode(pm.ResponseBodyMode),
+		}
+	}
+	return globalExternalProcessingConfig, nil
+}
+
+func (s *Server) setupDebugService(debugConfig contour_v1alpha1.DebugConfig, builder *dag.Builder, latestDAG *debug.LatestDAG) error {
 	debugsvc := &debug.Service{
 		Service: httpsvc.Service{
 			Addr:        debugConfig.Address,
@@ -894,10 +879,38 @@ func (s *Server) setupDebugService(debugConfig contour_v1alpha1.DebugConfig, bui
 			FieldLogger: s.log.WithField("context", "debugsvc"),
 		},
 		Builder: builder,
+		DAG:     latestDAG,
 	}
 	return s.mgr.Add(debugsvc)
 }
 
+// setupWebhook creates the HTTPProxy validating webhook server, if a
+// certificate directory is configured for it.
+func (s *Server) setupWebhook(simulator webhook.Simulator) error {
+	if s.ctx.webhookCertDir == "" {
+		return nil
+	}
+
+	server := ctrl_webhook.NewServer(ctrl_webhook.Options{
+		Host:    s.ctx.webhookAddr,
+		Port:    s.ctx.webhookPort,
+		CertDir: s.ctx.webhookCertDir,
+	})
+	server.Register(webhook.HTTPProxyPath, &ctrl_webhook.Admission{
+		Handler: &webhook.HTTPProxyValidator{
+			Simulator:   simulator,
+			Decoder:     admission.NewDecoder(s.mgr.GetScheme()),
+			FieldLogger: s.log.WithField("context", "httpproxy-webhook"),
+		},
+	})
+
+	s.log.WithField("address", net.JoinHostPort(s.ctx.webhookAddr, strconv.Itoa(s.ctx.webhookPort))).
+		WithField("path", webhook.HTTPProxyPath).
+		Info("serving HTTPProxy validating webhook")
+
+	return s.mgr.Add(server)
+}
+
 type xdsServer struct {
 	log             logrus.FieldLogger
 	registry        *prometheus.Registry
@@ -1017,16 +1030,18 @@ func (s *Server) setupGatewayAPI(contourConfiguration contour_v1alpha1.ContourCo
 	// Watch resources for Gateway API if enabled.
 	if contourConfiguration.Gateway != nil {
 		resources := map[string]client.Object{
-			"gatewayclasses":     &gatewayapi_v1.GatewayClass{},
-			"gateways":           &gatewayapi_v1.Gateway{},
-			"httproutes":         &gatewayapi_v1.HTTPRoute{},
-			"referencegrants":    &gatewayapi_v1beta1.ReferenceGrant{},
-			"namespaces":         &core_v1.Namespace{},
-			"tlsroutes":          &gatewayapi_v1alpha2.TLSRoute{},
-			"grpcroutes":         &gatewayapi_v1.GRPCRoute{},
-			"tcproutes":          &gatewayapi_v1alpha2.TCPRoute{},
-			"backendtlspolicies": &gatewayapi_v1alpha3.BackendTLSPolicy{},
-			"configmaps":         &core_v1.ConfigMap{},
+			"gatewayclasses":          &gatewayapi_v1.GatewayClass{},
+			"gateways":                &gatewayapi_v1.Gateway{},
+			"httproutes":              &gatewayapi_v1.HTTPRoute{},
+			"referencegrants":         &gatewayapi_v1beta1.ReferenceGrant{},
+			"namespaces":              &core_v1.Namespace{},
+			"tlsroutes":               &gatewayapi_v1alpha2.TLSRoute{},
+			"grpcroutes":              &gatewayapi_v1.GRPCRoute{},
+			"tcproutes":               &gatewayapi_v1alpha2.TCPRoute{},
+			"udproutes":               &gatewayapi_v1alpha2.UDPRoute{},
+			"backendtlspolicies":      &gatewayapi_v1alpha3.BackendTLSPolicy{},
+			"xbackendtrafficpolicies": &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{},
+			"configmaps":              &core_v1.ConfigMap{},
 		}
 
 		for _, disabled := range s.ctx.disabledFeatures {
@@ -1053,23 +1068,170 @@ type dagBuilderConfig struct {
 	gatewayRef                         *types.NamespacedName
 	disablePermitInsecure              bool
 	enableExternalNameService          bool
+	enableSubsetLoadBalancing          bool
 	dnsLookupFamily                    contour_v1alpha1.ClusterDNSFamilyType
 	headersPolicy                      *contour_v1alpha1.PolicyConfig
 	clientCert                         *types.NamespacedName
 	fallbackCert                       *types.NamespacedName
 	connectTimeout                     time.Duration
-	client                             client.Client
+	client                             client.Reader
 	metrics                            *metrics.Metrics
 	httpAddress                        string
 	httpPort                           int
 	httpsAddress                       string
 	httpsPort                          int
 	globalExternalAuthorizationService *contour_v1.AuthorizationServer
+	globalExternalProcessingService    *contour_v1.ExternalProcessing
 	maxRequestsPerConnection           *uint32
 	perConnectionBufferLimitBytes      *uint32
 	globalRateLimitService             *contour_v1alpha1.RateLimitServiceConfig
 	globalCircuitBreakerDefaults       *contour_v1alpha1.CircuitBreakers
 	upstreamTLS                        *dag.UpstreamTLS
+	ingressErrorSink                   func(dag.IngressError)
+}
+
+// getResourceCaches returns the xDS resource caches for the given configuration,
+// along with the translator that the endpoint informers feed.
+func (s *Server) getResourceCaches(contourConfiguration contour_v1alpha1.ContourConfigurationSpec, timeouts contourconfig.Timeouts) ([]xdscache.ResourceCache, EndpointsTranslator, error) {
+	var err error
+
+	listenerConfig := xdscache_v3.ListenerConfig{
+		UseProxyProto:                 *contourConfiguration.Envoy.Listener.UseProxyProto,
+		HTTPAccessLog:                 contourConfiguration.Envoy.HTTPListener.AccessLog,
+		HTTPSAccessLog:                contourConfiguration.Envoy.HTTPSListener.AccessLog,
+		AccessLogType:                 contourConfiguration.Envoy.Logging.AccessLogFormat,
+		AccessLogJSONFields:           contourConfiguration.Envoy.Logging.AccessLogJSONFields,
+		AccessLogLevel:                contourConfiguration.Envoy.Logging.AccessLogLevel,
+		AccessLogFormatString:         contourConfiguration.Envoy.Logging.AccessLogFormatString,
+		AccessLogFormatterExtensions:  contourConfiguration.Envoy.Logging.AccessLogFormatterExtensions(),
+		MinimumTLSVersion:             annotation.TLSVersion(contourConfiguration.Envoy.Listener.TLS.MinimumProtocolVersion, "1.2"),
+		MaximumTLSVersion:             annotation.TLSVersion(contourConfiguration.Envoy.Listener.TLS.MaximumProtocolVersion, "1.3"),
+		CipherSuites:                  contourConfiguration.Envoy.Listener.TLS.SanitizedCipherSuites(),
+		Timeouts:                      timeouts,
+		DefaultHTTPVersions:           parseDefaultHTTPVersions(contourConfiguration.Envoy.DefaultHTTPVersions),
+		AllowChunkedLength:            !*contourConfiguration.Envoy.Listener.DisableAllowChunkedLength,
+		MergeSlashes:                  !*contourConfiguration.Envoy.Listener.DisableMergeSlashes,
+		ServerHeaderTransformation:    contourConfiguration.Envoy.Listener.ServerHeaderTransformation,
+		Compression:                   contourConfiguration.Envoy.Listener.Compression,
+		HTTP3:                         contourConfiguration.Envoy.Listener.HTTP3,
+		XffNumTrustedHops:             *contourConfiguration.Envoy.Network.XffNumTrustedHops,
+		ConnectionBalancer:            contourConfiguration.Envoy.Listener.ConnectionBalancer,
+		MaxRequestsPerConnection:      contourConfiguration.Envoy.Listener.MaxRequestsPerConnection,
+		HTTP2MaxConcurrentStreams:     contourConfiguration.Envoy.Listener.HTTP2MaxConcurrentStreams,
+		PerConnectionBufferLimitBytes: contourConfiguration.Envoy.Listener.PerConnectionBufferLimitBytes,
+		SocketOptions:                 contourConfiguration.Envoy.Listener.SocketOptions,
+	}
+
+	if listenerConfig.TracingConfig, err = s.setupTracingService(contourConfiguration.Tracing); err != nil {
+		return nil, nil, err
+	}
+
+	if listenerConfig.RateLimitConfig, err = s.setupRateLimitService(contourConfiguration); err != nil {
+		return nil, nil, err
+	}
+
+	if listenerConfig.GlobalExternalAuthConfig, err = s.setupGlobalExternalAuthentication(contourConfiguration); err != nil {
+		return nil, nil, err
+	}
+
+	if listenerConfig.GlobalExternalProcessingConfig, err = s.setupGlobalExternalProcessing(contourConfiguration); err != nil {
+		return nil, nil, err
+	}
+
+	// Endpoints updates are handled directly by the EndpointsTranslator/EndpointSliceTranslator due to the high update volume.
+	var endpointHandler EndpointsTranslator
+	topologyAwareRouting := contourConfiguration.Envoy.Cluster.TopologyAwareRouting
+	enableSubsetLoadBalancing := contourConfiguration.Envoy.Cluster.SubsetLoadBalancing != nil &&
+		contourConfiguration.Envoy.Cluster.SubsetLoadBalancing.Enabled
+	if contourConfiguration.FeatureFlags.IsEndpointSliceEnabled() {
+		endpointSliceHandler := xdscache_v3.NewEndpointSliceTranslator(s.log.WithField("context", "endpointslicetranslator"))
+		if topologyAwareRouting != nil && topologyAwareRouting.Enabled {
+			endpointSliceHandler.EnableTopologyAwareRouting(types.NamespacedName{
+				Namespace: contourConfiguration.Envoy.Service.Namespace,
+				Name:      contourConfiguration.Envoy.Service.Name,
+			})
+		}
+		endpointHandler = endpointSliceHandler
+	} else {
+		if topologyAwareRouting != nil && topologyAwareRouting.Enabled {
+			return nil, nil, errors.New("topology aware routing requires EndpointSlices to be enabled")
+		}
+		if enableSubsetLoadBalancing {
+			return nil, nil, errors.New("subset load balancing requires EndpointSlices to be enabled")
+		}
+		endpointHandler = xdscache_v3.NewEndpointsTranslator(s.log.WithField("context", "endpointstranslator"))
+	}
+
+	resources := []xdscache.ResourceCache{
+		xdscache_v3.NewListenerCache(listenerConfig, *contourConfiguration.Envoy.Metrics, *contourConfiguration.Envoy.Health, *contourConfiguration.Envoy.Network.EnvoyAdminPort),
+		xdscache_v3.NewSecretsCache(envoy_v3.StatsSecrets(contourConfiguration.Envoy.Metrics.TLS)),
+		&xdscache_v3.ExtensionConfigCache{},
+		&xdscache_v3.RouteCache{},
+		&xdscache_v3.ClusterCache{TopologyAwareRouting: topologyAwareRouting},
+		endpointHandler,
+		xdscache_v3.NewRuntimeCache(xdscache_v3.ConfigurableRuntimeSettings{
+			MaxRequestsPerIOCycle:     contourConfiguration.Envoy.Listener.MaxRequestsPerIOCycle,
+			MaxConnectionsPerListener: contourConfiguration.Envoy.Listener.MaxConnectionsPerListener,
+		}),
+	}
+
+	return resources, endpointHandler, nil
+}
+
+// newDAGBuilderConfig returns the dagBuilderConfig for the given configuration.
+// The client and metrics fields are left for the caller to fill in.
+func newDAGBuilderConfig(contourConfiguration contour_v1alpha1.ContourConfigurationSpec, connectTimeout time.Duration) dagBuilderConfig {
+	var ingressClassNames []string
+	if contourConfiguration.Ingress != nil {
+		ingressClassNames = contourConfiguration.Ingress.ClassNames
+	}
+
+	var clientCert *types.NamespacedName
+	var fallbackCert *types.NamespacedName
+	if contourConfiguration.Envoy.ClientCertificate != nil {
+		clientCert = &types.NamespacedName{Name: contourConfiguration.Envoy.ClientCertificate.Name, Namespace: contourConfiguration.Envoy.ClientCertificate.Namespace}
+	}
+	if contourConfiguration.HTTPProxy.FallbackCertificate != nil {
+		fallbackCert = &types.NamespacedName{Name: contourConfiguration.HTTPProxy.FallbackCertificate.Name, Namespace: contourConfiguration.HTTPProxy.FallbackCertificate.Namespace}
+	}
+
+	var gatewayRef *types.NamespacedName
+
+	if contourConfiguration.Gateway != nil {
+		gatewayRef = &types.NamespacedName{
+			Namespace: contourConfiguration.Gateway.GatewayRef.Namespace,
+			Name:      contourConfiguration.Gateway.GatewayRef.Name,
+		}
+	}
+
+	return dagBuilderConfig{
+		ingressClassNames:                  ingressClassNames,
+		rootNamespaces:                     contourConfiguration.HTTPProxy.RootNamespaces,
+		gatewayRef:                         gatewayRef,
+		disablePermitInsecure:              *contourConfiguration.HTTPProxy.DisablePermitInsecure,
+		enableExternalNameService:          *contourConfiguration.EnableExternalNameService,
+		enableSubsetLoadBalancing:          contourConfiguration.Envoy.Cluster.SubsetLoadBalancing != nil && contourConfiguration.Envoy.Cluster.SubsetLoadBalancing.Enabled,
+		dnsLookupFamily:                    contourConfiguration.Envoy.Cluster.DNSLookupFamily,
+		headersPolicy:                      contourConfiguration.Policy,
+		clientCert:                         clientCert,
+		fallbackCert:                       fallbackCert,
+		connectTimeout:                     connectTimeout,
+		httpAddress:                        contourConfiguration.Envoy.HTTPListener.Address,
+		httpPort:                           contourConfiguration.Envoy.HTTPListener.Port,
+		httpsAddress:                       contourConfiguration.Envoy.HTTPSListener.Address,
+		httpsPort:                          contourConfiguration.Envoy.HTTPSListener.Port,
+		globalExternalAuthorizationService: contourConfiguration.GlobalExternalAuthorization,
+		globalExternalProcessingService:    contourConfiguration.GlobalExternalProcessing,
+		globalRateLimitService:             contourConfiguration.RateLimitService,
+		maxRequestsPerConnection:           contourConfiguration.Envoy.Cluster.MaxRequestsPerConnection,
+		perConnectionBufferLimitBytes:      contourConfiguration.Envoy.Cluster.PerConnectionBufferLimitBytes,
+		globalCircuitBreakerDefaults:       contourConfiguration.Envoy.Cluster.GlobalCircuitBreakerDefaults,
+		upstreamTLS: &dag.UpstreamTLS{
+			MinimumProtocolVersion: annotation.TLSVersion(contourConfiguration.Envoy.Cluster.UpstreamTLS.MinimumProtocolVersion, "1.2"),
+			MaximumProtocolVersion: annotation.TLSVersion(contourConfiguration.Envoy.Cluster.UpstreamTLS.MaximumProtocolVersion, "1.3"),
+			CipherSuites:           contourConfiguration.Envoy.Cluster.UpstreamTLS.SanitizedCipherSuites(),
+		},
+	}
 }
 
 func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
@@ -1141,6 +1303,7 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 			GlobalCircuitBreakerDefaults:  dbc.globalCircuitBreakerDefaults,
 			SetSourceMetadataOnRoutes:     true,
 			UpstreamTLS:                   dbc.upstreamTLS,
+			ErrorSink:                     dbc.ingressErrorSink,
 		},
 		&dag.ExtensionServiceProcessor{
 			// Note that ExtensionService does not support ExternalName, if it does get added,
@@ -1150,8 +1313,12 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 			ConnectTimeout:               dbc.connectTimeout,
 			GlobalCircuitBreakerDefaults: dbc.globalCircuitBreakerDefaults,
 		},
+		&dag.BackendProcessor{
+			EnableExternalNameService: dbc.enableExternalNameService,
+		},
 		&dag.HTTPProxyProcessor{
 			EnableExternalNameService:     dbc.enableExternalNameService,
+			EnableSubsetLoadBalancing:     dbc.enableSubsetLoadBalancing,
 			DisablePermitInsecure:         dbc.disablePermitInsecure,
 			FallbackCertificate:           dbc.fallbackCert,
 			DNSLookupFamily:               dbc.dnsLookupFamily,
@@ -1160,6 +1327,7 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 			ResponseHeadersPolicy:         &responseHeadersPolicy,
 			ConnectTimeout:                dbc.connectTimeout,
 			GlobalExternalAuthorization:   dbc.globalExternalAuthorizationService,
+			GlobalExternalProcessing:      dbc.globalExternalProcessingService,
 			MaxRequestsPerConnection:      dbc.maxRequestsPerConnection,
 			GlobalRateLimitService:        dbc.globalRateLimitService,
 			PerConnectionBufferLimitBytes: dbc.perConnectionBufferLimitBytes,
@@ -33,7 +33,7 @@ func TestGetDAGBuilder(t *testing.T) {
 		// note that these first two assertions will not hold when a gateway
 		// is configured, but we don't currently have test cases that cover
 		// that so it's OK to keep them in the "common" assertions for now.
-		assert.Len(t, builder.Processors, 4)
+		assert.Len(t, builder.Processors, 5)
 		assert.IsType(t, &dag.ListenerProcessor{}, builder.Processors[0])
 
 		ingressProcessor := mustGetIngressProcessor(t, builder)
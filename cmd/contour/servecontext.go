@@ -61,6 +61,11 @@ type serveContext struct {
 	healthAddr string
 	healthPort int
 
+	// HTTPProxy validating webhook parameters.
+	webhookAddr    string
+	webhookPort    int
+	webhookCertDir string
+
 	// httpproxy root namespaces
 	rootNamespaces string
 
@@ -126,6 +131,8 @@ func newServeContext() *serveContext {
 		healthPort:         8000,
 		metricsAddr:        "0.0.0.0",
 		metricsPort:        8000,
+		webhookAddr:        "0.0.0.0",
+		webhookPort:        9443,
 		httpAccessLog:      xdscache_v3.DEFAULT_HTTP_ACCESS_LOG,
 		httpsAccessLog:     xdscache_v3.DEFAULT_HTTPS_ACCESS_LOG,
 		httpAddr:           "0.0.0.0",
@@ -459,6 +466,63 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_v1alpha1.Co
 		}
 	}
 
+	var globalExtProc *contour_v1.ExternalProcessing
+	if ctx.Config.GlobalExternalProcessing.ExtensionService != "" {
+		nsedName := k8s.NamespacedNameFrom(ctx.Config.GlobalExternalProcessing.ExtensionService)
+		globalExtProc = &contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Name:      nsedName.Name,
+				Namespace: nsedName.Namespace,
+			},
+			ResponseTimeout: ctx.Config.GlobalExternalProcessing.ResponseTimeout,
+			FailOpen:        ctx.Config.GlobalExternalProcessing.FailOpen,
+		}
+
+		if ctx.Config.GlobalExternalProcessing.ProcessingMode != nil {
+			globalExtProc.ProcessingMode = &contour_v1.ProcessingMode{
+				RequestHeaderMode:  contour_v1.HeaderSendMode(ctx.Config.GlobalExternalProcessing.ProcessingMode.RequestHeaderMode),
+				ResponseHeaderMode: contour_v1.HeaderSendMode(ctx.Config.GlobalExternalProcessing.ProcessingMode.ResponseHeaderMode),
+				RequestBodyMode:    contour_v1.BodySendMode(ctx.Config.GlobalExternalProcessing.ProcessingMode.RequestBodyMode),
+				ResponseBodyMode:   contour_v1.BodySendMode(ctx.Config.GlobalExternalProcessing.ProcessingMode.ResponseBodyMode),
+			}
+		}
+	}
+
+	var compression *contour_v1alpha1.EnvoyCompression
+	if ctx.Config.Listener.Compression != nil {
+		compression = &contour_v1alpha1.EnvoyCompression{
+			MinContentLength: ctx.Config.Listener.Compression.MinContentLength,
+			ContentTypes:     ctx.Config.Listener.Compression.ContentTypes,
+		}
+
+		for _, a := range ctx.Config.Listener.Compression.Algorithms {
+			compression.Algorithms = append(compression.Algorithms, contour_v1alpha1.CompressionAlgorithm(a))
+		}
+	}
+
+	var http3 *contour_v1alpha1.EnvoyHTTP3
+	if ctx.Config.Listener.HTTP3.Enabled {
+		http3 = &contour_v1alpha1.EnvoyHTTP3{
+			Enabled:        true,
+			AdvertisedPort: ctx.Config.Listener.HTTP3.AdvertisedPort,
+		}
+	}
+
+	var topologyAwareRouting *contour_v1alpha1.TopologyAwareRouting
+	if ctx.Config.Cluster.TopologyAwareRouting.Enabled {
+		topologyAwareRouting = &contour_v1alpha1.TopologyAwareRouting{
+			Enabled:        true,
+			MinClusterSize: ctx.Config.Cluster.TopologyAwareRouting.MinClusterSize,
+		}
+	}
+
+	var subsetLoadBalancing *contour_v1alpha1.SubsetLoadBalancing
+	if ctx.Config.Cluster.SubsetLoadBalancing.Enabled {
+		subsetLoadBalancing = &contour_v1alpha1.SubsetLoadBalancing{
+			Enabled: true,
+		}
+	}
+
 	policy := &contour_v1alpha1.PolicyConfig{
 		RequestHeadersPolicy: &contour_v1alpha1.HeadersPolicy{
 			Set:    ctx.Config.Policy.RequestHeadersPolicy.Set,
@@ -530,6 +594,8 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_v1alpha1.Co
 				MaxRequestsPerIOCycle:         ctx.Config.Listener.MaxRequestsPerIOCycle,
 				HTTP2MaxConcurrentStreams:     ctx.Config.Listener.HTTP2MaxConcurrentStreams,
 				MaxConnectionsPerListener:     ctx.Config.Listener.MaxConnectionsPerListener,
+				Compression:                   compression,
+				HTTP3:                         http3,
 				TLS: &contour_v1alpha1.EnvoyTLS{
 					MinimumProtocolVersion: ctx.Config.TLS.MinimumProtocolVersion,
 					MaximumProtocolVersion: ctx.Config.TLS.MaximumProtocolVersion,
@@ -578,6 +644,8 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_v1alpha1.Co
 					MaximumProtocolVersion: ctx.Config.Cluster.UpstreamTLS.MaximumProtocolVersion,
 					CipherSuites:           ctx.Config.Cluster.UpstreamTLS.CipherSuites,
 				},
+				TopologyAwareRouting: topologyAwareRouting,
+				SubsetLoadBalancing:  subsetLoadBalancing,
 			},
 			Network: &contour_v1alpha1.NetworkParameters{
 				XffNumTrustedHops: &ctx.Config.Network.XffNumTrustedHops,
@@ -592,6 +660,7 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_v1alpha1.Co
 		},
 		EnableExternalNameService:   &ctx.Config.EnableExternalNameService,
 		GlobalExternalAuthorization: globalExtAuth,
+		GlobalExternalProcessing:    globalExtProc,
 		RateLimitService:            rateLimitService,
 		Policy:                      policy,
 		Metrics:                     &contourMetrics,
@@ -826,6 +826,100 @@ func TestConvertServeContext(t *testing.T) {
 				return cfg
 			},
 		},
+		"global external processing": {
+			getServeContext: func(ctx *serveContext) *serveContext {
+				ctx.Config.GlobalExternalProcessing = config.GlobalExternalProcessing{
+					ExtensionService: "extprocns/extproctext",
+					ResponseTimeout:  "1s",
+					FailOpen:         true,
+					ProcessingMode: &config.GlobalProcessingMode{
+						RequestBodyMode: "Buffered",
+					},
+				}
+				return ctx
+			},
+			getContourConfiguration: func(cfg contour_v1alpha1.ContourConfigurationSpec) contour_v1alpha1.ContourConfigurationSpec {
+				cfg.GlobalExternalProcessing = &contour_v1.ExternalProcessing{
+					ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+						Name:      "extproctext",
+						Namespace: "extprocns",
+					},
+					ResponseTimeout: "1s",
+					FailOpen:        true,
+					ProcessingMode: &contour_v1.ProcessingMode{
+						RequestBodyMode: contour_v1.BodySendModeBuffered,
+					},
+				}
+				return cfg
+			},
+		},
+		"listener compression": {
+			getServeContext: func(ctx *serveContext) *serveContext {
+				ctx.Config.Listener.Compression = &config.CompressionParameters{
+					Algorithms:       []string{"zstd", "gzip"},
+					MinContentLength: ptr.To(uint32(256)),
+					ContentTypes:     []string{"text/html"},
+				}
+				return ctx
+			},
+			getContourConfiguration: func(cfg contour_v1alpha1.ContourConfigurationSpec) contour_v1alpha1.ContourConfigurationSpec {
+				cfg.Envoy.Listener.Compression = &contour_v1alpha1.EnvoyCompression{
+					Algorithms: []contour_v1alpha1.CompressionAlgorithm{
+						contour_v1alpha1.ZstdCompression,
+						contour_v1alpha1.GzipCompression,
+					},
+					MinContentLength: ptr.To(uint32(256)),
+					ContentTypes:     []string{"text/html"},
+				}
+				return cfg
+			},
+		},
+		"cluster topology aware routing": {
+			getServeContext: func(ctx *serveContext) *serveContext {
+				ctx.Config.Cluster.TopologyAwareRouting = config.TopologyAwareRoutingParameters{
+					Enabled:        true,
+					MinClusterSize: ptr.To(uint32(2)),
+				}
+				return ctx
+			},
+			getContourConfiguration: func(cfg contour_v1alpha1.ContourConfigurationSpec) contour_v1alpha1.ContourConfigurationSpec {
+				cfg.Envoy.Cluster.TopologyAwareRouting = &contour_v1alpha1.TopologyAwareRouting{
+					Enabled:        true,
+					MinClusterSize: ptr.To(uint32(2)),
+				}
+				return cfg
+			},
+		},
+		"cluster subset load balancing": {
+			getServeContext: func(ctx *serveContext) *serveContext {
+				ctx.Config.Cluster.SubsetLoadBalancing = config.SubsetLoadBalancingParameters{
+					Enabled: true,
+				}
+				return ctx
+			},
+			getContourConfiguration: func(cfg contour_v1alpha1.ContourConfigurationSpec) contour_v1alpha1.ContourConfigurationSpec {
+				cfg.Envoy.Cluster.SubsetLoadBalancing = &contour_v1alpha1.SubsetLoadBalancing{
+					Enabled: true,
+				}
+				return cfg
+			},
+		},
+		"listener http3": {
+			getServeContext: func(ctx *serveContext) *serveContext {
+				ctx.Config.Listener.HTTP3 = config.HTTP3Parameters{
+					Enabled:        true,
+					AdvertisedPort: ptr.To(uint32(8443)),
+				}
+				return ctx
+			},
+			getContourConfiguration: func(cfg contour_v1alpha1.ContourConfigurationSpec) contour_v1alpha1.ContourConfigurationSpec {
+				cfg.Envoy.Listener.HTTP3 = &contour_v1alpha1.EnvoyHTTP3{
+					Enabled:        true,
+					AdvertisedPort: ptr.To(uint32(8443)),
+				}
+				return cfg
+			},
+		},
 		"tracing config normal": {
 			getServeContext: func(ctx *serveContext) *serveContext {
 				ctx.Config.Tracing = &config.Tracing{
@@ -0,0 +1,420 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/sirupsen/logrus"
+	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/build"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/k8s"
+)
+
+// errValidationFailed is returned by doValidate when the manifests
+// have at least one error finding.
+var errValidationFailed = errors.New("validation failed")
+
+// registerValidate registers the validate subcommand and flags
+// with the Application provided.
+func registerValidate(app *kingpin.Application) (*kingpin.CmdClause, *validateContext) {
+	ctx := &validateContext{}
+
+	validate := app.Command("validate", "Validate Kubernetes manifests against Contour's processing without a cluster.")
+	validate.Arg("paths", "Manifest files or directories to read, or - to read from stdin.").Required().StringsVar(&ctx.paths)
+
+	validate.Flag("config-path", "Path to base configuration.").Short('c').PlaceHolder("/path/to/file").ExistingFileVar(&ctx.configFile)
+	validate.Flag("namespace", "Namespace of manifests that do not set one.").Short('n').Default("default").StringVar(&ctx.namespace)
+	validate.Flag("output", "Output format for the findings.").Short('o').Default("json").EnumVar(&ctx.output, "json", "sarif")
+
+	return validate, ctx
+}
+
+// validateContext holds the configuration for the validate subcommand.
+type validateContext struct {
+	// configFile is the path to a Contour configuration file.
+	configFile string
+
+	// namespace is the namespace given to namespaced objects
+	// whose manifests do not set one.
+	namespace string
+
+	// output is the output format, either json or sarif.
+	output string
+
+	// paths are the manifest files and directories to read.
+	paths []string
+}
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// finding is a problem Contour found with an object.
+type finding struct {
+	Severity   string `json:"severity"`
+	Source     string `json:"source"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Reason     string `json:"reason"`
+	Message    string `json:"message"`
+}
+
+// doValidate runs the validate subcommand, writing the findings to w.
+// errValidationFailed is returned if any of the findings is an error.
+func doValidate(ctx *validateContext, log *logrus.Logger, w io.Writer) error {
+	contourConfiguration, err := offlineConfig(ctx.configFile)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := k8s.NewContourScheme()
+	if err != nil {
+		return fmt.Errorf("unable to create scheme: %w", err)
+	}
+
+	objects, sources, err := readManifests(log, scheme, ctx.namespace, ctx.paths)
+	if err != nil {
+		return err
+	}
+
+	// Ingresses have no status conditions, the IngressProcessor
+	// reports the problems it finds instead.
+	var findings []finding
+	ingressErrorSink := func(e dag.IngressError) {
+		findings = append(findings, finding{
+			Severity:   severityError,
+			APIVersion: networking_v1.SchemeGroupVersion.String(),
+			Kind:       "Ingress",
+			Namespace:  e.Ingress.Namespace,
+			Name:       e.Ingress.Name,
+			Type:       e.Type,
+			Reason:     e.Reason,
+			Message:    e.Message,
+		})
+	}
+
+	latestDAG, _, err := offlineBuild(log, scheme, contourConfiguration, objects, ingressErrorSink)
+	if err != nil {
+		return err
+	}
+
+	updated, err := statusObjects(scheme, latestDAG, objects)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range updated {
+		findings = append(findings, objectFindings(obj)...)
+	}
+
+	failed := false
+	for i := range findings {
+		f := &findings[i]
+		key := manifestKey{
+			gvk:  schema.FromAPIVersionAndKind(f.APIVersion, f.Kind),
+			name: types.NamespacedName{Namespace: f.Namespace, Name: f.Name},
+		}
+		f.Source = sources[key]
+		if f.Severity == severityError {
+			failed = true
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b finding) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	var out any = findings
+	if ctx.output == "sarif" {
+		out = sarifLog(findings)
+	} else if findings == nil {
+		out = []finding{}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if failed {
+		return errValidationFailed
+	}
+	return nil
+}
+
+// objectFindings returns the findings recorded in the status of obj.
+func objectFindings(obj client.Object) []finding {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	newFinding := func(severity, condType, reason, message string) finding {
+		return finding{
+			Severity:   severity,
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			Type:       condType,
+			Reason:     reason,
+			Message:    message,
+		}
+	}
+
+	// detailedFindings returns the errors and warnings of Contour's
+	// DetailedConditions.
+	detailedFindings := func(conds []contour_v1.DetailedCondition) []finding {
+		var findings []finding
+		for _, cond := range conds {
+			for _, e := range cond.Errors {
+				findings = append(findings, newFinding(severityError, e.Type, e.Reason, e.Message))
+			}
+			for _, w := range cond.Warnings {
+				findings = append(findings, newFinding(severityWarning, w.Type, w.Reason, w.Message))
+			}
+		}
+		return findings
+	}
+
+	// conditionFindings returns the Gateway API conditions that are
+	// not in their healthy state, prefixing their messages with scope.
+	conditionFindings := func(scope string, conds []meta_v1.Condition) []finding {
+		var findings []finding
+		for _, cond := range conds {
+			severity := ""
+			switch cond.Type {
+			case string(gatewayapi_v1.ListenerConditionConflicted):
+				if cond.Status == meta_v1.ConditionTrue {
+					severity = severityError
+				}
+			case string(gatewayapi_v1.RouteConditionPartiallyInvalid):
+				if cond.Status == meta_v1.ConditionTrue {
+					severity = severityWarning
+				}
+			default:
+				if cond.Status == meta_v1.ConditionFalse {
+					severity = severityError
+				}
+			}
+			if severity == "" {
+				continue
+			}
+
+			message := cond.Message
+			if scope != "" {
+				message = scope + ": " + message
+			}
+			findings = append(findings, newFinding(severity, cond.Type, cond.Reason, message))
+		}
+		return findings
+	}
+
+	routeFindings := func(status gatewayapi_v1.RouteStatus) []finding {
+		var findings []finding
+		for _, parent := range status.Parents {
+			findings = append(findings, conditionFindings("parent "+parentRefString(parent.ParentRef), parent.Conditions)...)
+		}
+		return findings
+	}
+
+	policyFindings := func(status gatewayapi_v1alpha2.PolicyStatus) []finding {
+		var findings []finding
+		for _, ancestor := range status.Ancestors {
+			findings = append(findings, conditionFindings("ancestor "+parentRefString(ancestor.AncestorRef), ancestor.Conditions)...)
+		}
+		return findings
+	}
+
+	switch o := obj.(type) {
+	case *contour_v1.HTTPProxy:
+		return detailedFindings(o.Status.Conditions)
+	case *contour_v1alpha1.ExtensionService:
+		return detailedFindings(o.Status.Conditions)
+	case *contour_v1alpha1.Backend:
+		return detailedFindings(o.Status.Conditions)
+	case *gatewayapi_v1.GatewayClass:
+		return conditionFindings("", o.Status.Conditions)
+	case *gatewayapi_v1.Gateway:
+		findings := conditionFindings("", o.Status.Conditions)
+		for _, listener := range o.Status.Listeners {
+			findings = append(findings, conditionFindings(fmt.Sprintf("listener %q", listener.Name), listener.Conditions)...)
+		}
+		return findings
+	case *gatewayapi_v1.HTTPRoute:
+		return routeFindings(o.Status.RouteStatus)
+	case *gatewayapi_v1.GRPCRoute:
+		return routeFindings(o.Status.RouteStatus)
+	case *gatewayapi_v1alpha2.TLSRoute:
+		return routeFindings(o.Status.RouteStatus)
+	case *gatewayapi_v1alpha2.TCPRoute:
+		return routeFindings(o.Status.RouteStatus)
+	case *gatewayapi_v1alpha2.UDPRoute:
+		return routeFindings(o.Status.RouteStatus)
+	case *gatewayapi_v1alpha3.BackendTLSPolicy:
+		return policyFindings(o.Status)
+	case *gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
+		return policyFindings(o.Status)
+	}
+
+	return nil
+}
+
+// parentRefString returns ref as namespace/name, or name when the
+// namespace is not set.
+func parentRefString(ref gatewayapi_v1.ParentReference) string {
+	if ref.Namespace != nil {
+		return string(*ref.Namespace) + "/" + string(ref.Name)
+	}
+	return string(ref.Name)
+}
+
+// sarif is a SARIF 2.1.0 log, holding the subset of the format
+// needed to report findings.
+type sarif struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Kind               string `json:"kind"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLog returns findings as a SARIF log, using the finding reasons
+// as rule IDs.
+func sarifLog(findings []finding) *sarif {
+	rules := []sarifRule{}
+	results := []sarifResult{}
+	seen := map[string]bool{}
+
+	for _, f := range findings {
+		if !seen[f.Reason] {
+			seen[f.Reason] = true
+			rules = append(rules, sarifRule{ID: f.Reason})
+		}
+
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{
+				Kind:               "object",
+				FullyQualifiedName: f.Kind + "/" + types.NamespacedName{Namespace: f.Namespace, Name: f.Name}.String(),
+			}},
+		}
+		if f.Source != "" && f.Source != "<stdin>" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.Source)},
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    f.Reason,
+			Level:     f.Severity,
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	slices.SortFunc(rules, func(a, b sarifRule) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return &sarif{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "contour",
+					Version:        build.Version,
+					InformationURI: "https://projectcontour.io",
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+const validateConfig = `
+gateway:
+  gatewayRef:
+    namespace: projectcontour
+    name: contour
+`
+
+const validateManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: kuard
+spec:
+  ports:
+  - name: http
+    port: 80
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: first
+spec:
+  virtualhost:
+    fqdn: kuard.example.com
+  routes:
+  - services:
+    - name: kuard
+      port: 80
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: second
+spec:
+  virtualhost:
+    fqdn: kuard.example.com
+  routes:
+  - services:
+    - name: kuard
+      port: 80
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ingress
+spec:
+  rules:
+  - host: ingress.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: missing
+            port:
+              name: http
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: contour
+spec:
+  controllerName: projectcontour.io/gateway-controller
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: contour
+  namespace: projectcontour
+spec:
+  gatewayClassName: contour
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+    allowedRoutes:
+      namespaces:
+        from: All
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route
+spec:
+  parentRefs:
+  - name: contour
+    namespace: projectcontour
+  rules:
+  - backendRefs:
+    - group: ""
+      kind: Service
+      name: missing
+      port: 80
+`
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "contour.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(validateConfig), 0o600))
+	manifests := filepath.Join(dir, "manifests")
+	require.NoError(t, os.Mkdir(manifests, 0o700))
+	manifestFile := filepath.Join(manifests, "app.yaml")
+	require.NoError(t, os.WriteFile(manifestFile, []byte(validateManifests), 0o600))
+
+	var out bytes.Buffer
+	ctx := &validateContext{
+		configFile: configFile,
+		namespace:  "default",
+		output:     "json",
+		paths:      []string{manifests},
+	}
+	err := doValidate(ctx, fixture.NewTestLogger(t), &out)
+	require.ErrorIs(t, err, errValidationFailed)
+
+	var findings []finding
+	require.NoError(t, json.Unmarshal(out.Bytes(), &findings))
+
+	type summary struct {
+		kind, name, condType, reason string
+	}
+	var got []summary
+	for _, f := range findings {
+		assert.Equal(t, manifestFile, f.Source)
+		assert.Equal(t, severityError, f.Severity)
+		got = append(got, summary{f.Kind, f.Name, f.Type, f.Reason})
+	}
+
+	assert.Equal(t, []summary{
+		{"HTTPProxy", "first", "VirtualHostError", "DuplicateVhost"},
+		{"HTTPProxy", "second", "VirtualHostError", "DuplicateVhost"},
+		{"HTTPRoute", "route", "ResolvedRefs", "BackendNotFound"},
+		{"Ingress", "ingress", "ServiceError", "ServiceUnresolvedReference"},
+	}, got)
+}
+
+func TestValidateSARIF(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(manifestFile, []byte(validateManifests), 0o600))
+
+	var out bytes.Buffer
+	ctx := &validateContext{
+		namespace: "default",
+		output:    "sarif",
+		paths:     []string{manifestFile},
+	}
+	require.ErrorIs(t, doValidate(ctx, fixture.NewTestLogger(t), &out), errValidationFailed)
+
+	var log sarif
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, []sarifRule{{ID: "DuplicateVhost"}, {ID: "ServiceUnresolvedReference"}}, log.Runs[0].Tool.Driver.Rules)
+
+	require.Len(t, log.Runs[0].Results, 3)
+	for _, result := range log.Runs[0].Results {
+		assert.Equal(t, "error", result.Level)
+		require.Len(t, result.Locations, 1)
+		require.NotNil(t, result.Locations[0].PhysicalLocation)
+		assert.Equal(t, filepath.ToSlash(manifestFile), result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+	assert.Equal(t, "HTTPProxy/default/first", log.Runs[0].Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestValidateNoFindings(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "app.yaml")
+	valid := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: kuard
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: kuard
+spec:
+  virtualhost:
+    fqdn: kuard.example.com
+  routes:
+  - services:
+    - name: kuard
+      port: 80
+`
+	require.NoError(t, os.WriteFile(manifestFile, []byte(valid), 0o600))
+
+	var out bytes.Buffer
+	ctx := &validateContext{
+		namespace: "default",
+		output:    "json",
+		paths:     []string{manifestFile},
+	}
+	require.NoError(t, doValidate(ctx, fixture.NewTestLogger(t), &out))
+	assert.JSONEq(t, "[]", out.String())
+}
@@ -18,6 +18,7 @@ package contour
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"sync/atomic"
 	"time"
@@ -31,6 +32,12 @@ import (
 	"github.com/projectcontour/contour/internal/k8s"
 )
 
+// maxPendingSimulations is the number of callers of Simulate that may
+// wait for the event handler at once. Simulations are built on the event
+// handler's goroutine, so further callers are refused rather than being
+// allowed to delay the processing of changes to the cluster.
+const maxPendingSimulations = 4
+
 type EventHandlerConfig struct {
 	Logger                        logrus.FieldLogger
 	Builder                       *dag.Builder
@@ -54,6 +61,11 @@ type EventHandler struct {
 
 	update chan any
 
+	simulate chan opSimulate
+
+	// simulations limits the number of pending simulations.
+	simulations chan struct{}
+
 	sequence chan int
 
 	// seq is the sequence counter of the number of times
@@ -77,6 +89,8 @@ func NewEventHandler(config EventHandlerConfig, upstreamHasSynced cache.Informer
 		holdoffMaxDelay: config.HoldoffMaxDelay,
 		statusUpdater:   config.StatusUpdater,
 		update:          make(chan any),
+		simulate:        make(chan opSimulate),
+		simulations:     make(chan struct{}, maxPendingSimulations),
 		sequence:        make(chan int, 1),
 		syncTracker:     &synctrack.SingleFileTracker{UpstreamHasSynced: upstreamHasSynced},
 	}
@@ -95,6 +109,15 @@ type opDelete struct {
 	obj any
 }
 
+type opSimulate struct {
+	obj    any
+	result chan<- simulation
+}
+
+type simulation struct {
+	current, simulated *dag.DAG
+}
+
 func (e *EventHandler) OnAdd(obj any, isInInitialList bool) {
 	if isInInitialList {
 		e.syncTracker.Start()
@@ -110,6 +133,38 @@ func (e *EventHandler) OnDelete(obj any) {
 	e.update <- opDelete{obj: obj}
 }
 
+// Simulate returns the DAG built from the cached objects, and the DAG
+// that would be built if obj was added to them, without changing the
+// cache. An error is returned if the initial DAG has not been built yet,
+// since the cache does not yet reflect the cluster, or if too many
+// simulations are pending.
+func (e *EventHandler) Simulate(ctx context.Context, obj any) (current, simulated *dag.DAG, err error) {
+	if !e.HasBuiltInitialDag() {
+		return nil, nil, errors.New("initial DAG has not been built")
+	}
+
+	select {
+	case e.simulations <- struct{}{}:
+		defer func() { <-e.simulations }()
+	default:
+		return nil, nil, errors.New("too many simulations pending")
+	}
+
+	result := make(chan simulation, 1)
+	select {
+	case e.simulate <- opSimulate{obj: obj, result: result}:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	select {
+	case sim := <-result:
+		return sim.current, sim.simulated, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
 // NeedLeaderElection is included to implement manager.LeaderElectionRunnable
 func (e *EventHandler) NeedLeaderElection() bool {
 	return false
@@ -155,6 +210,11 @@ func (e *EventHandler) Start(ctx context.Context) error {
 
 		// initialSyncPoll is the channel that will receive a signal when to poll the initial informer synchronization status.
 		initialSyncPoll = initialSyncPollTicker.C
+
+		// current holds the DAG built from the cached objects, or nil
+		// if the cache has changed since it was built. It is reused by
+		// simulations, which only need to build the simulated DAG.
+		current *dag.DAG
 	)
 
 	reset := func() (v int) {
@@ -163,19 +223,21 @@ func (e *EventHandler) Start(ctx context.Context) error {
 	}
 
 	for {
-		// In the main loop one of four things can happen.
+		// In the main loop one of five things can happen.
 		// 1. We're waiting for an event on op, stop, or pending, noting that
 		//    pending may be nil if there are no pending events.
 		// 2. We're processing an event.
 		// 3. The holdoff timer from a previous event has fired and we're
 		//    building a new DAG and sending to the Observer.
-		// 4. We're stopping.
+		// 4. We're simulating a change for a caller of Simulate.
+		// 5. We're stopping.
 		//
 		// Only one of these things can happen at a time.
 		select {
 		case op := <-e.update:
 			if e.onUpdate(op) {
 				outstanding++
+				current = nil
 				// If there is already a timer running, stop it.
 				if timer != nil {
 					timer.Stop()
@@ -216,6 +278,7 @@ func (e *EventHandler) Start(ctx context.Context) error {
 			// Build a new DAG and sends it to the Observer.
 			latestDAG := e.builder.Build()
 			e.observer.OnChange(latestDAG)
+			current = latestDAG
 
 			// Update the status on objects.
 			for _, upd := range latestDAG.StatusCache.GetStatusUpdates() {
@@ -224,6 +287,16 @@ func (e *EventHandler) Start(ctx context.Context) error {
 
 			e.incSequence()
 			lastDAGRebuild = time.Now()
+		case op := <-e.simulate:
+			// Simulations are run here as the builder is not safe
+			// for concurrent use.
+			if current == nil {
+				current = e.builder.Simulate()
+			}
+			op.result <- simulation{
+				current:   current,
+				simulated: e.builder.Simulate(op.obj),
+			}
 		case <-initialSyncPoll:
 			if e.syncTracker.HasSynced() {
 				// Informer caches are synced, stop the polling and allow xDS server to start.
@@ -14,13 +14,125 @@
 package contour
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
 )
 
 func TestEventHandlerNotRequireLeaderElection(t *testing.T) {
 	var e manager.LeaderElectionRunnable = &EventHandler{}
 	require.False(t, e.NeedLeaderElection())
 }
+
+func TestEventHandlerSimulate(t *testing.T) {
+	log := fixture.NewTestLogger(t)
+	observed := make(chan *dag.DAG, 1)
+	eh := NewEventHandler(EventHandlerConfig{
+		Logger: log,
+		Builder: &dag.Builder{
+			Source: dag.KubernetesCache{
+				FieldLogger: log,
+			},
+			Processors: []dag.Processor{
+				&dag.ListenerProcessor{},
+				&dag.HTTPProxyProcessor{},
+			},
+		},
+		Observer: dag.ObserverFunc(func(d *dag.DAG) {
+			observed <- d
+		}),
+		HoldoffDelay:  time.Millisecond,
+		StatusUpdater: &k8s.StatusUpdateCacher{},
+	}, func() bool { return true })
+
+	proxy := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "simple",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Simulations are refused until the initial DAG is built.
+	_, _, err := eh.Simulate(ctx, proxy)
+	require.Error(t, err)
+
+	go func() {
+		_ = eh.Start(ctx)
+	}()
+	eh.OnAdd(&core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{{Name: "http", Protocol: "TCP", Port: 8080}},
+		},
+	}, true)
+	require.Eventually(t, eh.HasBuiltInitialDag, time.Second, 10*time.Millisecond)
+
+	current, simulated, err := eh.Simulate(ctx, proxy)
+	require.NoError(t, err)
+	assert.Empty(t, current.StatusCache.GetProxyUpdates())
+	require.Len(t, simulated.StatusCache.GetProxyUpdates(), 1)
+
+	// The simulated object is not added to the cache.
+	current, _, err = eh.Simulate(ctx, proxy)
+	require.NoError(t, err)
+	assert.Empty(t, current.StatusCache.GetProxyUpdates())
+
+	// Once the DAG has been rebuilt, simulations reuse it.
+	eh.OnAdd(proxy, false)
+	latest := <-observed
+	current, _, err = eh.Simulate(ctx, proxy)
+	require.NoError(t, err)
+	assert.Same(t, latest, current)
+}
+
+func TestEventHandlerSimulateLimit(t *testing.T) {
+	eh := NewEventHandler(EventHandlerConfig{
+		Logger: fixture.NewTestLogger(t),
+	}, func() bool { return true })
+	eh.initialDagBuilt.Store(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The event handler is not started, so these simulations
+	// stay pending until ctx is canceled.
+	for range maxPendingSimulations {
+		go func() {
+			_, _, _ = eh.Simulate(ctx, nil)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return len(eh.simulations) == maxPendingSimulations
+	}, time.Second, 10*time.Millisecond)
+
+	_, _, err := eh.Simulate(ctx, nil)
+	require.EqualError(t, err, "too many simulations pending")
+}
@@ -15,14 +15,20 @@ package dag
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 
 	"github.com/projectcontour/contour/internal/annotation"
+	"github.com/projectcontour/contour/internal/k8s"
 	"github.com/projectcontour/contour/internal/xds"
 )
 
@@ -43,11 +49,103 @@ func (d *DAG) EnsureService(meta types.NamespacedName, port, healthPort int, cac
 		}
 	}
 
-	err = validateExternalName(svc, enableExternalNameSvc)
+	return newService(svc, svcPort, healthSvcPort, enableExternalNameSvc)
+}
+
+// EnsureUDPService looks for a Kubernetes service in the cache matching the provided
+// namespace, name and UDP port, and returns a DAG service for it. If a matching service
+// cannot be found in the cache, an error is returned.
+func (d *DAG) EnsureUDPService(meta types.NamespacedName, port int, cache *KubernetesCache, enableExternalNameSvc bool) (*Service, error) {
+	svc, svcPort, err := cache.LookupUDPService(meta, intstr.FromInt(port))
+	if err != nil {
+		return nil, err
+	}
+
+	return newService(svc, svcPort, svcPort, enableExternalNameSvc)
+}
+
+// EnsureBackend looks for a Backend in the cache matching the provided
+// namespace and name, and returns a DAG service for its endpoints. Endpoints
+// without a port use the provided port. If a matching and valid Backend
+// cannot be found in the cache, an error is returned.
+//
+// Like ExternalName Services, Backends let their owner send traffic from
+// Envoy to arbitrary addresses, so they are only processed when
+// enableExternalNameSvc is set.
+func (d *DAG) EnsureBackend(meta types.NamespacedName, port int, cache *KubernetesCache, enableExternalNameSvc bool) (*Service, error) {
+	if !enableExternalNameSvc {
+		return nil, fmt.Errorf("%s is a Backend, these are not currently enabled. See the config.enableExternalNameService config file setting", meta)
+	}
+
+	backend, err := cache.LookupBackend(meta)
 	if err != nil {
 		return nil, err
 	}
 
+	svc := &Service{
+		Weighted: WeightedService{
+			ServiceName:      backend.Name,
+			ServiceNamespace: backend.Namespace,
+			ServicePort:      core_v1.ServicePort{Protocol: core_v1.ProtocolTCP, Port: int32(port)},
+			HealthPort:       core_v1.ServicePort{Protocol: core_v1.ProtocolTCP, Port: int32(port)},
+			Weight:           1,
+		},
+		Protocol: ptr.Deref(backend.Spec.Protocol, ""),
+	}
+
+	for i, e := range backend.Spec.Endpoints {
+		if err := validateBackendEndpoint(e); err != nil {
+			return nil, fmt.Errorf("backend %q endpoint %d is invalid: %w", meta, i, err)
+		}
+
+		endpointPort := e.Port
+		if endpointPort == 0 {
+			endpointPort = port
+		}
+
+		svc.Endpoints = append(svc.Endpoints, BackendEndpoint{
+			Address: e.Address,
+			Port:    uint32(endpointPort),
+		})
+	}
+
+	if len(svc.Endpoints) == 0 {
+		return nil, fmt.Errorf("backend %q has no endpoints", meta)
+	}
+
+	if tls := backend.Spec.TLS; tls != nil {
+		// Backends with TLS settings are always reached over TLS,
+		// so the settings are not silently ignored.
+		switch svc.Protocol {
+		case "":
+			svc.Protocol = "tls"
+		case "h2c":
+			return nil, fmt.Errorf("backend %q sets tls but its protocol is h2c", meta)
+		}
+
+		if v := tls.UpstreamValidation; v != nil {
+			caCertNamespacedName := k8s.NamespacedNameFrom(v.CACertificate, k8s.DefaultNamespace(backend.Namespace))
+			uv, err := cache.LookupUpstreamValidation(v, caCertNamespacedName, backend.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("backend %q TLS upstream validation policy error: %w", meta, err)
+			}
+			svc.UpstreamValidation = uv
+			svc.SNI = uv.SubjectNames[0]
+		}
+
+		if tls.SNI != "" {
+			svc.SNI = tls.SNI
+		}
+	}
+
+	return svc, nil
+}
+
+func newService(svc *core_v1.Service, svcPort, healthSvcPort core_v1.ServicePort, enableExternalNameSvc bool) (*Service, error) {
+	if err := validateExternalName(svc, enableExternalNameSvc); err != nil {
+		return nil, err
+	}
+
 	// There's no need to walk the DAG to look for a matching
 	// existing Service here. They're terminal nodes in the DAG
 	// so nothing is getting attached to them, and when used
@@ -87,17 +185,6 @@ func validateExternalName(svc *core_v1.Service, enableExternalNameSvc bool) erro
 		return fmt.Errorf("%s/%s is an ExternalName service, these are not currently enabled. See the config.enableExternalNameService config file setting", svc.Namespace, svc.Name)
 	}
 
-	// Check against a list of known localhost names, using a map to approximate a set.
-	// TODO(youngnick) This is a very porous hack, and we should probably look into doing a DNS
-	// lookup to check what the externalName resolves to, but I'm worried about the
-	// performance impact of doing one or more DNS lookups per DAG run, so we're
-	// going to go with a specific blocklist for now.
-	localhostNames := map[string]struct{}{
-		"localhost":               {},
-		"localhost.localdomain":   {},
-		"local.projectcontour.io": {},
-	}
-
 	_, localhost := localhostNames[en]
 	if localhost {
 		return fmt.Errorf("%s/%s is an ExternalName service that points to localhost, this is not allowed", svc.Namespace, svc.Name)
@@ -106,6 +193,84 @@ func validateExternalName(svc *core_v1.Service, enableExternalNameSvc bool) erro
 	return nil
 }
 
+// localhostNames is a list of known localhost names, using a map to approximate a set.
+// TODO(youngnick) This is a very porous hack, and we should probably look into doing a DNS
+// lookup to check what the externalName resolves to, but I'm worried about the
+// performance impact of doing one or more DNS lookups per DAG run, so we're
+// going to go with a specific blocklist for now.
+var localhostNames = map[string]struct{}{
+	"localhost":               {},
+	"localhost.localdomain":   {},
+	"local.projectcontour.io": {},
+}
+
+// metadataAddresses is a list of cloud instance metadata service addresses
+// that are not link-local, using a map to approximate a set.
+var metadataAddresses = map[string]struct{}{
+	"100.100.100.200": {}, // Alibaba Cloud
+	"fd00:ec2::254":   {}, // AWS over IPv6
+}
+
+// metadataNames is a list of known cloud instance metadata service names.
+var metadataNames = map[string]struct{}{
+	"instance-data":              {},
+	"instance-data.ec2.internal": {},
+	"metadata":                   {},
+	"metadata.goog":              {},
+	"metadata.google.internal":   {},
+}
+
+// wildcardDomains is a list of public DNS domains whose names resolve to
+// the IP address embedded in them, or to localhost. A Backend using them
+// could reach any address, so they are rejected.
+var wildcardDomains = []string{
+	"localtest.me",
+	"lvh.me",
+	"nip.io",
+	"sslip.io",
+	"xip.io",
+}
+
+// validateBackendEndpoint checks that the address of a Backend endpoint
+// is an IP address or DNS hostname that does not point to localhost, a
+// link-local address or a cloud instance metadata service. Envoy resolves
+// DNS hostnames itself, so a name that resolves to such an address can
+// only be rejected if it is known here.
+func validateBackendEndpoint(e contour_v1alpha1.BackendEndpoint) error {
+	if ip := net.ParseIP(e.Address); ip != nil {
+		switch {
+		case ip.IsLoopback() || ip.IsUnspecified():
+			return fmt.Errorf("address %q points to localhost, this is not allowed", e.Address)
+		case ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast():
+			return fmt.Errorf("address %q is a link-local address, this is not allowed", e.Address)
+		}
+		if _, metadata := metadataAddresses[ip.String()]; metadata {
+			return fmt.Errorf("address %q is an instance metadata address, this is not allowed", e.Address)
+		}
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(e.Address); len(errs) > 0 {
+		return fmt.Errorf("address %q is not an IP address or DNS hostname", e.Address)
+	}
+
+	if _, localhost := localhostNames[e.Address]; localhost || strings.HasSuffix(e.Address, ".localhost") {
+		return fmt.Errorf("address %q points to localhost, this is not allowed", e.Address)
+	}
+
+	if _, metadata := metadataNames[e.Address]; metadata {
+		return fmt.Errorf("address %q is an instance metadata address, this is not allowed", e.Address)
+	}
+
+	for _, domain := range wildcardDomains {
+		if e.Address == domain || strings.HasSuffix(e.Address, "."+domain) {
+			return fmt.Errorf("address %q is in the wildcard DNS domain %q, this is not allowed", e.Address, domain)
+		}
+	}
+
+	return nil
+}
+
 // the ServicePort's AppProtocol must be one of the these.
 const (
 	protoK8sH2C = "kubernetes.io/h2c"
@@ -226,6 +391,10 @@ func (d *DAG) GetClusters() []*Cluster {
 			res = append(res, listener.TCPProxy.Clusters...)
 		}
 
+		if listener.UDPProxy != nil {
+			res = append(res, listener.UDPProxy.Clusters...)
+		}
+
 		for _, vhost := range listener.VirtualHosts {
 			for _, route := range vhost.Routes {
 				res = append(res, route.Clusters...)
@@ -258,6 +427,30 @@ func (d *DAG) GetClusters() []*Cluster {
 	return res
 }
 
+func (d *DAG) GetAggregateClusters() []*AggregateCluster {
+	var res []*AggregateCluster
+
+	for _, listener := range d.Listeners {
+		for _, vhost := range listener.VirtualHosts {
+			for _, route := range vhost.Routes {
+				if route.AggregateCluster != nil {
+					res = append(res, route.AggregateCluster)
+				}
+			}
+		}
+
+		for _, vhost := range listener.SecureVirtualHosts {
+			for _, route := range vhost.Routes {
+				if route.AggregateCluster != nil {
+					res = append(res, route.AggregateCluster)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
 func (d *DAG) GetDNSNameClusters() []*DNSNameCluster {
 	var res []*DNSNameCluster
 
@@ -267,6 +460,10 @@ func (d *DAG) GetDNSNameClusters() []*DNSNameCluster {
 				provider := provider
 				res = append(res, &provider.RemoteJWKS.Cluster)
 			}
+
+			if svhost.OAuth2 != nil {
+				res = append(res, &svhost.OAuth2.TokenEndpointCluster)
+			}
 		}
 	}
 
@@ -285,6 +482,11 @@ func (d *DAG) GetServiceClusters() []*ServiceCluster {
 			continue
 		}
 
+		// Likewise, the endpoints of Backends are static.
+		if len(cluster.Upstream.Endpoints) > 0 {
+			continue
+		}
+
 		// A Service has only one WeightedService entry. Fake up a
 		// ServiceCluster so that the visitor can pretend to not
 		// know this.
@@ -298,6 +500,7 @@ func (d *DAG) GetServiceClusters() []*ServiceCluster {
 			Services: []WeightedService{
 				cluster.Upstream.Weighted,
 			},
+			SubsetKeys: SubsetKeys(cluster.SubsetSelector),
 		}
 
 		res = append(res, c)
@@ -344,6 +547,21 @@ func (d *DAG) GetSecrets() []*Secret {
 	return res
 }
 
+// GetOAuth2Secrets returns the Secrets holding the client
+// credentials of the OAuth2 configurations in the DAG.
+func (d *DAG) GetOAuth2Secrets() []*Secret {
+	var res []*Secret
+	for _, l := range d.Listeners {
+		for _, svh := range l.SecureVirtualHosts {
+			if svh.OAuth2 != nil {
+				res = append(res, svh.OAuth2.ClientSecret)
+			}
+		}
+	}
+
+	return res
+}
+
 // GetExtensionCluster returns the extension cluster in the DAG that
 // matches the provided name, or nil if no matching extension cluster
 // is found.
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/fixture"
 )
 
@@ -260,6 +261,184 @@ func TestBuilderLookupService(t *testing.T) {
 	}
 }
 
+func TestBuilderLookupBackend(t *testing.T) {
+	backend := func(name string, endpoints ...contour_v1alpha1.BackendEndpoint) *contour_v1alpha1.Backend {
+		return &contour_v1alpha1.Backend{
+			ObjectMeta: fixture.ObjectMeta("default/" + name),
+			Spec: contour_v1alpha1.BackendSpec{
+				Endpoints: endpoints,
+				Protocol:  ptr.To("h2c"),
+			},
+		}
+	}
+
+	backends := map[types.NamespacedName]*contour_v1alpha1.Backend{
+		{Name: "static", Namespace: "default"}: backend("static",
+			contour_v1alpha1.BackendEndpoint{Address: "10.0.0.1", Port: 8080},
+			contour_v1alpha1.BackendEndpoint{Address: "api.example.com"},
+		),
+		{Name: "loopback", Namespace: "default"}: backend("loopback",
+			contour_v1alpha1.BackendEndpoint{Address: "127.0.0.1"},
+		),
+		{Name: "localhost", Namespace: "default"}: backend("localhost",
+			contour_v1alpha1.BackendEndpoint{Address: "localhost"},
+		),
+		{Name: "invalid", Namespace: "default"}: backend("invalid",
+			contour_v1alpha1.BackendEndpoint{Address: "not a hostname"},
+		),
+		{Name: "linklocal", Namespace: "default"}: backend("linklocal",
+			contour_v1alpha1.BackendEndpoint{Address: "169.254.169.254"},
+		),
+		{Name: "metadata-ip", Namespace: "default"}: backend("metadata-ip",
+			contour_v1alpha1.BackendEndpoint{Address: "fd00:ec2::254"},
+		),
+		{Name: "metadata-name", Namespace: "default"}: backend("metadata-name",
+			contour_v1alpha1.BackendEndpoint{Address: "metadata.google.internal"},
+		),
+		{Name: "dot-localhost", Namespace: "default"}: backend("dot-localhost",
+			contour_v1alpha1.BackendEndpoint{Address: "envoy.localhost"},
+		),
+		{Name: "wildcard", Namespace: "default"}: backend("wildcard",
+			contour_v1alpha1.BackendEndpoint{Address: "127.0.0.1.nip.io"},
+		),
+		{Name: "tls", Namespace: "default"}: {
+			ObjectMeta: fixture.ObjectMeta("default/tls"),
+			Spec: contour_v1alpha1.BackendSpec{
+				Endpoints: []contour_v1alpha1.BackendEndpoint{{Address: "10.0.0.1"}},
+				TLS:       &contour_v1alpha1.BackendTLS{SNI: "api.example.com"},
+			},
+		},
+		{Name: "tls-h2c", Namespace: "default"}: {
+			ObjectMeta: fixture.ObjectMeta("default/tls-h2c"),
+			Spec: contour_v1alpha1.BackendSpec{
+				Endpoints: []contour_v1alpha1.BackendEndpoint{{Address: "10.0.0.1"}},
+				Protocol:  ptr.To("h2c"),
+				TLS:       &contour_v1alpha1.BackendTLS{SNI: "api.example.com"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		types.NamespacedName
+		port     int
+		disabled bool
+		want     *Service
+		wantErr  string
+	}{
+		"lookup backend": {
+			NamespacedName: types.NamespacedName{Name: "static", Namespace: "default"},
+			port:           443,
+			want: &Service{
+				Weighted: WeightedService{
+					Weight:           1,
+					ServiceName:      "static",
+					ServiceNamespace: "default",
+					ServicePort:      core_v1.ServicePort{Protocol: "TCP", Port: 443},
+					HealthPort:       core_v1.ServicePort{Protocol: "TCP", Port: 443},
+				},
+				Protocol: "h2c",
+				Endpoints: []BackendEndpoint{
+					{Address: "10.0.0.1", Port: 8080},
+					{Address: "api.example.com", Port: 443},
+				},
+			},
+		},
+		"backend with tls settings defaults to the tls protocol": {
+			NamespacedName: types.NamespacedName{Name: "tls", Namespace: "default"},
+			port:           443,
+			want: &Service{
+				Weighted: WeightedService{
+					Weight:           1,
+					ServiceName:      "tls",
+					ServiceNamespace: "default",
+					ServicePort:      core_v1.ServicePort{Protocol: "TCP", Port: 443},
+					HealthPort:       core_v1.ServicePort{Protocol: "TCP", Port: 443},
+				},
+				Protocol:  "tls",
+				SNI:       "api.example.com",
+				Endpoints: []BackendEndpoint{{Address: "10.0.0.1", Port: 443}},
+			},
+		},
+		"when a backend with tls settings uses h2c an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "tls-h2c", Namespace: "default"},
+			port:           443,
+			wantErr:        `backend "default/tls-h2c" sets tls but its protocol is h2c`,
+		},
+		"when backend does not exist an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "nonexistent", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/nonexistent" not found`,
+		},
+		"when an endpoint is a loopback address an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "loopback", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/loopback" endpoint 0 is invalid: address "127.0.0.1" points to localhost, this is not allowed`,
+		},
+		"when an endpoint is localhost an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "localhost", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/localhost" endpoint 0 is invalid: address "localhost" points to localhost, this is not allowed`,
+		},
+		"when an endpoint is not a hostname an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "invalid", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/invalid" endpoint 0 is invalid: address "not a hostname" is not an IP address or DNS hostname`,
+		},
+		"when an endpoint is a link-local address an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "linklocal", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/linklocal" endpoint 0 is invalid: address "169.254.169.254" is a link-local address, this is not allowed`,
+		},
+		"when an endpoint is an instance metadata address an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "metadata-ip", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/metadata-ip" endpoint 0 is invalid: address "fd00:ec2::254" is an instance metadata address, this is not allowed`,
+		},
+		"when an endpoint is an instance metadata name an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "metadata-name", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/metadata-name" endpoint 0 is invalid: address "metadata.google.internal" is an instance metadata address, this is not allowed`,
+		},
+		"when an endpoint is in the localhost domain an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "dot-localhost", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/dot-localhost" endpoint 0 is invalid: address "envoy.localhost" points to localhost, this is not allowed`,
+		},
+		"when an endpoint is in a wildcard DNS domain an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "wildcard", Namespace: "default"},
+			port:           80,
+			wantErr:        `backend "default/wildcard" endpoint 0 is invalid: address "127.0.0.1.nip.io" is in the wildcard DNS domain "nip.io", this is not allowed`,
+		},
+		"when backends are not enabled an error is returned": {
+			NamespacedName: types.NamespacedName{Name: "static", Namespace: "default"},
+			port:           443,
+			disabled:       true,
+			wantErr:        `default/static is a Backend, these are not currently enabled. See the config.enableExternalNameService config file setting`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := Builder{
+				Source: KubernetesCache{
+					backends:    backends,
+					FieldLogger: fixture.NewTestLogger(t),
+				},
+			}
+
+			var dag DAG
+
+			got, gotErr := dag.EnsureBackend(tc.NamespacedName, tc.port, &b.Source, !tc.disabled)
+			if tc.wantErr != "" {
+				require.EqualError(t, gotErr, tc.wantErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestGetSingleListener(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		d := &DAG{
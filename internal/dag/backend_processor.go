@@ -0,0 +1,86 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dag
+
+import (
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/status"
+)
+
+// BackendProcessor validates Backends and sets their status. The
+// endpoints of a Backend are added to the DAG by the processors
+// of the routes that reference it.
+type BackendProcessor struct {
+	// EnableExternalNameService allows processing of Backends, which
+	// like ExternalName Services can point to arbitrary addresses.
+	EnableExternalNameService bool
+}
+
+var _ Processor = &BackendProcessor{}
+
+func (p *BackendProcessor) Run(dag *DAG, cache *KubernetesCache) {
+	for _, b := range cache.backends {
+		backendStatus, commit := status.BackendAccessor(&dag.StatusCache, b)
+		validCondition := backendStatus.ConditionFor(status.ValidCondition)
+
+		if p.EnableExternalNameService {
+			validateBackend(cache, b, validCondition)
+		} else {
+			validCondition.AddError(contour_v1.ConditionTypeSpecError, "BackendsNotEnabled",
+				"Backends are not enabled, see the enableExternalNameService config file setting")
+		}
+
+		if len(validCondition.Errors) == 0 {
+			validCondition.Status = contour_v1.ConditionTrue
+			validCondition.Reason = "Valid"
+			validCondition.Message = "Valid Backend"
+		}
+
+		commit()
+	}
+}
+
+// validateBackend adds an error to validCondition for each
+// problem with the given Backend.
+func validateBackend(cache *KubernetesCache, backend *contour_v1alpha1.Backend, validCondition *contour_v1.DetailedCondition) {
+	if len(backend.Spec.Endpoints) == 0 {
+		validCondition.AddErrorf(contour_v1.ConditionTypeSpecError, "EndpointNotValid",
+			"spec.endpoints must not be empty")
+	}
+
+	for i, e := range backend.Spec.Endpoints {
+		if err := validateBackendEndpoint(e); err != nil {
+			validCondition.AddErrorf(contour_v1.ConditionTypeSpecError, "EndpointNotValid",
+				"spec.endpoints[%d] is invalid: %s", i, err)
+		}
+	}
+
+	if backend.Spec.TLS == nil || backend.Spec.TLS.UpstreamValidation == nil {
+		return
+	}
+
+	v := backend.Spec.TLS.UpstreamValidation
+	caCertNamespacedName := k8s.NamespacedNameFrom(v.CACertificate, k8s.DefaultNamespace(backend.Namespace))
+	if _, err := cache.LookupUpstreamValidation(v, caCertNamespacedName, backend.Namespace); err != nil {
+		if _, ok := err.(DelegationNotPermittedError); ok {
+			validCondition.AddErrorf(contour_v1.ConditionTypeTLSError, "CACertificateNotDelegated",
+				"spec.tls.validation.caSecret Secret %q is not configured for certificate delegation", caCertNamespacedName)
+		} else {
+			validCondition.AddErrorf(contour_v1.ConditionTypeSpecError, "TLSUpstreamValidation",
+				"TLS upstream validation policy error: %s", err.Error())
+		}
+	}
+}
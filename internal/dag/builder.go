@@ -57,13 +57,35 @@ type Builder struct {
 // Build builds and returns a new DAG by running the
 // configured DAG processors, in order.
 func (b *Builder) Build() *DAG {
+	if b.Metrics != nil {
+		t := prometheus.NewTimer(b.Metrics.DAGRebuildSeconds)
+		defer t.ObserveDuration()
+	}
+
+	return b.build(&b.Source)
+}
+
+// Simulate returns the DAG that Build would return if objs were
+// inserted into the Source, leaving the Source unchanged.
+// Like Build, Simulate must not be called concurrently with
+// other calls to the Builder.
+func (b *Builder) Simulate(objs ...any) *DAG {
+	source := b.Source.clone()
+	for _, obj := range objs {
+		source.Insert(obj)
+	}
+
+	return b.build(source)
+}
+
+func (b *Builder) build(source *KubernetesCache) *DAG {
 	gatewayNSName := types.NamespacedName{}
-	if b.Source.gateway != nil {
-		gatewayNSName = k8s.NamespacedNameOf(b.Source.gateway)
+	if source.gateway != nil {
+		gatewayNSName = k8s.NamespacedNameOf(source.gateway)
 	}
 	var gatewayController gatewayapi_v1.GatewayController
-	if b.Source.gatewayclass != nil {
-		gatewayController = b.Source.gatewayclass.Spec.ControllerName
+	if source.gatewayclass != nil {
+		gatewayController = source.gatewayclass.Spec.ControllerName
 	}
 
 	dag := &DAG{
@@ -71,13 +93,8 @@ func (b *Builder) Build() *DAG {
 		Listeners:   map[string]*Listener{},
 	}
 
-	if b.Metrics != nil {
-		t := prometheus.NewTimer(b.Metrics.DAGRebuildSeconds)
-		defer t.ObserveDuration()
-	}
-
 	for _, p := range b.Processors {
-		p.Run(dag, &b.Source)
+		p.Run(dag, source)
 	}
 
 	// Prune invalid virtual hosts, and Listeners
@@ -116,7 +133,7 @@ func (b *Builder) Build() *DAG {
 			listeners[listener.Name] = listener
 		}
 
-		if listener.TCPProxy != nil {
+		if listener.TCPProxy != nil || listener.UDPProxy != nil {
 			listeners[listener.Name] = listener
 		}
 	}
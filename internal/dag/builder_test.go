@@ -30,6 +30,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/projectcontour/contour/internal/fixture"
@@ -249,7 +250,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		},
 		Spec: gatewayapi_v1.GatewaySpec{
 			GatewayClassName: gatewayapi_v1.ObjectName(validClass.Name),
-			Addresses: []gatewayapi_v1.GatewayAddress{
+			Addresses: []gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.IPAddressType),
 					Value: "1.2.3.4",
@@ -1583,10 +1584,11 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 			},
 			want: listeners(
 				&Listener{
-					Name:     "http-443",
-					Protocol: "http",
-					Address:  "0.0.0.0",
-					Port:     8443,
+					Name:        "http-443",
+					Protocol:    "http",
+					Address:     "0.0.0.0",
+					Port:        8443,
+					ServicePort: 443,
 					VirtualHosts: virtualhosts(
 						virtualhost("test.projectcontour.io",
 							prefixrouteHTTPRoute("/", service(kuardService)),
@@ -3125,7 +3127,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
-						withMirror(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 100))),
+						withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 100, 100))),
 				},
 			),
 		},
@@ -3159,7 +3161,194 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
-						withMirror(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2), service(kuardService3)}, 100))),
+						withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2), service(kuardService3)}, 100, 100))),
+				},
+			),
+		},
+		"HTTPRoute rule with request mirror filter with percent": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				kuardService2,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Filters: []gatewayapi_v1.HTTPRouteFilter{{
+						Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+							Percent:    ptr.To(int32(25)),
+						},
+					}},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 25, 100))),
+				},
+			),
+		},
+		"HTTPRoute rule with two request mirror filters with fractions": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				kuardService2,
+				kuardService3,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Filters: []gatewayapi_v1.HTTPRouteFilter{
+						{
+							Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+								Fraction: &gatewayapi_v1.Fraction{
+									Numerator:   1,
+									Denominator: ptr.To(int32(1000)),
+								},
+							},
+						},
+						{
+							Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("kuard3", 8080),
+								Fraction: &gatewayapi_v1.Fraction{
+									Numerator: 5,
+								},
+							},
+						},
+					},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withMirrorFraction(
+							withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 1, 1000),
+							[]*Service{service(kuardService3)}, 5, 100,
+						),
+					)),
+				},
+			),
+		},
+		"HTTPRoute rule with CORS filter": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Filters: []gatewayapi_v1.HTTPRouteFilter{{
+						Type: gatewayapi_v1.HTTPRouteFilterCORS,
+						CORS: &gatewayapi_v1.HTTPCORSFilter{
+							AllowOrigins:     []gatewayapi_v1.AbsoluteURI{"https://www.example.com", "https://*.example.org"},
+							AllowCredentials: true,
+							AllowMethods:     []gatewayapi_v1.HTTPMethodWithWildcard{"GET", "POST"},
+							AllowHeaders:     []gatewayapi_v1.HTTPHeaderName{"x-header-1"},
+							ExposeHeaders:    []gatewayapi_v1.HTTPHeaderName{"x-header-2"},
+							MaxAge:           60,
+						},
+					}},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withCORSPolicy(prefixrouteHTTPRoute("/", service(kuardService)), &CORSPolicy{
+							AllowCredentials: true,
+							AllowOrigin: []CORSAllowOriginMatch{
+								{Type: CORSAllowOriginMatchExact, Value: "https://www.example.com"},
+								{Type: CORSAllowOriginMatchRegex, Value: `https://.*\.example\.org`},
+							},
+							AllowMethods:  []string{"GET", "POST"},
+							AllowHeaders:  []string{"x-header-1"},
+							ExposeHeaders: []string{"x-header-2"},
+							MaxAge:        timeout.DurationSetting(60 * time.Second),
+						}))),
+				},
+			),
+		},
+		"HTTPRoute rule with CORS filter allowing all origins and default max age": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Filters: []gatewayapi_v1.HTTPRouteFilter{{
+						Type: gatewayapi_v1.HTTPRouteFilterCORS,
+						CORS: &gatewayapi_v1.HTTPCORSFilter{
+							AllowOrigins: []gatewayapi_v1.AbsoluteURI{"*"},
+							AllowMethods: []gatewayapi_v1.HTTPMethodWithWildcard{"*"},
+						},
+					}},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withCORSPolicy(prefixrouteHTTPRoute("/", service(kuardService)), &CORSPolicy{
+							AllowOrigin: []CORSAllowOriginMatch{
+								{Type: CORSAllowOriginMatchExact, Value: "*"},
+							},
+							AllowMethods: []string{"*"},
+							MaxAge:       timeout.DurationSetting(5 * time.Second),
+						}))),
+				},
+			),
+		},
+		"HTTPRoute rule with request mirror filter to a backend in a different namespace, with valid ReferenceGrant": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				kuardServiceCustomNs,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Filters: []gatewayapi_v1.HTTPRouteFilter{{
+						Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayapi_v1.BackendObjectReference{
+								Kind:      ptr.To(gatewayapi_v1.Kind("Service")),
+								Namespace: ptr.To(gatewayapi_v1.Namespace(kuardServiceCustomNs.Namespace)),
+								Name:      gatewayapi_v1.ObjectName(kuardServiceCustomNs.Name),
+								Port:      ptr.To(gatewayapi_v1.PortNumber(8080)),
+							},
+						},
+					}},
+				}),
+				&gatewayapi_v1beta1.ReferenceGrant{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "foo",
+						Namespace: kuardServiceCustomNs.Namespace,
+					},
+					Spec: gatewayapi_v1beta1.ReferenceGrantSpec{
+						From: []gatewayapi_v1beta1.ReferenceGrantFrom{{
+							Group:     gatewayapi_v1.GroupName,
+							Kind:      "HTTPRoute",
+							Namespace: "projectcontour",
+						}},
+						To: []gatewayapi_v1beta1.ReferenceGrantTo{{
+							Kind: "Service",
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardServiceCustomNs)}, 100, 100))),
 				},
 			),
 		},
@@ -3187,8 +3376,8 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
-						withMirror(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 100),
-						withMirror(segmentPrefixHTTPRoute("/another-match", service(kuardService)), []*Service{service(kuardService2)}, 100),
+						withMirrorFraction(prefixrouteHTTPRoute("/", service(kuardService)), []*Service{service(kuardService2)}, 100, 100),
+						withMirrorFraction(segmentPrefixHTTPRoute("/another-match", service(kuardService)), []*Service{service(kuardService2)}, 100, 100),
 					)),
 				},
 			),
@@ -3491,114 +3680,401 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			),
 		},
-		"HTTPRoute rule with invalid request timeout": {
+		"HTTPRoute rule with default session persistence": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []any{
 				kuardService,
-				makeHTTPRouteWithTimeouts("invalid", ""),
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:            gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs:        gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					SessionPersistence: &gatewayapi_v1.SessionPersistence{},
+				}),
 			},
-			want: listeners(),
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								SessionPersistence: &SessionPersistence{
+									Type: SessionPersistenceCookie,
+									Name: "projectcontour-basic-0-session",
+								},
+							},
+						),
+					),
+				},
+			),
 		},
-		"HTTPRoute rule with invalid backend request timeout": {
+		"HTTPRoute rule with permanent cookie session persistence": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []any{
 				kuardService,
-				makeHTTPRouteWithTimeouts("5s", "invalid"),
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					SessionPersistence: &gatewayapi_v1.SessionPersistence{
+						SessionName:     ptr.To("cart"),
+						Type:            ptr.To(gatewayapi_v1.CookieBasedSessionPersistence),
+						AbsoluteTimeout: ptr.To(gatewayapi_v1.Duration("1h")),
+						CookieConfig: &gatewayapi_v1.CookieConfig{
+							LifetimeType: ptr.To(gatewayapi_v1.PermanentCookieLifetimeType),
+						},
+					},
+				}),
 			},
-			want: listeners(),
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								SessionPersistence: &SessionPersistence{
+									Type:      SessionPersistenceCookie,
+									Name:      "cart",
+									CookieTTL: time.Hour,
+								},
+							},
+						),
+					),
+				},
+			),
 		},
-
-		"HTTPRoute rule with backend request timeout greater than request timeout": {
+		"HTTPRoute rule with header session persistence": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []any{
 				kuardService,
-				makeHTTPRouteWithTimeouts("5s", "6s"),
-			},
-			want: listeners(),
-		},
-
-		"HTTPRoute with BackendTLSPolicy": {
-			gatewayclass: validClass,
-			gateway:      gatewayHTTPAllNamespaces,
-			upstreamTLS: &UpstreamTLS{
-				MinimumProtocolVersion: "1.2",
-				MaximumProtocolVersion: "1.2",
-			},
-			objs: []any{
-				tlsService,
-				configMapCert1,
-				makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
-				&gatewayapi_v1alpha3.BackendTLSPolicy{
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "basic",
-						Namespace: "projectcontour",
-					},
-					Spec: gatewayapi_v1alpha3.BackendTLSPolicySpec{
-						TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReferenceWithSectionName{
-							{
-								LocalPolicyTargetReference: gatewayapi_v1alpha2.LocalPolicyTargetReference{
-									Kind: "Service",
-									Name: "tlssvc",
-								},
-							},
-						},
-						Validation: gatewayapi_v1alpha3.BackendTLSPolicyValidation{
-							CACertificateRefs: []gatewayapi_v1.LocalObjectReference{{
-								Kind: "ConfigMap",
-								Name: gatewayapi_v1.ObjectName(configMapCert1.Name),
-							}},
-							Hostname: "example.com",
-						},
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					SessionPersistence: &gatewayapi_v1.SessionPersistence{
+						SessionName: ptr.To("x-session"),
+						Type:        ptr.To(gatewayapi_v1.HeaderBasedSessionPersistence),
 					},
-				},
+				}),
 			},
 			want: listeners(
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(
-						virtualhost("*", routeCluster("/",
-							&Cluster{
-								Weight: 1,
-								Upstream: &Service{
-									Protocol: "tls",
-									Weighted: WeightedService{
-										Weight:           1,
-										ServiceName:      tlsService.Name,
-										ServiceNamespace: tlsService.Namespace,
-										ServicePort:      tlsService.Spec.Ports[0],
-										HealthPort:       tlsService.Spec.Ports[0],
-									},
-								},
-								Protocol: "tls",
-								UpstreamValidation: &PeerValidationContext{
-									CACertificates: []*Secret{
-										caSecret(cert1),
-									},
-									SubjectNames: []string{"example.com"},
-								},
-								UpstreamTLS: &UpstreamTLS{
-									MinimumProtocolVersion: "1.2",
-									MaximumProtocolVersion: "1.2",
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								SessionPersistence: &SessionPersistence{
+									Type: SessionPersistenceHeader,
+									Name: "x-session",
 								},
 							},
-						)),
+						),
 					),
 				},
 			),
 		},
-		"HTTPRoute with BackendTLSPolicy and CA cert in secret": {
+		"HTTPRoute rule with unsupported session persistence idle timeout": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
-			upstreamTLS: &UpstreamTLS{
-				MinimumProtocolVersion: "1.2",
-				MaximumProtocolVersion: "1.2",
-			},
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					SessionPersistence: &gatewayapi_v1.SessionPersistence{
+						IdleTimeout: ptr.To(gatewayapi_v1.Duration("10m")),
+					},
+				}),
+			},
+			want: listeners(),
+		},
+		"HTTPRoute rule with retry": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Retry: &gatewayapi_v1.HTTPRouteRetry{
+						Codes:    []gatewayapi_v1.HTTPRouteRetryStatusCode{502, 503},
+						Attempts: ptr.To(3),
+						Backoff:  ptr.To(gatewayapi_v1.Duration("100ms")),
+					},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								RetryPolicy: &RetryPolicy{
+									RetryOn:              "reset,connect-failure,refused-stream,retriable-status-codes",
+									RetriableStatusCodes: []uint32{502, 503},
+									NumRetries:           3,
+									PerTryTimeout:        timeout.DefaultSetting(),
+									BackOffBaseInterval:  100 * time.Millisecond,
+								},
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute rule with retry and backendRequest timeout": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Timeouts:    makeHTTPRouteTimeouts("10s", "2s"),
+					Retry:       &gatewayapi_v1.HTTPRouteRetry{},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								TimeoutPolicy: RouteTimeoutPolicy{
+									ResponseTimeout: timeout.DurationSetting(10 * time.Second),
+								},
+								RetryPolicy: &RetryPolicy{
+									RetryOn:       "reset,connect-failure,refused-stream",
+									NumRetries:    1,
+									PerTryTimeout: timeout.DurationSetting(2 * time.Second),
+								},
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute rule with retry attempts set to 0": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Retry: &gatewayapi_v1.HTTPRouteRetry{
+						Attempts: ptr.To(0),
+					},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute rule with invalid retry backoff": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					Retry: &gatewayapi_v1.HTTPRouteRetry{
+						Backoff: ptr.To(gatewayapi_v1.Duration("invalid")),
+					},
+				}),
+			},
+			want: listeners(),
+		},
+		"HTTPRoute with cookie XBackendTrafficPolicy": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+				makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+					SessionName:     ptr.To("cart"),
+					AbsoluteTimeout: ptr.To(gatewayapi_v1.Duration("1h")),
+					CookieConfig: &gatewayapi_v1.CookieConfig{
+						LifetimeType: ptr.To(gatewayapi_v1.PermanentCookieLifetimeType),
+					},
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters: []*Cluster{{
+									Upstream:           service(kuardService),
+									Weight:             1,
+									LoadBalancerPolicy: LoadBalancerPolicyCookie,
+								}},
+								RequestHashPolicies: []RequestHashPolicy{{
+									CookieHashOptions: &CookieHashOptions{
+										CookieName: "cart",
+										TTL:        time.Hour,
+										Path:       "/",
+									},
+								}},
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute with header XBackendTrafficPolicy": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+				makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+					SessionName: ptr.To("x-session-id"),
+					Type:        ptr.To(gatewayapi_v1.HeaderBasedSessionPersistence),
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters: []*Cluster{{
+									Upstream:           service(kuardService),
+									Weight:             1,
+									LoadBalancerPolicy: LoadBalancerPolicyRequestHash,
+								}},
+								RequestHashPolicies: []RequestHashPolicy{{
+									HeaderHashOptions: &HeaderHashOptions{
+										HeaderName: "X-Session-Id",
+									},
+								}},
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute rule session persistence takes precedence over XBackendTrafficPolicy": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", gatewayapi_v1.HTTPRouteRule{
+					Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+					BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					SessionPersistence: &gatewayapi_v1.SessionPersistence{
+						SessionName: ptr.To("route-session"),
+					},
+				}),
+				makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+					SessionName: ptr.To("cart"),
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+								SessionPersistence: &SessionPersistence{
+									Type: SessionPersistenceCookie,
+									Name: "route-session",
+								},
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute with invalid XBackendTrafficPolicy": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRoute("basic", "projectcontour", "test.projectcontour.io", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+				makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+					IdleTimeout: ptr.To(gatewayapi_v1.Duration("10m")),
+				}),
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io",
+							&Route{
+								PathMatchCondition: prefixString("/"),
+								Clusters:           clustersWeight(service(kuardService)),
+							},
+						),
+					),
+				},
+			),
+		},
+		"HTTPRoute rule with invalid request timeout": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRouteWithTimeouts("invalid", ""),
+			},
+			want: listeners(),
+		},
+		"HTTPRoute rule with invalid backend request timeout": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRouteWithTimeouts("5s", "invalid"),
+			},
+			want: listeners(),
+		},
+
+		"HTTPRoute rule with backend request timeout greater than request timeout": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []any{
+				kuardService,
+				makeHTTPRouteWithTimeouts("5s", "6s"),
+			},
+			want: listeners(),
+		},
+
+		"HTTPRoute with BackendTLSPolicy": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			upstreamTLS: &UpstreamTLS{
+				MinimumProtocolVersion: "1.2",
+				MaximumProtocolVersion: "1.2",
+			},
 			objs: []any{
 				tlsService,
-				cert1,
+				configMapCert1,
 				makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
 				&gatewayapi_v1alpha3.BackendTLSPolicy{
 					ObjectMeta: meta_v1.ObjectMeta{
@@ -3616,8 +4092,8 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						},
 						Validation: gatewayapi_v1alpha3.BackendTLSPolicyValidation{
 							CACertificateRefs: []gatewayapi_v1.LocalObjectReference{{
-								Kind: "Secret",
-								Name: gatewayapi_v1.ObjectName(cert1.Name),
+								Kind: "ConfigMap",
+								Name: gatewayapi_v1.ObjectName(configMapCert1.Name),
 							}},
 							Hostname: "example.com",
 						},
@@ -3658,7 +4134,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			),
 		},
-		"HTTPRoute with BackendTLSPolicy using multiple certs": {
+		"HTTPRoute with BackendTLSPolicy and CA cert in secret": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			upstreamTLS: &UpstreamTLS{
@@ -3668,7 +4144,6 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 			objs: []any{
 				tlsService,
 				cert1,
-				cert2,
 				makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
 				&gatewayapi_v1alpha3.BackendTLSPolicy{
 					ObjectMeta: meta_v1.ObjectMeta{
@@ -3685,16 +4160,10 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 							},
 						},
 						Validation: gatewayapi_v1alpha3.BackendTLSPolicyValidation{
-							CACertificateRefs: []gatewayapi_v1.LocalObjectReference{
-								{
-									Kind: "Secret",
-									Name: gatewayapi_v1.ObjectName(cert1.Name),
-								},
-								{
-									Kind: "Secret",
-									Name: gatewayapi_v1.ObjectName(cert2.Name),
-								},
-							},
+							CACertificateRefs: []gatewayapi_v1.LocalObjectReference{{
+								Kind: "Secret",
+								Name: gatewayapi_v1.ObjectName(cert1.Name),
+							}},
 							Hostname: "example.com",
 						},
 					},
@@ -3721,7 +4190,6 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 								UpstreamValidation: &PeerValidationContext{
 									CACertificates: []*Secret{
 										caSecret(cert1),
-										caSecret(cert2),
 									},
 									SubjectNames: []string{"example.com"},
 								},
@@ -3735,7 +4203,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			),
 		},
-		"HTTPRoute with BackendTLSPolicy and sectionName set": {
+		"HTTPRoute with BackendTLSPolicy using multiple certs": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			upstreamTLS: &UpstreamTLS{
@@ -3743,7 +4211,84 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				MaximumProtocolVersion: "1.2",
 			},
 			objs: []any{
-				tlsAndNonTLSService,
+				tlsService,
+				cert1,
+				cert2,
+				makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
+				&gatewayapi_v1alpha3.BackendTLSPolicy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha3.BackendTLSPolicySpec{
+						TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReferenceWithSectionName{
+							{
+								LocalPolicyTargetReference: gatewayapi_v1alpha2.LocalPolicyTargetReference{
+									Kind: "Service",
+									Name: "tlssvc",
+								},
+							},
+						},
+						Validation: gatewayapi_v1alpha3.BackendTLSPolicyValidation{
+							CACertificateRefs: []gatewayapi_v1.LocalObjectReference{
+								{
+									Kind: "Secret",
+									Name: gatewayapi_v1.ObjectName(cert1.Name),
+								},
+								{
+									Kind: "Secret",
+									Name: gatewayapi_v1.ObjectName(cert2.Name),
+								},
+							},
+							Hostname: "example.com",
+						},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "http-80",
+					VirtualHosts: virtualhosts(
+						virtualhost("*", routeCluster("/",
+							&Cluster{
+								Weight: 1,
+								Upstream: &Service{
+									Protocol: "tls",
+									Weighted: WeightedService{
+										Weight:           1,
+										ServiceName:      tlsService.Name,
+										ServiceNamespace: tlsService.Namespace,
+										ServicePort:      tlsService.Spec.Ports[0],
+										HealthPort:       tlsService.Spec.Ports[0],
+									},
+								},
+								Protocol: "tls",
+								UpstreamValidation: &PeerValidationContext{
+									CACertificates: []*Secret{
+										caSecret(cert1),
+										caSecret(cert2),
+									},
+									SubjectNames: []string{"example.com"},
+								},
+								UpstreamTLS: &UpstreamTLS{
+									MinimumProtocolVersion: "1.2",
+									MaximumProtocolVersion: "1.2",
+								},
+							},
+						)),
+					),
+				},
+			),
+		},
+		"HTTPRoute with BackendTLSPolicy and sectionName set": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			upstreamTLS: &UpstreamTLS{
+				MinimumProtocolVersion: "1.2",
+				MaximumProtocolVersion: "1.2",
+			},
+			objs: []any{
+				tlsAndNonTLSService,
 				cert1,
 				makeHTTPRoute("tls-basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/tls", "tlsandnontlssvc", 443, 1)),
 				&gatewayapi_v1.HTTPRoute{
@@ -5064,7 +5609,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Rules: []gatewayapi_v1.GRPCRouteRule{{
 							Matches: []gatewayapi_v1.GRPCRouteMatch{{
 								Method:  gatewayapi.GRPCMethodMatch(gatewayapi_v1.GRPCMethodMatchExact, "io.projectcontour", "Login"),
-								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.HeaderMatchExact, "version", "2"),
+								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.GRPCHeaderMatchExact, "version", "2"),
 							}},
 							BackendRefs: gatewayapi.GRPCRouteBackendRef("kuard", 8080, 1),
 						}},
@@ -5106,7 +5651,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Rules: []gatewayapi_v1.GRPCRouteRule{{
 							Matches: []gatewayapi_v1.GRPCRouteMatch{{
 								Method:  gatewayapi.GRPCMethodMatch(gatewayapi_v1.GRPCMethodMatchExact, "io.projectcontour", "Login"),
-								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.HeaderMatchRegularExpression, "version", "2+"),
+								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.GRPCHeaderMatchRegularExpression, "version", "2+"),
 							}},
 							BackendRefs: gatewayapi.GRPCRouteBackendRef("kuard", 8080, 1),
 						}},
@@ -5147,7 +5692,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						},
 						Rules: []gatewayapi_v1.GRPCRouteRule{{
 							Matches: []gatewayapi_v1.GRPCRouteMatch{{
-								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.HeaderMatchExact, "version", "2"),
+								Headers: gatewayapi.GRPCHeaderMatch(gatewayapi_v1.GRPCHeaderMatchExact, "version", "2"),
 							}},
 							BackendRefs: gatewayapi.GRPCRouteBackendRef("kuard", 8080, 1),
 						}},
@@ -5512,7 +6057,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
-						withMirror(exactrouteGRPCRoute("/io.projectcontour/Login", grpcService(kuardService, "h2c")), []*Service{grpcService(kuardService2, "h2c")}, 100))),
+						withMirrorFraction(exactrouteGRPCRoute("/io.projectcontour/Login", grpcService(kuardService, "h2c")), []*Service{grpcService(kuardService2, "h2c")}, 100, 100))),
 				},
 			),
 		},
@@ -5562,7 +6107,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				&Listener{
 					Name: "http-80",
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
-						withMirror(exactrouteGRPCRoute("/io.projectcontour/Login", grpcService(kuardService, "h2c")), []*Service{grpcService(kuardService2, "h2c"), grpcService(kuardService3, "h2c")}, 100))),
+						withMirrorFraction(exactrouteGRPCRoute("/io.projectcontour/Login", grpcService(kuardService, "h2c")), []*Service{grpcService(kuardService2, "h2c"), grpcService(kuardService3, "h2c")}, 100, 100))),
 				},
 			),
 		},
@@ -7689,7 +8234,7 @@ func TestDAGInsert(t *testing.T) {
 		},
 	}
 
-	// proxy13 has two mirrors, invalid.
+	// proxy13 has two mirrors, each with its own fraction.
 	proxy13 := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "example-com",
@@ -7710,13 +8255,19 @@ func TestDAGInsert(t *testing.T) {
 					Name:   s2.Name,
 					Port:   8080,
 					Mirror: true,
+					MirrorFraction: &contour_v1.MirrorFraction{
+						Numerator: 5,
+					},
 				}, {
-					// it is legal to mention a service more that
-					// once, however it is not legal for more than one
-					// service to be marked as mirror.
+					// it is legal to mention a service more than
+					// once, including as more than one mirror.
 					Name:   s2.Name,
 					Port:   8080,
 					Mirror: true,
+					MirrorFraction: &contour_v1.MirrorFraction{
+						Numerator:   1,
+						Denominator: ptr.To(int32(1000)),
+					},
 				}},
 			}},
 		},
@@ -8716,6 +9267,57 @@ func TestDAGInsert(t *testing.T) {
 		},
 	}
 
+	proxySessionPersistence := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "example-com",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Conditions: []contour_v1.MatchCondition{{
+					Prefix: "/",
+				}},
+				Services: []contour_v1.Service{{
+					Name: "nginx",
+					Port: 80,
+				}},
+				SessionPersistence: &contour_v1.SessionPersistence{
+					Name:      "cart",
+					CookieTTL: "30m",
+				},
+			}},
+		},
+	}
+
+	proxyRouteCORSPolicy := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "example-com",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Conditions: []contour_v1.MatchCondition{{
+					Prefix: "/",
+				}},
+				Services: []contour_v1.Service{{
+					Name: "nginx",
+					Port: 80,
+				}},
+				CORSPolicy: &contour_v1.CORSPolicy{
+					AllowOrigin:  []string{"https://example.com"},
+					AllowMethods: []contour_v1.CORSHeaderValue{"GET", "POST"},
+					MaxAge:       "10m",
+				},
+			}},
+		},
+	}
+
 	proxyLoadBalancerHashPolicyHeader := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "example-com",
@@ -10996,7 +11598,19 @@ func TestDAGInsert(t *testing.T) {
 			objs: []any{
 				proxy13, s1, s2,
 			},
-			want: listeners(),
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 8080,
+					VirtualHosts: virtualhosts(
+						virtualhost("example.com",
+							withMirrorFraction(
+								withMirrorFraction(prefixroute("/", service(s1)), []*Service{service(s2)}, 5, 100),
+								[]*Service{service(s2)}, 1, 1000),
+						),
+					),
+				},
+			),
 		},
 		"insert httpproxy with websocket route and prefix rewrite": {
 			objs: []any{
@@ -13205,6 +13819,54 @@ func TestDAGInsert(t *testing.T) {
 				},
 			),
 		},
+		"insert proxy with session persistence": {
+			objs: []any{
+				proxySessionPersistence,
+				s9,
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 8080,
+					VirtualHosts: virtualhosts(
+						virtualhost("example.com", &Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters:           clusters(service(s9)),
+							SessionPersistence: &SessionPersistence{
+								Type:      SessionPersistenceCookie,
+								Name:      "cart",
+								CookieTTL: 30 * time.Minute,
+							},
+						}),
+					),
+				},
+			),
+		},
+		"insert proxy with route cors policy": {
+			objs: []any{
+				proxyRouteCORSPolicy,
+				s9,
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 8080,
+					VirtualHosts: virtualhosts(
+						virtualhost("example.com", &Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters:           clusters(service(s9)),
+							CORSPolicy: &CORSPolicy{
+								AllowOrigin:   []CORSAllowOriginMatch{{Type: CORSAllowOriginMatchExact, Value: "https://example.com"}},
+								AllowMethods:  []string{"GET", "POST"},
+								AllowHeaders:  []string{},
+								ExposeHeaders: []string{},
+								MaxAge:        timeout.DurationSetting(10 * time.Minute),
+							},
+						}),
+					),
+				},
+			),
+		},
 		"insert proxy with load balancer hash source ip": {
 			objs: []any{
 				proxyLoadBalancerHashPolicySourceIP,
@@ -14905,189 +15567,607 @@ func TestGatewayWithHTTPProxyAndIngress(t *testing.T) {
 			assert.Equal(t, want, got)
 		})
 	}
-}
+}
+
+func backendv1(name string, port intstr.IntOrString) *networking_v1.IngressBackend {
+	var v1port networking_v1.ServiceBackendPort
+
+	switch port.Type {
+	case intstr.Int:
+		v1port = networking_v1.ServiceBackendPort{
+			Number: port.IntVal,
+		}
+	case intstr.String:
+		v1port = networking_v1.ServiceBackendPort{
+			Name: port.StrVal,
+		}
+	}
+
+	return &networking_v1.IngressBackend{
+		Service: &networking_v1.IngressServiceBackend{
+			Name: name,
+			Port: v1port,
+		},
+	}
+}
+
+func ingressrulev1value(backend *networking_v1.IngressBackend) networking_v1.IngressRuleValue {
+	return networking_v1.IngressRuleValue{
+		HTTP: &networking_v1.HTTPIngressRuleValue{
+			Paths: []networking_v1.HTTPIngressPath{{
+				Backend: *backend,
+			}},
+		},
+	}
+}
+
+func TestDAGRootNamespaces(t *testing.T) {
+	proxy1 := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "example-com",
+			Namespace: "allowed1",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	// proxy2 is like proxy1, but in a different namespace
+	proxy2 := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "example-com",
+			Namespace: "allowed2",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example2.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	s2 := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "allowed1",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+		},
+	}
+
+	s3 := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "allowed2",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+		},
+	}
+
+	tests := map[string]struct {
+		rootNamespaces []string
+		objs           []any
+		want           int
+	}{
+		"nil root httpproxy namespaces": {
+			objs: []any{proxy1, s2},
+			want: 1,
+		},
+		"empty root httpproxy namespaces": {
+			objs: []any{proxy1, s2},
+			want: 1,
+		},
+		"single root namespace with root httpproxy": {
+			rootNamespaces: []string{"allowed1"},
+			objs:           []any{proxy1, s2},
+			want:           1,
+		},
+		"multiple root namespaces, one with a root httpproxy": {
+			rootNamespaces: []string{"foo", "allowed1", "bar"},
+			objs:           []any{proxy1, s2},
+			want:           1,
+		},
+		"multiple root namespaces, each with a root httpproxy": {
+			rootNamespaces: []string{"foo", "allowed1", "allowed2"},
+			objs:           []any{proxy1, proxy2, s2, s3},
+			want:           2,
+		},
+		"root httpproxy defined outside single root namespaces": {
+			rootNamespaces: []string{"foo"},
+			objs:           []any{proxy1},
+			want:           0,
+		},
+		"root httpproxy defined outside multiple root namespaces": {
+			rootNamespaces: []string{"foo", "bar"},
+			objs:           []any{proxy1},
+			want:           0,
+		},
+		"two root httpproxy, one inside root namespace, one outside": {
+			rootNamespaces: []string{"foo", "allowed2"},
+			objs:           []any{proxy1, proxy2, s3},
+			want:           1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := Builder{
+				Source: KubernetesCache{
+					RootNamespaces: tc.rootNamespaces,
+					FieldLogger:    fixture.NewTestLogger(t),
+				},
+				Processors: []Processor{
+					&ListenerProcessor{},
+					&IngressProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+					&HTTPProxyProcessor{},
+				},
+			}
+
+			for _, o := range tc.objs {
+				builder.Source.Insert(o)
+			}
+			dag := builder.Build()
+
+			var got int
+			if l := dag.Listeners[HTTP_LISTENER_NAME]; l != nil {
+				got = len(l.VirtualHosts)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuilderRunsProcessorsInOrder(t *testing.T) {
+	var got []string
+
+	b := Builder{
+		Processors: []Processor{
+			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "foo") }),
+			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "bar") }),
+			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "baz") }),
+			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "abc") }),
+			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "def") }),
+		},
+	}
+
+	b.Build()
+
+	assert.Equal(t, []string{"foo", "bar", "baz", "abc", "def"}, got)
+}
+
+func TestBuilderSimulate(t *testing.T) {
+	s1 := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+		},
+	}
+
+	proxy1 := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "example-com",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	// proxy2 claims the same fqdn as proxy1.
+	proxy2 := proxy1.DeepCopy()
+	proxy2.Name = "example-com-2"
+
+	builder := Builder{
+		Source: KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		},
+		Processors: []Processor{
+			&ListenerProcessor{},
+			&HTTPProxyProcessor{},
+		},
+	}
+	builder.Source.Insert(s1)
+	builder.Source.Insert(proxy1)
+
+	simulated := builder.Simulate(proxy2)
+	assert.NotContains(t, simulated.Listeners, HTTP_LISTENER_NAME)
+
+	got := map[types.NamespacedName]bool{}
+	for _, pu := range simulated.StatusCache.GetProxyUpdates() {
+		got[pu.Fullname] = pu.ConditionFor(status.ValidCondition).Status == contour_v1.ConditionTrue
+	}
+	assert.Equal(t, map[types.NamespacedName]bool{
+		{Namespace: "default", Name: "example-com"}:   false,
+		{Namespace: "default", Name: "example-com-2"}: false,
+	}, got)
+
+	// The source is left unchanged.
+	built := builder.Build()
+	assert.Len(t, built.Listeners[HTTP_LISTENER_NAME].VirtualHosts, 1)
+	assert.Len(t, built.StatusCache.GetProxyUpdates(), 1)
+}
+
+func TestBuilderRouteSources(t *testing.T) {
+	s1 := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+		},
+	}
+
+	root := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "root",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Includes: []contour_v1.Include{{
+				Name:       "missing",
+				Conditions: []contour_v1.MatchCondition{{Prefix: "/missing"}},
+			}, {
+				Name:       "child",
+				Conditions: []contour_v1.MatchCondition{{Prefix: "/child"}},
+			}},
+		},
+	}
+
+	child := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "child",
+			Namespace: "default",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}, {
+				Conditions: []contour_v1.MatchCondition{{Prefix: "/api"}},
+				Services:   []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}},
+		},
+	}
+
+	ingress := &networking_v1.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ingress",
+			Namespace: "default",
+		},
+		Spec: networking_v1.IngressSpec{
+			DefaultBackend: backendv1("kuard", intstr.FromInt(8080)),
+			Rules: []networking_v1.IngressRule{{
+				Host: "ingress.example.com",
+				IngressRuleValue: networking_v1.IngressRuleValue{
+					HTTP: &networking_v1.HTTPIngressRuleValue{
+						Paths: []networking_v1.HTTPIngressPath{{
+							Path:    "/",
+							Backend: *backendv1("kuard", intstr.FromInt(8080)),
+						}, {
+							Path:    "/static",
+							Backend: *backendv1("kuard", intstr.FromInt(8080)),
+						}},
+					},
+				},
+			}},
+		},
+	}
 
-func backendv1(name string, port intstr.IntOrString) *networking_v1.IngressBackend {
-	var v1port networking_v1.ServiceBackendPort
+	builder := Builder{
+		Source: KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		},
+		Processors: []Processor{
+			&ListenerProcessor{},
+			&IngressProcessor{FieldLogger: fixture.NewTestLogger(t)},
+			&HTTPProxyProcessor{},
+		},
+	}
+	for _, o := range []any{s1, root, child, ingress} {
+		builder.Source.Insert(o)
+	}
+	d := builder.Build()
 
-	switch port.Type {
-	case intstr.Int:
-		v1port = networking_v1.ServiceBackendPort{
-			Number: port.IntVal,
+	got := map[string][]Source{}
+	for _, vh := range d.Listeners[HTTP_LISTENER_NAME].VirtualHosts {
+		for _, r := range vh.Routes {
+			got[vh.Name+" "+r.PathMatchCondition.(*PrefixMatchCondition).Prefix] = d.Sources(r)
 		}
-	case intstr.String:
-		v1port = networking_v1.ServiceBackendPort{
-			Name: port.StrVal,
+	}
+
+	assert.Equal(t, map[string][]Source{
+		"example.com /missing": {{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.includes[0]"}},
+		"example.com /child": {
+			{Kind: "HTTPProxy", Namespace: "default", Name: "child", FieldPath: "spec.routes[0]"},
+			{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.includes[1]"},
+		},
+		"example.com /child/api": {
+			{Kind: "HTTPProxy", Namespace: "default", Name: "child", FieldPath: "spec.routes[1]"},
+			{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.includes[1]"},
+		},
+		"* /":                         {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.defaultBackend"}},
+		"ingress.example.com /":       {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.rules[0].http.paths[0]"}},
+		"ingress.example.com /static": {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.rules[0].http.paths[1]"}},
+	}, got)
+
+	vhosts := map[string][]Source{}
+	for _, vh := range d.Listeners[HTTP_LISTENER_NAME].VirtualHosts {
+		vhosts[vh.Name] = d.Sources(vh)
+	}
+	assert.Equal(t, map[string][]Source{
+		"example.com":         {{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.virtualhost"}},
+		"*":                   {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.defaultBackend"}},
+		"ingress.example.com": {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.rules[0]"}},
+	}, vhosts)
+
+	clusters := map[string][]Source{}
+	for _, vh := range d.Listeners[HTTP_LISTENER_NAME].VirtualHosts {
+		for _, r := range vh.Routes {
+			for _, c := range r.Clusters {
+				clusters[vh.Name+" "+r.PathMatchCondition.(*PrefixMatchCondition).Prefix] = d.Sources(c)
+			}
 		}
 	}
+	assert.Equal(t, []Source{{Kind: "HTTPProxy", Namespace: "default", Name: "child", FieldPath: "spec.routes[1].services[0]"}}, clusters["example.com /child/api"])
+	assert.Equal(t, []Source{{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.rules[0].http.paths[1]"}}, clusters["ingress.example.com /static"])
+}
 
-	return &networking_v1.IngressBackend{
-		Service: &networking_v1.IngressServiceBackend{
-			Name: name,
-			Port: v1port,
+func TestBuilderSecretSources(t *testing.T) {
+	s1 := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
 		},
 	}
-}
 
-func ingressrulev1value(backend *networking_v1.IngressBackend) networking_v1.IngressRuleValue {
-	return networking_v1.IngressRuleValue{
-		HTTP: &networking_v1.HTTPIngressRuleValue{
-			Paths: []networking_v1.HTTPIngressPath{{
-				Backend: *backend,
-			}},
+	sec1 := &core_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "secret",
+			Namespace: "default",
 		},
+		Type: core_v1.SecretTypeTLS,
+		Data: secretdata(fixture.CERTIFICATE, fixture.RSA_PRIVATE_KEY),
 	}
-}
 
-func TestDAGRootNamespaces(t *testing.T) {
-	proxy1 := &contour_v1.HTTPProxy{
+	proxy := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "example-com",
-			Namespace: "allowed1",
+			Name:      "secure",
+			Namespace: "default",
 		},
 		Spec: contour_v1.HTTPProxySpec{
 			VirtualHost: &contour_v1.VirtualHost{
-				Fqdn: "example.com",
+				Fqdn: "secure.example.com",
+				TLS: &contour_v1.TLS{
+					SecretName: sec1.Name,
+				},
 			},
 			Routes: []contour_v1.Route{{
-				Services: []contour_v1.Service{{
-					Name: "kuard",
-					Port: 8080,
-				}},
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
 			}},
 		},
 	}
 
-	// proxy2 is like proxy1, but in a different namespace
-	proxy2 := &contour_v1.HTTPProxy{
+	ingress := &networking_v1.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "example-com",
-			Namespace: "allowed2",
+			Name:      "ingress",
+			Namespace: "default",
 		},
-		Spec: contour_v1.HTTPProxySpec{
-			VirtualHost: &contour_v1.VirtualHost{
-				Fqdn: "example2.com",
-			},
-			Routes: []contour_v1.Route{{
-				Services: []contour_v1.Service{{
-					Name: "kuard",
-					Port: 8080,
-				}},
+		Spec: networking_v1.IngressSpec{
+			TLS: []networking_v1.IngressTLS{{
+				Hosts:      []string{"ingress.example.com"},
+				SecretName: sec1.Name,
+			}},
+			Rules: []networking_v1.IngressRule{{
+				Host: "ingress.example.com",
+				IngressRuleValue: networking_v1.IngressRuleValue{
+					HTTP: &networking_v1.HTTPIngressRuleValue{
+						Paths: []networking_v1.HTTPIngressPath{{
+							Backend: *backendv1("kuard", intstr.FromInt(8080)),
+						}},
+					},
+				},
 			}},
 		},
 	}
 
-	s2 := &core_v1.Service{
-		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "kuard",
-			Namespace: "allowed1",
+	builder := Builder{
+		Source: KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
 		},
-		Spec: core_v1.ServiceSpec{
-			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+		Processors: []Processor{
+			&ListenerProcessor{},
+			&IngressProcessor{FieldLogger: fixture.NewTestLogger(t)},
+			&HTTPProxyProcessor{},
 		},
 	}
+	for _, o := range []any{s1, sec1, proxy, ingress} {
+		builder.Source.Insert(o)
+	}
+	d := builder.Build()
 
-	s3 := &core_v1.Service{
+	vhosts := map[string][]Source{}
+	var secret *Secret
+	for _, svh := range d.Listeners[HTTPS_LISTENER_NAME].SecureVirtualHosts {
+		vhosts[svh.Name] = d.Sources(svh)
+		secret = svh.Secret
+	}
+	assert.Equal(t, map[string][]Source{
+		"secure.example.com":  {{Kind: "HTTPProxy", Namespace: "default", Name: "secure", FieldPath: "spec.virtualhost"}},
+		"ingress.example.com": {{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.tls[0]"}, {Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.rules[0]"}},
+	}, vhosts)
+
+	// Both virtual hosts share the same secret, which records
+	// every object that references it.
+	if assert.NotNil(t, secret) {
+		assert.ElementsMatch(t, []Source{
+			{Kind: "HTTPProxy", Namespace: "default", Name: "secure", FieldPath: "spec.virtualhost.tls.secretName"},
+			{Kind: "Ingress", Namespace: "default", Name: "ingress", FieldPath: "spec.tls[0].secretName"},
+		}, d.Sources(secret))
+	}
+}
+
+func TestBuilderGatewayAPISources(t *testing.T) {
+	kuardService := &core_v1.Service{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "kuard",
-			Namespace: "allowed2",
+			Namespace: "projectcontour",
 		},
 		Spec: core_v1.ServiceSpec{
-			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080)},
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080, 8080)},
 		},
 	}
 
-	tests := map[string]struct {
-		rootNamespaces []string
-		objs           []any
-		want           int
-	}{
-		"nil root httpproxy namespaces": {
-			objs: []any{proxy1, s2},
-			want: 1,
-		},
-		"empty root httpproxy namespaces": {
-			objs: []any{proxy1, s2},
-			want: 1,
-		},
-		"single root namespace with root httpproxy": {
-			rootNamespaces: []string{"allowed1"},
-			objs:           []any{proxy1, s2},
-			want:           1,
-		},
-		"multiple root namespaces, one with a root httpproxy": {
-			rootNamespaces: []string{"foo", "allowed1", "bar"},
-			objs:           []any{proxy1, s2},
-			want:           1,
-		},
-		"multiple root namespaces, each with a root httpproxy": {
-			rootNamespaces: []string{"foo", "allowed1", "allowed2"},
-			objs:           []any{proxy1, proxy2, s2, s3},
-			want:           2,
+	sec1 := &core_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "secret",
+			Namespace: "projectcontour",
 		},
-		"root httpproxy defined outside single root namespaces": {
-			rootNamespaces: []string{"foo"},
-			objs:           []any{proxy1},
-			want:           0,
+		Type: core_v1.SecretTypeTLS,
+		Data: secretdata(fixture.CERTIFICATE, fixture.RSA_PRIVATE_KEY),
+	}
+
+	gatewayClass := &gatewayapi_v1.GatewayClass{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "test-validClass",
 		},
-		"root httpproxy defined outside multiple root namespaces": {
-			rootNamespaces: []string{"foo", "bar"},
-			objs:           []any{proxy1},
-			want:           0,
+		Spec: gatewayapi_v1.GatewayClassSpec{
+			ControllerName: "projectcontour.io/contour",
 		},
-		"two root httpproxy, one inside root namespace, one outside": {
-			rootNamespaces: []string{"foo", "allowed2"},
-			objs:           []any{proxy1, proxy2, s3},
-			want:           1,
+		Status: gatewayapi_v1.GatewayClassStatus{
+			Conditions: []meta_v1.Condition{{
+				Type:   string(gatewayapi_v1.GatewayClassConditionStatusAccepted),
+				Status: meta_v1.ConditionTrue,
+			}},
 		},
 	}
 
-	for name, tc := range tests {
-		t.Run(name, func(t *testing.T) {
-			builder := Builder{
-				Source: KubernetesCache{
-					RootNamespaces: tc.rootNamespaces,
-					FieldLogger:    fixture.NewTestLogger(t),
+	gateway := &gatewayapi_v1.Gateway{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1.GatewaySpec{
+			GatewayClassName: gatewayapi_v1.ObjectName(gatewayClass.Name),
+			Listeners: []gatewayapi_v1.Listener{{
+				Name:     "http",
+				Port:     80,
+				Protocol: gatewayapi_v1.HTTPProtocolType,
+				AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+					Namespaces: &gatewayapi_v1.RouteNamespaces{
+						From: ptr.To(gatewayapi_v1.NamespacesFromSame),
+					},
 				},
-				Processors: []Processor{
-					&ListenerProcessor{},
-					&IngressProcessor{
-						FieldLogger: fixture.NewTestLogger(t),
+			}, {
+				Name:     "https",
+				Port:     443,
+				Protocol: gatewayapi_v1.HTTPSProtocolType,
+				TLS: &gatewayapi_v1.GatewayTLSConfig{
+					CertificateRefs: []gatewayapi_v1.SecretObjectReference{
+						gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 					},
-					&HTTPProxyProcessor{},
 				},
-			}
-
-			for _, o := range tc.objs {
-				builder.Source.Insert(o)
-			}
-			dag := builder.Build()
-
-			var got int
-			if l := dag.Listeners[HTTP_LISTENER_NAME]; l != nil {
-				got = len(l.VirtualHosts)
-			}
-			assert.Equal(t, tc.want, got)
-		})
+				AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+					Namespaces: &gatewayapi_v1.RouteNamespaces{
+						From: ptr.To(gatewayapi_v1.NamespacesFromSame),
+					},
+				},
+			}},
+		},
 	}
-}
 
-func TestBuilderRunsProcessorsInOrder(t *testing.T) {
-	var got []string
+	route := &gatewayapi_v1.HTTPRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+			},
+			Hostnames: []gatewayapi_v1.Hostname{"test.projectcontour.io"},
+			Rules: []gatewayapi_v1.HTTPRouteRule{{
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+				BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+			}},
+		},
+	}
 
-	b := Builder{
+	builder := Builder{
+		Source: KubernetesCache{
+			gatewayclass: gatewayClass,
+			gateway:      gateway,
+			FieldLogger:  fixture.NewTestLogger(t),
+		},
 		Processors: []Processor{
-			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "foo") }),
-			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "bar") }),
-			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "baz") }),
-			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "abc") }),
-			ProcessorFunc(func(*DAG, *KubernetesCache) { got = append(got, "def") }),
+			&ListenerProcessor{},
+			&GatewayAPIProcessor{
+				FieldLogger: fixture.NewTestLogger(t),
+			},
 		},
 	}
+	for _, o := range []any{kuardService, sec1, route} {
+		builder.Source.Insert(o)
+	}
+	d := builder.Build()
 
-	b.Build()
-
-	assert.Equal(t, []string{"foo", "bar", "baz", "abc", "def"}, got)
+	routeSrc := Source{Kind: "HTTPRoute", Namespace: "projectcontour", Name: "basic", FieldPath: "spec.rules[0]"}
+	for _, l := range d.Listeners {
+		for _, vh := range l.VirtualHosts {
+			assert.Equal(t, []Source{
+				{Kind: "Gateway", Namespace: "projectcontour", Name: "contour", FieldPath: "spec.listeners[0]"},
+				{Kind: "HTTPRoute", Namespace: "projectcontour", Name: "basic"},
+			}, d.Sources(vh))
+			for _, r := range vh.Routes {
+				assert.Equal(t, []Source{routeSrc}, d.Sources(r))
+				assert.Equal(t, []Source{routeSrc}, d.Sources(r.Clusters[0]))
+			}
+		}
+		for _, svh := range l.SecureVirtualHosts {
+			assert.Equal(t, []Source{
+				{Kind: "Gateway", Namespace: "projectcontour", Name: "contour", FieldPath: "spec.listeners[1]"},
+				{Kind: "HTTPRoute", Namespace: "projectcontour", Name: "basic"},
+			}, d.Sources(svh))
+			assert.Equal(t, []Source{
+				{Kind: "Gateway", Namespace: "projectcontour", Name: "contour", FieldPath: "spec.listeners[1].tls.certificateRefs"},
+			}, d.Sources(svh.Secret))
+		}
+	}
+	assert.Len(t, d.Listeners["http-80"].VirtualHosts, 1)
+	assert.Len(t, d.Listeners["https-443"].SecureVirtualHosts, 1)
 }
 
 func TestHTTPProxyConficts(t *testing.T) {
@@ -16068,11 +17148,13 @@ func listeners(ls ...*Listener) []*Listener {
 			listener.Protocol = "http"
 			listener.Address = "0.0.0.0"
 			listener.Port = 8080
+			listener.ServicePort = 80
 			listener.EnableWebsockets = true
 		case "https-443":
 			listener.Protocol = "https"
 			listener.Address = "0.0.0.0"
 			listener.Port = 8443
+			listener.ServicePort = 443
 			listener.EnableWebsockets = true
 		}
 	}
@@ -16092,17 +17174,27 @@ func exact(path string) MatchCondition  { return &ExactMatchCondition{Path: path
 func regex(regex string) MatchCondition { return &RegexMatchCondition{Regex: regex} }
 
 func withMirror(r *Route, mirrors []*Service, weight int64) *Route {
+	return withMirrorFraction(r, mirrors, weight, 0)
+}
+
+func withMirrorFraction(r *Route, mirrors []*Service, weight, denominator int64) *Route {
 	for _, mirror := range mirrors {
 		r.MirrorPolicies = append(r.MirrorPolicies, &MirrorPolicy{
 			Cluster: &Cluster{
 				Upstream: mirror,
 			},
-			Weight: weight,
+			Weight:      weight,
+			Denominator: denominator,
 		})
 	}
 	return r
 }
 
+func withCORSPolicy(r *Route, cp *CORSPolicy) *Route {
+	r.CORSPolicy = cp
+	return r
+}
+
 func makeHTTPRouteTimeouts(request, backendRequest string) *gatewayapi_v1.HTTPRouteTimeouts {
 	httpRouteTimeouts := &gatewayapi_v1.HTTPRouteTimeouts{}
 
@@ -16164,6 +17256,22 @@ func makeHTTPRoute(name, namespace, hostname string, firstRule gatewayapi_v1.HTT
 	}
 }
 
+func makeBackendTrafficPolicy(namespace, serviceName string, sessionPersistence *gatewayapi_v1alpha2.SessionPersistence) *gatewayapi_x_v1alpha1.XBackendTrafficPolicy {
+	return &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      serviceName + "-lb",
+			Namespace: namespace,
+		},
+		Spec: gatewayapi_x_v1alpha1.BackendTrafficPolicySpec{
+			TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReference{{
+				Kind: "Service",
+				Name: gatewayapi_v1.ObjectName(serviceName),
+			}},
+			SessionPersistence: sessionPersistence,
+		},
+	}
+}
+
 func makeHTTPRouteRule(pathType gatewayapi_v1.PathMatchType, pathValue, serviceName string, port int, weight int32) gatewayapi_v1.HTTPRouteRule {
 	return gatewayapi_v1.HTTPRouteRule{
 		Matches:     gatewayapi.HTTPRouteMatch(pathType, pathValue),
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -32,6 +33,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -75,9 +77,12 @@ type KubernetesCache struct {
 	tlsroutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.TLSRoute
 	grpcroutes                map[types.NamespacedName]*gatewayapi_v1.GRPCRoute
 	tcproutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.TCPRoute
+	udproutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.UDPRoute
 	referencegrants           map[types.NamespacedName]*gatewayapi_v1beta1.ReferenceGrant
 	backendtlspolicies        map[types.NamespacedName]*gatewayapi_v1alpha3.BackendTLSPolicy
+	backendtrafficpolicies    map[types.NamespacedName]*gatewayapi_x_v1alpha1.XBackendTrafficPolicy
 	extensions                map[types.NamespacedName]*contour_v1alpha1.ExtensionService
+	backends                  map[types.NamespacedName]*contour_v1alpha1.Backend
 
 	// Metrics contains Prometheus metrics.
 	Metrics *metrics.Metrics
@@ -112,8 +117,50 @@ func (kc *KubernetesCache) init() {
 	kc.tlsroutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.TLSRoute)
 	kc.grpcroutes = make(map[types.NamespacedName]*gatewayapi_v1.GRPCRoute)
 	kc.tcproutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.TCPRoute)
+	kc.udproutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.UDPRoute)
 	kc.backendtlspolicies = make(map[types.NamespacedName]*gatewayapi_v1alpha3.BackendTLSPolicy)
+	kc.backendtrafficpolicies = make(map[types.NamespacedName]*gatewayapi_x_v1alpha1.XBackendTrafficPolicy)
 	kc.extensions = make(map[types.NamespacedName]*contour_v1alpha1.ExtensionService)
+	kc.backends = make(map[types.NamespacedName]*contour_v1alpha1.Backend)
+}
+
+// clone returns a copy of kc that can be changed without changing
+// kc. The cached objects are shared and must not be modified.
+// Metrics are not recorded for changes to the copy.
+func (kc *KubernetesCache) clone() *KubernetesCache {
+	kc.initialize.Do(kc.init)
+
+	c := &KubernetesCache{
+		RootNamespaces:            kc.RootNamespaces,
+		IngressClassNames:         kc.IngressClassNames,
+		ConfiguredGatewayToCache:  kc.ConfiguredGatewayToCache,
+		ConfiguredSecretRefs:      kc.ConfiguredSecretRefs,
+		ingresses:                 maps.Clone(kc.ingresses),
+		httpproxies:               maps.Clone(kc.httpproxies),
+		secrets:                   maps.Clone(kc.secrets),
+		configmapsecrets:          maps.Clone(kc.configmapsecrets),
+		tlscertificatedelegations: maps.Clone(kc.tlscertificatedelegations),
+		services:                  maps.Clone(kc.services),
+		namespaces:                maps.Clone(kc.namespaces),
+		gatewayclass:              kc.gatewayclass,
+		gateway:                   kc.gateway,
+		httproutes:                maps.Clone(kc.httproutes),
+		tlsroutes:                 maps.Clone(kc.tlsroutes),
+		grpcroutes:                maps.Clone(kc.grpcroutes),
+		tcproutes:                 maps.Clone(kc.tcproutes),
+		udproutes:                 maps.Clone(kc.udproutes),
+		referencegrants:           maps.Clone(kc.referencegrants),
+		backendtlspolicies:        maps.Clone(kc.backendtlspolicies),
+		backendtrafficpolicies:    maps.Clone(kc.backendtrafficpolicies),
+		extensions:                maps.Clone(kc.extensions),
+		backends:                  maps.Clone(kc.backends),
+		Client:                    kc.Client,
+		FieldLogger:               kc.FieldLogger,
+	}
+	// The maps are already set up.
+	c.initialize.Do(func() {})
+
+	return c
 }
 
 // Insert inserts obj into the KubernetesCache.
@@ -247,6 +294,10 @@ func (kc *KubernetesCache) Insert(obj any) bool {
 			kc.tcproutes[k8s.NamespacedNameOf(obj)] = obj
 			return kc.routeTriggersRebuild(obj.Spec.ParentRefs), len(kc.tcproutes)
 
+		case *gatewayapi_v1alpha2.UDPRoute:
+			kc.udproutes[k8s.NamespacedNameOf(obj)] = obj
+			return kc.routeTriggersRebuild(obj.Spec.ParentRefs), len(kc.udproutes)
+
 		case *gatewayapi_v1beta1.ReferenceGrant:
 			kc.referencegrants[k8s.NamespacedNameOf(obj)] = obj
 			return true, len(kc.referencegrants)
@@ -255,10 +306,18 @@ func (kc *KubernetesCache) Insert(obj any) bool {
 			kc.backendtlspolicies[k8s.NamespacedNameOf(obj)] = obj
 			return true, len(kc.backendtlspolicies)
 
+		case *gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
+			kc.backendtrafficpolicies[k8s.NamespacedNameOf(obj)] = obj
+			return true, len(kc.backendtrafficpolicies)
+
 		case *contour_v1alpha1.ExtensionService:
 			kc.extensions[k8s.NamespacedNameOf(obj)] = obj
 			return true, len(kc.extensions)
 
+		case *contour_v1alpha1.Backend:
+			kc.backends[k8s.NamespacedNameOf(obj)] = obj
+			return true, len(kc.backends)
+
 		default:
 			// not an interesting object
 			kc.WithField("object", obj).Error("insert unknown object")
@@ -407,6 +466,11 @@ func (kc *KubernetesCache) remove(obj any) (bool, int) {
 		delete(kc.tcproutes, m)
 		return kc.routeTriggersRebuild(obj.Spec.ParentRefs), len(kc.tcproutes)
 
+	case *gatewayapi_v1alpha2.UDPRoute:
+		m := k8s.NamespacedNameOf(obj)
+		delete(kc.udproutes, m)
+		return kc.routeTriggersRebuild(obj.Spec.ParentRefs), len(kc.udproutes)
+
 	case *gatewayapi_v1beta1.ReferenceGrant:
 		m := k8s.NamespacedNameOf(obj)
 		_, ok := kc.referencegrants[m]
@@ -419,12 +483,24 @@ func (kc *KubernetesCache) remove(obj any) (bool, int) {
 		delete(kc.backendtlspolicies, m)
 		return ok, len(kc.backendtlspolicies)
 
+	case *gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
+		m := k8s.NamespacedNameOf(obj)
+		_, ok := kc.backendtrafficpolicies[m]
+		delete(kc.backendtrafficpolicies, m)
+		return ok, len(kc.backendtrafficpolicies)
+
 	case *contour_v1alpha1.ExtensionService:
 		m := k8s.NamespacedNameOf(obj)
 		_, ok := kc.extensions[m]
 		delete(kc.extensions, m)
 		return ok, len(kc.extensions)
 
+	case *contour_v1alpha1.Backend:
+		m := k8s.NamespacedNameOf(obj)
+		_, ok := kc.backends[m]
+		delete(kc.backends, m)
+		return ok, len(kc.backends)
+
 	default:
 		// not interesting
 		kc.WithField("object", obj).Error("remove unknown object")
@@ -518,6 +594,16 @@ func (kc *KubernetesCache) serviceTriggersRebuild(service *core_v1.Service) bool
 		}
 	}
 
+	for _, route := range kc.udproutes {
+		for _, rule := range route.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				if isRefToService(backend.BackendObjectReference, service, route.Namespace) {
+					return true
+				}
+			}
+		}
+	}
+
 	return false
 }
 
@@ -557,11 +643,44 @@ func (kc *KubernetesCache) secretTriggersRebuild(secretObj *core_v1.Secret) bool
 	}
 
 	for _, proxy := range kc.httpproxies {
+		// Basic auth policies can be set on the routes
+		// of any proxy, including included ones.
+		for _, route := range proxy.Spec.Routes {
+			if route.BasicAuthPolicy != nil && len(route.BasicAuthPolicy.SecretName) > 0 &&
+				secret == (types.NamespacedName{Namespace: proxy.Namespace, Name: route.BasicAuthPolicy.SecretName}) {
+				return true
+			}
+			for _, service := range route.Services {
+				if service.ClientCertificate != "" &&
+					secret == k8s.NamespacedNameFrom(service.ClientCertificate, k8s.DefaultNamespace(proxy.Namespace)) {
+					return true
+				}
+			}
+		}
+
+		if tcpproxy := proxy.Spec.TCPProxy; tcpproxy != nil {
+			for _, service := range tcpproxy.Services {
+				if service.ClientCertificate != "" &&
+					secret == k8s.NamespacedNameFrom(service.ClientCertificate, k8s.DefaultNamespace(proxy.Namespace)) {
+					return true
+				}
+			}
+		}
+
 		vh := proxy.Spec.VirtualHost
 		if vh == nil {
 			// not a root ingress
 			continue
 		}
+
+		if vh.BasicAuth != nil && secret == (types.NamespacedName{Namespace: proxy.Namespace, Name: vh.BasicAuth.SecretName}) {
+			return true
+		}
+
+		if vh.OAuth2 != nil && secret == (types.NamespacedName{Namespace: proxy.Namespace, Name: vh.OAuth2.ClientSecretRef}) {
+			return true
+		}
+
 		tls := vh.TLS
 		if tls == nil {
 			// no tls spec
@@ -735,6 +854,48 @@ func (kc *KubernetesCache) LookupCRLSecret(name types.NamespacedName, targetName
 	return sec, nil
 }
 
+// LookupOAuth2Secret returns Secret with OAuth2 client credentials from the cache.
+func (kc *KubernetesCache) LookupOAuth2Secret(name types.NamespacedName) (*Secret, error) {
+	sec, ok := kc.secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("Secret not found")
+	}
+
+	// Compute and store the validation result if not
+	// already stored.
+	if sec.ValidOAuth2Secret == nil {
+		sec.ValidOAuth2Secret = &SecretValidationStatus{
+			Error: validOAuth2Secret(sec.Object),
+		}
+	}
+
+	if err := sec.ValidOAuth2Secret.Error; err != nil {
+		return nil, err
+	}
+	return sec, nil
+}
+
+// LookupBasicAuthSecret returns Secret with htpasswd users from the cache.
+func (kc *KubernetesCache) LookupBasicAuthSecret(name types.NamespacedName) (*Secret, error) {
+	sec, ok := kc.secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("Secret not found")
+	}
+
+	// Compute and store the validation result if not
+	// already stored.
+	if sec.ValidBasicAuthSecret == nil {
+		sec.ValidBasicAuthSecret = &SecretValidationStatus{
+			Error: validBasicAuthSecret(sec.Object),
+		}
+	}
+
+	if err := sec.ValidBasicAuthSecret.Error; err != nil {
+		return nil, err
+	}
+	return sec, nil
+}
+
 // LookupUpstreamValidation constructs PeerValidationContext with CA certificate from the cache.
 // If name (referred Secret) is in different namespace than targetNamespace (the referring object),
 // then delegation check is performed.
@@ -834,29 +995,67 @@ func (kc *KubernetesCache) delegationPermitted(secret types.NamespacedName, targ
 	return false
 }
 
-// LookupService returns the Kubernetes service and port matching the provided parameters,
+// LookupService returns the Kubernetes service and TCP port matching the provided parameters,
 // or an error if a match can't be found.
 func (kc *KubernetesCache) LookupService(meta types.NamespacedName, port intstr.IntOrString) (*core_v1.Service, core_v1.ServicePort, error) {
+	return kc.lookupService(meta, port, core_v1.ProtocolTCP)
+}
+
+// LookupUDPService returns the Kubernetes service and UDP port matching the provided parameters,
+// or an error if a match can't be found.
+func (kc *KubernetesCache) LookupUDPService(meta types.NamespacedName, port intstr.IntOrString) (*core_v1.Service, core_v1.ServicePort, error) {
+	return kc.lookupService(meta, port, core_v1.ProtocolUDP)
+}
+
+func (kc *KubernetesCache) lookupService(meta types.NamespacedName, port intstr.IntOrString, protocol core_v1.Protocol) (*core_v1.Service, core_v1.ServicePort, error) {
 	svc, ok := kc.services[meta]
 	if !ok {
 		return nil, core_v1.ServicePort{}, fmt.Errorf("service %q not found", meta)
 	}
 
+	var unsupportedProtocol *core_v1.Protocol
+
 	for i := range svc.Spec.Ports {
 		p := svc.Spec.Ports[i]
 		if int(p.Port) == port.IntValue() || port.String() == p.Name {
-			switch p.Protocol {
-			case "", core_v1.ProtocolTCP:
-				return svc, p, nil
-			default:
-				return nil, core_v1.ServicePort{}, fmt.Errorf("unsupported service protocol %q", p.Protocol)
+			// An empty protocol defaults to TCP.
+			portProtocol := p.Protocol
+			if portProtocol == "" {
+				portProtocol = core_v1.ProtocolTCP
 			}
+
+			// A Service may expose the same port number for
+			// multiple protocols (e.g. DNS on 53/TCP and 53/UDP),
+			// so keep looking for a port with a matching protocol.
+			if portProtocol != protocol {
+				if unsupportedProtocol == nil {
+					unsupportedProtocol = &p.Protocol
+				}
+				continue
+			}
+
+			return svc, p, nil
 		}
 	}
 
+	if unsupportedProtocol != nil {
+		return nil, core_v1.ServicePort{}, fmt.Errorf("unsupported service protocol %q", *unsupportedProtocol)
+	}
+
 	return nil, core_v1.ServicePort{}, fmt.Errorf("port %q on service %q not matched", port.String(), meta)
 }
 
+// LookupBackend returns the Backend matching the provided parameters,
+// or an error if a match can't be found.
+func (kc *KubernetesCache) LookupBackend(meta types.NamespacedName) (*contour_v1alpha1.Backend, error) {
+	backend, ok := kc.backends[meta]
+	if !ok {
+		return nil, fmt.Errorf("backend %q not found", meta)
+	}
+
+	return backend, nil
+}
+
 // LookupBackendTLSPolicyByTargetRef returns the Kubernetes BackendTLSPolicy that matches the provided targetRef with
 // a SectionName, if possible. A BackendTLSPolicy may be returned if there is a BackendTLSPolicy matching the targetRef
 // but has no SectionName.
@@ -902,6 +1101,41 @@ func (kc *KubernetesCache) LookupBackendTLSPolicyByTargetRef(targetRef gatewayap
 	return nil, false
 }
 
+// LookupBackendTrafficPolicyByTargetRef returns the Kubernetes XBackendTrafficPolicy that matches the provided targetRef.
+//
+// The namespace provided is intended to be the namespace of the backend we are looking up a reference to (since only
+// namespace-local references are allowed) and is used to match the namespace on the resulting backendTrafficPolicy.
+//
+// If more than one XBackendTrafficPolicy matches, the oldest one, ordered by creation timestamp and then by name, is
+// returned.
+//
+// If a policy is found, true is returned.
+func (kc *KubernetesCache) LookupBackendTrafficPolicyByTargetRef(targetRef gatewayapi_v1alpha2.LocalPolicyTargetReference, namespace string) (*gatewayapi_x_v1alpha1.XBackendTrafficPolicy, bool) {
+	var backendTrafficPolicy *gatewayapi_x_v1alpha1.XBackendTrafficPolicy
+	for _, v := range kc.backendtrafficpolicies {
+		// Make sure the XBackendTrafficPolicy namespace matches the backend namespace.
+		if v.Namespace != namespace {
+			continue
+		}
+
+		// One of the Policy target refs must match the expected target ref.
+		for _, tr := range v.Spec.TargetRefs {
+			if tr != targetRef {
+				continue
+			}
+
+			if backendTrafficPolicy == nil ||
+				v.CreationTimestamp.Before(&backendTrafficPolicy.CreationTimestamp) ||
+				(v.CreationTimestamp.Equal(&backendTrafficPolicy.CreationTimestamp) && v.Name < backendTrafficPolicy.Name) {
+				backendTrafficPolicy = v
+			}
+			break
+		}
+	}
+
+	return backendTrafficPolicy, backendTrafficPolicy != nil
+}
+
 func (kc *KubernetesCache) convertCACertConfigMapToSecret(configMap *core_v1.ConfigMap) (*core_v1.Secret, bool) {
 	if _, ok := configMap.Data[CACertificateKey]; !ok {
 		return nil, false
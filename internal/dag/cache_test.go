@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -1112,6 +1114,12 @@ func TestKubernetesCacheInsert(t *testing.T) {
 			},
 			want: true,
 		},
+		"insert backend": {
+			obj: &contour_v1alpha1.Backend{
+				ObjectMeta: fixture.ObjectMeta("default/backend"),
+			},
+			want: true,
+		},
 		"insert secret that is referred by configuration file": {
 			obj: &core_v1.Secret{
 				ObjectMeta: meta_v1.ObjectMeta{
@@ -1168,6 +1176,24 @@ func TestKubernetesCacheInsert(t *testing.T) {
 			want: true,
 		},
 
+		"insert gateway-api XBackendTrafficPolicy": {
+			obj: &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "backendtrafficpolicy",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_x_v1alpha1.BackendTrafficPolicySpec{
+					TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReference{
+						{
+							Kind: "Service",
+							Name: "service",
+						},
+					},
+				},
+			},
+			want: true,
+		},
+
 		// SPECIFIC GATEWAY TESTS
 		"specific gateway configured, insert gatewayclass, no gateway cached": {
 			cacheGateway: &types.NamespacedName{
@@ -1820,6 +1846,21 @@ func TestKubernetesCacheRemove(t *testing.T) {
 			},
 			want: true,
 		},
+		"remove gateway-api XBackendTrafficPolicy": {
+			cache: cache(&gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "backendtrafficpolicy",
+					Namespace: "default",
+				},
+			}),
+			obj: &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "backendtrafficpolicy",
+					Namespace: "default",
+				},
+			},
+			want: true,
+		},
 		"remove secret that is referenced by gateway-api BackendTLSPolicy": {
 			cache: cache(
 				&gatewayapi_v1alpha3.BackendTLSPolicy{
@@ -1906,6 +1947,15 @@ func TestKubernetesCacheRemove(t *testing.T) {
 			},
 			want: true,
 		},
+		"remove backend": {
+			cache: cache(&contour_v1alpha1.Backend{
+				ObjectMeta: fixture.ObjectMeta("default/backend"),
+			}),
+			obj: &contour_v1alpha1.Backend{
+				ObjectMeta: fixture.ObjectMeta("default/backend"),
+			},
+			want: true,
+		},
 		"remove unknown": {
 			cache: cache("not an object"),
 			obj:   "not an object",
@@ -2728,6 +2778,107 @@ func TestSecretTriggersRebuild(t *testing.T) {
 			secret: secret("default", "crl"),
 			want:   true,
 		},
+		"HTTPProxy with OAuth2 client secret triggers rebuild": {
+			cache: cache(
+				&contour_v1.HTTPProxy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "proxy",
+						Namespace: "user",
+					},
+					Spec: contour_v1.HTTPProxySpec{
+						VirtualHost: &contour_v1.VirtualHost{
+							Fqdn: "test.projectcontour.io",
+							OAuth2: &contour_v1.OAuth2{
+								ClientSecretRef: "oauth2",
+							},
+						},
+					},
+				},
+			),
+			secret: secret("user", "oauth2"),
+			want:   true,
+		},
+		"HTTPProxy with basic auth secret triggers rebuild": {
+			cache: cache(
+				&contour_v1.HTTPProxy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "proxy",
+						Namespace: "user",
+					},
+					Spec: contour_v1.HTTPProxySpec{
+						VirtualHost: &contour_v1.VirtualHost{
+							Fqdn: "test.projectcontour.io",
+							BasicAuth: &contour_v1.BasicAuth{
+								SecretName: "htpasswd",
+							},
+						},
+					},
+				},
+			),
+			secret: secret("user", "htpasswd"),
+			want:   true,
+		},
+		"included HTTPProxy with route basic auth secret triggers rebuild": {
+			cache: cache(
+				&contour_v1.HTTPProxy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "child",
+						Namespace: "user",
+					},
+					Spec: contour_v1.HTTPProxySpec{
+						Routes: []contour_v1.Route{{
+							BasicAuthPolicy: &contour_v1.BasicAuthPolicy{
+								SecretName: "admins",
+							},
+						}},
+					},
+				},
+			),
+			secret: secret("user", "admins"),
+			want:   true,
+		},
+		"HTTPProxy with service client certificate secret triggers rebuild": {
+			cache: cache(
+				&contour_v1.HTTPProxy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "child",
+						Namespace: "user",
+					},
+					Spec: contour_v1.HTTPProxySpec{
+						Routes: []contour_v1.Route{{
+							Services: []contour_v1.Service{{
+								Name:              "backend",
+								Port:              443,
+								ClientCertificate: "certs/client",
+							}},
+						}},
+					},
+				},
+			),
+			secret: secret("certs", "client"),
+			want:   true,
+		},
+		"HTTPProxy with tcpproxy service client certificate secret triggers rebuild": {
+			cache: cache(
+				&contour_v1.HTTPProxy{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "proxy",
+						Namespace: "user",
+					},
+					Spec: contour_v1.HTTPProxySpec{
+						TCPProxy: &contour_v1.TCPProxy{
+							Services: []contour_v1.Service{{
+								Name:              "backend",
+								Port:              443,
+								ClientCertificate: "client",
+							}},
+						},
+					},
+				},
+			),
+			secret: secret("user", "client"),
+			want:   true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -3157,6 +3308,113 @@ func TestLookupBackendTLSPolicyByTargetRef(t *testing.T) {
 	}
 }
 
+func TestLookupBackendTrafficPolicyByTargetRef(t *testing.T) {
+	serviceTargetRef := func(name string) gatewayapi_v1alpha2.LocalPolicyTargetReference {
+		return gatewayapi_v1alpha2.LocalPolicyTargetReference{
+			Group: "",
+			Kind:  "Service",
+			Name:  gatewayapi_v1.ObjectName(name),
+		}
+	}
+
+	backendTrafficPolicy := func(name, namespace string, created time.Time, targetRefs ...gatewayapi_v1alpha2.LocalPolicyTargetReference) *gatewayapi_x_v1alpha1.XBackendTrafficPolicy {
+		return &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: meta_v1.NewTime(created),
+			},
+			Spec: gatewayapi_x_v1alpha1.BackendTrafficPolicySpec{
+				TargetRefs: targetRefs,
+			},
+		}
+	}
+
+	now := time.Now().Truncate(time.Second)
+	earlier := now.Add(-time.Hour)
+
+	tests := map[string]struct {
+		targetRef              gatewayapi_v1alpha2.LocalPolicyTargetReference
+		namespace              string
+		backendTrafficPolicies []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy
+		want                   *gatewayapi_x_v1alpha1.XBackendTrafficPolicy
+		wantFound              bool
+	}{
+		"finds the XBackendTrafficPolicy with the matching targetRef": {
+			targetRef: serviceTargetRef("backend-service"),
+			namespace: "ns1",
+			backendTrafficPolicies: []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				backendTrafficPolicy("blp", "ns1", now, serviceTargetRef("backend-service")),
+				backendTrafficPolicy("blp1", "ns1", now, serviceTargetRef("other-service")),
+			},
+			want:      backendTrafficPolicy("blp", "ns1", now, serviceTargetRef("backend-service")),
+			wantFound: true,
+		},
+		"finds the oldest XBackendTrafficPolicy when more than one matches": {
+			targetRef: serviceTargetRef("backend-service"),
+			namespace: "ns1",
+			backendTrafficPolicies: []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				backendTrafficPolicy("blp", "ns1", now, serviceTargetRef("backend-service")),
+				backendTrafficPolicy("blp1", "ns1", earlier, serviceTargetRef("other-service"), serviceTargetRef("backend-service")),
+			},
+			want:      backendTrafficPolicy("blp1", "ns1", earlier, serviceTargetRef("other-service"), serviceTargetRef("backend-service")),
+			wantFound: true,
+		},
+		"finds the XBackendTrafficPolicy first by name when creation timestamps match": {
+			targetRef: serviceTargetRef("backend-service"),
+			namespace: "ns1",
+			backendTrafficPolicies: []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				backendTrafficPolicy("blp-b", "ns1", now, serviceTargetRef("backend-service")),
+				backendTrafficPolicy("blp-a", "ns1", now, serviceTargetRef("backend-service")),
+			},
+			want:      backendTrafficPolicy("blp-a", "ns1", now, serviceTargetRef("backend-service")),
+			wantFound: true,
+		},
+		"does not find the XBackendTrafficPolicy if the namespace does not match": {
+			targetRef: serviceTargetRef("backend-service"),
+			namespace: "ns1",
+			backendTrafficPolicies: []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				backendTrafficPolicy("blp", "other-ns", now, serviceTargetRef("backend-service")),
+			},
+			wantFound: false,
+		},
+		"does not find the XBackendTrafficPolicy if the GroupKind does not match": {
+			targetRef: gatewayapi_v1alpha2.LocalPolicyTargetReference{
+				Group: "example.api",
+				Kind:  "ExampleService",
+				Name:  "backend-service",
+			},
+			namespace: "ns1",
+			backendTrafficPolicies: []*gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+				backendTrafficPolicy("blp", "ns1", now, serviceTargetRef("backend-service")),
+			},
+			wantFound: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := KubernetesCache{
+				FieldLogger: fixture.NewTestLogger(t),
+			}
+
+			for _, backendTrafficPolicy := range tc.backendTrafficPolicies {
+				cache.Insert(backendTrafficPolicy)
+			}
+
+			gotBLP, gotFound := cache.LookupBackendTrafficPolicyByTargetRef(tc.targetRef, tc.namespace)
+
+			if tc.wantFound {
+				assert.True(t, gotFound)
+				assert.Equal(t, tc.want, gotBLP)
+			} else {
+				assert.False(t, gotFound)
+				assert.Nil(t, gotBLP)
+			}
+		})
+	}
+}
+
 func TestLookupCAConfigMap(t *testing.T) {
 	cache := func(objs ...any) *KubernetesCache {
 		cache := KubernetesCache{
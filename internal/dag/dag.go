@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -68,6 +69,49 @@ type DAG struct {
 	// and Listeners are derived from the Gateway's Listeners, or
 	// false otherwise.
 	HasDynamicListeners bool
+
+	// sources records the Kubernetes objects each vertex was
+	// generated from. It does not affect the Envoy configuration.
+	sources map[any][]Source
+}
+
+// Source identifies the Kubernetes object, and the field within it,
+// that a DAG vertex was generated from.
+type Source struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	// FieldPath is the path of the field of the object that
+	// generated the vertex, for example "spec.routes[1]", if any.
+	FieldPath string
+}
+
+func (s Source) String() string {
+	name := s.Kind + "/" + s.Name
+	if s.Namespace != "" {
+		name = s.Kind + "/" + s.Namespace + "/" + s.Name
+	}
+	if s.FieldPath != "" {
+		return name + " " + s.FieldPath
+	}
+	return name
+}
+
+// addSource records that vertex was generated from src.
+func (d *DAG) addSource(vertex any, src Source) {
+	if d.sources == nil {
+		d.sources = map[any][]Source{}
+	}
+	if !slices.Contains(d.sources[vertex], src) {
+		d.sources[vertex] = append(d.sources[vertex], src)
+	}
+}
+
+// Sources returns the Kubernetes objects vertex was generated from,
+// in the order they were recorded.
+func (d *DAG) Sources(vertex any) []Source {
+	return d.sources[vertex]
 }
 
 type MatchCondition interface {
@@ -295,6 +339,11 @@ type Route struct {
 
 	Clusters []*Cluster
 
+	// AggregateCluster, if set, fails over between the Clusters
+	// of this route in priority order instead of balancing traffic
+	// between them by weight.
+	AggregateCluster *AggregateCluster
+
 	// Should this route generate a 301 upgrade if accessed
 	// over HTTP?
 	HTTPSUpgrade bool
@@ -307,16 +356,40 @@ type Route struct {
 	// AuthContext sets the authorization context (if authorization is enabled).
 	AuthContext map[string]string
 
+	// ExternalProcessingDisabled is set if external processing
+	// should be disabled for this route.
+	ExternalProcessingDisabled bool
+
+	// ExternalProcessingMode overrides the external processing
+	// mode of the virtual host for this route.
+	ExternalProcessingMode *ProcessingMode
+
 	// Is this a websocket route?
+	// BasicAuthDisabled is set if basic authentication
+	// should be disabled for this route.
+	BasicAuthDisabled bool
+
+	// BasicAuthSecret overrides the htpasswd users of the
+	// virtual host for this route.
+	BasicAuthSecret *Secret
+
 	// TODO(dfc) this should go on the service
 	Websocket bool
 
+	// CompressionDisabled is set if response compression
+	// should be disabled for this route.
+	CompressionDisabled bool
+
 	// TimeoutPolicy defines the timeout request/idle
 	TimeoutPolicy RouteTimeoutPolicy
 
 	// RetryPolicy defines the retry / number / timeout options for a route
 	RetryPolicy *RetryPolicy
 
+	// FaultInjectionPolicy defines the faults injected into
+	// requests for a route.
+	FaultInjectionPolicy *FaultInjectionPolicy
+
 	// Indicates that during forwarding, the matched prefix (or path) should be swapped with this value
 	PathRewritePolicy *PathRewritePolicy
 
@@ -343,6 +416,14 @@ type Route struct {
 	// request attributes.
 	RequestHashPolicies []RequestHashPolicy
 
+	// SessionPersistence defines how requests are pinned to
+	// the same upstream endpoint.
+	SessionPersistence *SessionPersistence
+
+	// CORSPolicy is the cross-origin policy to apply to the route.
+	// When set, it overrides any policy set on the virtual host.
+	CORSPolicy *CORSPolicy
+
 	// DirectResponse allows for a specific HTTP status code
 	// to be the response to a route request vs routing to
 	// an envoy cluster.
@@ -414,6 +495,35 @@ type ClusterTimeoutPolicy struct {
 	ConnectTimeout time.Duration
 }
 
+// FaultInjectionPolicy defines the delays and aborts injected
+// into requests for a route.
+type FaultInjectionPolicy struct {
+	// Delay, if set, delays requests before they are forwarded.
+	Delay *FaultDelay
+
+	// Abort, if set, responds to requests with an error
+	// instead of forwarding them.
+	Abort *FaultAbort
+
+	// HeaderMatchConditions restrict faults to requests
+	// that match all of the conditions.
+	HeaderMatchConditions []HeaderMatchCondition
+}
+
+// FaultDelay defines a fixed delay injected into a
+// percentage of requests.
+type FaultDelay struct {
+	Duration   time.Duration
+	Percentage uint32
+}
+
+// FaultAbort defines an HTTP status code returned to a
+// percentage of requests.
+type FaultAbort struct {
+	StatusCode uint32
+	Percentage uint32
+}
+
 // RetryPolicy defines the retry / number / timeout options
 type RetryPolicy struct {
 	// RetryOn specifies the conditions under which retry takes place.
@@ -430,6 +540,10 @@ type RetryPolicy struct {
 	// PerTryTimeout specifies the timeout per retry attempt.
 	// Ignored if RetryOn is blank.
 	PerTryTimeout timeout.Setting
+
+	// BackOffBaseInterval specifies the base interval between
+	// retry attempts. If zero, the Envoy default is used.
+	BackOffBaseInterval time.Duration
 }
 
 // PathRewritePolicy defines a policy for rewriting the path of
@@ -450,7 +564,15 @@ type PathRewritePolicy struct {
 // MirrorPolicy defines the mirroring policy for a route.
 type MirrorPolicy struct {
 	Cluster *Cluster
-	Weight  int64
+
+	// Weight is the numerator of the fraction of
+	// requests that are mirrored to Cluster.
+	Weight int64
+
+	// Denominator is the denominator of the fraction of
+	// requests that are mirrored to Cluster. If unset,
+	// Weight is treated as a percentage.
+	Denominator int64
 }
 
 // HeadersPolicy defines how headers are managed during forwarding
@@ -467,6 +589,32 @@ type HeadersPolicy struct {
 	Remove []string
 }
 
+// SessionPersistenceType is the mechanism used to track a session.
+type SessionPersistenceType string
+
+const (
+	// SessionPersistenceCookie tracks the session using a cookie.
+	SessionPersistenceCookie SessionPersistenceType = "Cookie"
+
+	// SessionPersistenceHeader tracks the session using a header.
+	SessionPersistenceHeader SessionPersistenceType = "Header"
+)
+
+// SessionPersistence defines how requests are pinned to
+// the same upstream endpoint.
+type SessionPersistence struct {
+	// Type is the mechanism used to track the session.
+	Type SessionPersistenceType
+
+	// Name is the name of the cookie or header that
+	// tracks the session.
+	Name string
+
+	// CookieTTL is the lifetime of the session cookie.
+	// A zero value results in a session cookie.
+	CookieTTL time.Duration
+}
+
 // CookieRewritePolicy defines how attributes of an HTTP Set-Cookie header
 // can be rewritten.
 type CookieRewritePolicy struct {
@@ -812,8 +960,21 @@ type SecureVirtualHost struct {
 	// the ExtAuthz filter.
 	ExternalAuthorization *ExternalAuthorization
 
+	// ExternalProcessing contains the configuration for enabling
+	// the ExtProc filter.
+	ExternalProcessing *ExternalProcessing
+
 	// JWTProviders specify how to verify JWTs.
 	JWTProviders []JWTProvider
+
+	// OAuth2 contains the configuration for enabling
+	// the OAuth2 filter.
+	OAuth2 *OAuth2
+
+	// BasicAuthSecret is the Secret holding the htpasswd
+	// users for basic authentication. If nil, basic
+	// authentication is not enabled.
+	BasicAuthSecret *Secret
 }
 
 type JWTProvider struct {
@@ -831,6 +992,51 @@ type RemoteJWKS struct {
 	CacheDuration *time.Duration
 }
 
+// OAuth2 holds the configuration of an OAuth2 authorization
+// code flow for a virtual host.
+type OAuth2 struct {
+	// TokenEndpoint is the URI of the token endpoint.
+	TokenEndpoint string
+
+	// TokenEndpointTimeout is how long to wait for a
+	// response from the token endpoint.
+	TokenEndpointTimeout time.Duration
+
+	// TokenEndpointCluster is the cluster that routes to
+	// the token endpoint.
+	TokenEndpointCluster DNSNameCluster
+
+	// AuthorizationEndpoint is the URI users are
+	// redirected to in order to log in.
+	AuthorizationEndpoint string
+
+	// ClientID is the OAuth2 client identifier.
+	ClientID string
+
+	// ClientSecret is the Secret holding the client
+	// secret and the HMAC secret.
+	ClientSecret *Secret
+
+	// RedirectPath is the path of the callback that
+	// receives the authorization code.
+	RedirectPath string
+
+	// SignoutPath is the path that clears the session.
+	SignoutPath string
+
+	// Scopes are the scopes requested from the
+	// authorization server.
+	Scopes []string
+
+	// ForwardBearerToken forwards the access token
+	// to the upstream service.
+	ForwardBearerToken bool
+
+	// PassThroughMatchers are header conditions that
+	// bypass the OAuth2 flow.
+	PassThroughMatchers []HeaderMatchCondition
+}
+
 // DNSNameCluster is a cluster that routes directly to a DNS
 // name (i.e. not a Kubernetes service).
 type DNSNameCluster struct {
@@ -842,6 +1048,13 @@ type DNSNameCluster struct {
 	UpstreamTLS        *UpstreamTLS
 }
 
+// AggregateCluster is a cluster that sends traffic to the first
+// of its Clusters that has healthy endpoints.
+type AggregateCluster struct {
+	// Clusters are in priority order, highest priority first.
+	Clusters []*Cluster
+}
+
 type JWTRule struct {
 	PathMatchCondition    MatchCondition
 	HeaderMatchConditions []HeaderMatchCondition
@@ -901,6 +1114,58 @@ type AuthorizationServerBufferSettings struct {
 	PackAsBytes bool
 }
 
+// ExternalProcessing contains the configuration for enabling
+// the ExtProc filter.
+type ExternalProcessing struct {
+	// ProcessingService points to the extension that client
+	// requests and upstream responses are sent to for processing.
+	ProcessingService *ExtensionCluster
+
+	// ResponseTimeout sets how long the proxy should wait for
+	// each message from the processing server.
+	ResponseTimeout timeout.Setting
+
+	// FailOpen sets whether processing server failures should
+	// be ignored rather than failing the client request.
+	FailOpen bool
+
+	// ProcessingMode sets which parts of requests and responses
+	// are sent to the processing server. If nil, the Envoy
+	// defaults are used.
+	ProcessingMode *ProcessingMode
+}
+
+// HeaderSendMode defines how headers are sent to an external
+// processing server.
+type HeaderSendMode string
+
+const (
+	HeaderSendModeDefault HeaderSendMode = "Default"
+	HeaderSendModeSend    HeaderSendMode = "Send"
+	HeaderSendModeSkip    HeaderSendMode = "Skip"
+)
+
+// BodySendMode defines how bodies are sent to an external
+// processing server.
+type BodySendMode string
+
+const (
+	BodySendModeNone            BodySendMode = "None"
+	BodySendModeStreamed        BodySendMode = "Streamed"
+	BodySendModeBuffered        BodySendMode = "Buffered"
+	BodySendModeBufferedPartial BodySendMode = "BufferedPartial"
+)
+
+// ProcessingMode sets which parts of requests and responses are
+// sent to an external processing server. Empty fields use the
+// Envoy defaults.
+type ProcessingMode struct {
+	RequestHeaderMode  HeaderSendMode
+	ResponseHeaderMode HeaderSendMode
+	RequestBodyMode    BodySendMode
+	ResponseBodyMode   BodySendMode
+}
+
 func (s *SecureVirtualHost) Valid() bool {
 	// A SecureVirtualHost is valid if either
 	// 1. it has a secret and at least one route.
@@ -915,7 +1180,7 @@ type Listener struct {
 	Name string
 
 	// Protocol is the listener protocol. Must be
-	// "http" or "https".
+	// "http", "https", "tcp" or "udp".
 	Protocol string
 
 	// Address is the TCP address to listen on.
@@ -925,6 +1190,12 @@ type Listener struct {
 	// Port is the TCP port to listen on.
 	Port int
 
+	// ServicePort is the port that clients reach the listener on
+	// through the Envoy service, if known. It differs from Port
+	// for Gateway listeners, which are mapped to unprivileged
+	// container ports.
+	ServicePort int
+
 	// RouteConfigName is the Listener name component to use when
 	// constructing RouteConfig names. If empty, the Listener
 	// name will be used.
@@ -950,6 +1221,11 @@ type Listener struct {
 	// on a given Listener.
 	TCPProxy *TCPProxy
 
+	// UDPProxy configures an L4 UDP proxy for this Listener.
+	// This can only be used on a Listener with the "udp"
+	// protocol.
+	UDPProxy *UDPProxy
+
 	// EnableWebsockets defines whether to enable the websocket
 	// upgrade.
 	EnableWebsockets bool
@@ -962,6 +1238,13 @@ type TCPProxy struct {
 	Clusters []*Cluster
 }
 
+// UDPProxy represents a cluster of UDP endpoints.
+type UDPProxy struct {
+	// Clusters is the set of upstream services
+	// to forward datagrams to.
+	Clusters []*Cluster
+}
+
 // Service represents a single Kubernetes' Service's Port.
 type Service struct {
 	Weighted WeightedService
@@ -975,6 +1258,25 @@ type Service struct {
 
 	// ExternalName is an optional field referencing a dns entry for Service type "ExternalName"
 	ExternalName string
+
+	// Endpoints are the static endpoints of a Backend. If set, they
+	// are used instead of discovering endpoints with EDS.
+	Endpoints []BackendEndpoint
+
+	// SNI is the server name to use when connecting to the
+	// endpoints of a Backend over TLS.
+	SNI string
+
+	// UpstreamValidation defines how to verify the certificates
+	// of the endpoints of a Backend.
+	UpstreamValidation *PeerValidationContext
+}
+
+// BackendEndpoint is an IP address or DNS hostname and port
+// that a Backend sends traffic to.
+type BackendEndpoint struct {
+	Address string
+	Port    uint32
 }
 
 // Cluster holds the connection specific parameters that apply to
@@ -1037,11 +1339,22 @@ type Cluster struct {
 	// private key to be used when establishing TLS connection to upstream cluster.
 	ClientCertificate *Secret
 
+	// ServiceClientCertificate is true if ClientCertificate was set by
+	// the service rather than by the global envoy-client-certificate.
+	ServiceClientCertificate bool
+
 	// TimeoutPolicy specifies how to handle timeouts for this cluster.
 	TimeoutPolicy ClusterTimeoutPolicy
 
 	SlowStartConfig *SlowStartConfig
 
+	// OutlierDetectionPolicy defines how failing endpoints are ejected from the cluster.
+	OutlierDetectionPolicy *OutlierDetectionPolicy
+
+	// SubsetSelector restricts traffic to the endpoints whose Pods
+	// have all of the given labels.
+	SubsetSelector map[string]string
+
 	// MaxRequestsPerConnection defines the maximum number of requests per connection to the upstream before it is closed.
 	MaxRequestsPerConnection *uint32
 
@@ -1052,6 +1365,20 @@ type Cluster struct {
 	UpstreamTLS *UpstreamTLS
 }
 
+// SubsetKeys returns the sorted label keys of the given subset selector.
+func SubsetKeys(selector map[string]string) []string {
+	if len(selector) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(selector))
+	for k := range selector {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 // WeightedService represents the load balancing weight of a
 // particular core_v1.Weighted port.
 type WeightedService struct {
@@ -1077,6 +1404,9 @@ type ServiceCluster struct {
 	ClusterName string
 	// Services are the load balancing targets. This slice must not be empty.
 	Services []WeightedService
+	// SubsetKeys are the Pod labels that are published as
+	// endpoint metadata for subset load balancing.
+	SubsetKeys []string
 }
 
 // DeepCopy performs a deep copy of ServiceClusters
@@ -1085,6 +1415,7 @@ func (s *ServiceCluster) DeepCopy() *ServiceCluster {
 	s2 := ServiceCluster{
 		ClusterName: s.ClusterName,
 		Services:    make([]WeightedService, len(s.Services)),
+		SubsetKeys:  slices.Clone(s.SubsetKeys),
 	}
 
 	for i, w := range s.Services {
@@ -1160,6 +1491,9 @@ type Secret struct {
 	ValidTLSSecret *SecretValidationStatus
 	ValidCASecret  *SecretValidationStatus
 	ValidCRLSecret *SecretValidationStatus
+
+	ValidOAuth2Secret    *SecretValidationStatus
+	ValidBasicAuthSecret *SecretValidationStatus
 }
 
 func (s *Secret) Name() string      { return s.Object.Name }
@@ -1245,6 +1579,9 @@ type ExtensionCluster struct {
 
 	// Circuit breaking limits
 	CircuitBreakers CircuitBreakers
+
+	// OutlierDetectionPolicy defines how failing endpoints are ejected from the cluster.
+	OutlierDetectionPolicy *OutlierDetectionPolicy
 }
 
 const singleDNSLabelWildcardRegex = "^[a-z0-9]([-a-z0-9]*[a-z0-9])?"
@@ -1277,6 +1614,32 @@ func (s *SlowStartConfig) String() string {
 	return fmt.Sprintf("%s%f%d", s.Window.String(), s.Aggression, s.MinWeightPercent)
 }
 
+// OutlierDetectionPolicy holds configuration for ejecting endpoints
+// that keep failing requests.
+type OutlierDetectionPolicy struct {
+	// ConsecutiveServerErrors is the number of consecutive 5xx
+	// responses after which an endpoint is ejected. Zero disables
+	// ejection based on server errors.
+	ConsecutiveServerErrors uint32
+
+	// ConsecutiveGatewayErrors is the number of consecutive 502,
+	// 503 and 504 responses after which an endpoint is ejected.
+	// Zero disables ejection based on gateway errors.
+	ConsecutiveGatewayErrors uint32
+
+	// BaseEjectionTime is the base time that an endpoint is ejected for.
+	BaseEjectionTime time.Duration
+
+	// MaxEjectionPercent is the maximum percentage of endpoints
+	// that can be ejected at the same time.
+	MaxEjectionPercent uint32
+}
+
+func (o *OutlierDetectionPolicy) String() string {
+	return fmt.Sprintf("consecutiveServerErrors=%d,consecutiveGatewayErrors=%d,baseEjectionTime=%s,maxEjectionPercent=%d",
+		o.ConsecutiveServerErrors, o.ConsecutiveGatewayErrors, o.BaseEjectionTime.String(), o.MaxEjectionPercent)
+}
+
 // UpstreamTLS holds the TLS configuration for upstream connections
 type UpstreamTLS struct {
 	MinimumProtocolVersion string
@@ -145,6 +145,13 @@ func (p *ExtensionServiceProcessor) buildExtensionService(
 		}
 	}
 
+	odp, err := outlierDetectionPolicy(ext.Spec.OutlierDetectionPolicy)
+	if err != nil {
+		validCondition.AddErrorf(contour_v1.ConditionTypeSpecError, "OutlierDetectionPolicyNotValid",
+			"spec.outlierDetectionPolicy is invalid: %s", err)
+	}
+	extension.OutlierDetectionPolicy = odp
+
 	lbPolicy := loadBalancerPolicy(ext.Spec.LoadBalancerPolicy)
 	switch lbPolicy {
 	case LoadBalancerPolicyCookie, LoadBalancerPolicyRequestHash:
@@ -16,7 +16,9 @@ package dag
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -27,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -44,6 +47,7 @@ const (
 	KindTLSRoute  = "TLSRoute"
 	KindGRPCRoute = "GRPCRoute"
 	KindTCPRoute  = "TCPRoute"
+	KindUDPRoute  = "UDPRoute"
 	KindGateway   = "Gateway"
 )
 
@@ -174,6 +178,11 @@ func (p *GatewayAPIProcessor) Run(dag *DAG, source *KubernetesCache) {
 		p.processRoute(KindTCPRoute, tcpRoute, tcpRoute.Spec.ParentRefs, gatewayNotProgrammedCondition, listenerInfos, listenerAttachedRoutes, &gatewayapi_v1alpha2.TCPRoute{})
 	}
 
+	// Process UDPRoutes.
+	for _, udpRoute := range p.source.udproutes {
+		p.processRoute(KindUDPRoute, udpRoute, udpRoute.Spec.ParentRefs, gatewayNotProgrammedCondition, listenerInfos, listenerAttachedRoutes, &gatewayapi_v1alpha2.UDPRoute{})
+	}
+
 	for listenerName, attachedRoutes := range listenerAttachedRoutes {
 		gwAccessor.SetListenerAttachedRoutes(listenerName, attachedRoutes)
 	}
@@ -239,8 +248,8 @@ func (p *GatewayAPIProcessor) processRoute(
 			var hosts sets.Set[string]
 			var errs []error
 
-			// TCPRoutes don't have hostnames.
-			if routeKind != KindTCPRoute {
+			// TCPRoutes and UDPRoutes don't have hostnames.
+			if routeKind != KindTCPRoute && routeKind != KindUDPRoute {
 				var routeHostnames []gatewayapi_v1.Hostname
 
 				switch route := route.(type) {
@@ -277,12 +286,14 @@ func (p *GatewayAPIProcessor) processRoute(
 				p.computeGRPCRouteForListener(route, routeParentStatus, listener, hosts)
 			case *gatewayapi_v1alpha2.TCPRoute:
 				p.computeTCPRouteForListener(route, routeParentStatus, listener)
+			case *gatewayapi_v1alpha2.UDPRoute:
+				p.computeUDPRouteForListener(route, routeParentStatus, listener)
 			}
 
 			hostCount += hosts.Len()
 		}
 
-		if routeKind != KindTCPRoute && hostCount == 0 && !routeParentStatus.ConditionExists(gatewayapi_v1.RouteConditionAccepted) {
+		if routeKind != KindTCPRoute && routeKind != KindUDPRoute && hostCount == 0 && !routeParentStatus.ConditionExists(gatewayapi_v1.RouteConditionAccepted) {
 			routeParentStatus.AddCondition(
 				gatewayapi_v1.RouteConditionAccepted,
 				meta_v1.ConditionFalse,
@@ -412,7 +423,7 @@ func (l *listenerInfo) AllowsKind(kind gatewayapi_v1.Kind) bool {
 
 // isAddressAssigned returns true if either there are no addresses requested in specAddresses,
 // or if at least one address from specAddresses appears in statusAddresses.
-func isAddressAssigned(specAddresses []gatewayapi_v1.GatewayAddress, statusAddresses []gatewayapi_v1.GatewayStatusAddress) bool {
+func isAddressAssigned(specAddresses []gatewayapi_v1.GatewaySpecAddress, statusAddresses []gatewayapi_v1.GatewayStatusAddress) bool {
 	if len(specAddresses) == 0 {
 		return true
 	}
@@ -641,6 +652,8 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1.Liste
 			return []gatewayapi_v1.Kind{KindTLSRoute, KindTCPRoute}
 		case gatewayapi_v1.TCPProtocolType:
 			return []gatewayapi_v1.Kind{KindTCPRoute}
+		case gatewayapi_v1.UDPProtocolType:
+			return []gatewayapi_v1.Kind{KindUDPRoute}
 		}
 	}
 
@@ -657,13 +670,13 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1.Liste
 			)
 			continue
 		}
-		if routeKind.Kind != KindHTTPRoute && routeKind.Kind != KindTLSRoute && routeKind.Kind != KindGRPCRoute && routeKind.Kind != KindTCPRoute {
+		if routeKind.Kind != KindHTTPRoute && routeKind.Kind != KindTLSRoute && routeKind.Kind != KindGRPCRoute && routeKind.Kind != KindTCPRoute && routeKind.Kind != KindUDPRoute {
 			gwAccessor.AddListenerCondition(
 				string(listener.Name),
 				gatewayapi_v1.ListenerConditionResolvedRefs,
 				meta_v1.ConditionFalse,
 				gatewayapi_v1.ListenerReasonInvalidRouteKinds,
-				fmt.Sprintf("Kind %q is not supported, kind must be %q, %q, %q or %q", routeKind.Kind, KindHTTPRoute, KindTLSRoute, KindGRPCRoute, KindTCPRoute),
+				fmt.Sprintf("Kind %q is not supported, kind must be %q, %q, %q, %q or %q", routeKind.Kind, KindHTTPRoute, KindTLSRoute, KindGRPCRoute, KindTCPRoute, KindUDPRoute),
 			)
 			continue
 		}
@@ -687,6 +700,16 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1.Liste
 			)
 			continue
 		}
+		if (routeKind.Kind == KindUDPRoute) != (listener.Protocol == gatewayapi_v1.UDPProtocolType) {
+			gwAccessor.AddListenerCondition(
+				string(listener.Name),
+				gatewayapi_v1.ListenerConditionResolvedRefs,
+				meta_v1.ConditionFalse,
+				gatewayapi_v1.ListenerReasonInvalidRouteKinds,
+				fmt.Sprintf("%ss are incompatible with listener protocol %q", routeKind.Kind, listener.Protocol),
+			)
+			continue
+		}
 
 		routeKinds = append(routeKinds, routeKind.Kind)
 	}
@@ -1014,7 +1037,7 @@ func (p *GatewayAPIProcessor) computeGatewayConditions(gwAccessor *status.Gatewa
 
 func (p *GatewayAPIProcessor) computeTLSRouteForListener(route *gatewayapi_v1alpha2.TLSRoute, routeAccessor *status.RouteParentStatusUpdate, listener *listenerInfo, hosts sets.Set[string]) bool {
 	var programmed bool
-	for _, rule := range route.Spec.Rules {
+	for ruleIndex, rule := range route.Spec.Rules {
 		if len(rule.BackendRefs) == 0 {
 			routeAccessor.AddCondition(gatewayapi_v1.RouteConditionResolvedRefs, meta_v1.ConditionFalse, status.ReasonDegraded, "At least one Spec.Rules.BackendRef must be specified.")
 			continue
@@ -1045,7 +1068,7 @@ func (p *GatewayAPIProcessor) computeTLSRouteForListener(route *gatewayapi_v1alp
 			service.Weighted.Weight = routeWeight
 			proxy.Clusters = append(proxy.Clusters, &Cluster{
 				Upstream:                      service,
-				SNI:                           service.ExternalName,
+				SNI:                           determineSNI(nil, nil, service),
 				Weight:                        routeWeight,
 				TimeoutPolicy:                 ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
 				MaxRequestsPerConnection:      p.MaxRequestsPerConnection,
@@ -1066,6 +1089,10 @@ func (p *GatewayAPIProcessor) computeTLSRouteForListener(route *gatewayapi_v1alp
 			continue
 		}
 
+		for _, c := range proxy.Clusters {
+			p.dag.addSource(c, routeSource(KindTLSRoute, route, ruleIndex))
+		}
+
 		for host := range hosts {
 			secure := p.dag.EnsureSecureVirtualHost(listener.dagListenerName, host)
 
@@ -1074,6 +1101,7 @@ func (p *GatewayAPIProcessor) computeTLSRouteForListener(route *gatewayapi_v1alp
 			}
 
 			secure.TCPProxy = &proxy
+			p.addVirtualHostSources(secure, listener, routeSource(KindTLSRoute, route, -1))
 
 			programmed = true
 		}
@@ -1104,8 +1132,6 @@ func (p *GatewayAPIProcessor) resolveRouteRefs(route any, routeAccessor *status.
 					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionType(cond.Type), cond.Status, gatewayapi_v1.RouteConditionReason(cond.Reason), cond.Message)
 				}
 
-				// RequestMirror filter is not supported so we don't check it here
-
 				// TODO: validate filter extension refs if they become relevant
 			}
 		}
@@ -1118,6 +1144,15 @@ func (p *GatewayAPIProcessor) resolveRouteRefs(route any, routeAccessor *status.
 				}
 			}
 		}
+	case *gatewayapi_v1alpha2.UDPRoute:
+		for _, r := range route.Spec.Rules {
+			for _, b := range r.BackendRefs {
+				_, cond := p.validateBackendRef(b, KindUDPRoute, route.Namespace)
+				if cond != nil {
+					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionType(cond.Type), cond.Status, gatewayapi_v1.RouteConditionReason(cond.Reason), cond.Message)
+				}
+			}
+		}
 	case *gatewayapi_v1.GRPCRoute:
 		for _, r := range route.Spec.Rules {
 			for _, f := range r.Filters {
@@ -1137,15 +1172,36 @@ func (p *GatewayAPIProcessor) resolveRouteRefs(route any, routeAccessor *status.
 					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionType(cond.Type), cond.Status, gatewayapi_v1.RouteConditionReason(cond.Reason), cond.Message)
 				}
 
-				// RequestMirror filter is not supported so we don't check it here
-
 				// TODO: validate filter extension refs if they become relevant
 			}
 		}
 	}
 }
 
-func parseHTTPRouteTimeouts(httpRouteTimeouts *gatewayapi_v1.HTTPRouteTimeouts) (*RouteTimeoutPolicy, error) {
+// requestMirrorFraction returns the numerator and denominator of the
+// fraction of requests to mirror for the given RequestMirror filter. If
+// neither Percent nor Fraction is specified, all requests are mirrored.
+func requestMirrorFraction(filter *gatewayapi_v1.HTTPRequestMirrorFilter) (int64, int64, error) {
+	switch {
+	case filter.Percent != nil && filter.Fraction != nil:
+		return 0, 0, fmt.Errorf("only one of Percent or Fraction may be specified")
+	case filter.Percent != nil:
+		if *filter.Percent < 0 || *filter.Percent > 100 {
+			return 0, 0, fmt.Errorf("invalid Percent %d: must be between 0 and 100", *filter.Percent)
+		}
+		return int64(*filter.Percent), 100, nil
+	case filter.Fraction != nil:
+		return mirrorFraction("Fraction", filter.Fraction.Numerator, filter.Fraction.Denominator)
+	default:
+		return 100, 100, nil
+	}
+}
+
+// parseHTTPRouteTimeouts returns the route timeout policy for the given
+// HTTPRoute timeouts. If retries are enabled for the rule, the backend request
+// timeout is applied to each attempt by setting the retry policy's per-try
+// timeout.
+func parseHTTPRouteTimeouts(httpRouteTimeouts *gatewayapi_v1.HTTPRouteTimeouts, retryPolicy *RetryPolicy) (*RouteTimeoutPolicy, error) {
 	if httpRouteTimeouts == nil || (httpRouteTimeouts.Request == nil && httpRouteTimeouts.BackendRequest == nil) {
 		return nil, nil
 	}
@@ -1166,10 +1222,10 @@ func parseHTTPRouteTimeouts(httpRouteTimeouts *gatewayapi_v1.HTTPRouteTimeouts)
 		responseTimeout = requestTimeout
 	}
 
-	// Note, since retries are not yet implemented in Gateway API, the backend
-	// request timeout is functionally equivalent to the request timeout for now.
-	// The API spec requires that it be less than/equal to the request timeout if
-	// both are specified. This implementation will change when retries are implemented.
+	// The backend request timeout applies to each individual attempt to reach
+	// the backend. Without retries it is functionally equivalent to the request
+	// timeout. The API spec requires that it be less than/equal to the request
+	// timeout if both are specified.
 	if httpRouteTimeouts.BackendRequest != nil {
 		backendRequestTimeout, err := timeout.Parse(string(*httpRouteTimeouts.BackendRequest))
 		if err != nil {
@@ -1187,7 +1243,11 @@ func parseHTTPRouteTimeouts(httpRouteTimeouts *gatewayapi_v1.HTTPRouteTimeouts)
 			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.Timeouts.BackendRequest must be less than/equal to HTTPRoute.Spec.Rules.Timeouts.Request when both are specified")
 		}
 
-		responseTimeout = backendRequestTimeout
+		if retryPolicy != nil {
+			retryPolicy.PerTryTimeout = backendRequestTimeout
+		} else {
+			responseTimeout = backendRequestTimeout
+		}
 	}
 
 	return &RouteTimeoutPolicy{
@@ -1195,6 +1255,172 @@ func parseHTTPRouteTimeouts(httpRouteTimeouts *gatewayapi_v1.HTTPRouteTimeouts)
 	}, nil
 }
 
+// parseHTTPRouteRetry returns the retry policy for the given HTTPRoute retry
+// configuration, or nil if retries are not enabled.
+func parseHTTPRouteRetry(httpRouteRetry *gatewayapi_v1.HTTPRouteRetry) (*RetryPolicy, error) {
+	if httpRouteRetry == nil {
+		return nil, nil
+	}
+
+	retryPolicy := &RetryPolicy{
+		// Always retry on connection level failures, in addition to any
+		// status codes that are specified.
+		RetryOn:       "reset,connect-failure,refused-stream",
+		NumRetries:    1,
+		PerTryTimeout: timeout.DefaultSetting(),
+	}
+
+	if httpRouteRetry.Attempts != nil {
+		switch {
+		case *httpRouteRetry.Attempts < 0:
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.Retry.Attempts must be greater than or equal to 0")
+		case *httpRouteRetry.Attempts == 0:
+			// Zero attempts disables retries.
+			return nil, nil
+		}
+
+		retryPolicy.NumRetries = uint32(*httpRouteRetry.Attempts)
+	}
+
+	if len(httpRouteRetry.Codes) > 0 {
+		retryPolicy.RetryOn += ",retriable-status-codes"
+		for _, code := range httpRouteRetry.Codes {
+			retryPolicy.RetriableStatusCodes = append(retryPolicy.RetriableStatusCodes, uint32(code))
+		}
+	}
+
+	if httpRouteRetry.Backoff != nil {
+		backoff, err := time.ParseDuration(string(*httpRouteRetry.Backoff))
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTPRoute.Spec.Rules.Retry.Backoff: %v", err)
+		}
+		retryPolicy.BackOffBaseInterval = backoff
+	}
+
+	return retryPolicy, nil
+}
+
+func parseHTTPRouteSessionPersistence(route *gatewayapi_v1.HTTPRoute, ruleIndex int, sessionPersistence *gatewayapi_v1.SessionPersistence) (*SessionPersistence, error) {
+	if sessionPersistence == nil {
+		return nil, nil
+	}
+
+	if sessionPersistence.IdleTimeout != nil {
+		return nil, fmt.Errorf("HTTPRoute.Spec.Rules.SessionPersistence.IdleTimeout is not supported")
+	}
+
+	sp := &SessionPersistence{
+		Type: SessionPersistenceCookie,
+	}
+
+	if sessionPersistence.Type != nil {
+		switch *sessionPersistence.Type {
+		case gatewayapi_v1.CookieBasedSessionPersistence:
+		case gatewayapi_v1.HeaderBasedSessionPersistence:
+			sp.Type = SessionPersistenceHeader
+		default:
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.SessionPersistence.Type %q is not supported", *sessionPersistence.Type)
+		}
+	}
+
+	// If no session name is specified, generate one that is
+	// unique to this rule.
+	if sessionPersistence.SessionName != nil && *sessionPersistence.SessionName != "" {
+		sp.Name = *sessionPersistence.SessionName
+	} else {
+		sp.Name = fmt.Sprintf("%s-%s-%d-session", route.Namespace, route.Name, ruleIndex)
+	}
+
+	lifetimeType := gatewayapi_v1.SessionCookieLifetimeType
+	if sessionPersistence.CookieConfig != nil && sessionPersistence.CookieConfig.LifetimeType != nil {
+		lifetimeType = *sessionPersistence.CookieConfig.LifetimeType
+	}
+
+	switch {
+	case sp.Type == SessionPersistenceHeader && sessionPersistence.CookieConfig != nil:
+		return nil, fmt.Errorf("HTTPRoute.Spec.Rules.SessionPersistence.CookieConfig cannot be specified for type %q", gatewayapi_v1.HeaderBasedSessionPersistence)
+	case lifetimeType == gatewayapi_v1.PermanentCookieLifetimeType:
+		// Per Gateway API, AbsoluteTimeout is required for
+		// permanent cookies and sets the cookie's Max-Age.
+		if sessionPersistence.AbsoluteTimeout == nil {
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.SessionPersistence.AbsoluteTimeout must be specified when CookieConfig.LifetimeType is %q", gatewayapi_v1.PermanentCookieLifetimeType)
+		}
+		ttl, err := time.ParseDuration(string(*sessionPersistence.AbsoluteTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTPRoute.Spec.Rules.SessionPersistence.AbsoluteTimeout: %v", err)
+		}
+		sp.CookieTTL = ttl
+	case sessionPersistence.AbsoluteTimeout != nil:
+		return nil, fmt.Errorf("HTTPRoute.Spec.Rules.SessionPersistence.AbsoluteTimeout is only supported for cookies with CookieConfig.LifetimeType %q", gatewayapi_v1.PermanentCookieLifetimeType)
+	}
+
+	return sp, nil
+}
+
+// parseHTTPRouteCORS returns the CORS policy for the given HTTPRoute CORS
+// filter.
+func parseHTTPRouteCORS(filter *gatewayapi_v1.HTTPCORSFilter) (*CORSPolicy, error) {
+	// When credentials are allowed, Gateway API requires wildcard
+	// methods and headers to be replaced by the values from the request.
+	// Envoy passes these values through as-is, which browsers reject
+	// for credentialed requests, so don't allow the combination.
+	if filter.AllowCredentials {
+		if slices.Contains(filter.AllowMethods, "*") {
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.Filters.CORS.AllowMethods: wildcard is not supported when AllowCredentials is true")
+		}
+		if slices.Contains(filter.AllowHeaders, "*") {
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.Filters.CORS.AllowHeaders: wildcard is not supported when AllowCredentials is true")
+		}
+		if slices.Contains(filter.ExposeHeaders, "*") {
+			return nil, fmt.Errorf("HTTPRoute.Spec.Rules.Filters.CORS.ExposeHeaders: wildcard is not supported when AllowCredentials is true")
+		}
+	}
+
+	cp := &CORSPolicy{
+		AllowCredentials: bool(filter.AllowCredentials),
+		// Per Gateway API, the default max age is 5 seconds.
+		MaxAge: timeout.DurationSetting(5 * time.Second),
+	}
+
+	for _, origin := range filter.AllowOrigins {
+		switch {
+		case origin == "*":
+			cp.AllowOrigin = append(cp.AllowOrigin, CORSAllowOriginMatch{
+				Type:  CORSAllowOriginMatchExact,
+				Value: "*",
+			})
+		case strings.Contains(string(origin), "*"):
+			// A wildcard in the host matches any number of
+			// DNS labels to its left.
+			cp.AllowOrigin = append(cp.AllowOrigin, CORSAllowOriginMatch{
+				Type:  CORSAllowOriginMatchRegex,
+				Value: strings.ReplaceAll(regexp.QuoteMeta(string(origin)), `\*`, ".*"),
+			})
+		default:
+			cp.AllowOrigin = append(cp.AllowOrigin, CORSAllowOriginMatch{
+				Type:  CORSAllowOriginMatchExact,
+				Value: string(origin),
+			})
+		}
+	}
+
+	for _, method := range filter.AllowMethods {
+		cp.AllowMethods = append(cp.AllowMethods, string(method))
+	}
+	for _, header := range filter.AllowHeaders {
+		cp.AllowHeaders = append(cp.AllowHeaders, string(header))
+	}
+	for _, header := range filter.ExposeHeaders {
+		cp.ExposeHeaders = append(cp.ExposeHeaders, string(header))
+	}
+
+	if filter.MaxAge > 0 {
+		cp.MaxAge = timeout.DurationSetting(time.Duration(filter.MaxAge) * time.Second)
+	}
+
+	return cp, nil
+}
+
 func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 	route *gatewayapi_v1.HTTPRoute,
 	routeAccessor *status.RouteParentStatusUpdate,
@@ -1252,9 +1478,24 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 			responseHeaderPolicy *HeadersPolicy
 			pathRewritePolicy    *PathRewritePolicy
 			timeoutPolicy        *RouteTimeoutPolicy
+			sessionPersistence   *SessionPersistence
+			retryPolicy          *RetryPolicy
+			corsPolicy           *CORSPolicy
 		)
 
-		timeoutPolicy, err = parseHTTPRouteTimeouts(rule.Timeouts)
+		retryPolicy, err = parseHTTPRouteRetry(rule.Retry)
+		if err != nil {
+			routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, err.Error())
+			continue
+		}
+
+		timeoutPolicy, err = parseHTTPRouteTimeouts(rule.Timeouts, retryPolicy)
+		if err != nil {
+			routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, err.Error())
+			continue
+		}
+
+		sessionPersistence, err = parseHTTPRouteSessionPersistence(route, ruleIndex, rule.SessionPersistence)
 		if err != nil {
 			routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, err.Error())
 			continue
@@ -1358,6 +1599,12 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 					continue
 				}
 
+				weight, denominator, err := requestMirrorFraction(filter.RequestMirror)
+				if err != nil {
+					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, fmt.Sprintf("HTTPRoute.Spec.Rules.Filters.RequestMirror: %s", err))
+					continue
+				}
+
 				mirrorService, cond := p.validateBackendObjectRef(filter.RequestMirror.BackendRef, "Spec.Rules.Filters.RequestMirror.BackendRef", KindHTTPRoute, route.Namespace)
 				if cond != nil {
 					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionType(cond.Type), cond.Status, gatewayapi_v1.RouteConditionReason(cond.Reason), cond.Message)
@@ -1367,7 +1614,8 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 					Cluster: &Cluster{
 						Upstream: mirrorService,
 					},
-					Weight: 100,
+					Weight:      weight,
+					Denominator: denominator,
 				})
 			case gatewayapi_v1.HTTPRouteFilterURLRewrite:
 				if filter.URLRewrite == nil || pathRewritePolicy != nil {
@@ -1411,12 +1659,22 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 					PrefixRewrite:   prefixRewrite,
 					FullPathRewrite: fullPathRewrite,
 				}
+			case gatewayapi_v1.HTTPRouteFilterCORS:
+				if filter.CORS == nil || corsPolicy != nil {
+					continue
+				}
+
+				var err error
+				corsPolicy, err = parseHTTPRouteCORS(filter.CORS)
+				if err != nil {
+					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, err.Error())
+				}
 			default:
 				routeAccessor.AddCondition(
 					gatewayapi_v1.RouteConditionAccepted,
 					meta_v1.ConditionFalse,
 					gatewayapi_v1.RouteReasonUnsupportedValue,
-					fmt.Sprintf("HTTPRoute.Spec.Rules.Filters: invalid type %q: only RequestHeaderModifier, ResponseHeaderModifier, RequestRedirect, RequestMirror and URLRewrite are supported.", filter.Type),
+					fmt.Sprintf("HTTPRoute.Spec.Rules.Filters: invalid type %q: only RequestHeaderModifier, ResponseHeaderModifier, RequestRedirect, RequestMirror, URLRewrite and CORS are supported.", filter.Type),
 				)
 			}
 		}
@@ -1465,6 +1723,14 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 			if !ok {
 				continue
 			}
+
+			// Per Gateway API, session persistence configured on the
+			// route rule takes precedence over any XBackendTrafficPolicy.
+			var requestHashPolicies []RequestHashPolicy
+			if sessionPersistence == nil {
+				requestHashPolicies = p.computeBackendTrafficPolicies(clusters, routeParentRef)
+			}
+
 			routes = p.clusterRoutes(
 				matchconditions,
 				clusters,
@@ -1477,23 +1743,34 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 				responseHeaderPolicy,
 				mirrorPolicies,
 				pathRewritePolicy,
-				timeoutPolicy)
+				timeoutPolicy,
+				sessionPersistence,
+				retryPolicy,
+				requestHashPolicies)
 		}
 
+		for _, r := range routes {
+			r.CORSPolicy = corsPolicy
+		}
+
+		p.addRouteSources(routes, routeSource(KindHTTPRoute, route, ruleIndex))
+
 		// Check all the routes whether there is conflict against previous rules.
 		if !p.hasConflictRoute(listener, hosts, routes) {
 			// Add the route if there is no conflict at the same rule level.
 			// Add each route to the relevant vhost(s)/svhosts(s).
 			for host := range hosts {
-				for _, route := range routes {
+				for _, r := range routes {
 					switch {
 					case listener.tlsSecret != nil:
 						svhost := p.dag.EnsureSecureVirtualHost(listener.dagListenerName, host)
 						svhost.Secret = listener.tlsSecret
-						svhost.AddRoute(route)
+						svhost.AddRoute(r)
+						p.addVirtualHostSources(svhost, listener, routeSource(KindHTTPRoute, route, -1))
 					default:
 						vhost := p.dag.EnsureVirtualHost(listener.dagListenerName, host)
-						vhost.AddRoute(route)
+						vhost.AddRoute(r)
+						p.addVirtualHostSources(vhost, listener, routeSource(KindHTTPRoute, route, -1))
 					}
 				}
 			}
@@ -1512,6 +1789,61 @@ func (p *GatewayAPIProcessor) computeHTTPRouteForListener(
 	}
 }
 
+// routeSource returns the Source of a vertex generated from the rule
+// of route at ruleIndex, or from route as a whole if ruleIndex is -1.
+func routeSource(kind string, route client.Object, ruleIndex int) Source {
+	src := Source{
+		Kind:      kind,
+		Namespace: route.GetNamespace(),
+		Name:      route.GetName(),
+	}
+	if ruleIndex >= 0 {
+		src.FieldPath = fmt.Sprintf("spec.rules[%d]", ruleIndex)
+	}
+	return src
+}
+
+// addRouteSources records that routes, and the clusters they
+// forward and mirror to, were generated from src.
+func (p *GatewayAPIProcessor) addRouteSources(routes []*Route, src Source) {
+	for _, r := range routes {
+		p.dag.addSource(r, src)
+		for _, c := range r.Clusters {
+			p.dag.addSource(c, src)
+		}
+		for _, mp := range r.MirrorPolicies {
+			if mp.Cluster != nil {
+				p.dag.addSource(mp.Cluster, src)
+			}
+		}
+	}
+}
+
+// addVirtualHostSources records that vhost was generated from route
+// and listener, and that the listener's certificate, if any, was
+// generated from the listener.
+func (p *GatewayAPIProcessor) addVirtualHostSources(vhost any, listener *listenerInfo, route Source) {
+	gw := p.source.gateway
+	src := Source{
+		Kind:      KindGateway,
+		Namespace: gw.Namespace,
+		Name:      gw.Name,
+	}
+	for i, l := range gw.Spec.Listeners {
+		if l.Name == listener.listener.Name {
+			src.FieldPath = fmt.Sprintf("spec.listeners[%d]", i)
+		}
+	}
+
+	p.dag.addSource(vhost, src)
+	p.dag.addSource(vhost, route)
+
+	if listener.tlsSecret != nil {
+		src.FieldPath += ".tls.certificateRefs"
+		p.dag.addSource(listener.tlsSecret, src)
+	}
+}
+
 func (p *GatewayAPIProcessor) hasConflictRoute(listener *listenerInfo, hosts sets.Set[string], routes []*Route) bool {
 	// check if there is conflict match first
 	for host := range hosts {
@@ -1603,6 +1935,12 @@ func (p *GatewayAPIProcessor) computeGRPCRouteForListener(route *gatewayapi_v1.G
 					continue
 				}
 
+				weight, denominator, err := requestMirrorFraction(filter.RequestMirror)
+				if err != nil {
+					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1.RouteReasonUnsupportedValue, fmt.Sprintf("GRPCRoute.Spec.Rules.Filters.RequestMirror: %s", err))
+					continue
+				}
+
 				mirrorService, cond := p.validateBackendObjectRef(filter.RequestMirror.BackendRef, "Spec.Rules.Filters.RequestMirror.BackendRef", KindGRPCRoute, route.Namespace)
 				if cond != nil {
 					routeAccessor.AddCondition(gatewayapi_v1.RouteConditionType(cond.Type), cond.Status, gatewayapi_v1.RouteConditionReason(cond.Reason), cond.Message)
@@ -1614,7 +1952,8 @@ func (p *GatewayAPIProcessor) computeGRPCRouteForListener(route *gatewayapi_v1.G
 					Cluster: &Cluster{
 						Upstream: mirrorService,
 					},
-					Weight: 100,
+					Weight:      weight,
+					Denominator: denominator,
 				})
 			default:
 				routeAccessor.AddCondition(
@@ -1656,22 +1995,29 @@ func (p *GatewayAPIProcessor) computeGRPCRouteForListener(route *gatewayapi_v1.G
 			mirrorPolicies,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
 		)
 
+		p.addRouteSources(routes, routeSource(KindGRPCRoute, route, ruleIndex))
+
 		// Check all the routes whether there is conflict against previous rules.
 		if !p.hasConflictRoute(listener, hosts, routes) {
 			// Add the route if there is no conflict at the same rule level.
 			// Add each route to the relevant vhost(s)/svhosts(s).
 			for host := range hosts {
-				for _, route := range routes {
+				for _, r := range routes {
 					switch {
 					case listener.tlsSecret != nil:
 						svhost := p.dag.EnsureSecureVirtualHost(listener.dagListenerName, host)
 						svhost.Secret = listener.tlsSecret
-						svhost.AddRoute(route)
+						svhost.AddRoute(r)
+						p.addVirtualHostSources(svhost, listener, routeSource(KindGRPCRoute, route, -1))
 					default:
 						vhost := p.dag.EnsureVirtualHost(listener.dagListenerName, host)
-						vhost.AddRoute(route)
+						vhost.AddRoute(r)
+						p.addVirtualHostSources(vhost, listener, routeSource(KindGRPCRoute, route, -1))
 					}
 				}
 			}
@@ -1727,10 +2073,10 @@ func gatewayGRPCHeaderMatchConditions(matches []gatewayapi_v1.GRPCHeaderMatch) (
 	for _, match := range matches {
 		// "Exact" and "RegularExpression" are the only supported match types. If match type is not specified, use "Exact" as default.
 		var matchType string
-		switch ptr.Deref(match.Type, gatewayapi_v1.HeaderMatchExact) {
-		case gatewayapi_v1.HeaderMatchExact:
+		switch ptr.Deref(match.Type, gatewayapi_v1.GRPCHeaderMatchExact) {
+		case gatewayapi_v1.GRPCHeaderMatchExact:
 			matchType = HeaderMatchTypeExact
-		case gatewayapi_v1.HeaderMatchRegularExpression:
+		case gatewayapi_v1.GRPCHeaderMatchRegularExpression:
 			if err := ValidateRegex(match.Value); err != nil {
 				return nil, fmt.Errorf("GRPCRoute.Spec.Rules.Matches.Headers: Invalid value for RegularExpression match type is specified")
 			}
@@ -1810,7 +2156,7 @@ func (p *GatewayAPIProcessor) computeTCPRouteForListener(route *gatewayapi_v1alp
 		service.Weighted.Weight = routeWeight
 		proxy.Clusters = append(proxy.Clusters, &Cluster{
 			Upstream:                      service,
-			SNI:                           service.ExternalName,
+			SNI:                           determineSNI(nil, nil, service),
 			Weight:                        routeWeight,
 			TimeoutPolicy:                 ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
 			MaxRequestsPerConnection:      p.MaxRequestsPerConnection,
@@ -1836,10 +2182,15 @@ func (p *GatewayAPIProcessor) computeTCPRouteForListener(route *gatewayapi_v1alp
 		return false
 	}
 
+	for _, c := range proxy.Clusters {
+		p.dag.addSource(c, routeSource(KindTCPRoute, route, 0))
+	}
+
 	if listener.tlsSecret != nil {
 		secure := p.dag.EnsureSecureVirtualHost(listener.dagListenerName, "*")
 		secure.Secret = listener.tlsSecret
 		secure.TCPProxy = &proxy
+		p.addVirtualHostSources(secure, listener, routeSource(KindTCPRoute, route, -1))
 	} else {
 		p.dag.Listeners[listener.dagListenerName].TCPProxy = &proxy
 	}
@@ -1847,6 +2198,80 @@ func (p *GatewayAPIProcessor) computeTCPRouteForListener(route *gatewayapi_v1alp
 	return true
 }
 
+func (p *GatewayAPIProcessor) computeUDPRouteForListener(route *gatewayapi_v1alpha2.UDPRoute, routeAccessor *status.RouteParentStatusUpdate, listener *listenerInfo) bool {
+	if len(route.Spec.Rules) != 1 {
+		routeAccessor.AddCondition(
+			gatewayapi_v1.RouteConditionAccepted,
+			meta_v1.ConditionFalse,
+			"InvalidRouteRules",
+			"UDPRoute must have only a single rule defined",
+		)
+
+		return false
+	}
+
+	rule := route.Spec.Rules[0]
+
+	// Envoy's UDP proxy filter routes all datagrams for a
+	// listener to a single upstream cluster, so weighted
+	// backends are not supported.
+	if len(rule.BackendRefs) != 1 {
+		routeAccessor.AddCondition(
+			gatewayapi_v1.RouteConditionResolvedRefs,
+			meta_v1.ConditionFalse,
+			status.ReasonDegraded,
+			"Exactly one Spec.Rules.BackendRef must be specified.",
+		)
+		return false
+	}
+
+	backendRef := rule.BackendRefs[0]
+
+	service, cond := p.validateBackendRef(backendRef, KindUDPRoute, route.Namespace)
+	if cond != nil {
+		routeAccessor.AddCondition(
+			gatewayapi_v1.RouteConditionType(cond.Type),
+			cond.Status,
+			gatewayapi_v1.RouteConditionReason(cond.Reason),
+			cond.Message,
+		)
+		return false
+	}
+
+	if backendRef.Weight != nil && *backendRef.Weight == 0 {
+		routeAccessor.AddCondition(
+			status.ConditionValidBackendRefs,
+			meta_v1.ConditionFalse,
+			status.ReasonAllBackendRefsHaveZeroWeights,
+			"At least one Spec.Rules.BackendRef must have a non-zero weight.",
+		)
+		return false
+	}
+
+	// Only one UDPRoute can be attached to a given
+	// Listener since there's no way to differentiate
+	// between them.
+	if p.dag.Listeners[listener.dagListenerName].UDPProxy != nil {
+		routeAccessor.AddCondition(
+			gatewayapi_v1.RouteConditionAccepted,
+			meta_v1.ConditionFalse,
+			gatewayapi_v1.RouteReasonNotAllowedByListeners,
+			"Listener already has a UDPRoute attached.",
+		)
+		return false
+	}
+
+	p.dag.Listeners[listener.dagListenerName].UDPProxy = &UDPProxy{
+		Clusters: []*Cluster{{
+			Upstream:      service,
+			Weight:        1,
+			TimeoutPolicy: ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
+		}},
+	}
+
+	return true
+}
+
 // validateBackendRef verifies that the specified BackendRef is valid.
 // Returns a meta_v1.Condition for the route if any errors are detected.
 func (p *GatewayAPIProcessor) validateBackendRef(backendRef gatewayapi_v1.BackendRef, routeKind, routeNamespace string) (*Service, *meta_v1.Condition) {
@@ -1872,12 +2297,18 @@ func (p *GatewayAPIProcessor) validateBackendObjectRef(
 	routeKind string,
 	routeNamespace string,
 ) (*Service, *meta_v1.Condition) {
-	if !(backendObjectRef.Group == nil || *backendObjectRef.Group == "") {
-		return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonInvalidKind, fmt.Sprintf("%s.Group must be \"\"", field)))
-	}
+	// Besides Services, routes can forward to Contour Backends.
+	isBackend := ptr.Deref(backendObjectRef.Group, "") == gatewayapi_v1.Group(contour_v1alpha1.GroupVersion.Group) &&
+		ptr.Deref(backendObjectRef.Kind, "") == "Backend"
 
-	if !(backendObjectRef.Kind != nil && *backendObjectRef.Kind == "Service") {
-		return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonInvalidKind, fmt.Sprintf("%s.Kind must be 'Service'", field)))
+	if !isBackend {
+		if !(backendObjectRef.Group == nil || *backendObjectRef.Group == "") {
+			return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonInvalidKind, fmt.Sprintf("%s.Group must be \"\"", field)))
+		}
+
+		if !(backendObjectRef.Kind != nil && *backendObjectRef.Kind == "Service") {
+			return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonInvalidKind, fmt.Sprintf("%s.Kind must be 'Service'", field)))
+		}
 	}
 
 	if backendObjectRef.Name == "" {
@@ -1898,8 +2329,8 @@ func (p *GatewayAPIProcessor) validateBackendObjectRef(
 				namespace: routeNamespace,
 			},
 			crossNamespaceTo{
-				group:     "",
-				kind:      "Service",
+				group:     string(ptr.Deref(backendObjectRef.Group, "")),
+				kind:      string(ptr.Deref(backendObjectRef.Kind, "Service")),
 				namespace: string(*backendObjectRef.Namespace),
 				name:      string(backendObjectRef.Name),
 			},
@@ -1915,6 +2346,31 @@ func (p *GatewayAPIProcessor) validateBackendObjectRef(
 		meta = types.NamespacedName{Name: string(backendObjectRef.Name), Namespace: routeNamespace}
 	}
 
+	if isBackend {
+		// Backend endpoints are always TCP.
+		if routeKind == KindUDPRoute {
+			return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonInvalidKind, fmt.Sprintf("%s.Kind must be 'Service' for UDPRoutes", field)))
+		}
+
+		service, err := p.dag.EnsureBackend(meta, int(*backendObjectRef.Port), p.source, p.EnableExternalNameService)
+		if err != nil {
+			return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonBackendNotFound, fmt.Sprintf("backend %q is invalid: %s", meta.Name, err)))
+		}
+
+		return serviceCircuitBreakerPolicy(service, p.GlobalCircuitBreakerDefaults), nil
+	}
+
+	// UDPRoutes can only forward to UDP service ports and all
+	// other routes can only forward to TCP service ports.
+	if routeKind == KindUDPRoute {
+		service, err := p.dag.EnsureUDPService(meta, int(*backendObjectRef.Port), p.source, p.EnableExternalNameService)
+		if err != nil {
+			return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonBackendNotFound, fmt.Sprintf("service %q is invalid: %s", meta.Name, err)))
+		}
+
+		return service, nil
+	}
+
 	service, err := p.dag.EnsureService(meta, int(*backendObjectRef.Port), int(*backendObjectRef.Port), p.source, p.EnableExternalNameService)
 	if err != nil {
 		return nil, ptr.To(resolvedRefsFalse(gatewayapi_v1.RouteReasonBackendNotFound, fmt.Sprintf("service %q is invalid: %s", meta.Name, err)))
@@ -2088,6 +2544,9 @@ func (p *GatewayAPIProcessor) httpClusters(routeNamespace string, backendRefs []
 		upstreamValidation, upstreamTLS := p.computeBackendTLSPolicies(routeNamespace, backendRef, service, routeParentRef)
 		if upstreamValidation != nil {
 			service.Protocol = "tls"
+		} else if service.UpstreamValidation != nil {
+			// Backends may carry their own TLS settings.
+			upstreamValidation = service.UpstreamValidation
 		}
 
 		var clusterRequestHeaderPolicy *HeadersPolicy
@@ -2148,6 +2607,7 @@ func (p *GatewayAPIProcessor) httpClusters(routeNamespace string, backendRefs []
 			Protocol:                      service.Protocol,
 			RequestHeadersPolicy:          clusterRequestHeaderPolicy,
 			ResponseHeadersPolicy:         clusterResponseHeaderPolicy,
+			SNI:                           service.SNI,
 			TimeoutPolicy:                 ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
 			MaxRequestsPerConnection:      p.MaxRequestsPerConnection,
 			PerConnectionBufferLimitBytes: p.PerConnectionBufferLimitBytes,
@@ -2271,6 +2731,131 @@ func (p *GatewayAPIProcessor) computeBackendTLSPolicies(routeNamespace string, b
 	return upstreamValidation, upstreamTLS
 }
 
+// computeBackendTrafficPolicies configures the load balancing policy of each
+// cluster that has an associated XBackendTrafficPolicy for the service being
+// referenced, and returns the request hash policies the route should use.
+//
+// XBackendTrafficPolicy session persistence is implemented using consistent
+// hashing on the session cookie or header.
+func (p *GatewayAPIProcessor) computeBackendTrafficPolicies(clusters []*Cluster, routeParentRef gatewayapi_v1.ParentReference) []RequestHashPolicy {
+	var requestHashPolicies []RequestHashPolicy
+
+	for _, cluster := range clusters {
+		service := cluster.Upstream
+
+		policyTargetRef := gatewayapi_v1alpha2.LocalPolicyTargetReference{
+			Group: "",
+			Kind:  "Service",
+			Name:  gatewayapi_v1.ObjectName(service.Weighted.ServiceName),
+		}
+
+		backendTrafficPolicy, found := p.source.LookupBackendTrafficPolicyByTargetRef(policyTargetRef, service.Weighted.ServiceNamespace)
+		if !found {
+			continue
+		}
+
+		backendTrafficPolicyAccessor, commit := p.dag.StatusCache.BackendTrafficPolicyConditionsAccessor(
+			k8s.NamespacedNameOf(backendTrafficPolicy),
+			backendTrafficPolicy.GetGeneration(),
+		)
+		backendTrafficPolicyAncestorStatus := backendTrafficPolicyAccessor.StatusUpdateFor(routeParentRef)
+
+		if backendTrafficPolicy.Spec.RetryConstraint != nil {
+			backendTrafficPolicyAncestorStatus.AddCondition(gatewayapi_v1alpha2.PolicyConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1alpha2.PolicyReasonInvalid, "XBackendTrafficPolicy.Spec.RetryConstraint is unsupported")
+			commit()
+			continue
+		}
+
+		lbPolicy, rhp, err := backendTrafficPolicySessionPersistence(backendTrafficPolicy.Spec.SessionPersistence)
+		if err != nil {
+			backendTrafficPolicyAncestorStatus.AddCondition(gatewayapi_v1alpha2.PolicyConditionAccepted, meta_v1.ConditionFalse, gatewayapi_v1alpha2.PolicyReasonInvalid, err.Error())
+			commit()
+			continue
+		}
+
+		backendTrafficPolicyAncestorStatus.AddCondition(gatewayapi_v1alpha2.PolicyConditionAccepted, meta_v1.ConditionTrue, gatewayapi_v1alpha2.PolicyReasonAccepted, "Accepted XBackendTrafficPolicy")
+		commit()
+
+		if rhp == nil {
+			continue
+		}
+
+		cluster.LoadBalancerPolicy = lbPolicy
+		if !slices.ContainsFunc(requestHashPolicies, func(existing RequestHashPolicy) bool {
+			return reflect.DeepEqual(existing, *rhp)
+		}) {
+			requestHashPolicies = append(requestHashPolicies, *rhp)
+		}
+	}
+
+	return requestHashPolicies
+}
+
+// backendTrafficPolicySessionPersistence returns the load balancer policy and
+// request hash policy for the given XBackendTrafficPolicy session persistence.
+func backendTrafficPolicySessionPersistence(sessionPersistence *gatewayapi_v1alpha2.SessionPersistence) (string, *RequestHashPolicy, error) {
+	if sessionPersistence == nil {
+		return "", nil, nil
+	}
+
+	if sessionPersistence.IdleTimeout != nil {
+		return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.IdleTimeout is unsupported")
+	}
+
+	sessionType := gatewayapi_v1.CookieBasedSessionPersistence
+	if sessionPersistence.Type != nil {
+		sessionType = *sessionPersistence.Type
+	}
+
+	switch sessionType {
+	case gatewayapi_v1.CookieBasedSessionPersistence:
+		cookieName := "X-Contour-Session-Affinity"
+		if sessionPersistence.SessionName != nil && *sessionPersistence.SessionName != "" {
+			cookieName = *sessionPersistence.SessionName
+		}
+
+		var ttl time.Duration
+		if sessionPersistence.CookieConfig != nil && sessionPersistence.CookieConfig.LifetimeType != nil &&
+			*sessionPersistence.CookieConfig.LifetimeType == gatewayapi_v1.PermanentCookieLifetimeType {
+			if sessionPersistence.AbsoluteTimeout == nil {
+				return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.AbsoluteTimeout must be specified when CookieConfig.LifetimeType is %q", gatewayapi_v1.PermanentCookieLifetimeType)
+			}
+
+			var err error
+			if ttl, err = time.ParseDuration(string(*sessionPersistence.AbsoluteTimeout)); err != nil {
+				return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.AbsoluteTimeout is invalid: %v", err)
+			}
+		} else if sessionPersistence.AbsoluteTimeout != nil {
+			return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.AbsoluteTimeout is only supported when CookieConfig.LifetimeType is %q", gatewayapi_v1.PermanentCookieLifetimeType)
+		}
+
+		return LoadBalancerPolicyCookie, &RequestHashPolicy{
+			CookieHashOptions: &CookieHashOptions{
+				CookieName: cookieName,
+				TTL:        ttl,
+				Path:       "/",
+			},
+		}, nil
+	case gatewayapi_v1.HeaderBasedSessionPersistence:
+		if sessionPersistence.SessionName == nil || *sessionPersistence.SessionName == "" {
+			return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.SessionName must be specified for type %q", gatewayapi_v1.HeaderBasedSessionPersistence)
+		}
+
+		headerName := http.CanonicalHeaderKey(*sessionPersistence.SessionName)
+		if msgs := validation.IsHTTPHeaderName(headerName); len(msgs) != 0 {
+			return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.SessionName %q is not a valid header name: %s", headerName, strings.Join(msgs, ", "))
+		}
+
+		return LoadBalancerPolicyRequestHash, &RequestHashPolicy{
+			HeaderHashOptions: &HeaderHashOptions{
+				HeaderName: headerName,
+			},
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("XBackendTrafficPolicy.Spec.SessionPersistence.Type %q is unsupported", sessionType)
+	}
+}
+
 // grpcClusters builds clusters from backendRef.
 func (p *GatewayAPIProcessor) grpcClusters(routeNamespace string, backendRefs []gatewayapi_v1.GRPCBackendRef, routeAccessor *status.RouteParentStatusUpdate, protocolType gatewayapi_v1.ProtocolType) ([]*Cluster, uint32, bool) {
 	totalWeight := uint32(0)
@@ -2350,9 +2935,11 @@ func (p *GatewayAPIProcessor) grpcClusters(routeNamespace string, backendRefs []
 			Protocol:                      service.Protocol,
 			RequestHeadersPolicy:          clusterRequestHeaderPolicy,
 			ResponseHeadersPolicy:         clusterResponseHeaderPolicy,
+			SNI:                           service.SNI,
 			TimeoutPolicy:                 ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
 			MaxRequestsPerConnection:      p.MaxRequestsPerConnection,
 			PerConnectionBufferLimitBytes: p.PerConnectionBufferLimitBytes,
+			UpstreamValidation:            service.UpstreamValidation,
 		})
 	}
 	return clusters, totalWeight, true
@@ -2372,6 +2959,9 @@ func (p *GatewayAPIProcessor) clusterRoutes(
 	mirrorPolicies []*MirrorPolicy,
 	pathRewritePolicy *PathRewritePolicy,
 	timeoutPolicy *RouteTimeoutPolicy,
+	sessionPersistence *SessionPersistence,
+	retryPolicy *RetryPolicy,
+	requestHashPolicies []RequestHashPolicy,
 ) []*Route {
 	var routes []*Route
 
@@ -2394,6 +2984,9 @@ func (p *GatewayAPIProcessor) clusterRoutes(
 			MirrorPolicies:            mirrorPolicies,
 			Priority:                  priority,
 			PathRewritePolicy:         pathRewritePolicy,
+			SessionPersistence:        sessionPersistence,
+			RetryPolicy:               retryPolicy,
+			RequestHashPolicies:       requestHashPolicies,
 		}
 		if timeoutPolicy != nil {
 			route.TimeoutPolicy = *timeoutPolicy
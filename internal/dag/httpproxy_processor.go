@@ -69,6 +69,10 @@ type HTTPProxyProcessor struct {
 	// See https://github.com/projectcontour/contour/security/advisories/GHSA-5ph6-qq5x-7jwc for details.
 	EnableExternalNameService bool
 
+	// EnableSubsetLoadBalancing allows services to select a subset
+	// of their endpoints by Pod label.
+	EnableSubsetLoadBalancing bool
+
 	// DNSLookupFamily defines how external names are looked up
 	// When configured as V4, the DNS resolver will only perform a lookup
 	// for addresses in the IPv4 family. If V6 is configured, the DNS resolver
@@ -95,6 +99,10 @@ type HTTPProxyProcessor struct {
 	// GlobalExternalAuthorization defines how requests will be authorized.
 	GlobalExternalAuthorization *contour_v1.AuthorizationServer
 
+	// GlobalExternalProcessing defines how requests and responses
+	// will be externally processed.
+	GlobalExternalProcessing *contour_v1.ExternalProcessing
+
 	// ConnectTimeout defines how long the proxy should wait when establishing connection to upstream service.
 	ConnectTimeout time.Duration
 
@@ -196,12 +204,34 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 		}
 	}
 
+	if proxy.Spec.VirtualHost.OAuth2 != nil {
+		if proxy.Spec.VirtualHost.TLS == nil || len(proxy.Spec.VirtualHost.TLS.SecretName) == 0 {
+			validCond.AddError(contour_v1.ConditionTypeOAuth2Error, "OAuth2NotPermitted",
+				"Spec.VirtualHost.OAuth2 can only be defined for root HTTPProxies that terminate TLS")
+			return
+		}
+	}
+
+	if proxy.Spec.VirtualHost.BasicAuth != nil {
+		if proxy.Spec.VirtualHost.TLS == nil || len(proxy.Spec.VirtualHost.TLS.SecretName) == 0 {
+			validCond.AddError(contour_v1.ConditionTypeBasicAuthError, "BasicAuthNotPermitted",
+				"Spec.VirtualHost.BasicAuth can only be defined for root HTTPProxies that terminate TLS")
+			return
+		}
+	}
+
 	if proxy.Spec.VirtualHost.TLS == nil && proxy.Spec.VirtualHost.Authorization != nil && len(proxy.Spec.VirtualHost.Authorization.ExtensionServiceRef.Name) > 0 {
 		validCond.AddError(contour_v1.ConditionTypeAuthError, "AuthNotPermitted",
 			"Spec.VirtualHost.Authorization.ExtensionServiceRef can only be defined for root HTTPProxies that terminate TLS")
 		return
 	}
 
+	if proxy.Spec.VirtualHost.TLS == nil && proxy.Spec.VirtualHost.ExternalProcessing != nil {
+		validCond.AddError(contour_v1.ConditionTypeExternalProcessingError, "ExternalProcessingNotPermitted",
+			"Spec.VirtualHost.ExternalProcessing can only be defined for root HTTPProxies that terminate TLS")
+		return
+	}
+
 	if len(proxy.Spec.VirtualHost.IPAllowFilterPolicy) > 0 && len(proxy.Spec.VirtualHost.IPDenyFilterPolicy) > 0 {
 		validCond.AddError(contour_v1.ConditionTypeIPFilterError, "IncompatibleIPAddressFilters",
 			"Spec.VirtualHost.IPAllowFilterPolicy and Spec.VirtualHost.IPDepnyFilterPolicy cannot both be defined.")
@@ -269,6 +299,8 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 			svhost := p.dag.EnsureSecureVirtualHost(listener.Name, host)
 			svhost.Secret = sec
 			svhost.MinTLSVersion = minTLSVer
+			p.dag.addSource(svhost, proxySource(proxy, "spec.virtualhost"))
+			p.dag.addSource(sec, proxySource(proxy, "spec.virtualhost.tls.secretName"))
 			svhost.MaxTLSVersion = maxTLSVer
 
 			// Check if FallbackCertificate && ClientValidation are both enabled in the same vhost
@@ -289,6 +321,27 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 				return
 			}
 
+			// The same applies to external processing.
+			if tls.EnableFallbackCertificate && proxy.Spec.VirtualHost.ExternalProcessing != nil {
+				validCond.AddError(contour_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures",
+					"Spec.Virtualhost.TLS fallback & external processing are incompatible")
+				return
+			}
+
+			// And to OAuth2.
+			if tls.EnableFallbackCertificate && proxy.Spec.VirtualHost.OAuth2 != nil {
+				validCond.AddError(contour_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures",
+					"Spec.Virtualhost.TLS fallback & OAuth2 are incompatible")
+				return
+			}
+
+			// And to basic authentication.
+			if tls.EnableFallbackCertificate && proxy.Spec.VirtualHost.BasicAuth != nil {
+				validCond.AddError(contour_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures",
+					"Spec.Virtualhost.TLS fallback & basic authentication are incompatible")
+				return
+			}
+
 			// If FallbackCertificate is enabled, but no cert passed, set error
 			if tls.EnableFallbackCertificate {
 				if p.FallbackCertificate == nil {
@@ -310,6 +363,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 				}
 
 				svhost.FallbackCertificate = sec
+				p.dag.addSource(sec, proxySource(proxy, "spec.virtualhost.tls.enableFallbackCertificate"))
 			}
 
 			// Fill in DownstreamValidation when external client validation is enabled.
@@ -344,6 +398,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 					dv.CACertificates = []*Secret{
 						cacert,
 					}
+					p.dag.addSource(cacert, proxySource(proxy, "spec.virtualhost.tls.clientValidation.caSecret"))
 				} else if !tls.ClientValidation.SkipClientCertValidation {
 					validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "ClientValidationInvalid",
 						"Spec.VirtualHost.TLS client validation is invalid: CA Secret must be specified")
@@ -372,6 +427,18 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 				return
 			}
 
+			if !p.computeSecureVirtualHostExternalProcessing(validCond, proxy, svhost) {
+				return
+			}
+
+			if !p.computeSecureVirtualHostOAuth2(validCond, proxy, svhost) {
+				return
+			}
+
+			if !p.computeSecureVirtualHostBasicAuth(validCond, proxy, svhost) {
+				return
+			}
+
 			providerNames := sets.NewString()
 			for _, jwtProvider := range proxy.Spec.VirtualHost.JWTProviders {
 				if providerNames.Has(jwtProvider.Name) {
@@ -528,6 +595,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 	}
 
 	insecure := p.dag.EnsureVirtualHost(listener.Name, host)
+	p.dag.addSource(insecure, proxySource(proxy, "spec.virtualhost"))
 
 	cp, err := toCORSPolicy(proxy.Spec.VirtualHost.CORSPolicy)
 	if err != nil {
@@ -547,6 +615,10 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 		p.computeVirtualHostAuthorization(p.GlobalExternalAuthorization, validCond, proxy)
 	}
 
+	if p.GlobalExternalProcessing != nil {
+		p.computeVirtualHostExternalProcessing(p.GlobalExternalProcessing, validCond, proxy)
+	}
+
 	insecure.IPFilterAllow, insecure.IPFilterRules, err = toIPFilterRules(proxy.Spec.VirtualHost.IPAllowFilterPolicy, proxy.Spec.VirtualHost.IPDenyFilterPolicy, validCond)
 	if err != nil {
 		validCond.AddErrorf(contour_v1.ConditionTypeIPFilterError, "IPFilterPolicyNotValid",
@@ -567,6 +639,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_v1.HTTPProxy) {
 
 		secure := p.dag.EnsureSecureVirtualHost(listener.Name, host)
 		secure.CORSPolicy = cp
+		p.dag.addSource(secure, proxySource(proxy, "spec.virtualhost"))
 
 		secure.RateLimitPolicy, isValidRLP = computeVirtualHostRateLimitPolicy(proxy, p.GlobalRateLimitService, validCond)
 		if !isValidRLP {
@@ -628,7 +701,18 @@ func addRoutes(vhost vhost, routes []*Route) {
 	}
 }
 
-func (p *HTTPProxyProcessor) addStatusBadGatewayRoute(routes []*Route, conds []contour_v1.MatchCondition, proxy *contour_v1.HTTPProxy) []*Route {
+// proxySource returns the Source of a vertex generated from the
+// field of proxy at fieldPath.
+func proxySource(proxy *contour_v1.HTTPProxy, fieldPath string) Source {
+	return Source{
+		Kind:      "HTTPProxy",
+		Namespace: proxy.Namespace,
+		Name:      proxy.Name,
+		FieldPath: fieldPath,
+	}
+}
+
+func (p *HTTPProxyProcessor) addStatusBadGatewayRoute(routes []*Route, conds []contour_v1.MatchCondition, proxy *contour_v1.HTTPProxy, includeIndex int) []*Route {
 	if len(conds) > 0 {
 		route := &Route{
 			PathMatchCondition:        mergePathMatchConditions(conds),
@@ -636,6 +720,7 @@ func (p *HTTPProxyProcessor) addStatusBadGatewayRoute(routes []*Route, conds []c
 			QueryParamMatchConditions: mergeQueryParamMatchConditions(conds),
 			DirectResponse:            directResponse(http.StatusBadGateway, ""),
 		}
+		p.dag.addSource(route, proxySource(proxy, fmt.Sprintf("spec.includes[%d]", includeIndex)))
 
 		if p.SetSourceMetadataOnRoutes {
 			route.Kind = "HTTPProxy"
@@ -676,7 +761,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 
 	// Loop over and process all includes, including checking for duplicate conditions.
 	seenConds := map[string][]matchConditionAggregate{}
-	for _, include := range proxy.Spec.Includes {
+	for i, include := range proxy.Spec.Includes {
 		namespace := include.Namespace
 		if namespace == "" {
 			namespace = proxy.Namespace
@@ -719,7 +804,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				"include %s/%s not found", namespace, include.Name)
 
 			// Set 502 response when include was not found but include condition was valid.
-			routes = p.addStatusBadGatewayRoute(routes, include.Conditions, proxy)
+			routes = p.addStatusBadGatewayRoute(routes, include.Conditions, proxy, i)
 			continue
 		}
 
@@ -727,13 +812,17 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			validCond.AddErrorf(contour_v1.ConditionTypeIncludeError, "RootIncludesRoot",
 				"root httpproxy cannot include another root httpproxy (%s/%s)", includedProxy.Namespace, includedProxy.Name)
 			// Set 502 response if include references another root
-			routes = p.addStatusBadGatewayRoute(routes, include.Conditions, proxy)
+			routes = p.addStatusBadGatewayRoute(routes, include.Conditions, proxy, i)
 			continue
 		}
 
 		inc, incCommit := p.dag.StatusCache.ProxyAccessor(includedProxy)
 		incValidCond := inc.ConditionFor(status.ValidCondition)
-		routes = append(routes, p.computeRoutes(incValidCond, rootProxy, includedProxy, append(conditions, include.Conditions...), visited, enforceTLS, defaultJWTProvider)...)
+		incRoutes := p.computeRoutes(incValidCond, rootProxy, includedProxy, append(conditions, include.Conditions...), visited, enforceTLS, defaultJWTProvider)
+		for _, r := range incRoutes {
+			p.dag.addSource(r, proxySource(proxy, fmt.Sprintf("spec.includes[%d]", i)))
+		}
+		routes = append(routes, incRoutes...)
 		incCommit()
 
 		// dest is not an orphaned httpproxy, as there is an httpproxy that points to it
@@ -744,7 +833,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 		"CONTOUR_NAMESPACE": proxy.Namespace,
 	}
 
-	for _, route := range proxy.Spec.Routes {
+	for i, route := range proxy.Spec.Routes {
 		if err := routeActionCountValid(route); err != nil {
 			validCond.AddError(contour_v1.ConditionTypeRouteError, "RouteActionCountNotValid", err.Error())
 			return nil
@@ -812,6 +901,20 @@ func (p *HTTPProxyProcessor) computeRoutes(
 
 		requestHashPolicies, lbPolicy := loadBalancerRequestHashPolicies(route.LoadBalancerPolicy, validCond)
 
+		sp, err := sessionPersistence(route.SessionPersistence)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeRouteError, "SessionPersistenceNotValid",
+				"route.sessionPersistence is invalid: %s", err)
+			return nil
+		}
+
+		cp, err := toCORSPolicy(route.CORSPolicy)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeCORSError, "PolicyDidNotParse",
+				"route.corsPolicy is invalid: %s", err)
+			return nil
+		}
+
 		redirectPolicy, err := redirectRoutePolicy(route.RequestRedirectPolicy)
 		if err != nil {
 			validCond.AddErrorf(contour_v1.ConditionTypeRouteError, "RequestRedirectPolicy",
@@ -823,20 +926,31 @@ func (p *HTTPProxyProcessor) computeRoutes(
 
 		directPolicy := directResponsePolicy(route.DirectResponsePolicy)
 
+		fip, err := faultInjectionPolicy(route.FaultInjectionPolicy)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeRouteError, "FaultInjectionPolicyInvalid",
+				"route.faultInjectionPolicy is invalid: %s", err)
+			return nil
+		}
+
 		r := &Route{
 			PathMatchCondition:        mergePathMatchConditions(routeConditions),
 			HeaderMatchConditions:     mergeHeaderMatchConditions(routeConditions),
 			QueryParamMatchConditions: mergeQueryParamMatchConditions(routeConditions),
 			Websocket:                 route.EnableWebsockets,
+			CompressionDisabled:       route.DisableCompression,
 			HTTPSUpgrade:              routeEnforceTLS(enforceTLS, route.PermitInsecure && !p.DisablePermitInsecure),
 			TimeoutPolicy:             rtp,
 			RetryPolicy:               retryPolicy(route.RetryPolicy),
+			FaultInjectionPolicy:      fip,
 			RequestHeadersPolicy:      reqHP,
 			ResponseHeadersPolicy:     respHP,
 			CookieRewritePolicies:     cookieRP,
 			RateLimitPolicy:           rlp,
 			RateLimitPerRoute:         vrl,
 			RequestHashPolicies:       requestHashPolicies,
+			SessionPersistence:        sp,
+			CORSPolicy:                cp,
 			Redirect:                  redirectPolicy,
 			DirectResponse:            directPolicy,
 			InternalRedirectPolicy:    irp,
@@ -847,6 +961,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			r.Namespace = proxy.Namespace
 			r.Name = proxy.Name
 		}
+		p.dag.addSource(r, proxySource(proxy, fmt.Sprintf("spec.routes[%d]", i)))
 
 		// If the enclosing root proxy enabled authorization,
 		// enable it on the route and propagate defaults
@@ -875,6 +990,47 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			}
 		}
 
+		// If the enclosing root proxy or the global configuration
+		// enabled external processing, apply any route level policy.
+		if (rootProxy.Spec.VirtualHost.ExternalProcessing != nil || p.GlobalExternalProcessing != nil) && route.ExternalProcessingPolicy != nil {
+			r.ExternalProcessingDisabled = route.ExternalProcessingPolicy.Disabled
+
+			pm, err := processingMode(route.ExternalProcessingPolicy.ProcessingMode)
+			if err != nil {
+				validCond.AddErrorf(contour_v1.ConditionTypeExternalProcessingError, "ProcessingModeNotValid",
+					"route.externalProcessingPolicy.processingMode is invalid: %s", err)
+				return nil
+			}
+			r.ExternalProcessingMode = pm
+		}
+
+		if route.BasicAuthPolicy != nil {
+			if rootProxy.Spec.VirtualHost.BasicAuth != nil {
+				r.BasicAuthDisabled = route.BasicAuthPolicy.Disabled
+			}
+
+			if len(route.BasicAuthPolicy.SecretName) > 0 {
+				// Replacing the users only works if the filter was
+				// added to the virtual host, so refuse to serve the
+				// route without authentication.
+				if rootProxy.Spec.VirtualHost.BasicAuth == nil {
+					validCond.AddError(contour_v1.ConditionTypeBasicAuthError, "BasicAuthNotEnabled",
+						"route.basicAuthPolicy.secretName requires basic authentication to be enabled on the root HTTPProxy")
+					return nil
+				}
+
+				secretName := types.NamespacedName{Namespace: proxy.Namespace, Name: route.BasicAuthPolicy.SecretName}
+				secret, err := p.source.LookupBasicAuthSecret(secretName)
+				if err != nil {
+					validCond.AddErrorf(contour_v1.ConditionTypeBasicAuthError, "SecretNotValid",
+						"route.basicAuthPolicy.secretName Secret %q is invalid: %s", secretName, err)
+					return nil
+				}
+				r.BasicAuthSecret = secret
+				p.dag.addSource(secret, proxySource(proxy, fmt.Sprintf("spec.routes[%d].basicAuthPolicy.secretName", i)))
+			}
+		}
+
 		if len(route.GetPrefixReplacements()) > 0 {
 			if !r.HasPathPrefix() {
 				validCond.AddError(contour_v1.ConditionTypePrefixReplaceError, "MustHavePrefix",
@@ -918,11 +1074,22 @@ func (p *HTTPProxyProcessor) computeRoutes(
 
 		healthPolicy, err := httpHealthCheckPolicy(route.HealthCheckPolicy)
 		if err != nil {
-			validCond.AddErrorf(contour_v1.ConditionTypeRouteError, "HealthCheckPolicyInvalid", err.Error())
+			validCond.AddErrorf(contour_v1.ConditionTypeRouteError, "HealthCheckPolicyInvalid", "%s", err)
 			return nil
 		}
 
-		for _, service := range route.Services {
+		failover, err := failoverPolicy(&route)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "PriorityInvalid",
+				"%s", err)
+			return nil
+		}
+
+		// priorities records the priority of each of the route's
+		// clusters when failing over between them.
+		priorities := map[*Cluster]uint32{}
+
+		for j, service := range route.Services {
 			if service.Port < 1 || service.Port > 65535 {
 				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "ServicePortInvalid",
 					"service %q: port must be in the range 1-65535", service.Name)
@@ -937,7 +1104,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			}
 
 			m := types.NamespacedName{Name: service.Name, Namespace: proxy.Namespace}
-			s, err := p.dag.EnsureService(m, service.Port, healthPort, p.source, p.EnableExternalNameService)
+			s, err := p.ensureService(m, service, healthPort)
 			if err != nil {
 				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference",
 					"Spec.Routes unresolved service reference: %s", err)
@@ -951,6 +1118,11 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				validCond.AddError(contour_v1.ConditionTypeServiceError, "UnsupportedProtocol", err.Error())
 				return nil
 			}
+			if s.UpstreamValidation != nil && protocol != "tls" && protocol != "h2" {
+				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "UnsupportedProtocol",
+					"Backend %q verifies its endpoints over TLS, protocol %q is not supported", service.Name, protocol)
+				return nil
+			}
 
 			var uv *PeerValidationContext
 			if (protocol == "tls" || protocol == "h2") && service.UpstreamValidation != nil {
@@ -968,6 +1140,9 @@ func (p *HTTPProxyProcessor) computeRoutes(
 					return nil
 				}
 			}
+			if uv == nil && (protocol == "tls" || protocol == "h2") {
+				uv = s.UpstreamValidation
+			}
 
 			dynamicHeaders["CONTOUR_SERVICE_NAME"] = service.Name
 			dynamicHeaders["CONTOUR_SERVICE_PORT"] = strconv.Itoa(service.Port)
@@ -992,15 +1167,9 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				return nil
 			}
 
-			var clientCertSecret *Secret
-			if p.ClientCertificate != nil {
-				// Since the client certificate is configured by admin, explicit delegation is not required.
-				clientCertSecret, err = p.source.LookupTLSSecretInsecure(*p.ClientCertificate)
-				if err != nil {
-					validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "SecretNotValid",
-						"tls.envoy-client-certificate Secret %q is invalid: %s", p.ClientCertificate, err)
-					return nil
-				}
+			clientCertSecret, ok := p.clientCertificate(validCond, proxy, service)
+			if !ok {
+				return nil
 			}
 
 			var slowStart *SlowStartConfig
@@ -1020,6 +1189,40 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				}
 			}
 
+			odp, err := outlierDetectionPolicy(service.OutlierDetectionPolicy)
+			if err != nil {
+				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "OutlierDetectionInvalid",
+					"%s on outlier detection", err)
+				return nil
+			}
+
+			var subsetSelector map[string]string
+			if service.Subset != nil {
+				switch {
+				case !p.EnableSubsetLoadBalancing:
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "SubsetLoadBalancingNotEnabled",
+						"service %q: subset load balancing is not enabled", service.Name)
+					return nil
+				case len(s.ExternalName) > 0:
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "SubsetNotValid",
+						"service %q: subsets are not supported for ExternalName services", service.Name)
+					return nil
+				case len(s.Endpoints) > 0:
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "SubsetNotValid",
+						"service %q: subsets are not supported for Backends", service.Name)
+					return nil
+				case service.Mirror:
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "SubsetNotValid",
+						"service %q: subsets are not supported for mirror services", service.Name)
+					return nil
+				case len(service.Subset.MatchLabels) == 0:
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "SubsetNotValid",
+						"service %q: subset.matchLabels must not be empty", service.Name)
+					return nil
+				}
+				subsetSelector = service.Subset.MatchLabels
+			}
+
 			c := &Cluster{
 				Upstream:                      s,
 				LoadBalancerPolicy:            lbPolicy,
@@ -1033,37 +1236,41 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				SNI:                           determineSNI(r.RequestHeadersPolicy, reqHP, s),
 				DNSLookupFamily:               string(p.DNSLookupFamily),
 				ClientCertificate:             clientCertSecret,
+				ServiceClientCertificate:      service.ClientCertificate != "",
 				TimeoutPolicy:                 ctp,
 				SlowStartConfig:               slowStart,
+				OutlierDetectionPolicy:        odp,
+				SubsetSelector:                subsetSelector,
 				MaxRequestsPerConnection:      p.MaxRequestsPerConnection,
 				PerConnectionBufferLimitBytes: p.PerConnectionBufferLimitBytes,
 				UpstreamTLS:                   p.UpstreamTLS,
 			}
-			if service.Mirror && len(r.MirrorPolicies) > 0 {
-				validCond.AddError(contour_v1.ConditionTypeServiceError, "OnlyOneMirror",
-					"only one service per route may be nominated as mirror")
+			p.dag.addSource(c, proxySource(proxy, fmt.Sprintf("spec.routes[%d].services[%d]", i, j)))
+
+			if service.MirrorFraction != nil && !service.Mirror {
+				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "MirrorNotValid",
+					"service %q: mirrorFraction may only be set when mirror is true", service.Name)
 				return nil
 			}
 			if service.Mirror {
-				// Legal Weight values are 1-100. The default value of a float64 if Weight is omitted
-				// is 0. To retain backwards compatibility omitted weights will be treated as 100% mirroring.
-				// EDGE CASE: This means that explicitly setting Weight to 0 will also result in 100%
-				// mirroring. The Mirror field must be set to false or removed to disable the mirror.
-				if service.Weight == 0 {
-					r.MirrorPolicies = []*MirrorPolicy{{
-						Cluster: c,
-						Weight:  100,
-					}}
-				} else {
-					r.MirrorPolicies = []*MirrorPolicy{{
-						Cluster: c,
-						Weight:  service.Weight,
-					}}
+				mp, err := mirrorPolicy(c, service)
+				if err != nil {
+					validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "MirrorNotValid",
+						"service %q: %s", service.Name, err)
+					return nil
 				}
+				r.MirrorPolicies = append(r.MirrorPolicies, mp)
 			} else {
 				r.Clusters = append(r.Clusters, c)
+				priorities[c] = service.Priority
 			}
 		}
+		if failover && len(r.Clusters) > 0 {
+			sort.SliceStable(r.Clusters, func(i, j int) bool {
+				return priorities[r.Clusters[i]] < priorities[r.Clusters[j]]
+			})
+			r.AggregateCluster = &AggregateCluster{Clusters: r.Clusters}
+		}
 		if len(r.Clusters) == 0 && route.RequestRedirectPolicy == nil && route.DirectResponsePolicy == nil {
 			r.DirectResponse = directResponse(http.StatusServiceUnavailable, "")
 		}
@@ -1191,7 +1398,7 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_v1.Deta
 
 	if len(tcpproxy.Services) > 0 {
 		var proxy TCPProxy
-		for _, service := range httpproxy.Spec.TCPProxy.Services {
+		for i, service := range httpproxy.Spec.TCPProxy.Services {
 			var healthPort int
 			healthPolicy := tcpHealthCheckPolicy(tcpproxy.HealthCheckPolicy)
 			if healthPolicy != nil && service.HealthPort > 0 {
@@ -1201,7 +1408,7 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_v1.Deta
 			}
 
 			m := types.NamespacedName{Name: service.Name, Namespace: httpproxy.Namespace}
-			s, err := p.dag.EnsureService(m, service.Port, healthPort, p.source, p.EnableExternalNameService)
+			s, err := p.ensureService(m, service, healthPort)
 			if err != nil {
 				validCond.AddErrorf(contour_v1.ConditionTypeTCPProxyError, "ServiceUnresolvedReference",
 					"Spec.TCPProxy unresolved service reference: %s", err)
@@ -1214,6 +1421,11 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_v1.Deta
 				validCond.AddError(contour_v1.ConditionTypeServiceError, "UnsupportedProtocol", err.Error())
 				return false
 			}
+			if s.UpstreamValidation != nil && protocol != "tls" && protocol != "h2" {
+				validCond.AddErrorf(contour_v1.ConditionTypeServiceError, "UnsupportedProtocol",
+					"Backend %q verifies its endpoints over TLS, protocol %q is not supported", service.Name, protocol)
+				return false
+			}
 
 			var uv *PeerValidationContext
 			if (protocol == "tls" || protocol == "h2") && service.UpstreamValidation != nil {
@@ -1222,30 +1434,30 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_v1.Deta
 					return false
 				}
 			}
+			if uv == nil && (protocol == "tls" || protocol == "h2") {
+				uv = s.UpstreamValidation
+			}
 
-			var clientCertSecret *Secret
-			if p.ClientCertificate != nil {
-				// Since the client certificate is configured by admin, explicit delegation is not required.
-				clientCertSecret, err = p.source.LookupTLSSecretInsecure(*p.ClientCertificate)
-				if err != nil {
-					validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "SecretNotValid",
-						"tls.envoy-client-certificate Secret %q is invalid: %s", p.ClientCertificate, err)
-					return false
-				}
+			clientCertSecret, ok := p.clientCertificate(validCond, httpproxy, service)
+			if !ok {
+				return false
 			}
 
-			proxy.Clusters = append(proxy.Clusters, &Cluster{
-				Upstream:             s,
-				Weight:               uint32(service.Weight),
-				Protocol:             protocol,
-				LoadBalancerPolicy:   lbPolicy,
-				TCPHealthCheckPolicy: healthPolicy,
-				SNI:                  s.ExternalName,
-				TimeoutPolicy:        ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
-				UpstreamTLS:          p.UpstreamTLS,
-				UpstreamValidation:   uv,
-				ClientCertificate:    clientCertSecret,
-			})
+			c := &Cluster{
+				Upstream:                 s,
+				Weight:                   uint32(service.Weight),
+				Protocol:                 protocol,
+				LoadBalancerPolicy:       lbPolicy,
+				TCPHealthCheckPolicy:     healthPolicy,
+				SNI:                      determineSNI(nil, nil, s),
+				TimeoutPolicy:            ClusterTimeoutPolicy{ConnectTimeout: p.ConnectTimeout},
+				UpstreamTLS:              p.UpstreamTLS,
+				UpstreamValidation:       uv,
+				ClientCertificate:        clientCertSecret,
+				ServiceClientCertificate: service.ClientCertificate != "",
+			}
+			p.dag.addSource(c, proxySource(httpproxy, fmt.Sprintf("spec.tcpproxy.services[%d]", i)))
+			proxy.Clusters = append(proxy.Clusters, c)
 		}
 
 		listener, err := p.dag.GetSingleListener("https")
@@ -1256,6 +1468,7 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_v1.Deta
 
 		secure := p.dag.EnsureSecureVirtualHost(listener.Name, host)
 		secure.TCPProxy = &proxy
+		p.dag.addSource(secure, proxySource(httpproxy, "spec.tcpproxy"))
 
 		return true
 	}
@@ -1495,6 +1708,256 @@ func computeVirtualHostRateLimitPolicy(proxy *contour_v1.HTTPProxy, rls *contour
 	return rlp, true
 }
 
+func (p *HTTPProxyProcessor) computeVirtualHostExternalProcessing(ep *contour_v1.ExternalProcessing, validCond *contour_v1.DetailedCondition, httpproxy *contour_v1.HTTPProxy) *ExternalProcessing {
+	ref := defaultExtensionRef(ep.ExtensionServiceRef)
+	if ref.APIVersion != contour_v1alpha1.GroupVersion.String() {
+		validCond.AddErrorf(contour_v1.ConditionTypeExternalProcessingError, "ExternalProcessingBadResourceVersion",
+			"Spec.Virtualhost.ExternalProcessing.extensionRef specifies an unsupported resource version %q", ref.APIVersion)
+		return nil
+	}
+
+	extensionName := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: stringOrDefault(ref.Namespace, httpproxy.Namespace),
+	}
+
+	ext := p.dag.GetExtensionCluster(ExtensionClusterName(extensionName))
+	if ext == nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeExternalProcessingError, "ExtensionServiceNotFound",
+			"Spec.Virtualhost.ExternalProcessing.extensionRef extension service %q not found", extensionName)
+		return nil
+	}
+
+	respTimeout, err := timeout.Parse(ep.ResponseTimeout)
+	if err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeExternalProcessingError, "ResponseTimeoutNotValid",
+			"Spec.Virtualhost.ExternalProcessing.ResponseTimeout is invalid: %s", err)
+		return nil
+	}
+	if respTimeout.UseDefault() {
+		respTimeout = ext.RouteTimeoutPolicy.ResponseTimeout
+	}
+
+	pm, err := processingMode(ep.ProcessingMode)
+	if err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeExternalProcessingError, "ProcessingModeNotValid",
+			"Spec.Virtualhost.ExternalProcessing.ProcessingMode is invalid: %s", err)
+		return nil
+	}
+
+	return &ExternalProcessing{
+		ProcessingService: ext,
+		ResponseTimeout:   respTimeout,
+		FailOpen:          ep.FailOpen,
+		ProcessingMode:    pm,
+	}
+}
+
+func (p *HTTPProxyProcessor) computeSecureVirtualHostExternalProcessing(validCond *contour_v1.DetailedCondition, httpproxy *contour_v1.HTTPProxy, svhost *SecureVirtualHost) bool {
+	if httpproxy.Spec.VirtualHost.ExternalProcessing != nil {
+		externalProcessing := p.computeVirtualHostExternalProcessing(httpproxy.Spec.VirtualHost.ExternalProcessing, validCond, httpproxy)
+		if externalProcessing == nil {
+			return false
+		}
+
+		svhost.ExternalProcessing = externalProcessing
+	} else if p.GlobalExternalProcessing != nil {
+		globalExternalProcessing := p.computeVirtualHostExternalProcessing(p.GlobalExternalProcessing, validCond, httpproxy)
+		if globalExternalProcessing == nil {
+			return false
+		}
+
+		svhost.ExternalProcessing = globalExternalProcessing
+	}
+
+	return true
+}
+
+// processingMode converts an external processing mode from the
+// HTTPProxy API to the DAG.
+func processingMode(in *contour_v1.ProcessingMode) (*ProcessingMode, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	headerMode := func(field string, mode contour_v1.HeaderSendMode) (HeaderSendMode, error) {
+		switch mode {
+		case "":
+			return "", nil
+		case contour_v1.HeaderSendModeDefault, contour_v1.HeaderSendModeSend, contour_v1.HeaderSendModeSkip:
+			return HeaderSendMode(mode), nil
+		default:
+			return "", fmt.Errorf("invalid %s %q", field, mode)
+		}
+	}
+	bodyMode := func(field string, mode contour_v1.BodySendMode) (BodySendMode, error) {
+		switch mode {
+		case "":
+			return "", nil
+		case contour_v1.BodySendModeNone, contour_v1.BodySendModeStreamed, contour_v1.BodySendModeBuffered, contour_v1.BodySendModeBufferedPartial:
+			return BodySendMode(mode), nil
+		default:
+			return "", fmt.Errorf("invalid %s %q", field, mode)
+		}
+	}
+
+	var (
+		pm  ProcessingMode
+		err error
+	)
+	if pm.RequestHeaderMode, err = headerMode("requestHeaderMode", in.RequestHeaderMode); err != nil {
+		return nil, err
+	}
+	if pm.ResponseHeaderMode, err = headerMode("responseHeaderMode", in.ResponseHeaderMode); err != nil {
+		return nil, err
+	}
+	if pm.RequestBodyMode, err = bodyMode("requestBodyMode", in.RequestBodyMode); err != nil {
+		return nil, err
+	}
+	if pm.ResponseBodyMode, err = bodyMode("responseBodyMode", in.ResponseBodyMode); err != nil {
+		return nil, err
+	}
+
+	return &pm, nil
+}
+
+// computeSecureVirtualHostOAuth2 validates the OAuth2 configuration
+// of the HTTPProxy and attaches it to the secure virtual host. It
+// returns false if the configuration is invalid.
+func (p *HTTPProxyProcessor) computeSecureVirtualHostOAuth2(validCond *contour_v1.DetailedCondition, httpproxy *contour_v1.HTTPProxy, svhost *SecureVirtualHost) bool {
+	oauth2 := httpproxy.Spec.VirtualHost.OAuth2
+	if oauth2 == nil {
+		return true
+	}
+
+	tokenURL, err := url.Parse(oauth2.TokenEndpoint)
+	if err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "TokenEndpointInvalid",
+			"Spec.VirtualHost.OAuth2.TokenEndpoint is invalid: %s", err)
+		return false
+	}
+
+	if tokenURL.Scheme != "http" && tokenURL.Scheme != "https" {
+		validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "TokenEndpointSchemeInvalid",
+			"Spec.VirtualHost.OAuth2.TokenEndpoint has invalid scheme %q, must be http or https", tokenURL.Scheme)
+		return false
+	}
+
+	if _, err := url.ParseRequestURI(oauth2.AuthorizationEndpoint); err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "AuthorizationEndpointInvalid",
+			"Spec.VirtualHost.OAuth2.AuthorizationEndpoint is invalid: %s", err)
+		return false
+	}
+
+	tokenTimeout := time.Second
+	if len(oauth2.TokenEndpointTimeout) > 0 {
+		res, err := time.ParseDuration(oauth2.TokenEndpointTimeout)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "TokenEndpointTimeoutInvalid",
+				"Spec.VirtualHost.OAuth2.TokenEndpointTimeout is invalid: %s", err)
+			return false
+		}
+
+		tokenTimeout = res
+	}
+
+	// Check for a specified port and use it, else use the
+	// standard ports by scheme.
+	var port int
+	switch {
+	case len(tokenURL.Port()) > 0:
+		res, err := strconv.Atoi(tokenURL.Port())
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "TokenEndpointPortInvalid",
+				"Spec.VirtualHost.OAuth2.TokenEndpoint has an invalid port: %s", err)
+			return false
+		}
+		port = res
+	case tokenURL.Scheme == "http":
+		port = 80
+	case tokenURL.Scheme == "https":
+		port = 443
+	}
+
+	for _, cond := range oauth2.PassThroughMatchers {
+		if len(cond.Regex) > 0 {
+			if err := ValidateRegex(cond.Regex); err != nil {
+				validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "PassThroughMatcherInvalid",
+					"Spec.VirtualHost.OAuth2.PassThroughMatchers is invalid: %s", err)
+				return false
+			}
+		}
+	}
+
+	redirectPath := oauth2.RedirectPath
+	if len(redirectPath) == 0 {
+		redirectPath = "/oauth2/callback"
+	}
+
+	signoutPath := oauth2.SignoutPath
+	if len(signoutPath) == 0 {
+		signoutPath = "/oauth2/signout"
+	}
+
+	if redirectPath == signoutPath {
+		validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "PathsConflict",
+			"Spec.VirtualHost.OAuth2 redirectPath and signoutPath must be different")
+		return false
+	}
+
+	secretName := types.NamespacedName{Namespace: httpproxy.Namespace, Name: oauth2.ClientSecretRef}
+	secret, err := p.source.LookupOAuth2Secret(secretName)
+	if err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeOAuth2Error, "ClientSecretNotValid",
+			"Spec.VirtualHost.OAuth2.ClientSecretRef Secret %q is invalid: %s", secretName, err)
+		return false
+	}
+
+	svhost.OAuth2 = &OAuth2{
+		TokenEndpoint:        oauth2.TokenEndpoint,
+		TokenEndpointTimeout: tokenTimeout,
+		TokenEndpointCluster: DNSNameCluster{
+			Address:         tokenURL.Hostname(),
+			Scheme:          tokenURL.Scheme,
+			Port:            port,
+			DNSLookupFamily: string(p.DNSLookupFamily),
+			UpstreamTLS:     p.UpstreamTLS,
+		},
+		AuthorizationEndpoint: oauth2.AuthorizationEndpoint,
+		ClientID:              oauth2.ClientID,
+		ClientSecret:          secret,
+		RedirectPath:          redirectPath,
+		SignoutPath:           signoutPath,
+		Scopes:                oauth2.Scopes,
+		ForwardBearerToken:    oauth2.ForwardBearerToken,
+		PassThroughMatchers:   headerMatchConditions(oauth2.PassThroughMatchers),
+	}
+
+	return true
+}
+
+// computeSecureVirtualHostBasicAuth looks up the htpasswd Secret
+// of the basic authentication configuration (if any) and sets it on
+// the SecureVirtualHost. It returns false if the Secret is not valid.
+func (p *HTTPProxyProcessor) computeSecureVirtualHostBasicAuth(validCond *contour_v1.DetailedCondition, httpproxy *contour_v1.HTTPProxy, svhost *SecureVirtualHost) bool {
+	basicAuth := httpproxy.Spec.VirtualHost.BasicAuth
+	if basicAuth == nil {
+		return true
+	}
+
+	secretName := types.NamespacedName{Namespace: httpproxy.Namespace, Name: basicAuth.SecretName}
+	secret, err := p.source.LookupBasicAuthSecret(secretName)
+	if err != nil {
+		validCond.AddErrorf(contour_v1.ConditionTypeBasicAuthError, "SecretNotValid",
+			"Spec.VirtualHost.BasicAuth.SecretName Secret %q is invalid: %s", secretName, err)
+		return false
+	}
+
+	svhost.BasicAuthSecret = secret
+	p.dag.addSource(secret, proxySource(httpproxy, "spec.virtualhost.basicAuth.secretName"))
+	return true
+}
+
 func (p *HTTPProxyProcessor) GlobalAuthorizationConfigured() bool {
 	return p.GlobalExternalAuthorization != nil
 }
@@ -1524,6 +1987,42 @@ func (p *HTTPProxyProcessor) peerValidationContext(validCond *contour_v1.Detaile
 	return uv
 }
 
+// clientCertificate returns the client certificate Envoy presents to the
+// given service. A certificate referenced by the service takes precedence
+// over the globally configured one. The second return value is false if the
+// referenced secret could not be resolved, in which case an error has been
+// added to validCond.
+func (p *HTTPProxyProcessor) clientCertificate(validCond *contour_v1.DetailedCondition, httpproxy *contour_v1.HTTPProxy, service contour_v1.Service) (*Secret, bool) {
+	if service.ClientCertificate != "" {
+		secretName := k8s.NamespacedNameFrom(service.ClientCertificate, k8s.DefaultNamespace(httpproxy.Namespace))
+		sec, err := p.source.LookupTLSSecret(secretName, httpproxy.Namespace)
+		if err != nil {
+			if _, ok := err.(DelegationNotPermittedError); ok {
+				validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "ClientCertificateNotDelegated",
+					"service.ClientCertificate Secret %q is not configured for certificate delegation", secretName)
+			} else {
+				validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "SecretNotValid",
+					"service.ClientCertificate Secret %q is invalid: %s", secretName, err)
+			}
+			return nil, false
+		}
+		return sec, true
+	}
+
+	if p.ClientCertificate != nil {
+		// Since the client certificate is configured by admin, explicit delegation is not required.
+		sec, err := p.source.LookupTLSSecretInsecure(*p.ClientCertificate)
+		if err != nil {
+			validCond.AddErrorf(contour_v1.ConditionTypeTLSError, "SecretNotValid",
+				"tls.envoy-client-certificate Secret %q is invalid: %s", p.ClientCertificate, err)
+			return nil, false
+		}
+		return sec, true
+	}
+
+	return nil, true
+}
+
 // expandPrefixMatches adds new Routes to account for the difference
 // between prefix replacement when matching on '/foo' and '/foo/'.
 //
@@ -1641,8 +2140,8 @@ func getProtocol(service contour_v1.Service, s *Service) (string, error) {
 }
 
 // determineSNI decides what the SNI should be on the request. It is configured via RequestHeadersPolicy.Host key.
-// Policies set on service are used before policies set on a route. Otherwise the value of the externalService
-// is used if the route is configured to proxy to an externalService type.
+// Policies set on service are used before policies set on a route. Otherwise the SNI of a Backend, or the value
+// of the externalService if the route is configured to proxy to an externalService type, is used.
 func determineSNI(routeRequestHeaders, clusterRequestHeaders *HeadersPolicy, service *Service) string {
 	// Service RequestHeadersPolicy take precedence
 	if clusterRequestHeaders != nil {
@@ -1658,9 +2157,23 @@ func determineSNI(routeRequestHeaders, clusterRequestHeaders *HeadersPolicy, ser
 		}
 	}
 
+	if service.SNI != "" {
+		return service.SNI
+	}
+
 	return service.ExternalName
 }
 
+// ensureService returns the DAG service for the Kubernetes Service
+// or Backend that the given service refers to.
+func (p *HTTPProxyProcessor) ensureService(meta types.NamespacedName, service contour_v1.Service, healthPort int) (*Service, error) {
+	if service.Kind == "Backend" {
+		return p.dag.EnsureBackend(meta, service.Port, p.source, p.EnableExternalNameService)
+	}
+
+	return p.dag.EnsureService(meta, service.Port, healthPort, p.source, p.EnableExternalNameService)
+}
+
 func toCORSPolicy(policy *contour_v1.CORSPolicy) (*CORSPolicy, error) {
 	if policy == nil {
 		return nil, nil
@@ -2022,6 +2535,68 @@ func slowStartConfig(slowStart *contour_v1.SlowStartPolicy) (*SlowStartConfig, e
 	}, nil
 }
 
+// mirrorPolicy returns the mirror policy for a Service nominated as
+// a mirror.
+func mirrorPolicy(c *Cluster, service contour_v1.Service) (*MirrorPolicy, error) {
+	if service.MirrorFraction != nil {
+		weight, denominator, err := mirrorFraction("mirrorFraction", service.MirrorFraction.Numerator, service.MirrorFraction.Denominator)
+		if err != nil {
+			return nil, err
+		}
+		return &MirrorPolicy{
+			Cluster:     c,
+			Weight:      weight,
+			Denominator: denominator,
+		}, nil
+	}
+
+	// Legal Weight values are 1-100. The default value of a float64 if Weight is omitted
+	// is 0. To retain backwards compatibility omitted weights will be treated as 100% mirroring.
+	// EDGE CASE: This means that explicitly setting Weight to 0 will also result in 100%
+	// mirroring. The Mirror field must be set to false or removed to disable the mirror.
+	if service.Weight == 0 {
+		return &MirrorPolicy{
+			Cluster: c,
+			Weight:  100,
+		}, nil
+	}
+	return &MirrorPolicy{
+		Cluster: c,
+		Weight:  service.Weight,
+	}, nil
+}
+
+func sessionPersistence(in *contour_v1.SessionPersistence) (*SessionPersistence, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	sp := &SessionPersistence{
+		Type: SessionPersistenceCookie,
+		Name: in.Name,
+	}
+
+	switch in.Type {
+	case "", contour_v1.CookieSessionPersistence:
+		if in.CookieTTL != "" {
+			ttl, err := time.ParseDuration(in.CookieTTL)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing cookieTTL: %s", err)
+			}
+			sp.CookieTTL = ttl
+		}
+	case contour_v1.HeaderSessionPersistence:
+		if in.CookieTTL != "" {
+			return nil, fmt.Errorf("cookieTTL cannot be specified for type %q", in.Type)
+		}
+		sp.Type = SessionPersistenceHeader
+	default:
+		return nil, fmt.Errorf("unsupported type %q", in.Type)
+	}
+
+	return sp, nil
+}
+
 func rateLimitPerRoute(in *contour_v1.RateLimitPolicy) *RateLimitPerRoute {
 	// Ignore the virtual host global rate limit policy if disabled is true
 	if in != nil && in.Global != nil && in.Global.Disabled {
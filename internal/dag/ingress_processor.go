@@ -14,6 +14,7 @@
 package dag
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -24,11 +25,22 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/annotation"
 	"github.com/projectcontour/contour/internal/k8s"
 )
 
+// IngressError is a problem the IngressProcessor found with an Ingress.
+// Ingresses have no status conditions, so Type and Reason are the
+// condition type and reason HTTPProxy status uses for the same problem.
+type IngressError struct {
+	Ingress types.NamespacedName
+	Type    string
+	Reason  string
+	Message string
+}
+
 // IngressProcessor translates Ingresses into DAG
 // objects and adds them to the DAG.
 type IngressProcessor struct {
@@ -73,6 +85,10 @@ type IngressProcessor struct {
 	// UpstreamTLS defines the TLS settings like min/max version
 	// and cipher suites for upstream connections.
 	UpstreamTLS *UpstreamTLS
+
+	// ErrorSink is called with each problem found with an Ingress,
+	// in addition to the problem being logged (optional).
+	ErrorSink func(IngressError)
 }
 
 // Run translates Ingresses into DAG objects and
@@ -98,22 +114,16 @@ func (p *IngressProcessor) Run(dag *DAG, source *KubernetesCache) {
 // secure virtual hosts.
 func (p *IngressProcessor) computeSecureVirtualhosts() {
 	for _, ing := range p.source.ingresses {
-		for _, tls := range ing.Spec.TLS {
+		for i, tls := range ing.Spec.TLS {
 			secretName := k8s.NamespacedNameFrom(tls.SecretName, k8s.TLSCertAnnotationNamespace(ing), k8s.DefaultNamespace(ing.GetNamespace()))
 			sec, err := p.source.LookupTLSSecret(secretName, ing.GetNamespace())
 			if err != nil {
 				if _, ok := err.(DelegationNotPermittedError); ok {
-					p.WithError(err).
-						WithField("name", ing.GetName()).
-						WithField("namespace", ing.GetNamespace()).
-						WithField("secret", secretName).
-						Error("certificate delegation not permitted")
+					p.ingressError(ing, err, logrus.Fields{"secret": secretName},
+						contour_v1.ConditionTypeTLSError, "DelegationNotPermitted", "certificate delegation not permitted")
 				} else {
-					p.WithError(err).
-						WithField("name", ing.GetName()).
-						WithField("namespace", ing.GetNamespace()).
-						WithField("secret", secretName).
-						Error("unresolved secret reference")
+					p.ingressError(ing, err, logrus.Fields{"secret": secretName},
+						contour_v1.ConditionTypeTLSError, "SecretNotValid", "unresolved secret reference")
 				}
 				continue
 			}
@@ -124,10 +134,8 @@ func (p *IngressProcessor) computeSecureVirtualhosts() {
 			for _, host := range tls.Hosts {
 				listener, err := p.dag.GetSingleListener("https")
 				if err != nil {
-					p.WithError(err).
-						WithField("name", ing.GetName()).
-						WithField("namespace", ing.GetNamespace()).
-						Errorf("error identifying listener")
+					p.ingressError(ing, err, nil,
+						contour_v1.ConditionTypeListenerError, "ErrorIdentifyingListener", "error identifying listener")
 					return
 				}
 
@@ -137,12 +145,8 @@ func (p *IngressProcessor) computeSecureVirtualhosts() {
 				maxTLSVer := annotation.TLSVersion(annotation.ContourAnnotation(ing, "tls-maximum-protocol-version"), "1.3")
 
 				if maxTLSVer < minTLSVer {
-					p.WithError(err).
-						WithField("name", ing.GetName()).
-						WithField("namespace", ing.GetNamespace()).
-						WithField("minTLSVersion", minTLSVer).
-						WithField("maxTLSVersion", maxTLSVer).
-						Errorf("error TLS protocol version, the minimum protocol version is greater than the maximum protocol version")
+					p.ingressError(ing, nil, logrus.Fields{"minTLSVersion": minTLSVer, "maxTLSVersion": maxTLSVer},
+						contour_v1.ConditionTypeTLSError, "TLSConfigNotValid", "error TLS protocol version, the minimum protocol version is greater than the maximum protocol version")
 					return
 				}
 
@@ -150,7 +154,8 @@ func (p *IngressProcessor) computeSecureVirtualhosts() {
 				svhost.Secret = sec
 				svhost.MinTLSVersion = minTLSVer
 				svhost.MaxTLSVersion = maxTLSVer
-
+				p.dag.addSource(svhost, ingressSource(ing, fmt.Sprintf("spec.tls[%d]", i)))
+				p.dag.addSource(sec, ingressSource(ing, fmt.Sprintf("spec.tls[%d].secretName", i)))
 			}
 		}
 	}
@@ -161,14 +166,22 @@ func (p *IngressProcessor) computeIngresses() {
 	for _, ing := range p.source.ingresses {
 
 		// rewrite the default ingress to a stock ingress rule.
-		rules := rulesFromSpec(ing.Spec)
-		for _, rule := range rules {
-			p.computeIngressRule(ing, rule)
+		if backend := ing.Spec.DefaultBackend; backend != nil {
+			p.computeIngressRule(ing, defaultBackendRule(backend), defaultBackendField)
+		}
+		for i, rule := range ing.Spec.Rules {
+			p.computeIngressRule(ing, rule, fmt.Sprintf("spec.rules[%d]", i))
 		}
 	}
 }
 
-func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule networking_v1.IngressRule) {
+// defaultBackendField is the field path of an Ingress's default backend,
+// which is rewritten to a rule with a single path.
+const defaultBackendField = "spec.defaultBackend"
+
+// computeIngressRule adds the routes for the paths of rule, which is
+// at ruleField in ing.
+func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule networking_v1.IngressRule, ruleField string) {
 	host := rule.Host
 
 	// If host name is blank, rewrite to Envoy's * default host.
@@ -182,16 +195,13 @@ func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule n
 		// Since the client certificate is configured by admin, explicit delegation is not required.
 		clientCertSecret, err = p.source.LookupTLSSecretInsecure(*p.ClientCertificate)
 		if err != nil {
-			p.WithError(err).
-				WithField("name", ing.GetName()).
-				WithField("namespace", ing.GetNamespace()).
-				WithField("secret", p.ClientCertificate).
-				Error("tls.envoy-client-certificate contains unresolved secret reference")
+			p.ingressError(ing, err, logrus.Fields{"secret": p.ClientCertificate},
+				contour_v1.ConditionTypeTLSError, "SecretNotValid", "tls.envoy-client-certificate contains unresolved secret reference")
 			return
 		}
 	}
 
-	for _, httppath := range httppaths(rule) {
+	for i, httppath := range httppaths(rule) {
 		path := stringOrDefault(httppath.Path, "/")
 		// Default to implementation specific path matching if not set.
 		pathType := ptr.Deref(httppath.PathType, networking_v1.PathTypeImplementationSpecific)
@@ -202,11 +212,8 @@ func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule n
 		if len(be.Service.Port.Name) > 0 {
 			_, svcPort, err2 := p.source.LookupService(m, intstr.FromString(be.Service.Port.Name))
 			if err2 != nil {
-				p.WithError(err2).
-					WithField("name", ing.GetName()).
-					WithField("namespace", ing.GetNamespace()).
-					WithField("service", be.Service.Name).
-					Error("service is not found")
+				p.ingressError(ing, err2, logrus.Fields{"service": be.Service.Name},
+					contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference", "service is not found")
 				continue
 			}
 
@@ -214,46 +221,46 @@ func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule n
 		}
 		s, err := p.dag.EnsureService(m, port, port, p.source, p.EnableExternalNameService)
 		if err != nil {
-			p.WithError(err).
-				WithField("name", ing.GetName()).
-				WithField("namespace", ing.GetNamespace()).
-				WithField("service", be.Service.Name).
-				Error("unresolved service reference")
+			p.ingressError(ing, err, logrus.Fields{"service": be.Service.Name},
+				contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference", "unresolved service reference")
 			continue
 		}
 		s = serviceCircuitBreakerPolicy(s, p.GlobalCircuitBreakerDefaults)
 
 		r, err := p.route(ing, rule.Host, path, pathType, s, clientCertSecret, be.Service.Name, be.Service.Port.Number, p.FieldLogger)
 		if err != nil {
-			p.WithError(err).
-				WithField("name", ing.GetName()).
-				WithField("namespace", ing.GetNamespace()).
-				WithField("regex", path).
-				Errorf("path regex is not valid")
+			p.ingressError(ing, err, logrus.Fields{"regex": path},
+				contour_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid", "path regex is not valid")
 			return
 		}
 
+		pathField := fmt.Sprintf("%s.http.paths[%d]", ruleField, i)
+		if ruleField == defaultBackendField {
+			pathField = ruleField
+		}
+		p.dag.addSource(r, ingressSource(ing, pathField))
+		for _, c := range r.Clusters {
+			p.dag.addSource(c, ingressSource(ing, pathField))
+		}
+
 		// should we create port 80 routes for this ingress
 		if annotation.TLSRequired(ing) || annotation.HTTPAllowed(ing) {
 			listener, err := p.dag.GetSingleListener("http")
 			if err != nil {
-				p.WithError(err).
-					WithField("name", ing.GetName()).
-					WithField("namespace", ing.GetNamespace()).
-					Errorf("error identifying listener")
+				p.ingressError(ing, err, nil,
+					contour_v1.ConditionTypeListenerError, "ErrorIdentifyingListener", "error identifying listener")
 				return
 			}
 
 			vhost := p.dag.EnsureVirtualHost(listener.Name, host)
 			vhost.AddRoute(r)
+			p.dag.addSource(vhost, ingressSource(ing, ruleField))
 		}
 
 		listener, err := p.dag.GetSingleListener("https")
 		if err != nil {
-			p.WithError(err).
-				WithField("name", ing.GetName()).
-				WithField("namespace", ing.GetNamespace()).
-				Errorf("error identifying listener")
+			p.ingressError(ing, err, nil,
+				contour_v1.ConditionTypeListenerError, "ErrorIdentifyingListener", "error identifying listener")
 			return
 		}
 
@@ -262,10 +269,47 @@ func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule n
 		// it is correctly configured for TLS.
 		if svh := p.dag.GetSecureVirtualHost(listener.Name, host); svh != nil && host != "*" {
 			svh.AddRoute(r)
+			p.dag.addSource(svh, ingressSource(ing, ruleField))
 		}
 	}
 }
 
+// ingressError logs a problem found with ing, along with err and fields,
+// and reports it to the ErrorSink.
+func (p *IngressProcessor) ingressError(ing *networking_v1.Ingress, err error, fields logrus.Fields, condType, reason, message string) {
+	log := p.WithField("name", ing.GetName()).
+		WithField("namespace", ing.GetNamespace()).
+		WithFields(fields)
+	if err != nil {
+		log = log.WithError(err)
+	}
+	log.Error(message)
+
+	if p.ErrorSink == nil {
+		return
+	}
+	if err != nil {
+		message += ": " + err.Error()
+	}
+	p.ErrorSink(IngressError{
+		Ingress: k8s.NamespacedNameOf(ing),
+		Type:    condType,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// ingressSource returns the Source of a vertex generated from the
+// field of ing at fieldPath.
+func ingressSource(ing *networking_v1.Ingress, fieldPath string) Source {
+	return Source{
+		Kind:      "Ingress",
+		Namespace: ing.Namespace,
+		Name:      ing.Name,
+		FieldPath: fieldPath,
+	}
+}
+
 // route builds a dag.Route for the supplied Ingress.
 func (p *IngressProcessor) route(ingress *networking_v1.Ingress, host, path string, pathType networking_v1.PathType, service *Service, clientCertSecret *Secret, serviceName string, servicePort int32, log logrus.FieldLogger) (*Route, error) {
 	log = log.WithFields(logrus.Fields{
@@ -352,18 +396,6 @@ func (p *IngressProcessor) route(ingress *networking_v1.Ingress, host, path stri
 	return r, nil
 }
 
-// rulesFromSpec merges the IngressSpec's Rules with a synthetic
-// rule representing the default backend.
-// Prepend the default backend so it can be overridden by later rules.
-func rulesFromSpec(spec networking_v1.IngressSpec) []networking_v1.IngressRule {
-	rules := spec.Rules
-	if backend := spec.DefaultBackend; backend != nil {
-		rule := defaultBackendRule(backend)
-		rules = append([]networking_v1.IngressRule{rule}, rules...)
-	}
-	return rules
-}
-
 // defaultBackendRule returns an IngressRule that represents the IngressBackend.
 func defaultBackendRule(be *networking_v1.IngressBackend) networking_v1.IngressRule {
 	return networking_v1.IngressRule{
@@ -18,9 +18,70 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/fixture"
 )
 
+func TestIngressProcessorErrorSink(t *testing.T) {
+	ingress := &networking_v1.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ingress",
+			Namespace: "default",
+		},
+		Spec: networking_v1.IngressSpec{
+			TLS: []networking_v1.IngressTLS{{
+				Hosts:      []string{"example.com"},
+				SecretName: "missing",
+			}},
+			Rules: []networking_v1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: networking_v1.IngressRuleValue{
+					HTTP: &networking_v1.HTTPIngressRuleValue{
+						Paths: []networking_v1.HTTPIngressPath{{
+							Backend: *backendv1("missing", intstr.FromInt(80)),
+						}},
+					},
+				},
+			}},
+		},
+	}
+
+	var got []IngressError
+	builder := Builder{
+		Source: KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		},
+		Processors: []Processor{
+			&ListenerProcessor{},
+			&IngressProcessor{
+				FieldLogger: fixture.NewTestLogger(t),
+				ErrorSink: func(e IngressError) {
+					got = append(got, e)
+				},
+			},
+		},
+	}
+	builder.Source.Insert(ingress)
+	builder.Build()
+
+	name := types.NamespacedName{Namespace: "default", Name: "ingress"}
+	assert.Equal(t, []IngressError{{
+		Ingress: name,
+		Type:    contour_v1.ConditionTypeTLSError,
+		Reason:  "SecretNotValid",
+		Message: `unresolved secret reference: Secret not found`,
+	}, {
+		Ingress: name,
+		Type:    contour_v1.ConditionTypeServiceError,
+		Reason:  "ServiceUnresolvedReference",
+		Message: `unresolved service reference: service "default/missing" not found`,
+	}}, got)
+}
+
 func TestHttpPaths(t *testing.T) {
 	tests := map[string]struct {
 		rule networking_v1.IngressRule
@@ -45,6 +45,7 @@ func (p *ListenerProcessor) Run(dag *DAG, cache *KubernetesCache) {
 				Protocol:         port.Protocol,
 				Address:          address,
 				Port:             int(port.ContainerPort),
+				ServicePort:      int(port.Port),
 				EnableWebsockets: true,
 				vhostsByName:     map[string]*VirtualHost{},
 				svhostsByName:    map[string]*SecureVirtualHost{},
@@ -563,6 +563,156 @@ func prefixReplacementsAreValid(replacements []contour_v1.ReplacePrefix) (string
 	return "", nil
 }
 
+// failoverPolicy validates the Service priorities of the given route and
+// returns true if the route should fail over between its Services in
+// priority order.
+func failoverPolicy(route *contour_v1.Route) (bool, error) {
+	failover := false
+	for _, service := range route.Services {
+		if service.Priority == 0 {
+			continue
+		}
+		if service.Mirror {
+			return false, fmt.Errorf("service %q: priority can not be set on mirror services", service.Name)
+		}
+		failover = true
+	}
+
+	if !failover {
+		return false, nil
+	}
+
+	if len(route.CookieRewritePolicies) > 0 {
+		return false, errors.New("cookieRewritePolicies can not be combined with service priorities")
+	}
+
+	priorities := map[uint32]string{}
+	for _, service := range route.Services {
+		if service.Mirror {
+			continue
+		}
+		if other, ok := priorities[service.Priority]; ok {
+			return false, fmt.Errorf("services %q and %q have the same priority %d", other, service.Name, service.Priority)
+		}
+		priorities[service.Priority] = service.Name
+
+		if service.Weight > 0 {
+			return false, fmt.Errorf("service %q: weight can not be combined with priority", service.Name)
+		}
+		if service.RequestHeadersPolicy != nil || service.ResponseHeadersPolicy != nil || len(service.CookieRewritePolicies) > 0 {
+			return false, fmt.Errorf("service %q: header and cookie rewrite policies can not be combined with priority", service.Name)
+		}
+		if service.Subset != nil {
+			return false, fmt.Errorf("service %q: subset can not be combined with priority", service.Name)
+		}
+	}
+
+	return true, nil
+}
+
+// outlierDetectionPolicy converts and validates an outlier detection
+// policy, applying the defaults for unset fields.
+func outlierDetectionPolicy(in *contour_v1.OutlierDetectionPolicy) (*OutlierDetectionPolicy, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	res := &OutlierDetectionPolicy{
+		ConsecutiveServerErrors:  ptr.Deref(in.ConsecutiveServerErrors, 5),
+		ConsecutiveGatewayErrors: ptr.Deref(in.ConsecutiveGatewayErrors, 0),
+		BaseEjectionTime:         30 * time.Second,
+		MaxEjectionPercent:       ptr.Deref(in.MaxEjectionPercent, 10),
+	}
+
+	if in.BaseEjectionTime != "" {
+		d, err := time.ParseDuration(in.BaseEjectionTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base ejection time %q: %w", in.BaseEjectionTime, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid base ejection time %q, must be greater than zero", in.BaseEjectionTime)
+		}
+		res.BaseEjectionTime = d
+	}
+
+	if res.MaxEjectionPercent > 100 {
+		return nil, fmt.Errorf("invalid max ejection percent %d, must be between 0 and 100", res.MaxEjectionPercent)
+	}
+
+	return res, nil
+}
+
+// faultInjectionPolicy converts and validates the fault injection policy
+// of a route.
+func faultInjectionPolicy(in *contour_v1.FaultInjectionPolicy) (*FaultInjectionPolicy, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	if in.Delay == nil && in.Abort == nil {
+		return nil, errors.New("at least one of delay or abort must be specified")
+	}
+
+	percentage := func(p *int32) (uint32, error) {
+		if p == nil {
+			return 100, nil
+		}
+		if *p < 0 || *p > 100 {
+			return 0, fmt.Errorf("invalid percentage %d, must be between 0 and 100", *p)
+		}
+		return uint32(*p), nil
+	}
+
+	res := &FaultInjectionPolicy{}
+
+	if in.Delay != nil {
+		duration, err := time.ParseDuration(in.Delay.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delay duration %q: %w", in.Delay.Duration, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("invalid delay duration %q, must be greater than zero", in.Delay.Duration)
+		}
+
+		pct, err := percentage(in.Delay.Percentage)
+		if err != nil {
+			return nil, fmt.Errorf("delay: %w", err)
+		}
+
+		res.Delay = &FaultDelay{
+			Duration:   duration,
+			Percentage: pct,
+		}
+	}
+
+	if in.Abort != nil {
+		if in.Abort.StatusCode < 200 || in.Abort.StatusCode > 599 {
+			return nil, fmt.Errorf("invalid abort status code %d, must be between 200 and 599", in.Abort.StatusCode)
+		}
+
+		pct, err := percentage(in.Abort.Percentage)
+		if err != nil {
+			return nil, fmt.Errorf("abort: %w", err)
+		}
+
+		res.Abort = &FaultAbort{
+			StatusCode: uint32(in.Abort.StatusCode),
+			Percentage: pct,
+		}
+	}
+
+	for _, cond := range in.Headers {
+		if len(cond.Regex) > 0 {
+			if err := ValidateRegex(cond.Regex); err != nil {
+				return nil, fmt.Errorf("invalid header condition: %w", err)
+			}
+		}
+	}
+	res.HeaderMatchConditions = headerMatchConditions(in.Headers)
+
+	return res, nil
+}
+
 func rateLimitPolicy(in *contour_v1.RateLimitPolicy) (*RateLimitPolicy, error) {
 	if in == nil || (in.Local == nil && (in.Global == nil || len(in.Global.Descriptors) == 0)) {
 		return nil, nil
@@ -835,3 +985,18 @@ func serviceCircuitBreakerPolicy(s *Service, cb *contour_v1alpha1.CircuitBreaker
 
 	return s
 }
+
+// mirrorFraction validates the fraction of requests to mirror, given
+// as numerator/denominator, and returns it as a MirrorPolicy weight and
+// denominator. If denominator is nil it defaults to 100. field is the
+// name of the fraction in the source object and is used in errors.
+func mirrorFraction(field string, numerator int32, denominator *int32) (int64, int64, error) {
+	d := ptr.Deref(denominator, 100)
+	if d <= 0 {
+		return 0, 0, fmt.Errorf("invalid %s.denominator %d: must be greater than 0", field, d)
+	}
+	if numerator < 0 || numerator > d {
+		return 0, 0, fmt.Errorf("invalid %s.numerator %d: must be between 0 and %s.denominator", field, numerator, field)
+	}
+	return int64(numerator), int64(d), nil
+}
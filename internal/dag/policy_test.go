@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	networking_v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -1009,6 +1010,200 @@ func TestRateLimitPolicy(t *testing.T) {
 	}
 }
 
+func TestOutlierDetectionPolicy(t *testing.T) {
+	tests := map[string]struct {
+		in      *contour_v1.OutlierDetectionPolicy
+		want    *OutlierDetectionPolicy
+		wantErr string
+	}{
+		"nil input": {
+			in:   nil,
+			want: nil,
+		},
+		"defaults": {
+			in: &contour_v1.OutlierDetectionPolicy{},
+			want: &OutlierDetectionPolicy{
+				ConsecutiveServerErrors: 5,
+				BaseEjectionTime:        30 * time.Second,
+				MaxEjectionPercent:      10,
+			},
+		},
+		"all fields set": {
+			in: &contour_v1.OutlierDetectionPolicy{
+				ConsecutiveServerErrors:  ptr.To(uint32(0)),
+				ConsecutiveGatewayErrors: ptr.To(uint32(3)),
+				BaseEjectionTime:         "1m",
+				MaxEjectionPercent:       ptr.To(uint32(100)),
+			},
+			want: &OutlierDetectionPolicy{
+				ConsecutiveServerErrors:  0,
+				ConsecutiveGatewayErrors: 3,
+				BaseEjectionTime:         time.Minute,
+				MaxEjectionPercent:       100,
+			},
+		},
+		"invalid base ejection time": {
+			in: &contour_v1.OutlierDetectionPolicy{
+				BaseEjectionTime: "soon",
+			},
+			wantErr: `invalid base ejection time "soon": time: invalid duration "soon"`,
+		},
+		"zero base ejection time": {
+			in: &contour_v1.OutlierDetectionPolicy{
+				BaseEjectionTime: "0s",
+			},
+			wantErr: `invalid base ejection time "0s", must be greater than zero`,
+		},
+		"invalid max ejection percent": {
+			in: &contour_v1.OutlierDetectionPolicy{
+				MaxEjectionPercent: ptr.To(uint32(101)),
+			},
+			wantErr: "invalid max ejection percent 101, must be between 0 and 100",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := outlierDetectionPolicy(tc.in)
+
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFaultInjectionPolicy(t *testing.T) {
+	tests := map[string]struct {
+		in      *contour_v1.FaultInjectionPolicy
+		want    *FaultInjectionPolicy
+		wantErr string
+	}{
+		"nil input": {
+			in:   nil,
+			want: nil,
+		},
+		"no delay or abort": {
+			in:      &contour_v1.FaultInjectionPolicy{},
+			wantErr: "at least one of delay or abort must be specified",
+		},
+		"delay with default percentage": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration: "2s",
+				},
+			},
+			want: &FaultInjectionPolicy{
+				Delay: &FaultDelay{
+					Duration:   2 * time.Second,
+					Percentage: 100,
+				},
+			},
+		},
+		"delay and abort with percentages and headers": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration:   "500ms",
+					Percentage: ptr.To(int32(10)),
+				},
+				Abort: &contour_v1.FaultAbort{
+					StatusCode: 503,
+					Percentage: ptr.To(int32(0)),
+				},
+				Headers: []contour_v1.HeaderMatchCondition{{
+					Name:  "x-fault",
+					Exact: "true",
+				}},
+			},
+			want: &FaultInjectionPolicy{
+				Delay: &FaultDelay{
+					Duration:   500 * time.Millisecond,
+					Percentage: 10,
+				},
+				Abort: &FaultAbort{
+					StatusCode: 503,
+					Percentage: 0,
+				},
+				HeaderMatchConditions: []HeaderMatchCondition{{
+					Name:      "x-fault",
+					Value:     "true",
+					MatchType: HeaderMatchTypeExact,
+				}},
+			},
+		},
+		"invalid delay duration": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration: "forever",
+				},
+			},
+			wantErr: `invalid delay duration "forever": time: invalid duration "forever"`,
+		},
+		"zero delay duration": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration: "0s",
+				},
+			},
+			wantErr: `invalid delay duration "0s", must be greater than zero`,
+		},
+		"invalid delay percentage": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration:   "1s",
+					Percentage: ptr.To(int32(101)),
+				},
+			},
+			wantErr: "delay: invalid percentage 101, must be between 0 and 100",
+		},
+		"invalid abort status code": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Abort: &contour_v1.FaultAbort{
+					StatusCode: 100,
+				},
+			},
+			wantErr: "invalid abort status code 100, must be between 200 and 599",
+		},
+		"invalid abort percentage": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Abort: &contour_v1.FaultAbort{
+					StatusCode: 500,
+					Percentage: ptr.To(int32(-1)),
+				},
+			},
+			wantErr: "abort: invalid percentage -1, must be between 0 and 100",
+		},
+		"invalid header regex": {
+			in: &contour_v1.FaultInjectionPolicy{
+				Abort: &contour_v1.FaultAbort{
+					StatusCode: 500,
+				},
+				Headers: []contour_v1.HeaderMatchCondition{{
+					Name:  "x-fault",
+					Regex: "^[",
+				}},
+			},
+			wantErr: "invalid header condition: error parsing regexp: missing closing ]: `[`",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := faultInjectionPolicy(tc.in)
+
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
 func TestValidateHeaderAlteration(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1477,3 +1672,167 @@ func TestHeadersPolicyRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestFailoverPolicy(t *testing.T) {
+	tests := map[string]struct {
+		in      *contour_v1.Route
+		want    bool
+		wantErr string
+	}{
+		"no priorities": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80, Weight: 10},
+					{Name: "b", Port: 80, Weight: 90},
+				},
+			},
+			want: false,
+		},
+		"priorities": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80},
+					{Name: "b", Port: 80, Priority: 1},
+					{Name: "mirror", Port: 80, Mirror: true},
+				},
+			},
+			want: true,
+		},
+		"duplicate priority": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80, Priority: 1},
+					{Name: "b", Port: 80, Priority: 1},
+				},
+			},
+			wantErr: `services "a" and "b" have the same priority 1`,
+		},
+		"priority on mirror": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80},
+					{Name: "b", Port: 80, Mirror: true, Priority: 1},
+				},
+			},
+			wantErr: `service "b": priority can not be set on mirror services`,
+		},
+		"priority with weight": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80, Weight: 50},
+					{Name: "b", Port: 80, Priority: 1},
+				},
+			},
+			wantErr: `service "a": weight can not be combined with priority`,
+		},
+		"priority with headers policy": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80},
+					{
+						Name:     "b",
+						Port:     80,
+						Priority: 1,
+						RequestHeadersPolicy: &contour_v1.HeadersPolicy{
+							Remove: []string{"x-header"},
+						},
+					},
+				},
+			},
+			wantErr: `service "b": header and cookie rewrite policies can not be combined with priority`,
+		},
+		"priority with route cookie rewrite": {
+			in: &contour_v1.Route{
+				CookieRewritePolicies: []contour_v1.CookieRewritePolicy{{Name: "cookie", Secure: ptr.To(true)}},
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80},
+					{Name: "b", Port: 80, Priority: 1},
+				},
+			},
+			wantErr: "cookieRewritePolicies can not be combined with service priorities",
+		},
+		"priority with subset": {
+			in: &contour_v1.Route{
+				Services: []contour_v1.Service{
+					{Name: "a", Port: 80},
+					{
+						Name:     "b",
+						Port:     80,
+						Priority: 1,
+						Subset: &contour_v1.SubsetSelector{
+							MatchLabels: map[string]string{"version": "v1"},
+						},
+					},
+				},
+			},
+			wantErr: `service "b": subset can not be combined with priority`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := failoverPolicy(tc.in)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMirrorFraction(t *testing.T) {
+	tests := map[string]struct {
+		numerator       int32
+		denominator     *int32
+		wantWeight      int64
+		wantDenominator int64
+		wantErr         string
+	}{
+		"default denominator": {
+			numerator:       25,
+			wantWeight:      25,
+			wantDenominator: 100,
+		},
+		"explicit denominator": {
+			numerator:       1,
+			denominator:     ptr.To(int32(1000)),
+			wantWeight:      1,
+			wantDenominator: 1000,
+		},
+		"zero numerator": {
+			numerator:       0,
+			denominator:     ptr.To(int32(10)),
+			wantWeight:      0,
+			wantDenominator: 10,
+		},
+		"zero denominator": {
+			numerator:   0,
+			denominator: ptr.To(int32(0)),
+			wantErr:     "invalid fraction.denominator 0: must be greater than 0",
+		},
+		"negative numerator": {
+			numerator: -1,
+			wantErr:   "invalid fraction.numerator -1: must be between 0 and fraction.denominator",
+		},
+		"numerator greater than denominator": {
+			numerator:   11,
+			denominator: ptr.To(int32(10)),
+			wantErr:     "invalid fraction.numerator 11: must be between 0 and fraction.denominator",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			weight, denominator, err := mirrorFraction("fraction", tc.numerator, tc.denominator)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWeight, weight)
+			assert.Equal(t, tc.wantDenominator, denominator)
+		})
+	}
+}
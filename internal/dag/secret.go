@@ -16,6 +16,7 @@ package dag
 import (
 	"bytes"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -30,6 +31,15 @@ const (
 
 	// CRLKey is the key name for accessing CRL bundles in Kubernetes Secrets.
 	CRLKey = "crl.pem"
+
+	// OAuth2ClientSecretKey is the key name for accessing OAuth2 client secrets in Kubernetes Secrets.
+	OAuth2ClientSecretKey = "client-secret"
+
+	// OAuth2HMACSecretKey is the key name for accessing OAuth2 cookie signing secrets in Kubernetes Secrets.
+	OAuth2HMACSecretKey = "hmac-secret"
+
+	// BasicAuthKey is the key name for accessing htpasswd users in Kubernetes Secrets.
+	BasicAuthKey = "auth"
 )
 
 // validTLSSecret returns an error if the Secret is not of type TLS or Opaque or
@@ -97,6 +107,80 @@ func validCRLSecret(secret *core_v1.Secret) error {
 	return nil
 }
 
+// validOAuth2Secret returns an error if the Secret is not of type Opaque or
+// if it doesn't contain a client secret and an HMAC secret.
+func validOAuth2Secret(secret *core_v1.Secret) error {
+	if secret.Type != core_v1.SecretTypeOpaque {
+		return fmt.Errorf("secret type is not %q", core_v1.SecretTypeOpaque)
+	}
+
+	if len(secret.Data[OAuth2ClientSecretKey]) == 0 {
+		return fmt.Errorf("empty %q key", OAuth2ClientSecretKey)
+	}
+
+	if len(secret.Data[OAuth2HMACSecretKey]) == 0 {
+		return fmt.Errorf("empty %q key", OAuth2HMACSecretKey)
+	}
+
+	return nil
+}
+
+// validBasicAuthSecret returns an error if the Secret is not of type Opaque
+// or if it doesn't contain htpasswd users that Envoy can load. Envoy only
+// supports SHA password hashes, so each non-empty line must have the form
+// "user:{SHA}<base64 digest>".
+func validBasicAuthSecret(secret *core_v1.Secret) error {
+	if secret.Type != core_v1.SecretTypeOpaque {
+		return fmt.Errorf("secret type is not %q", core_v1.SecretTypeOpaque)
+	}
+
+	data := secret.Data[BasicAuthKey]
+	if len(data) == 0 {
+		return fmt.Errorf("empty %q key", BasicAuthKey)
+	}
+
+	var errs []string
+	users := map[string]bool{}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		// Tolerate htpasswd files with CRLF line endings.
+		line = strings.TrimSuffix(line, "\r")
+		if len(line) == 0 {
+			continue
+		}
+
+		user, hash, found := strings.Cut(line, ":")
+		switch {
+		case !found || len(user) == 0:
+			errs = append(errs, fmt.Sprintf("line %d: expected \"user:password\"", i+1))
+		case !strings.HasPrefix(hash, "{SHA}"):
+			errs = append(errs, fmt.Sprintf("line %d: unsupported password hash, only {SHA} is supported", i+1))
+		case !validSHAHash(strings.TrimPrefix(hash, "{SHA}")):
+			errs = append(errs, fmt.Sprintf("line %d: invalid {SHA} password hash", i+1))
+		case users[user]:
+			errs = append(errs, fmt.Sprintf("line %d: duplicate user %q", i+1, user))
+		}
+
+		users[user] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid htpasswd entries: %s", strings.Join(errs, "; "))
+	}
+
+	if len(users) == 0 {
+		return errors.New("no htpasswd users")
+	}
+
+	return nil
+}
+
+// validSHAHash returns true if s is a base64 encoded SHA-1 digest.
+func validSHAHash(s string) bool {
+	digest, err := base64.StdEncoding.DecodeString(s)
+	return err == nil && len(digest) == 20
+}
+
 // containsPEMHeader returns true if the given slice contains a string
 // that looks like a PEM header block. The problem is that pem.Decode
 // does not give us a way to distinguish between a missing PEM block
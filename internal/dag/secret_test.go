@@ -16,6 +16,7 @@ package dag
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -246,6 +247,132 @@ func TestValidSecrets(t *testing.T) {
 	}
 }
 
+func TestValidOAuth2Secret(t *testing.T) {
+	tests := map[string]struct {
+		secret *core_v1.Secret
+		want   error
+	}{
+		"valid": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					OAuth2ClientSecretKey: []byte("client"),
+					OAuth2HMACSecretKey:   []byte("hmac"),
+				},
+			},
+		},
+		"TLS Secret": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeTLS,
+				Data: map[string][]byte{
+					OAuth2ClientSecretKey: []byte("client"),
+					OAuth2HMACSecretKey:   []byte("hmac"),
+				},
+			},
+			want: errors.New(`secret type is not "Opaque"`),
+		},
+		"missing client secret": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					OAuth2HMACSecretKey: []byte("hmac"),
+				},
+			},
+			want: errors.New(`empty "client-secret" key`),
+		},
+		"missing HMAC secret": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					OAuth2ClientSecretKey: []byte("client"),
+				},
+			},
+			want: errors.New(`empty "hmac-secret" key`),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, validOAuth2Secret(tc.secret))
+		})
+	}
+}
+
+func TestValidBasicAuthSecret(t *testing.T) {
+	tests := map[string]struct {
+		secret *core_v1.Secret
+		want   error
+	}{
+		"valid": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					BasicAuthKey: []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"),
+				},
+			},
+		},
+		"valid with CRLF line endings": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					BasicAuthKey: []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\r\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\r\n"),
+				},
+			},
+		},
+		"TLS Secret": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeTLS,
+				Data: map[string][]byte{
+					BasicAuthKey: []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="),
+				},
+			},
+			want: errors.New(`secret type is not "Opaque"`),
+		},
+		"missing auth key": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"users": []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="),
+				},
+			},
+			want: errors.New(`empty "auth" key`),
+		},
+		"no users": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					BasicAuthKey: []byte("\n\n"),
+				},
+			},
+			want: errors.New("no htpasswd users"),
+		},
+		"malformed entries": {
+			secret: &core_v1.Secret{
+				Type: core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					BasicAuthKey: []byte(strings.Join([]string{
+						"alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+						"bob",
+						"carol:$apr1$salt$hash",
+						"dave:{SHA}short",
+						"alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+					}, "\n")),
+				},
+			},
+			want: errors.New(`invalid htpasswd entries: line 2: expected "user:password"; ` +
+				`line 3: unsupported password hash, only {SHA} is supported; ` +
+				`line 4: invalid {SHA} password hash; ` +
+				`line 5: duplicate user "alice"`),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, validBasicAuthSecret(tc.secret))
+		})
+	}
+}
+
 func secretdata(cert, key string) map[string][]byte {
 	return map[string][]byte{
 		core_v1.TLSCertKey:       []byte(cert),
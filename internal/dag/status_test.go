@@ -1143,7 +1143,7 @@ func TestDAGStatus(t *testing.T) {
 		},
 	})
 
-	proxyInvalidTwoMirrors := &contour_v1.HTTPProxy{
+	proxyValidTwoMirrors := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "www",
 			Namespace: fixture.ServiceRootsKuard.Namespace,
@@ -1160,21 +1160,93 @@ func TestDAGStatus(t *testing.T) {
 					Name:   fixture.ServiceRootsKuard.Name,
 					Port:   8080,
 					Mirror: true,
+					Weight: 5,
 				}, {
 					Name:   fixture.ServiceRootsKuard.Name,
 					Port:   8080,
 					Mirror: true,
+					MirrorFraction: &contour_v1.MirrorFraction{
+						Numerator:   1,
+						Denominator: ptr.To(int32(1000)),
+					},
 				}},
 			}},
 		},
 	}
 
 	run(t, "proxy with two mirrors", testcase{
-		objs: []any{proxyInvalidTwoMirrors, fixture.ServiceRootsKuard},
+		objs: []any{proxyValidTwoMirrors, fixture.ServiceRootsKuard},
+		want: map[types.NamespacedName]contour_v1.DetailedCondition{
+			{Name: proxyValidTwoMirrors.Name, Namespace: proxyValidTwoMirrors.Namespace}: fixture.NewValidCondition().
+				WithGeneration(proxyValidTwoMirrors.Generation).
+				Valid(),
+		},
+	})
+
+	proxyInvalidMirrorFraction := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "www",
+			Namespace: fixture.ServiceRootsKuard.Namespace,
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: fixture.ServiceRootsKuard.Name,
+					Port: 8080,
+				}, {
+					Name:   fixture.ServiceRootsKuard.Name,
+					Port:   8080,
+					Mirror: true,
+					MirrorFraction: &contour_v1.MirrorFraction{
+						Numerator:   200,
+						Denominator: ptr.To(int32(100)),
+					},
+				}},
+			}},
+		},
+	}
+
+	run(t, "proxy with invalid mirror fraction", testcase{
+		objs: []any{proxyInvalidMirrorFraction, fixture.ServiceRootsKuard},
+		want: map[types.NamespacedName]contour_v1.DetailedCondition{
+			{Name: proxyInvalidMirrorFraction.Name, Namespace: proxyInvalidMirrorFraction.Namespace}: fixture.NewValidCondition().
+				WithGeneration(proxyInvalidMirrorFraction.Generation).
+				WithError(contour_v1.ConditionTypeServiceError, "MirrorNotValid",
+					`service "kuard": invalid mirrorFraction.numerator 200: must be between 0 and mirrorFraction.denominator`),
+		},
+	})
+
+	proxyMirrorFractionWithoutMirror := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "www",
+			Namespace: fixture.ServiceRootsKuard.Namespace,
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: fixture.ServiceRootsKuard.Name,
+					Port: 8080,
+					MirrorFraction: &contour_v1.MirrorFraction{
+						Numerator: 5,
+					},
+				}},
+			}},
+		},
+	}
+
+	run(t, "proxy with mirror fraction on a non-mirror service", testcase{
+		objs: []any{proxyMirrorFractionWithoutMirror, fixture.ServiceRootsKuard},
 		want: map[types.NamespacedName]contour_v1.DetailedCondition{
-			{Name: proxyInvalidTwoMirrors.Name, Namespace: proxyInvalidTwoMirrors.Namespace}: fixture.NewValidCondition().
-				WithGeneration(proxyInvalidTwoMirrors.Generation).
-				WithError(contour_v1.ConditionTypeServiceError, "OnlyOneMirror", "only one service per route may be nominated as mirror"),
+			{Name: proxyMirrorFractionWithoutMirror.Name, Namespace: proxyMirrorFractionWithoutMirror.Namespace}: fixture.NewValidCondition().
+				WithGeneration(proxyMirrorFractionWithoutMirror.Generation).
+				WithError(contour_v1.ConditionTypeServiceError, "MirrorNotValid",
+					`service "kuard": mirrorFraction may only be set when mirror is true`),
 		},
 	})
 
@@ -3432,6 +3504,43 @@ func TestDAGStatus(t *testing.T) {
 		},
 	})
 
+	invalidRouteAllowOrigin := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: fixture.ServiceRootsKuard.Namespace,
+			Name:      "invalid-route-alloworigin",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_v1.Route{
+				{
+					CORSPolicy: &contour_v1.CORSPolicy{
+						AllowOrigin:  []string{"**"},
+						AllowMethods: []contour_v1.CORSHeaderValue{"GET"},
+					},
+					Services: []contour_v1.Service{
+						{
+							Name: fixture.ServiceRootsKuard.Name,
+							Port: 8080,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	run(t, "proxy with invalid route allow origin is invalid", testcase{
+		objs: []any{invalidRouteAllowOrigin, fixture.ServiceRootsKuard},
+		want: map[types.NamespacedName]contour_v1.DetailedCondition{
+			{
+				Name:      invalidRouteAllowOrigin.Name,
+				Namespace: invalidRouteAllowOrigin.Namespace,
+			}: fixture.NewValidCondition().WithError(contour_v1.ConditionTypeCORSError, "PolicyDidNotParse",
+				`route.corsPolicy is invalid: invalid allowed origin "**": allowed origin is invalid exact match and invalid regex match`),
+		},
+	})
+
 	jwtVerificationValidProxy := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Namespace: "roots",
@@ -4651,6 +4760,46 @@ func TestDAGStatus(t *testing.T) {
 		},
 	})
 
+	// proxyWithInvalidSessionPersistence is invalid because a header based
+	// session cannot have a cookie TTL.
+	proxyWithInvalidSessionPersistence := &contour_v1.HTTPProxy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: "roots",
+			Name:      "session-persistence-invalid",
+		},
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "www.example.com",
+			},
+			Routes: []contour_v1.Route{{
+				SessionPersistence: &contour_v1.SessionPersistence{
+					Type:      contour_v1.HeaderSessionPersistence,
+					Name:      "x-session",
+					CookieTTL: "1h",
+				},
+				Services: []contour_v1.Service{{
+					Name: "home",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	run(t, "Session persistence with cookie TTL on header type", testcase{
+		objs: []any{
+			proxyWithInvalidSessionPersistence,
+			fixture.ServiceRootsHome,
+		},
+		want: map[types.NamespacedName]contour_v1.DetailedCondition{
+			k8s.NamespacedNameOf(proxyWithInvalidSessionPersistence): fixture.NewValidCondition().
+				WithError(
+					contour_v1.ConditionTypeRouteError,
+					"SessionPersistenceNotValid",
+					"route.sessionPersistence is invalid: cookieTTL cannot be specified for type \"Header\"",
+				),
+		},
+	})
+
 	// Invalid, Regex is in include match condition block
 	proxyRegexIncludeInvalid := &contour_v1.HTTPProxy{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -5201,6 +5350,13 @@ func validGatewayStatusUpdate(listenerName string, listenerProtocol gatewayapi_v
 				Kind:  KindTCPRoute,
 			},
 		)
+	case gatewayapi_v1.UDPProtocolType:
+		supportedKinds = append(supportedKinds,
+			gatewayapi_v1.RouteGroupKind{
+				Group: ptr.To(gatewayapi_v1.Group(gatewayapi_v1.GroupName)),
+				Kind:  KindUDPRoute,
+			},
+		)
 	}
 
 	return []*status.GatewayStatusUpdate{
@@ -8099,6 +8255,161 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
 	})
 
+	run(t, "Invalid RequestMirror filter due to cross-namespace backendRef without a ReferenceGrant", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1.HTTPRouteFilter{{
+							Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi_v1.BackendObjectReference{
+									Group:     ptr.To(gatewayapi_v1.Group("")),
+									Kind:      ptr.To(gatewayapi_v1.Kind("Service")),
+									Namespace: ptr.To(gatewayapi_v1.Namespace("projectcontour")),
+									Name:      gatewayapi_v1.ObjectName("kuard2"),
+									Port:      ptr.To(gatewayapi_v1.PortNumber(8080)),
+								},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						resolvedRefsFalse(gatewayapi_v1.RouteReasonRefNotPermitted, "Spec.Rules.Filters.RequestMirror.BackendRef.Namespace must match the route's namespace or be covered by a ReferenceGrant"),
+						routeAcceptedHTTPRouteCondition(),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "Invalid RequestMirror filter due to both percent and fraction specified", testcase{
+		objs: []any{
+			kuardService,
+			kuardService2,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1.HTTPRouteFilter{{
+							Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+								Percent:    ptr.To(int32(50)),
+								Fraction: &gatewayapi_v1.Fraction{
+									Numerator: 50,
+								},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						{
+							Type:    string(gatewayapi_v1.RouteConditionAccepted),
+							Status:  contour_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1.RouteReasonUnsupportedValue),
+							Message: "HTTPRoute.Spec.Rules.Filters.RequestMirror: only one of Percent or Fraction may be specified",
+						},
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "Invalid RequestMirror filter due to fraction numerator greater than denominator", testcase{
+		objs: []any{
+			kuardService,
+			kuardService2,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1.HTTPRouteFilter{{
+							Type: gatewayapi_v1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+								Fraction: &gatewayapi_v1.Fraction{
+									Numerator:   11,
+									Denominator: ptr.To(int32(10)),
+								},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						{
+							Type:    string(gatewayapi_v1.RouteConditionAccepted),
+							Status:  contour_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1.RouteReasonUnsupportedValue),
+							Message: "HTTPRoute.Spec.Rules.Filters.RequestMirror: invalid Fraction.numerator 11: must be between 0 and Fraction.denominator",
+						},
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
 	run(t, "Invalid RequestMirror filter due to invalid backendRef.name on two matches", testcase{
 		objs: []any{
 			kuardService,
@@ -8545,7 +8856,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
 					Conditions: []meta_v1.Condition{
 						routeResolvedRefsCondition(),
-						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.Filters: invalid type \"custom-filter\": only RequestHeaderModifier, ResponseHeaderModifier, RequestRedirect, RequestMirror and URLRewrite are supported."),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.Filters: invalid type \"custom-filter\": only RequestHeaderModifier, ResponseHeaderModifier, RequestRedirect, RequestMirror, URLRewrite and CORS are supported."),
 					},
 				},
 			},
@@ -8562,7 +8873,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				Namespace: "projectcontour",
 			},
 			Spec: gatewayapi_v1.GatewaySpec{
-				Addresses: []gatewayapi_v1.GatewayAddress{{
+				Addresses: []gatewayapi_v1.GatewaySpecAddress{{
 					Value: "1.2.3.4",
 				}},
 				Listeners: []gatewayapi_v1.Listener{{
@@ -8718,7 +9029,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 							Type:    string(gatewayapi_v1.ListenerConditionResolvedRefs),
 							Status:  meta_v1.ConditionFalse,
 							Reason:  string(gatewayapi_v1.ListenerReasonInvalidRouteKinds),
-							Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\", \"TLSRoute\", \"GRPCRoute\" or \"TCPRoute\"",
+							Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\", \"TLSRoute\", \"GRPCRoute\", \"TCPRoute\" or \"UDPRoute\"",
 						},
 					},
 				},
@@ -8972,7 +9283,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 							Type:    string(gatewayapi_v1.ListenerConditionAccepted),
 							Status:  meta_v1.ConditionFalse,
 							Reason:  string(gatewayapi_v1.ListenerReasonUnsupportedProtocol),
-							Message: "Listener protocol \"invalid\" is unsupported, must be one of HTTP, HTTPS, TLS, TCP or projectcontour.io/https",
+							Message: "Listener protocol \"invalid\" is unsupported, must be one of HTTP, HTTPS, TLS, TCP, UDP or projectcontour.io/https",
 						},
 						listenerResolvedRefsCondition(),
 					},
@@ -9637,7 +9948,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 								Type:    string(gatewayapi_v1.ListenerConditionResolvedRefs),
 								Status:  meta_v1.ConditionFalse,
 								Reason:  string(gatewayapi_v1.ListenerReasonInvalidRouteKinds),
-								Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\", \"TLSRoute\", \"GRPCRoute\" or \"TCPRoute\"",
+								Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\", \"TLSRoute\", \"GRPCRoute\", \"TCPRoute\" or \"UDPRoute\"",
 							},
 							{
 								Type:    string(gatewayapi_v1.ListenerConditionProgrammed),
@@ -9778,15 +10089,222 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		}},
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
 	})
-}
 
-func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
-	type testcase struct {
-		objs                    []any
-		gateway                 *gatewayapi_v1.Gateway
-		wantRouteConditions     []*status.RouteStatusUpdate
-		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
-	}
+	run(t, "retry with invalid backoff for httproute", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Retry: &gatewayapi_v1.HTTPRouteRetry{
+							Backoff: ptr.To(gatewayapi_v1.Duration("invalid")),
+						},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "invalid HTTPRoute.Spec.Rules.Retry.Backoff: time: invalid duration \"invalid\""),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "CORS filter with wildcard methods and credentials for httproute", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1.HTTPRouteFilter{{
+							Type: gatewayapi_v1.HTTPRouteFilterCORS,
+							CORS: &gatewayapi_v1.HTTPCORSFilter{
+								AllowOrigins:     []gatewayapi_v1.AbsoluteURI{"https://www.example.com"},
+								AllowCredentials: true,
+								AllowMethods:     []gatewayapi_v1.HTTPMethodWithWildcard{"*"},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.Filters.CORS.AllowMethods: wildcard is not supported when AllowCredentials is true"),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "CORS filter with wildcard headers and credentials for httproute", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1.HTTPRouteFilter{{
+							Type: gatewayapi_v1.HTTPRouteFilterCORS,
+							CORS: &gatewayapi_v1.HTTPCORSFilter{
+								AllowOrigins:     []gatewayapi_v1.AbsoluteURI{"https://www.example.com"},
+								AllowCredentials: true,
+								AllowHeaders:     []gatewayapi_v1.HTTPHeaderName{"*"},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.Filters.CORS.AllowHeaders: wildcard is not supported when AllowCredentials is true"),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "session persistence with idle timeout for httproute", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						SessionPersistence: &gatewayapi_v1.SessionPersistence{
+							IdleTimeout: ptr.To(gatewayapi_v1.Duration("10m")),
+						},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.SessionPersistence.IdleTimeout is not supported"),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+
+	run(t, "session persistence with permanent cookie and no absolute timeout for httproute", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1.HTTPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1.HTTPRouteRule{{
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						SessionPersistence: &gatewayapi_v1.SessionPersistence{
+							CookieConfig: &gatewayapi_v1.CookieConfig{
+								LifetimeType: ptr.To(gatewayapi_v1.PermanentCookieLifetimeType),
+							},
+						},
+					}},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						routeAcceptedFalse(gatewayapi_v1.RouteReasonUnsupportedValue, "HTTPRoute.Spec.Rules.SessionPersistence.AbsoluteTimeout must be specified when CookieConfig.LifetimeType is \"Permanent\""),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", gatewayapi_v1.HTTPProtocolType, 1),
+	})
+}
+
+func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
+	type testcase struct {
+		objs                    []any
+		gateway                 *gatewayapi_v1.Gateway
+		wantRouteConditions     []*status.RouteStatusUpdate
+		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
+	}
 
 	run := func(t *testing.T, desc string, tc testcase) {
 		t.Helper()
@@ -10754,7 +11272,7 @@ func TestGatewayAPIGRPCRouteDAGStatus(t *testing.T) {
 							},
 							Headers: []gatewayapi_v1.GRPCHeaderMatch{
 								{
-									Type:  ptr.To(gatewayapi_v1.HeaderMatchType("UNKNOWN")), // <---- unknown type to break the test
+									Type:  ptr.To(gatewayapi_v1.GRPCHeaderMatchType("UNKNOWN")), // <---- unknown type to break the test
 									Name:  gatewayapi_v1.GRPCHeaderName("foo"),
 									Value: "bar",
 								},
@@ -10809,7 +11327,7 @@ func TestGatewayAPIGRPCRouteDAGStatus(t *testing.T) {
 							},
 							Headers: []gatewayapi_v1.GRPCHeaderMatch{
 								{
-									Type:  ptr.To(gatewayapi_v1.HeaderMatchRegularExpression),
+									Type:  ptr.To(gatewayapi_v1.GRPCHeaderMatchRegularExpression),
 									Name:  gatewayapi_v1.GRPCHeaderName("foo"),
 									Value: "invalid(-)regex)",
 								},
@@ -11744,11 +12262,12 @@ func TestGatewayAPITCPRouteDAGStatus(t *testing.T) {
 	})
 }
 
-func TestGatewayAPIBackendTLSPolicyDAGStatus(t *testing.T) {
+func TestGatewayAPIUDPRouteDAGStatus(t *testing.T) {
 	type testcase struct {
-		objs                           []any
-		gateway                        *gatewayapi_v1.Gateway
-		wantBackendTLSPolicyConditions []*status.BackendTLSPolicyStatusUpdate
+		objs                    []any
+		gateway                 *gatewayapi_v1.Gateway
+		wantRouteConditions     []*status.RouteStatusUpdate
+		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
 	}
 
 	run := func(t *testing.T, desc string, tc testcase) {
@@ -11799,9 +12318,9 @@ func TestGatewayAPIBackendTLSPolicyDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1.GatewaySpec{
 						Listeners: []gatewayapi_v1.Listener{{
-							Name:     "http",
-							Port:     80,
-							Protocol: gatewayapi_v1.HTTPProtocolType,
+							Name:     "udp",
+							Port:     10000,
+							Protocol: gatewayapi_v1.UDPProtocolType,
 							AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
 								Namespaces: &gatewayapi_v1.RouteNamespaces{
 									From: ptr.To(gatewayapi_v1.NamespacesFromAll),
@@ -11815,19 +12334,23 @@ func TestGatewayAPIBackendTLSPolicyDAGStatus(t *testing.T) {
 			for _, o := range tc.objs {
 				builder.Source.Insert(o)
 			}
-
 			dag := builder.Build()
-			gotBackendTLSPolicyUpdates := dag.StatusCache.GetBackendTLSPolicyUpdates()
+			gotRouteUpdates := dag.StatusCache.GetRouteUpdates()
+			gotGatewayUpdates := dag.StatusCache.GetGatewayUpdates()
 
 			ops := []cmp.Option{
 				cmpopts.IgnoreFields(meta_v1.Condition{}, "LastTransitionTime"),
-				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "GatewayRef"),
-				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "Generation"),
-				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "TransitionTime"),
+				cmpopts.IgnoreFields(status.RouteStatusUpdate{}, "GatewayRef"),
+				cmpopts.IgnoreFields(status.RouteStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.RouteStatusUpdate{}, "TransitionTime"),
+				cmpopts.IgnoreFields(status.RouteStatusUpdate{}, "Resource"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "TransitionTime"),
 				cmpopts.SortSlices(func(i, j meta_v1.Condition) bool {
 					return i.Message < j.Message
 				}),
-				cmpopts.SortSlices(func(i, j *status.BackendTLSPolicyStatusUpdate) bool {
+				cmpopts.SortSlices(func(i, j *status.RouteStatusUpdate) bool {
 					return i.FullName.String() < j.FullName.String()
 				}),
 			}
@@ -11835,52 +12358,461 @@ func TestGatewayAPIBackendTLSPolicyDAGStatus(t *testing.T) {
 			// Since we're using a single static GatewayClass,
 			// set the expected controller string here for all
 			// test cases.
-			for _, u := range tc.wantBackendTLSPolicyConditions {
+			for _, u := range tc.wantRouteConditions {
 				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
 
-				for _, pas := range u.PolicyAncestorStatuses {
-					pas.ControllerName = builder.Source.gatewayclass.Spec.ControllerName
+				for _, rps := range u.RouteParentStatuses {
+					rps.ControllerName = builder.Source.gatewayclass.Spec.ControllerName
 				}
 			}
 
-			if diff := cmp.Diff(tc.wantBackendTLSPolicyConditions, gotBackendTLSPolicyUpdates, ops...); diff != "" {
-				t.Fatalf("expected backend tls policy status: %v, got %v", tc.wantBackendTLSPolicyConditions, diff)
+			if diff := cmp.Diff(tc.wantRouteConditions, gotRouteUpdates, ops...); diff != "" {
+				t.Fatalf("expected route status: %v, got %v", tc.wantRouteConditions, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantGatewayStatusUpdate, gotGatewayUpdates, ops...); diff != "" {
+				t.Fatalf("expected gateway status: %v, got %v", tc.wantGatewayStatusUpdate, diff)
 			}
 		})
 	}
 
-	tlsService := &core_v1.Service{
+	dnsService := &core_v1.Service{
 		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "tlssvc",
-			Namespace: "projectcontour",
+			Name:      "dns",
+			Namespace: "default",
 		},
 		Spec: core_v1.ServiceSpec{
-			Ports: []core_v1.ServicePort{makeServicePort("https", "TCP", 443, 8443)},
+			Ports: []core_v1.ServicePort{makeServicePort("dns", "UDP", 53, 5353)},
 		},
 	}
 
-	configMapCert1 := &core_v1.ConfigMap{
+	dnsService2 := &core_v1.Service{
 		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "ca",
-			Namespace: "projectcontour",
+			Name:      "dns2",
+			Namespace: "default",
 		},
-		Data: map[string]string{
-			CACertificateKey: fixture.CERTIFICATE,
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("dns", "UDP", 53, 5353)},
 		},
 	}
 
-	run(t, "simple httproute with backendtlspolicy", testcase{
-		objs: []any{
-			tlsService,
-			configMapCert1,
-			makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
-			&gatewayapi_v1alpha3.BackendTLSPolicy{
-				ObjectMeta: meta_v1.ObjectMeta{
-					Name:      "basic",
-					Namespace: "projectcontour",
-				},
-				Spec: gatewayapi_v1alpha3.BackendTLSPolicySpec{
-					TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReferenceWithSectionName{
+	kuardService := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080, 8080)},
+		},
+	}
+
+	run(t, "allowedroute of UDPRoute on a non-UDP listener results in a listener condition", testcase{
+		objs: []any{},
+		gateway: &gatewayapi_v1.Gateway{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "contour",
+				Namespace: "projectcontour",
+			},
+			Spec: gatewayapi_v1.GatewaySpec{
+				Listeners: []gatewayapi_v1.Listener{{
+					Name:     "tcp",
+					Port:     10000,
+					Protocol: gatewayapi_v1.TCPProtocolType,
+					AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+						Kinds: []gatewayapi_v1.RouteGroupKind{
+							{Kind: "UDPRoute"},
+						},
+						Namespaces: &gatewayapi_v1.RouteNamespaces{
+							From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+						},
+					},
+				}},
+			},
+		},
+		wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "contour"},
+			Conditions: map[gatewayapi_v1.GatewayConditionType]meta_v1.Condition{
+				gatewayapi_v1.GatewayConditionAccepted: gatewayAcceptedCondition(),
+				gatewayapi_v1.GatewayConditionProgrammed: {
+					Type:    string(gatewayapi_v1.GatewayConditionProgrammed),
+					Status:  contour_v1.ConditionFalse,
+					Reason:  string(gatewayapi_v1.GatewayReasonListenersNotValid),
+					Message: "Listeners are not valid",
+				},
+			},
+			ListenerStatus: map[string]*gatewayapi_v1.ListenerStatus{
+				"tcp": {
+					Name:           "tcp",
+					SupportedKinds: nil,
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1.ListenerConditionProgrammed),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  "Invalid",
+							Message: "Invalid listener, see other listener conditions for details",
+						},
+						listenerAcceptedCondition(),
+						{
+							Type:    string(gatewayapi_v1.ListenerConditionResolvedRefs),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1.ListenerReasonInvalidRouteKinds),
+							Message: "UDPRoutes are incompatible with listener protocol \"TCP\"",
+						},
+					},
+				},
+			},
+		}},
+	})
+
+	run(t, "allowedroute of TCPRoute on a UDP listener results in a listener condition", testcase{
+		objs: []any{},
+		gateway: &gatewayapi_v1.Gateway{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "contour",
+				Namespace: "projectcontour",
+			},
+			Spec: gatewayapi_v1.GatewaySpec{
+				Listeners: []gatewayapi_v1.Listener{{
+					Name:     "udp",
+					Port:     10000,
+					Protocol: gatewayapi_v1.UDPProtocolType,
+					AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+						Kinds: []gatewayapi_v1.RouteGroupKind{
+							{Kind: "TCPRoute"},
+						},
+						Namespaces: &gatewayapi_v1.RouteNamespaces{
+							From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+						},
+					},
+				}},
+			},
+		},
+		wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "contour"},
+			Conditions: map[gatewayapi_v1.GatewayConditionType]meta_v1.Condition{
+				gatewayapi_v1.GatewayConditionAccepted: gatewayAcceptedCondition(),
+				gatewayapi_v1.GatewayConditionProgrammed: {
+					Type:    string(gatewayapi_v1.GatewayConditionProgrammed),
+					Status:  contour_v1.ConditionFalse,
+					Reason:  string(gatewayapi_v1.GatewayReasonListenersNotValid),
+					Message: "Listeners are not valid",
+				},
+			},
+			ListenerStatus: map[string]*gatewayapi_v1.ListenerStatus{
+				"udp": {
+					Name:           "udp",
+					SupportedKinds: nil,
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1.ListenerConditionProgrammed),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  "Invalid",
+							Message: "Invalid listener, see other listener conditions for details",
+						},
+						listenerAcceptedCondition(),
+						{
+							Type:    string(gatewayapi_v1.ListenerConditionResolvedRefs),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1.ListenerReasonInvalidRouteKinds),
+							Message: "TCPRoutes are incompatible with listener protocol \"UDP\"",
+						},
+					},
+				},
+			},
+		}},
+	})
+
+	run(t, "UDPRoute with more than one rule", testcase{
+		objs: []any{
+			dnsService,
+			dnsService2,
+			&gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Rules: []gatewayapi_v1alpha2.UDPRouteRule{
+						{
+							BackendRefs: gatewayapi.TLSRouteBackendRef("dns", 53, ptr.To(int32(1))),
+						},
+						{
+							BackendRefs: gatewayapi.TLSRouteBackendRef("dns2", 53, ptr.To(int32(1))),
+						},
+					},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						routeResolvedRefsCondition(),
+						{
+							Type:    string(gatewayapi_v1.RouteConditionAccepted),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  "InvalidRouteRules",
+							Message: "UDPRoute must have only a single rule defined",
+						},
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", gatewayapi_v1.UDPProtocolType, 1),
+	})
+
+	run(t, "UDPRoute with rule with more than one backend", testcase{
+		objs: []any{
+			dnsService,
+			dnsService2,
+			&gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Rules: []gatewayapi_v1alpha2.UDPRouteRule{
+						{
+							BackendRefs: append(
+								gatewayapi.TLSRouteBackendRef("dns", 53, ptr.To(int32(1))),
+								gatewayapi.TLSRouteBackendRef("dns2", 53, ptr.To(int32(1)))...,
+							),
+						},
+					},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						resolvedRefsFalse(status.ReasonDegraded, "Exactly one Spec.Rules.BackendRef must be specified."),
+						routeAcceptedUDPRouteCondition(),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", gatewayapi_v1.UDPProtocolType, 1),
+	})
+
+	run(t, "UDPRoute with rule with ref to nonexistent backend", testcase{
+		objs: []any{
+			dnsService,
+			&gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Rules: []gatewayapi_v1alpha2.UDPRouteRule{
+						{
+							BackendRefs: gatewayapi.TLSRouteBackendRef("nonexistent", 53, ptr.To(int32(1))),
+						},
+					},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						resolvedRefsFalse(gatewayapi_v1.RouteReasonBackendNotFound, `service "nonexistent" is invalid: service "default/nonexistent" not found`),
+						routeAcceptedUDPRouteCondition(),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", gatewayapi_v1.UDPProtocolType, 1),
+	})
+
+	run(t, "UDPRoute with rule with ref to backend port without UDP protocol", testcase{
+		objs: []any{
+			kuardService,
+			&gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+				},
+				Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Rules: []gatewayapi_v1alpha2.UDPRouteRule{
+						{
+							BackendRefs: gatewayapi.TLSRouteBackendRef("kuard", 8080, ptr.To(int32(1))),
+						},
+					},
+				},
+			},
+		},
+		wantRouteConditions: []*status.RouteStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			RouteParentStatuses: []*gatewayapi_v1.RouteParentStatus{
+				{
+					ParentRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						resolvedRefsFalse(gatewayapi_v1.RouteReasonBackendNotFound, `service "kuard" is invalid: unsupported service protocol "TCP"`),
+						routeAcceptedUDPRouteCondition(),
+					},
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", gatewayapi_v1.UDPProtocolType, 1),
+	})
+}
+
+func TestGatewayAPIBackendTLSPolicyDAGStatus(t *testing.T) {
+	type testcase struct {
+		objs                           []any
+		gateway                        *gatewayapi_v1.Gateway
+		wantBackendTLSPolicyConditions []*status.BackendTLSPolicyStatusUpdate
+	}
+
+	run := func(t *testing.T, desc string, tc testcase) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			t.Helper()
+			builder := Builder{
+				Source: KubernetesCache{
+					RootNamespaces: []string{"roots", "marketing"},
+					FieldLogger:    fixture.NewTestLogger(t),
+					gatewayclass: &gatewayapi_v1.GatewayClass{
+						TypeMeta: meta_v1.TypeMeta{},
+						ObjectMeta: meta_v1.ObjectMeta{
+							Name: "test-gc",
+						},
+						Spec: gatewayapi_v1.GatewayClassSpec{
+							ControllerName: "projectcontour.io/contour",
+						},
+						Status: gatewayapi_v1.GatewayClassStatus{
+							Conditions: []meta_v1.Condition{
+								{
+									Type:   string(gatewayapi_v1.GatewayClassConditionStatusAccepted),
+									Status: meta_v1.ConditionTrue,
+								},
+							},
+						},
+					},
+					gateway: tc.gateway,
+				},
+				Processors: []Processor{
+					&ListenerProcessor{},
+					&IngressProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+					&HTTPProxyProcessor{},
+					&GatewayAPIProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+				},
+			}
+
+			// Set a default gateway if not defined by a test
+			if tc.gateway == nil {
+				builder.Source.gateway = &gatewayapi_v1.Gateway{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "contour",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1.GatewaySpec{
+						Listeners: []gatewayapi_v1.Listener{{
+							Name:     "http",
+							Port:     80,
+							Protocol: gatewayapi_v1.HTTPProtocolType,
+							AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+								Namespaces: &gatewayapi_v1.RouteNamespaces{
+									From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+								},
+							},
+						}},
+					},
+				}
+			}
+
+			for _, o := range tc.objs {
+				builder.Source.Insert(o)
+			}
+
+			dag := builder.Build()
+			gotBackendTLSPolicyUpdates := dag.StatusCache.GetBackendTLSPolicyUpdates()
+
+			ops := []cmp.Option{
+				cmpopts.IgnoreFields(meta_v1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "GatewayRef"),
+				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.BackendTLSPolicyStatusUpdate{}, "TransitionTime"),
+				cmpopts.SortSlices(func(i, j meta_v1.Condition) bool {
+					return i.Message < j.Message
+				}),
+				cmpopts.SortSlices(func(i, j *status.BackendTLSPolicyStatusUpdate) bool {
+					return i.FullName.String() < j.FullName.String()
+				}),
+			}
+
+			// Since we're using a single static GatewayClass,
+			// set the expected controller string here for all
+			// test cases.
+			for _, u := range tc.wantBackendTLSPolicyConditions {
+				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
+
+				for _, pas := range u.PolicyAncestorStatuses {
+					pas.ControllerName = builder.Source.gatewayclass.Spec.ControllerName
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantBackendTLSPolicyConditions, gotBackendTLSPolicyUpdates, ops...); diff != "" {
+				t.Fatalf("expected backend tls policy status: %v, got %v", tc.wantBackendTLSPolicyConditions, diff)
+			}
+		})
+	}
+
+	tlsService := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tlssvc",
+			Namespace: "projectcontour",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("https", "TCP", 443, 8443)},
+		},
+	}
+
+	configMapCert1 := &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ca",
+			Namespace: "projectcontour",
+		},
+		Data: map[string]string{
+			CACertificateKey: fixture.CERTIFICATE,
+		},
+	}
+
+	run(t, "simple httproute with backendtlspolicy", testcase{
+		objs: []any{
+			tlsService,
+			configMapCert1,
+			makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "tlssvc", 443, 1)),
+			&gatewayapi_v1alpha3.BackendTLSPolicy{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "projectcontour",
+				},
+				Spec: gatewayapi_v1alpha3.BackendTLSPolicySpec{
+					TargetRefs: []gatewayapi_v1alpha2.LocalPolicyTargetReferenceWithSectionName{
 						{
 							LocalPolicyTargetReference: gatewayapi_v1alpha2.LocalPolicyTargetReference{
 								Kind: "Service",
@@ -12393,6 +13325,15 @@ func routeAcceptedTCPRouteCondition() meta_v1.Condition {
 	}
 }
 
+func routeAcceptedUDPRouteCondition() meta_v1.Condition {
+	return meta_v1.Condition{
+		Type:    string(gatewayapi_v1.RouteConditionAccepted),
+		Status:  contour_v1.ConditionTrue,
+		Reason:  string(gatewayapi_v1.RouteReasonAccepted),
+		Message: "Accepted UDPRoute",
+	}
+}
+
 func listenerProgrammedCondition() meta_v1.Condition {
 	return meta_v1.Condition{
 		Type:    string(gatewayapi_v1.ListenerConditionProgrammed),
@@ -12427,3 +13368,197 @@ func listenerValidConditions() []meta_v1.Condition {
 		listenerResolvedRefsCondition(),
 	}
 }
+
+func TestGatewayAPIBackendTrafficPolicyDAGStatus(t *testing.T) {
+	type testcase struct {
+		objs                               []any
+		gateway                            *gatewayapi_v1.Gateway
+		wantBackendTrafficPolicyConditions []*status.BackendTrafficPolicyStatusUpdate
+	}
+
+	run := func(t *testing.T, desc string, tc testcase) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			t.Helper()
+			builder := Builder{
+				Source: KubernetesCache{
+					RootNamespaces: []string{"roots", "marketing"},
+					FieldLogger:    fixture.NewTestLogger(t),
+					gatewayclass: &gatewayapi_v1.GatewayClass{
+						TypeMeta: meta_v1.TypeMeta{},
+						ObjectMeta: meta_v1.ObjectMeta{
+							Name: "test-gc",
+						},
+						Spec: gatewayapi_v1.GatewayClassSpec{
+							ControllerName: "projectcontour.io/contour",
+						},
+						Status: gatewayapi_v1.GatewayClassStatus{
+							Conditions: []meta_v1.Condition{
+								{
+									Type:   string(gatewayapi_v1.GatewayClassConditionStatusAccepted),
+									Status: meta_v1.ConditionTrue,
+								},
+							},
+						},
+					},
+					gateway: tc.gateway,
+				},
+				Processors: []Processor{
+					&ListenerProcessor{},
+					&IngressProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+					&HTTPProxyProcessor{},
+					&GatewayAPIProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+				},
+			}
+
+			// Set a default gateway if not defined by a test
+			if tc.gateway == nil {
+				builder.Source.gateway = &gatewayapi_v1.Gateway{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "contour",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1.GatewaySpec{
+						Listeners: []gatewayapi_v1.Listener{{
+							Name:     "http",
+							Port:     80,
+							Protocol: gatewayapi_v1.HTTPProtocolType,
+							AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+								Namespaces: &gatewayapi_v1.RouteNamespaces{
+									From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+								},
+							},
+						}},
+					},
+				}
+			}
+
+			for _, o := range tc.objs {
+				builder.Source.Insert(o)
+			}
+
+			dag := builder.Build()
+			gotBackendTrafficPolicyUpdates := dag.StatusCache.GetBackendTrafficPolicyUpdates()
+
+			ops := []cmp.Option{
+				cmpopts.IgnoreFields(meta_v1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(status.BackendTrafficPolicyStatusUpdate{}, "GatewayRef"),
+				cmpopts.IgnoreFields(status.BackendTrafficPolicyStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.BackendTrafficPolicyStatusUpdate{}, "TransitionTime"),
+				cmpopts.SortSlices(func(i, j meta_v1.Condition) bool {
+					return i.Message < j.Message
+				}),
+				cmpopts.SortSlices(func(i, j *status.BackendTrafficPolicyStatusUpdate) bool {
+					return i.FullName.String() < j.FullName.String()
+				}),
+			}
+
+			// Since we're using a single static GatewayClass,
+			// set the expected controller string here for all
+			// test cases.
+			for _, u := range tc.wantBackendTrafficPolicyConditions {
+				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
+
+				for _, pas := range u.PolicyAncestorStatuses {
+					pas.ControllerName = builder.Source.gatewayclass.Spec.ControllerName
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantBackendTrafficPolicyConditions, gotBackendTrafficPolicyUpdates, ops...); diff != "" {
+				t.Fatalf("expected backend lb policy status: %v, got %v", tc.wantBackendTrafficPolicyConditions, diff)
+			}
+		})
+	}
+
+	kuardService := &core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "projectcontour",
+		},
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{makeServicePort("http", "TCP", 8080, 8080)},
+		},
+	}
+
+	run(t, "valid backendtrafficpolicy", testcase{
+		objs: []any{
+			kuardService,
+			makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+			makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+				SessionName: ptr.To("cart"),
+			}),
+		},
+		wantBackendTrafficPolicyConditions: []*status.BackendTrafficPolicyStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "kuard-lb"},
+			PolicyAncestorStatuses: []*gatewayapi_v1alpha2.PolicyAncestorStatus{
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:  meta_v1.ConditionTrue,
+							Reason:  string(gatewayapi_v1alpha2.PolicyReasonAccepted),
+							Message: "Accepted XBackendTrafficPolicy",
+						},
+					},
+				},
+			},
+		}},
+	})
+
+	run(t, "backendtrafficpolicy with idle timeout set", testcase{
+		objs: []any{
+			kuardService,
+			makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+			makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+				IdleTimeout: ptr.To(gatewayapi_v1.Duration("10m")),
+			}),
+		},
+		wantBackendTrafficPolicyConditions: []*status.BackendTrafficPolicyStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "kuard-lb"},
+			PolicyAncestorStatuses: []*gatewayapi_v1alpha2.PolicyAncestorStatus{
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.PolicyReasonInvalid),
+							Message: "XBackendTrafficPolicy.Spec.SessionPersistence.IdleTimeout is unsupported",
+						},
+					},
+				},
+			},
+		}},
+	})
+
+	run(t, "backendtrafficpolicy with header type and no session name", testcase{
+		objs: []any{
+			kuardService,
+			makeHTTPRoute("basic", "projectcontour", "", makeHTTPRouteRule(gatewayapi_v1.PathMatchPathPrefix, "/", "kuard", 8080, 1)),
+			makeBackendTrafficPolicy("projectcontour", "kuard", &gatewayapi_v1alpha2.SessionPersistence{
+				Type: ptr.To(gatewayapi_v1.HeaderBasedSessionPersistence),
+			}),
+		},
+		wantBackendTrafficPolicyConditions: []*status.BackendTrafficPolicyStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "kuard-lb"},
+			PolicyAncestorStatuses: []*gatewayapi_v1alpha2.PolicyAncestorStatus{
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:  meta_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.PolicyReasonInvalid),
+							Message: "XBackendTrafficPolicy.Spec.SessionPersistence.SessionName must be specified for type \"Header\"",
+						},
+					},
+				},
+			},
+		}},
+	})
+}
@@ -0,0 +1,404 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/envoy"
+	"github.com/projectcontour/contour/internal/sorter"
+)
+
+// dagDocument is the JSON representation of a DAG.
+type dagDocument struct {
+	Listeners []listenerDocument `json:"listeners"`
+	Clusters  []clusterDocument  `json:"clusters"`
+	Secrets   []secretDocument   `json:"secrets"`
+}
+
+type listenerDocument struct {
+	Name         string                `json:"name"`
+	Protocol     string                `json:"protocol"`
+	Address      string                `json:"address,omitempty"`
+	Port         int                   `json:"port"`
+	VirtualHosts []virtualHostDocument `json:"virtualHosts,omitempty"`
+
+	// TCPProxy and UDPProxy are the names of the clusters
+	// the listener forwards connections to, if any.
+	TCPProxy []string `json:"tcpProxy,omitempty"`
+	UDPProxy []string `json:"udpProxy,omitempty"`
+}
+
+type virtualHostDocument struct {
+	Name    string           `json:"name"`
+	Secure  bool             `json:"secure"`
+	Secret  string           `json:"secret,omitempty"`
+	Sources []sourceDocument `json:"sources,omitempty"`
+
+	// Routes are the routes of the virtual host in the order
+	// Envoy evaluates them.
+	Routes []routeDocument `json:"routes,omitempty"`
+
+	// TCPProxy is the names of the clusters the virtual host
+	// forwards TLS connections to, if any.
+	TCPProxy []string `json:"tcpProxy,omitempty"`
+}
+
+type routeDocument struct {
+	Match    string            `json:"match"`
+	Action   string            `json:"action"`
+	Clusters []weightedCluster `json:"clusters,omitempty"`
+	Mirrors  []weightedCluster `json:"mirrors,omitempty"`
+	Sources  []sourceDocument  `json:"sources,omitempty"`
+}
+
+type clusterDocument struct {
+	Name    string           `json:"name"`
+	Service string           `json:"service"`
+	Sources []sourceDocument `json:"sources,omitempty"`
+}
+
+type secretDocument struct {
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Sources   []sourceDocument `json:"sources,omitempty"`
+}
+
+type sourceDocument struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// dagFilter restricts a dagDocument to the vertices matching
+// each of its non empty fields.
+type dagFilter struct {
+	namespace string
+	fqdn      string
+	listener  string
+}
+
+func registerDAGJSON(mux *http.ServeMux, latest *LatestDAG) {
+	mux.HandleFunc("/debug/dag.json", func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		f := dagFilter{
+			namespace: params.Get("namespace"),
+			fqdn:      strings.ToLower(params.Get("fqdn")),
+			listener:  params.Get("listener"),
+		}
+
+		d := latest.DAG()
+		if d == nil {
+			http.Error(w, "the DAG has not been built yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(newDAGDocument(d, f))
+	})
+}
+
+// documentBuilder collects the clusters and secrets referenced by
+// the vertices added to a dagDocument.
+type documentBuilder struct {
+	dag      *dag.DAG
+	filter   dagFilter
+	clusters map[string]*clusterDocument
+	secrets  map[string]*secretDocument
+}
+
+// newDAGDocument returns the JSON representation of the parts of d
+// matching f.
+func newDAGDocument(d *dag.DAG, f dagFilter) *dagDocument {
+	b := &documentBuilder{
+		dag:      d,
+		filter:   f,
+		clusters: map[string]*clusterDocument{},
+		secrets:  map[string]*secretDocument{},
+	}
+
+	doc := &dagDocument{
+		Listeners: []listenerDocument{},
+		Clusters:  []clusterDocument{},
+		Secrets:   []secretDocument{},
+	}
+
+	var listeners []*dag.Listener
+	for _, l := range d.Listeners {
+		if f.listener == "" || f.listener == l.Name {
+			listeners = append(listeners, l)
+		}
+	}
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].Name < listeners[j].Name })
+
+	for _, l := range listeners {
+		if ld, ok := b.listener(l); ok {
+			doc.Listeners = append(doc.Listeners, ld)
+		}
+	}
+
+	for _, c := range b.clusters {
+		doc.Clusters = append(doc.Clusters, *c)
+	}
+	sort.Slice(doc.Clusters, func(i, j int) bool { return doc.Clusters[i].Name < doc.Clusters[j].Name })
+
+	for _, s := range b.secrets {
+		doc.Secrets = append(doc.Secrets, *s)
+	}
+	sort.Slice(doc.Secrets, func(i, j int) bool {
+		if doc.Secrets[i].Namespace != doc.Secrets[j].Namespace {
+			return doc.Secrets[i].Namespace < doc.Secrets[j].Namespace
+		}
+		return doc.Secrets[i].Name < doc.Secrets[j].Name
+	})
+
+	return doc
+}
+
+// listener returns the document for l, and whether anything in l
+// matched the filter.
+func (b *documentBuilder) listener(l *dag.Listener) (listenerDocument, bool) {
+	ld := listenerDocument{
+		Name:     l.Name,
+		Protocol: l.Protocol,
+		Address:  l.Address,
+		Port:     l.Port,
+	}
+
+	for _, vh := range l.VirtualHosts {
+		if vd, ok := b.virtualHost(vh, nil); ok {
+			ld.VirtualHosts = append(ld.VirtualHosts, vd)
+		}
+	}
+	for _, svh := range l.SecureVirtualHosts {
+		if vd, ok := b.virtualHost(&svh.VirtualHost, svh); ok {
+			ld.VirtualHosts = append(ld.VirtualHosts, vd)
+		}
+	}
+
+	// Listener level proxies have no fqdn.
+	if b.filter.fqdn == "" {
+		if l.TCPProxy != nil && b.anyInNamespace(l.TCPProxy.Clusters) {
+			ld.TCPProxy = b.addClusters(l.TCPProxy.Clusters)
+		}
+		if l.UDPProxy != nil && b.anyInNamespace(l.UDPProxy.Clusters) {
+			ld.UDPProxy = b.addClusters(l.UDPProxy.Clusters)
+		}
+	}
+
+	filtered := b.filter.namespace != "" || b.filter.fqdn != ""
+	return ld, !filtered || len(ld.VirtualHosts) > 0 || len(ld.TCPProxy) > 0 || len(ld.UDPProxy) > 0
+}
+
+// virtualHost returns the document for vh, and whether it matched the
+// filter. If the virtual host itself was not generated from an object
+// in the filtered namespace, only the routes that were are included.
+func (b *documentBuilder) virtualHost(vh *dag.VirtualHost, svh *dag.SecureVirtualHost) (virtualHostDocument, bool) {
+	if b.filter.fqdn != "" && b.filter.fqdn != strings.ToLower(vh.Name) {
+		return virtualHostDocument{}, false
+	}
+
+	var vertex any = vh
+	if svh != nil {
+		vertex = svh
+	}
+
+	vd := virtualHostDocument{
+		Name:    vh.Name,
+		Secure:  svh != nil,
+		Sources: sourceDocuments(b.dag.Sources(vertex)),
+	}
+	whole := b.inNamespace(vertex)
+
+	routes := make([]*dag.Route, 0, len(vh.Routes))
+	for _, r := range vh.Routes {
+		if whole || b.inNamespace(r) {
+			routes = append(routes, r)
+		}
+	}
+	sort.Stable(sorter.For(routes))
+
+	for _, r := range routes {
+		vd.Routes = append(vd.Routes, b.route(r))
+	}
+
+	if svh != nil && svh.TCPProxy != nil && (whole || b.anyInNamespace(svh.TCPProxy.Clusters)) {
+		vd.TCPProxy = b.addClusters(svh.TCPProxy.Clusters)
+	}
+
+	if !whole && len(vd.Routes) == 0 && len(vd.TCPProxy) == 0 {
+		return virtualHostDocument{}, false
+	}
+
+	if svh != nil {
+		if svh.Secret != nil {
+			vd.Secret = b.addSecret(svh.Secret)
+		}
+		b.addSecret(svh.FallbackCertificate)
+		b.addSecret(svh.BasicAuthSecret)
+		if dv := svh.DownstreamValidation; dv != nil {
+			for _, s := range dv.CACertificates {
+				b.addSecret(s)
+			}
+		}
+	}
+
+	return vd, true
+}
+
+func (b *documentBuilder) route(r *dag.Route) routeDocument {
+	rd := routeDocument{
+		Match:   conditionsString(r),
+		Action:  "route",
+		Sources: sourceDocuments(b.dag.Sources(r)),
+	}
+
+	switch {
+	case r.Redirect != nil:
+		rd.Action = "redirect"
+	case r.DirectResponse != nil:
+		rd.Action = fmt.Sprintf("direct response %d", r.DirectResponse.StatusCode)
+	}
+
+	for _, c := range r.Clusters {
+		rd.Clusters = append(rd.Clusters, weightedCluster{
+			Name:    b.addCluster(c),
+			Service: serviceString(c),
+			Weight:  c.Weight,
+		})
+	}
+	for _, mp := range r.MirrorPolicies {
+		if mp.Cluster != nil {
+			rd.Mirrors = append(rd.Mirrors, weightedCluster{
+				Name:    b.addCluster(mp.Cluster),
+				Service: serviceString(mp.Cluster),
+				Weight:  uint32(mp.Weight),
+			})
+		}
+	}
+
+	b.addSecret(r.BasicAuthSecret)
+
+	return rd
+}
+
+// addCluster records c and returns its Envoy name.
+func (b *documentBuilder) addCluster(c *dag.Cluster) string {
+	name := envoy.Clustername(c)
+
+	cd, ok := b.clusters[name]
+	if !ok {
+		cd = &clusterDocument{
+			Name:    name,
+			Service: serviceString(c),
+		}
+		b.clusters[name] = cd
+	}
+	cd.Sources = mergeSources(cd.Sources, sourceDocuments(b.dag.Sources(c)))
+
+	if uv := c.UpstreamValidation; uv != nil {
+		for _, s := range uv.CACertificates {
+			b.addSecret(s)
+		}
+	}
+	b.addSecret(c.ClientCertificate)
+
+	return name
+}
+
+func (b *documentBuilder) addClusters(clusters []*dag.Cluster) []string {
+	var names []string
+	for _, c := range clusters {
+		names = append(names, b.addCluster(c))
+	}
+	return names
+}
+
+// addSecret records s, if not nil, and returns its namespaced name.
+func (b *documentBuilder) addSecret(s *dag.Secret) string {
+	if s == nil || s.Object == nil {
+		return ""
+	}
+
+	key := s.Namespace() + "/" + s.Name()
+	sd, ok := b.secrets[key]
+	if !ok {
+		sd = &secretDocument{
+			Namespace: s.Namespace(),
+			Name:      s.Name(),
+		}
+		b.secrets[key] = sd
+	}
+	sd.Sources = mergeSources(sd.Sources, sourceDocuments(b.dag.Sources(s)))
+
+	return key
+}
+
+// inNamespace reports whether vertex was generated from an object in
+// the filtered namespace.
+func (b *documentBuilder) inNamespace(vertex any) bool {
+	if b.filter.namespace == "" {
+		return true
+	}
+	for _, src := range b.dag.Sources(vertex) {
+		if src.Namespace == b.filter.namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *documentBuilder) anyInNamespace(clusters []*dag.Cluster) bool {
+	for _, c := range clusters {
+		if b.inNamespace(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func serviceString(c *dag.Cluster) string {
+	if c.Upstream == nil {
+		return ""
+	}
+	ws := c.Upstream.Weighted
+	return fmt.Sprintf("%s/%s:%d", ws.ServiceNamespace, ws.ServiceName, ws.ServicePort.Port)
+}
+
+func sourceDocuments(sources []dag.Source) []sourceDocument {
+	var docs []sourceDocument
+	for _, src := range sources {
+		docs = append(docs, sourceDocument(src))
+	}
+	return docs
+}
+
+// mergeSources appends the sources in add not already in sources.
+func mergeSources(sources, add []sourceDocument) []sourceDocument {
+	for _, src := range add {
+		if !slices.Contains(sources, src) {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
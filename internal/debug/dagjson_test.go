@@ -0,0 +1,216 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func dagJSONTestDAG(t *testing.T) *dag.DAG {
+	t.Helper()
+
+	service := func(namespacedName string) *core_v1.Service {
+		return &core_v1.Service{
+			ObjectMeta: fixture.ObjectMeta(namespacedName),
+			Spec: core_v1.ServiceSpec{
+				Ports: []core_v1.ServicePort{{Name: "http", Protocol: "TCP", Port: 8080}},
+			},
+		}
+	}
+
+	secret := &core_v1.Secret{
+		ObjectMeta: fixture.ObjectMeta("default/tls"),
+		Type:       core_v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			core_v1.TLSCertKey:       []byte(fixture.CERTIFICATE),
+			core_v1.TLSPrivateKeyKey: []byte(fixture.RSA_PRIVATE_KEY),
+		},
+	}
+
+	root := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/root"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+				TLS: &contour_v1.TLS{
+					SecretName: "tls",
+				},
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}},
+			Includes: []contour_v1.Include{{
+				Name:       "child",
+				Namespace:  "team",
+				Conditions: []contour_v1.MatchCondition{{Prefix: "/team"}},
+			}},
+		},
+	}
+
+	child := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("team/child"),
+		Spec: contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}},
+		},
+	}
+
+	other := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/other"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "other.example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}},
+		},
+	}
+
+	builder := &dag.Builder{
+		Source: dag.KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		},
+		Processors: []dag.Processor{
+			&dag.ListenerProcessor{},
+			&dag.HTTPProxyProcessor{},
+		},
+	}
+	for _, o := range []any{service("default/kuard"), service("team/kuard"), secret, root, child, other} {
+		builder.Source.Insert(o)
+	}
+
+	return builder.Build()
+}
+
+func TestDAGDocument(t *testing.T) {
+	d := dagJSONTestDAG(t)
+
+	vhosts := func(doc *dagDocument) map[string][]string {
+		got := map[string][]string{}
+		for _, l := range doc.Listeners {
+			for _, vh := range l.VirtualHosts {
+				var routes []string
+				for _, r := range vh.Routes {
+					routes = append(routes, r.Match)
+				}
+				got[l.Name+" "+vh.Name] = routes
+			}
+		}
+		return got
+	}
+
+	doc := newDAGDocument(d, dagFilter{})
+	assert.Equal(t, map[string][]string{
+		"ingress_http example.com":       {"prefix: /team type: string", "prefix: / type: string"},
+		"ingress_http other.example.com": {"prefix: / type: string"},
+		"ingress_https example.com":      {"prefix: /team type: string", "prefix: / type: string"},
+	}, vhosts(doc))
+
+	require.Len(t, doc.Listeners, 2)
+	https := doc.Listeners[1]
+	assert.Equal(t, "ingress_https", https.Name)
+	require.Len(t, https.VirtualHosts, 1)
+	assert.True(t, https.VirtualHosts[0].Secure)
+	assert.Equal(t, "default/tls", https.VirtualHosts[0].Secret)
+	assert.Equal(t, []sourceDocument{{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.virtualhost"}}, https.VirtualHosts[0].Sources)
+	assert.Equal(t, []sourceDocument{
+		{Kind: "HTTPProxy", Namespace: "team", Name: "child", FieldPath: "spec.routes[0]"},
+		{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.includes[0]"},
+	}, https.VirtualHosts[0].Routes[0].Sources)
+
+	assert.Equal(t, []secretDocument{{
+		Namespace: "default",
+		Name:      "tls",
+		Sources:   []sourceDocument{{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.virtualhost.tls.secretName"}},
+	}}, doc.Secrets)
+
+	services := map[string][]sourceDocument{}
+	for _, c := range doc.Clusters {
+		services[c.Service] = c.Sources
+	}
+	assert.Equal(t, map[string][]sourceDocument{
+		"default/kuard:8080": {
+			{Kind: "HTTPProxy", Namespace: "default", Name: "root", FieldPath: "spec.routes[0].services[0]"},
+			{Kind: "HTTPProxy", Namespace: "default", Name: "other", FieldPath: "spec.routes[0].services[0]"},
+		},
+		"team/kuard:8080": {
+			{Kind: "HTTPProxy", Namespace: "team", Name: "child", FieldPath: "spec.routes[0].services[0]"},
+		},
+	}, services)
+
+	// Virtual hosts from other namespaces only include the routes
+	// generated from the filtered namespace.
+	doc = newDAGDocument(d, dagFilter{namespace: "team"})
+	assert.Equal(t, map[string][]string{
+		"ingress_http example.com":  {"prefix: /team type: string"},
+		"ingress_https example.com": {"prefix: /team type: string"},
+	}, vhosts(doc))
+	require.Len(t, doc.Clusters, 1)
+	assert.Equal(t, "team/kuard:8080", doc.Clusters[0].Service)
+
+	doc = newDAGDocument(d, dagFilter{fqdn: "other.example.com"})
+	assert.Equal(t, map[string][]string{
+		"ingress_http other.example.com": {"prefix: / type: string"},
+	}, vhosts(doc))
+	assert.Empty(t, doc.Secrets)
+
+	doc = newDAGDocument(d, dagFilter{listener: "ingress_https"})
+	assert.Equal(t, map[string][]string{
+		"ingress_https example.com": {"prefix: /team type: string", "prefix: / type: string"},
+	}, vhosts(doc))
+
+	doc = newDAGDocument(d, dagFilter{namespace: "missing"})
+	assert.Empty(t, doc.Listeners)
+	assert.Empty(t, doc.Clusters)
+}
+
+func TestDAGJSONHandler(t *testing.T) {
+	latest := &LatestDAG{}
+	mux := http.NewServeMux()
+	registerDAGJSON(mux, latest)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, get("/debug/dag.json").Code)
+
+	latest.OnChange(dagJSONTestDAG(t))
+
+	rec := get("/debug/dag.json?fqdn=Other.Example.com&listener=ingress_http")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc dagDocument
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Len(t, doc.Listeners, 1)
+	require.Len(t, doc.Listeners[0].VirtualHosts, 1)
+	assert.Equal(t, "other.example.com", doc.Listeners[0].VirtualHosts[0].Name)
+	assert.Equal(t, []sourceDocument{{Kind: "HTTPProxy", Namespace: "default", Name: "other", FieldPath: "spec.virtualhost"}}, doc.Listeners[0].VirtualHosts[0].Sources)
+}
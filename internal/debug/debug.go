@@ -29,6 +29,11 @@ type Service struct {
 	httpsvc.Service
 
 	Builder *dag.Builder
+
+	// DAG records the DAG most recently built by the
+	// event handler, which /debug/explain and /debug/dag.json
+	// inspect.
+	DAG *LatestDAG
 }
 
 func (svc *Service) NeedLeaderElection() bool {
@@ -40,6 +45,8 @@ func (svc *Service) NeedLeaderElection() bool {
 func (svc *Service) Start(ctx context.Context) error {
 	registerProfile(&svc.ServeMux)
 	registerDotWriter(&svc.ServeMux, svc.Builder)
+	registerExplain(&svc.ServeMux, svc.DAG)
+	registerDAGJSON(&svc.ServeMux, svc.DAG)
 	return svc.Service.Start(ctx)
 }
 
@@ -147,6 +147,20 @@ func collectDag(b DagBuilder) (nodeCollection, edgeCollection) {
 				}
 			}
 		}
+
+		if listener.UDPProxy != nil {
+			edges[pair{listener, listener.UDPProxy}] = true
+			nodes[listener.UDPProxy] = true
+			for _, cluster := range listener.UDPProxy.Clusters {
+				edges[pair{listener.UDPProxy, cluster}] = true
+				nodes[cluster] = true
+
+				if service := cluster.Upstream; service != nil {
+					edges[pair{cluster, service}] = true
+					nodes[service] = true
+				}
+			}
+		}
 	}
 
 	return nodes, edges
@@ -173,6 +187,8 @@ func printNodes(nodes nodeCollection, w io.Writer) {
 			fmt.Fprintf(w, `"%p" [shape=record, label="{secret|%s/%s}"]`+"\n", node, html.EscapeString(node.Namespace()), html.EscapeString(node.Name()))
 		case *dag.TCPProxy:
 			fmt.Fprintf(w, `"%p" [shape=record, label="{tcpproxy}"]`+"\n", node)
+		case *dag.UDPProxy:
+			fmt.Fprintf(w, `"%p" [shape=record, label="{udpproxy}"]`+"\n", node)
 
 		}
 	}
@@ -0,0 +1,564 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/envoy"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
+)
+
+// LatestDAG is a dag.Observer that records the most recently built DAG.
+type LatestDAG struct {
+	mu  sync.Mutex
+	dag *dag.DAG
+}
+
+// OnChange implements dag.Observer.
+func (l *LatestDAG) OnChange(d *dag.DAG) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dag = d
+}
+
+// DAG returns the most recently built DAG, or nil if none has been built.
+func (l *LatestDAG) DAG() *dag.DAG {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dag
+}
+
+// explanation describes how each listener would route a request.
+type explanation struct {
+	Host      string                `json:"host"`
+	Path      string                `json:"path"`
+	Headers   map[string]string     `json:"headers,omitempty"`
+	Listeners []listenerExplanation `json:"listeners"`
+}
+
+type listenerExplanation struct {
+	Listener    string `json:"listener"`
+	VirtualHost string `json:"virtualHost"`
+	Secure      bool   `json:"secure"`
+
+	// DomainMatch is how the request host matched the
+	// virtual host: "exact", "wildcard" or "default".
+	DomainMatch string `json:"domainMatch"`
+
+	// Routes are the routes of the virtual host in the order
+	// Envoy evaluates them.
+	Routes []routeCandidate `json:"routes"`
+
+	// Route is the first route that matches, if any.
+	Route *matchedRoute `json:"route,omitempty"`
+}
+
+type routeCandidate struct {
+	Order   int      `json:"order"`
+	Match   string   `json:"match"`
+	Matches bool     `json:"matches"`
+	Sources []string `json:"sources,omitempty"`
+}
+
+type matchedRoute struct {
+	Order    int               `json:"order"`
+	Action   string            `json:"action"`
+	Sources  []string          `json:"sources,omitempty"`
+	Clusters []weightedCluster `json:"clusters,omitempty"`
+	Mirrors  []weightedCluster `json:"mirrors,omitempty"`
+	Policies []string          `json:"policies,omitempty"`
+}
+
+type weightedCluster struct {
+	Name    string `json:"name"`
+	Service string `json:"service,omitempty"`
+	Weight  uint32 `json:"weight,omitempty"`
+}
+
+// request is the request being explained, in the form Envoy
+// matches routes against.
+type request struct {
+	host    string
+	path    string
+	query   url.Values
+	headers map[string]string
+}
+
+func registerExplain(mux *http.ServeMux, latest *LatestDAG) {
+	mux.HandleFunc("/debug/explain", func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		req, err := newRequest(params.Get("host"), params.Get("path"), params["header"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		d := latest.DAG()
+		if d == nil {
+			http.Error(w, "the DAG has not been built yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(explain(d, req))
+	})
+}
+
+// newRequest returns the request for the supplied host, path and
+// headers. Headers are of the form "name:value".
+func newRequest(host, path string, headers []string) (*request, error) {
+	if host == "" {
+		return nil, fmt.Errorf("the host parameter is required")
+	}
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path %q must start with /", path)
+	}
+
+	u, err := url.ParseRequestURI(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	req := &request{
+		host:  strings.ToLower(host),
+		path:  u.Path,
+		query: u.Query(),
+		headers: map[string]string{
+			":authority": host,
+			":path":      path,
+			":method":    http.MethodGet,
+			":scheme":    "http",
+		},
+	}
+
+	for _, h := range headers {
+		// Pseudo headers such as ":method" start with a colon.
+		name, value, ok := strings.Cut(h[min(len(h), 1):], ":")
+		if !ok {
+			return nil, fmt.Errorf("header %q must be of the form name:value", h)
+		}
+		name = strings.ToLower(h[:min(len(h), 1)] + name)
+		value = strings.TrimSpace(value)
+
+		if prev, ok := req.headers[name]; ok && !strings.HasPrefix(name, ":") {
+			value = prev + "," + value
+		}
+		req.headers[name] = value
+	}
+
+	return req, nil
+}
+
+// explain returns how each listener of d would route req.
+func explain(d *dag.DAG, req *request) *explanation {
+	e := &explanation{
+		Host:      req.host,
+		Path:      req.headers[":path"],
+		Listeners: []listenerExplanation{},
+	}
+	for name, value := range req.headers {
+		if !strings.HasPrefix(name, ":") {
+			if e.Headers == nil {
+				e.Headers = map[string]string{}
+			}
+			e.Headers[name] = value
+		}
+	}
+
+	var listeners []*dag.Listener
+	for _, l := range d.Listeners {
+		listeners = append(listeners, l)
+	}
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].Name < listeners[j].Name })
+
+	for _, l := range listeners {
+		var vhosts []*dag.VirtualHost
+		for _, vh := range l.VirtualHosts {
+			vhosts = append(vhosts, vh)
+		}
+		if vh, match := selectVirtualHost(vhosts, req.host); vh != nil {
+			e.Listeners = append(e.Listeners, explainVirtualHost(d, l, vh, nil, match, req))
+		}
+
+		vhosts = nil
+		secure := map[*dag.VirtualHost]*dag.SecureVirtualHost{}
+		for _, svh := range l.SecureVirtualHosts {
+			vhosts = append(vhosts, &svh.VirtualHost)
+			secure[&svh.VirtualHost] = svh
+		}
+		if vh, match := selectVirtualHost(vhosts, req.host); vh != nil {
+			e.Listeners = append(e.Listeners, explainVirtualHost(d, l, vh, secure[vh], match, req))
+		}
+	}
+
+	return e
+}
+
+// selectVirtualHost returns the virtual host Envoy would select for
+// host, and how it matched. Exact matches are preferred to wildcard
+// matches, which are preferred to the default "*" virtual host.
+func selectVirtualHost(vhosts []*dag.VirtualHost, host string) (*dag.VirtualHost, string) {
+	// The port is ignored when selecting a virtual host.
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	var wildcard, fallback *dag.VirtualHost
+	for _, vh := range vhosts {
+		if len(vh.Routes) == 0 {
+			// Virtual hosts without routes are not added to RDS.
+			continue
+		}
+
+		name := strings.ToLower(vh.Name)
+		switch {
+		case name == host:
+			return vh, "exact"
+		case name == "*":
+			fallback = vh
+		case strings.HasPrefix(name, "*") && strings.HasSuffix(host, name[1:]) && len(host) > len(name)-1:
+			if wildcard == nil || len(name) > len(wildcard.Name) {
+				wildcard = vh
+			}
+		}
+	}
+
+	switch {
+	case wildcard != nil:
+		return wildcard, "wildcard"
+	case fallback != nil:
+		return fallback, "default"
+	default:
+		return nil, ""
+	}
+}
+
+// explainVirtualHost evaluates the routes of vh in the order of the
+// generated RDS virtual host.
+func explainVirtualHost(d *dag.DAG, l *dag.Listener, vh *dag.VirtualHost, svh *dag.SecureVirtualHost, match string, req *request) listenerExplanation {
+	// SortRoutes sorts the match conditions of each route in place, so
+	// copies are sorted rather than the routes of the DAG, which is
+	// shared with the xDS caches.
+	routes := make([]*dag.Route, 0, len(vh.Routes))
+	original := map[*dag.Route]*dag.Route{}
+	for _, r := range vh.Routes {
+		route := *r
+		route.HeaderMatchConditions = slices.Clone(r.HeaderMatchConditions)
+		route.QueryParamMatchConditions = slices.Clone(r.QueryParamMatchConditions)
+		routes = append(routes, &route)
+		original[&route] = r
+	}
+	xdscache_v3.SortRoutes(routes)
+
+	evh := envoy_v3.VirtualHostAndRoutes(vh, routes, svh != nil)
+
+	le := listenerExplanation{
+		Listener:    l.Name,
+		VirtualHost: vh.Name,
+		Secure:      svh != nil,
+		DomainMatch: match,
+		Routes:      []routeCandidate{},
+	}
+
+	for i, route := range routes {
+		matches := routeMatches(evh.Routes[i].Match, req)
+		le.Routes = append(le.Routes, routeCandidate{
+			Order:   i,
+			Match:   conditionsString(route),
+			Matches: matches,
+			Sources: sourceStrings(d.Sources(original[route])),
+		})
+
+		if matches && le.Route == nil {
+			le.Route = explainRoute(d, i, original[route], evh.Routes[i], vh, svh)
+		}
+	}
+
+	return le
+}
+
+func explainRoute(d *dag.DAG, order int, route *dag.Route, er *envoy_config_route_v3.Route, vh *dag.VirtualHost, svh *dag.SecureVirtualHost) *matchedRoute {
+	mr := &matchedRoute{
+		Order:    order,
+		Sources:  sourceStrings(d.Sources(route)),
+		Policies: policies(route, vh, svh),
+	}
+
+	clusters := map[string]*dag.Cluster{}
+	for _, c := range route.Clusters {
+		clusters[envoy.Clustername(c)] = c
+	}
+	for _, mp := range route.MirrorPolicies {
+		if mp.Cluster != nil {
+			clusters[envoy.Clustername(mp.Cluster)] = mp.Cluster
+		}
+	}
+
+	switch action := er.Action.(type) {
+	case *envoy_config_route_v3.Route_Route:
+		mr.Action = "route"
+		switch spec := action.Route.ClusterSpecifier.(type) {
+		case *envoy_config_route_v3.RouteAction_Cluster:
+			mr.Clusters = append(mr.Clusters, newWeightedCluster(clusters, spec.Cluster, 0))
+		case *envoy_config_route_v3.RouteAction_WeightedClusters:
+			for _, c := range spec.WeightedClusters.Clusters {
+				mr.Clusters = append(mr.Clusters, newWeightedCluster(clusters, c.Name, c.Weight.GetValue()))
+			}
+		}
+		for _, m := range action.Route.RequestMirrorPolicies {
+			mr.Mirrors = append(mr.Mirrors, newWeightedCluster(clusters, m.Cluster, m.RuntimeFraction.GetDefaultValue().GetNumerator()))
+		}
+	case *envoy_config_route_v3.Route_Redirect:
+		mr.Action = "redirect"
+	case *envoy_config_route_v3.Route_DirectResponse:
+		mr.Action = fmt.Sprintf("direct response %d", action.DirectResponse.Status)
+	}
+
+	return mr
+}
+
+func newWeightedCluster(clusters map[string]*dag.Cluster, name string, weight uint32) weightedCluster {
+	wc := weightedCluster{
+		Name:   name,
+		Weight: weight,
+	}
+	if c, ok := clusters[name]; ok {
+		wc.Service = serviceString(c)
+	}
+	return wc
+}
+
+// policies returns the names of the policies that apply to requests
+// matching route.
+func policies(route *dag.Route, vh *dag.VirtualHost, svh *dag.SecureVirtualHost) []string {
+	var names []string
+	add := func(set bool, name string) {
+		if set {
+			names = append(names, name)
+		}
+	}
+
+	add(route.HTTPSUpgrade, "route.httpsUpgrade")
+	add(route.TimeoutPolicy != dag.RouteTimeoutPolicy{}, "route.timeoutPolicy")
+	add(route.RetryPolicy != nil, "route.retryPolicy")
+	add(route.FaultInjectionPolicy != nil, "route.faultInjectionPolicy")
+	add(route.PathRewritePolicy != nil, "route.pathRewritePolicy")
+	add(len(route.MirrorPolicies) > 0, "route.mirrorPolicies")
+	add(route.RequestHeadersPolicy != nil, "route.requestHeadersPolicy")
+	add(route.ResponseHeadersPolicy != nil, "route.responseHeadersPolicy")
+	add(len(route.CookieRewritePolicies) > 0, "route.cookieRewritePolicies")
+	add(route.RateLimitPolicy != nil, "route.rateLimitPolicy")
+	add(len(route.RequestHashPolicies) > 0, "route.requestHashPolicies")
+	add(route.SessionPersistence != nil, "route.sessionPersistence")
+	add(route.CORSPolicy != nil, "route.corsPolicy")
+	add(route.InternalRedirectPolicy != nil, "route.internalRedirectPolicy")
+	add(len(route.IPFilterRules) > 0, "route.ipFilterRules")
+	add(route.JWTProvider != "", "route.jwtProvider")
+	add(route.Websocket, "route.websocket")
+
+	add(vh.CORSPolicy != nil && route.CORSPolicy == nil, "virtualhost.corsPolicy")
+	add(vh.RateLimitPolicy != nil && route.RateLimitPerRoute == nil, "virtualhost.rateLimitPolicy")
+	add(len(vh.IPFilterRules) > 0 && len(route.IPFilterRules) == 0, "virtualhost.ipFilterRules")
+
+	if svh != nil {
+		add(svh.ExternalAuthorization != nil && !route.AuthDisabled, "virtualhost.authorization")
+		add(svh.ExternalProcessing != nil && !route.ExternalProcessingDisabled, "virtualhost.externalProcessing")
+		add(svh.OAuth2 != nil, "virtualhost.oauth2")
+		add((svh.BasicAuthSecret != nil || route.BasicAuthSecret != nil) && !route.BasicAuthDisabled, "virtualhost.basicAuth")
+		add(svh.DownstreamValidation != nil, "virtualhost.clientValidation")
+	}
+
+	return names
+}
+
+// conditionsString describes the match conditions of route.
+func conditionsString(route *dag.Route) string {
+	conds := []string{route.PathMatchCondition.String()}
+	for _, c := range route.HeaderMatchConditions {
+		conds = append(conds, matchString("header", c.Name, c.MatchType, c.Value, c.Invert, c.IgnoreCase))
+	}
+	for _, c := range route.QueryParamMatchConditions {
+		conds = append(conds, matchString("query", c.Name, c.MatchType, c.Value, false, c.IgnoreCase))
+	}
+	return strings.Join(conds, ", ")
+}
+
+func matchString(kind, name, matchType, value string, invert, ignoreCase bool) string {
+	if invert {
+		matchType = "not " + matchType
+	}
+	s := fmt.Sprintf("%s %s %s", kind, name, matchType)
+	if value != "" {
+		s += fmt.Sprintf(" %q", value)
+	}
+	if ignoreCase {
+		s += " (ignore case)"
+	}
+	return s
+}
+
+func sourceStrings(sources []dag.Source) []string {
+	var s []string
+	for _, src := range sources {
+		s = append(s, src.String())
+	}
+	return s
+}
+
+// routeMatches reports whether req matches m, following Envoy's
+// route matching rules.
+func routeMatches(m *envoy_config_route_v3.RouteMatch, req *request) bool {
+	path := req.path
+	caseSensitive := m.CaseSensitive == nil || m.CaseSensitive.Value
+
+	switch spec := m.PathSpecifier.(type) {
+	case *envoy_config_route_v3.RouteMatch_Prefix:
+		if !hasPrefix(path, spec.Prefix, caseSensitive) {
+			return false
+		}
+	case *envoy_config_route_v3.RouteMatch_Path:
+		if !hasPrefix(path, spec.Path, caseSensitive) || len(path) != len(spec.Path) {
+			return false
+		}
+	case *envoy_config_route_v3.RouteMatch_PathSeparatedPrefix:
+		if !hasPrefix(path, spec.PathSeparatedPrefix, caseSensitive) {
+			return false
+		}
+		if rest := path[len(spec.PathSeparatedPrefix):]; rest != "" && rest[0] != '/' {
+			return false
+		}
+	case *envoy_config_route_v3.RouteMatch_SafeRegex:
+		if !regexMatches(spec.SafeRegex.Regex, path) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	for _, h := range m.Headers {
+		if !headerMatches(h, req.headers) {
+			return false
+		}
+	}
+
+	for _, q := range m.QueryParameters {
+		if !queryParameterMatches(q, req.query) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func headerMatches(h *envoy_config_route_v3.HeaderMatcher, headers map[string]string) bool {
+	value, present := headers[strings.ToLower(h.Name)]
+	if !present && h.TreatMissingHeaderAsEmpty {
+		present = true
+	}
+
+	var matches bool
+	switch spec := h.HeaderMatchSpecifier.(type) {
+	case *envoy_config_route_v3.HeaderMatcher_PresentMatch:
+		matches = present == spec.PresentMatch
+	case *envoy_config_route_v3.HeaderMatcher_StringMatch:
+		matches = present && stringMatches(spec.StringMatch, value)
+	default:
+		matches = present
+	}
+
+	return matches != h.InvertMatch
+}
+
+func queryParameterMatches(q *envoy_config_route_v3.QueryParameterMatcher, query url.Values) bool {
+	values, present := query[q.Name]
+
+	switch spec := q.QueryParameterMatchSpecifier.(type) {
+	case *envoy_config_route_v3.QueryParameterMatcher_PresentMatch:
+		return present == spec.PresentMatch
+	case *envoy_config_route_v3.QueryParameterMatcher_StringMatch:
+		return present && stringMatches(spec.StringMatch, values[0])
+	default:
+		return present
+	}
+}
+
+func stringMatches(m *envoy_matcher_v3.StringMatcher, value string) bool {
+	if regex, ok := m.MatchPattern.(*envoy_matcher_v3.StringMatcher_SafeRegex); ok {
+		return regexMatches(regex.SafeRegex.Regex, value)
+	}
+
+	if m.IgnoreCase {
+		value = strings.ToLower(value)
+	}
+	pattern := func(s string) string {
+		if m.IgnoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+
+	switch p := m.MatchPattern.(type) {
+	case *envoy_matcher_v3.StringMatcher_Exact:
+		return value == pattern(p.Exact)
+	case *envoy_matcher_v3.StringMatcher_Prefix:
+		return strings.HasPrefix(value, pattern(p.Prefix))
+	case *envoy_matcher_v3.StringMatcher_Suffix:
+		return strings.HasSuffix(value, pattern(p.Suffix))
+	case *envoy_matcher_v3.StringMatcher_Contains:
+		return strings.Contains(value, pattern(p.Contains))
+	default:
+		return false
+	}
+}
+
+// regexMatches reports whether the whole of value matches regex, as
+// Envoy's safe regex matchers do.
+func regexMatches(regex, value string) bool {
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func hasPrefix(s, prefix string, caseSensitive bool) bool {
+	if caseSensitive {
+		return strings.HasPrefix(s, prefix)
+	}
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
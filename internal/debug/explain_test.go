@@ -0,0 +1,244 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func explainTestDAG(t *testing.T) *dag.DAG {
+	t.Helper()
+
+	service := func(name string) *core_v1.Service {
+		return &core_v1.Service{
+			ObjectMeta: fixture.ObjectMeta("default/" + name),
+			Spec: core_v1.ServiceSpec{
+				Ports: []core_v1.ServicePort{{Name: "http", Protocol: "TCP", Port: 8080}},
+			},
+		}
+	}
+
+	root := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/root"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Includes: []contour_v1.Include{{
+				Name:       "child",
+				Conditions: []contour_v1.MatchCondition{{Prefix: "/child"}},
+			}},
+		},
+	}
+
+	child := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/child"),
+		Spec: contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}, {
+				Conditions: []contour_v1.MatchCondition{{
+					Prefix: "/api",
+				}, {
+					Header: &contour_v1.HeaderMatchCondition{Name: "x-env", Exact: "canary"},
+				}},
+				Services: []contour_v1.Service{
+					{Name: "kuard", Port: 8080, Weight: 90},
+					{Name: "kuard-canary", Port: 8080, Weight: 10},
+				},
+				RetryPolicy: &contour_v1.RetryPolicy{NumRetries: 3},
+			}},
+		},
+	}
+
+	wildcard := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/wildcard"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "*.example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "kuard", Port: 8080}},
+			}},
+		},
+	}
+
+	builder := &dag.Builder{
+		Source: dag.KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		},
+		Processors: []dag.Processor{
+			&dag.ListenerProcessor{},
+			&dag.HTTPProxyProcessor{},
+		},
+	}
+	for _, o := range []any{service("kuard"), service("kuard-canary"), root, child, wildcard} {
+		builder.Source.Insert(o)
+	}
+
+	return builder.Build()
+}
+
+func TestExplain(t *testing.T) {
+	d := explainTestDAG(t)
+
+	req, err := newRequest("example.com:8080", "/child/api/v1?debug=true", []string{"X-Env: canary"})
+	require.NoError(t, err)
+
+	e := explain(d, req)
+	require.Len(t, e.Listeners, 1)
+
+	l := e.Listeners[0]
+	assert.Equal(t, "ingress_http", l.Listener)
+	assert.Equal(t, "example.com", l.VirtualHost)
+	assert.Equal(t, "exact", l.DomainMatch)
+	assert.Equal(t, []routeCandidate{{
+		Order:   0,
+		Match:   `prefix: /child/api type: string, header x-env exact "canary"`,
+		Matches: true,
+		Sources: []string{"HTTPProxy/default/child spec.routes[1]", "HTTPProxy/default/root spec.includes[0]"},
+	}, {
+		Order:   1,
+		Match:   "prefix: /child type: string",
+		Matches: true,
+		Sources: []string{"HTTPProxy/default/child spec.routes[0]", "HTTPProxy/default/root spec.includes[0]"},
+	}}, l.Routes)
+
+	require.NotNil(t, l.Route)
+	assert.Equal(t, 0, l.Route.Order)
+	assert.Equal(t, "route", l.Route.Action)
+	assert.Equal(t, []string{"HTTPProxy/default/child spec.routes[1]", "HTTPProxy/default/root spec.includes[0]"}, l.Route.Sources)
+	weights := map[string]uint32{}
+	for _, c := range l.Route.Clusters {
+		weights[c.Service] = c.Weight
+	}
+	assert.Equal(t, map[string]uint32{
+		"default/kuard:8080":        90,
+		"default/kuard-canary:8080": 10,
+	}, weights)
+	assert.Contains(t, l.Route.Policies, "route.retryPolicy")
+
+	// Without the header only the second route matches.
+	req, err = newRequest("example.com", "/child/api", nil)
+	require.NoError(t, err)
+
+	l = explain(d, req).Listeners[0]
+	assert.False(t, l.Routes[0].Matches)
+	require.NotNil(t, l.Route)
+	assert.Equal(t, 1, l.Route.Order)
+	assert.Equal(t, []weightedCluster{{
+		Name:    l.Route.Clusters[0].Name,
+		Service: "default/kuard:8080",
+	}}, l.Route.Clusters)
+
+	// Requests for other hosts select the wildcard virtual host.
+	req, err = newRequest("foo.example.com", "/", nil)
+	require.NoError(t, err)
+
+	l = explain(d, req).Listeners[0]
+	assert.Equal(t, "*.example.com", l.VirtualHost)
+	assert.Equal(t, "wildcard", l.DomainMatch)
+	require.NotNil(t, l.Route)
+	assert.Equal(t, []string{"HTTPProxy/default/wildcard spec.routes[0]"}, l.Route.Sources)
+
+	// No virtual host matches.
+	req, err = newRequest("example.org", "/", nil)
+	require.NoError(t, err)
+	assert.Empty(t, explain(d, req).Listeners)
+}
+
+func TestExplainSortsCopies(t *testing.T) {
+	route := &dag.Route{
+		PathMatchCondition: &dag.PrefixMatchCondition{Prefix: "/"},
+		HeaderMatchConditions: []dag.HeaderMatchCondition{
+			{Name: "x-b", Value: "b", MatchType: dag.HeaderMatchTypeExact},
+			{Name: "x-a", Value: "a", MatchType: dag.HeaderMatchTypeExact},
+		},
+		DirectResponse: &dag.DirectResponse{StatusCode: 200},
+	}
+	vh := &dag.VirtualHost{Name: "example.com"}
+	vh.AddRoute(route)
+
+	d := &dag.DAG{
+		Listeners: map[string]*dag.Listener{
+			"ingress_http": {Name: "ingress_http", VirtualHosts: []*dag.VirtualHost{vh}},
+		},
+	}
+
+	req, err := newRequest("example.com", "/", []string{"x-a:a", "x-b:b"})
+	require.NoError(t, err)
+
+	l := explain(d, req).Listeners[0]
+	assert.Equal(t, `prefix: / type: string, header x-a exact "a", header x-b exact "b"`, l.Routes[0].Match)
+	require.NotNil(t, l.Route)
+	assert.Equal(t, "direct response 200", l.Route.Action)
+
+	// The routes of the DAG are not modified.
+	assert.Equal(t, "x-b", route.HeaderMatchConditions[0].Name)
+}
+
+func TestExplainHandler(t *testing.T) {
+	latest := &LatestDAG{}
+	mux := http.NewServeMux()
+	registerExplain(mux, latest)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get("/debug/explain").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/debug/explain?host=example.com").Code)
+
+	latest.OnChange(explainTestDAG(t))
+
+	rec := get("/debug/explain?host=example.com&path=/child&header=x-env:canary")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var e explanation
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &e))
+	assert.Equal(t, "/child", e.Path)
+	assert.Equal(t, map[string]string{"x-env": "canary"}, e.Headers)
+	require.Len(t, e.Listeners, 1)
+	require.NotNil(t, e.Listeners[0].Route)
+	assert.Equal(t, []string{"HTTPProxy/default/child spec.routes[0]", "HTTPProxy/default/root spec.includes[0]"}, e.Listeners[0].Route.Sources)
+}
+
+func TestNewRequest(t *testing.T) {
+	req, err := newRequest("Example.com", "/foo?a=b", []string{":method:POST", "x-a: 1", "x-a:2"})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", req.host)
+	assert.Equal(t, "/foo", req.path)
+	assert.Equal(t, "b", req.query.Get("a"))
+	assert.Equal(t, "POST", req.headers[":method"])
+	assert.Equal(t, "1,2", req.headers["x-a"])
+
+	_, err = newRequest("example.com", "foo", nil)
+	require.Error(t, err)
+
+	_, err = newRequest("example.com", "/", []string{"x-a"})
+	require.Error(t, err)
+}
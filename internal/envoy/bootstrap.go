@@ -95,6 +95,19 @@ type BootstrapConfig struct {
 	// MaximumHeapSizeBytes specifies the number of bytes that overload manager allows heap to grow to.
 	// When reaching the set threshold, new connections are denied.
 	MaximumHeapSizeBytes uint64
+
+	// LocalityRegion is the region that Envoy runs in.
+	LocalityRegion string
+
+	// LocalityZone is the zone that Envoy runs in. When set, Envoy
+	// is configured with a local cluster so that zone aware routing
+	// can be used.
+	LocalityZone string
+
+	// LocalityNode is the name of the Node that Envoy runs on. When
+	// set, LocalityRegion and LocalityZone default to the topology
+	// labels of the Node.
+	LocalityNode string
 }
 
 // GetXdsAddress returns the address configured or defaults to "127.0.0.1"
@@ -20,6 +20,8 @@ import (
 	"strconv"
 	"strings"
 
+	core_v1 "k8s.io/api/core/v1"
+
 	"github.com/projectcontour/contour/internal/dag"
 )
 
@@ -55,12 +57,28 @@ func Clustername(cluster *dag.Cluster) string {
 		}
 	}
 	buf += cluster.Protocol + cluster.SNI
+	if cc := cluster.ClientCertificate; cc != nil && cluster.ServiceClientCertificate {
+		buf += cc.Object.Namespace + "/" + cc.Object.Name
+	}
+	if service.Weighted.ServicePort.Protocol == core_v1.ProtocolUDP {
+		buf += string(service.Weighted.ServicePort.Protocol)
+	}
 	if !cluster.TimeoutPolicy.IdleConnectionTimeout.UseDefault() {
 		buf += cluster.TimeoutPolicy.IdleConnectionTimeout.Duration().String()
 	}
 	if cluster.SlowStartConfig != nil {
 		buf += cluster.SlowStartConfig.String()
 	}
+	if cluster.OutlierDetectionPolicy != nil {
+		buf += cluster.OutlierDetectionPolicy.String()
+	}
+	if keys := dag.SubsetKeys(cluster.SubsetSelector); len(keys) > 0 {
+		buf += strings.Join(keys, ",")
+	}
+	if len(service.Endpoints) > 0 {
+		// Keep Backends apart from Services of the same name.
+		buf += "backend"
+	}
 
 	// This isn't a crypto hash, we just want a unique name.
 	hash := sha1.Sum([]byte(buf)) // nolint:gosec
@@ -70,6 +88,22 @@ func Clustername(cluster *dag.Cluster) string {
 	return Hashname(60, ns, name, strconv.Itoa(int(service.Weighted.ServicePort.Port)), fmt.Sprintf("%x", hash[:5]))
 }
 
+// AggregateClusterName returns the name of the CDS cluster that fails
+// over between the given clusters. The name is derived from the primary
+// service and the names of all the clusters, in priority order.
+func AggregateClusterName(cluster *dag.AggregateCluster) string {
+	names := make([]string, 0, len(cluster.Clusters))
+	for _, c := range cluster.Clusters {
+		names = append(names, Clustername(c))
+	}
+
+	// This isn't a crypto hash, we just want a unique name.
+	hash := sha1.Sum([]byte(strings.Join(names, ","))) // nolint:gosec
+
+	primary := cluster.Clusters[0].Upstream.Weighted
+	return Hashname(60, primary.ServiceNamespace, primary.ServiceName, "failover", fmt.Sprintf("%x", hash[:5]))
+}
+
 // AltStatName generates an alternative stat name for the service
 // using format ns_name_port
 func AltStatName(service *dag.Service) string {
@@ -28,3 +28,9 @@ func Secretname(s *dag.Secret) string {
 	name := s.Name()
 	return Hashname(60, ns, name, fmt.Sprintf("%x", hash[:5]))
 }
+
+// OAuth2SecretName returns the name of the SDS secret for the given
+// key of this OAuth2 client secret.
+func OAuth2SecretName(s *dag.Secret, key string) string {
+	return Hashname(60, s.Namespace(), s.Name(), key)
+}
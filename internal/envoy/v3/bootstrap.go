@@ -46,6 +46,11 @@ import (
 	"github.com/projectcontour/contour/internal/timeout"
 )
 
+// LocalClusterName is the name of the cluster, and of the
+// ClusterLoadAssignment, holding the endpoints of the Envoy fleet.
+// Envoy uses it to compute zone aware routing.
+const LocalClusterName = "envoy-local"
+
 // WriteBootstrap writes bootstrap configuration to files.
 func WriteBootstrap(c *envoy.BootstrapConfig) error {
 	// Create Envoy bootstrap config and associated resource files.
@@ -252,6 +257,31 @@ func bootstrapConfig(c *envoy.BootstrapConfig) *envoy_config_bootstrap_v3.Bootst
 			Address:   UnixSocketAddress(c.GetAdminAddress()),
 		},
 	}
+	if c.LocalityRegion != "" || c.LocalityZone != "" {
+		bootstrap.Node = &envoy_config_core_v3.Node{
+			Locality: &envoy_config_core_v3.Locality{
+				Region: c.LocalityRegion,
+				Zone:   c.LocalityZone,
+			},
+		}
+	}
+	if c.LocalityZone != "" {
+		// Zone aware routing requires the local cluster to
+		// be defined statically. Its endpoints are the Envoy
+		// instances, published by Contour over EDS.
+		bootstrap.ClusterManager = &envoy_config_bootstrap_v3.ClusterManager{
+			LocalClusterName: LocalClusterName,
+		}
+		bootstrap.StaticResources.Clusters = append(bootstrap.StaticResources.Clusters, &envoy_config_cluster_v3.Cluster{
+			Name:                 LocalClusterName,
+			AltStatName:          strings.Join([]string{c.Namespace, LocalClusterName}, "_"),
+			ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+			EdsClusterConfig: &envoy_config_cluster_v3.Cluster_EdsClusterConfig{
+				EdsConfig:   ConfigSource("contour"),
+				ServiceName: LocalClusterName,
+			},
+		})
+	}
 	if c.MaximumHeapSizeBytes > 0 {
 		bootstrap.OverloadManager = &envoy_config_overload_v3.OverloadManager{
 			RefreshInterval: durationpb.New(250 * time.Millisecond),
@@ -2003,6 +2003,220 @@ func TestBootstrap(t *testing.T) {
             }
           ]
         }
+      }`,
+		},
+		"--locality-region=us-east-1 --locality-zone=us-east-1a": {
+			config: envoy.BootstrapConfig{
+				Path:           "envoy.json",
+				Namespace:      "projectcontour",
+				LocalityRegion: "us-east-1",
+				LocalityZone:   "us-east-1a",
+			},
+			wantedBootstrapConfig: `{
+        "static_resources": {
+          "clusters": [
+            {
+              "name": "contour",
+              "alt_stat_name": "projectcontour_contour_8001",
+              "type": "STATIC",
+              "connect_timeout": "5s",
+              "load_assignment": {
+                "cluster_name": "contour",
+                "endpoints": [
+                  {
+                    "lb_endpoints": [
+                      {
+                        "endpoint": {
+                          "address": {
+                            "socket_address": {
+                              "address": "127.0.0.1",
+                              "port_value": 8001
+                            }
+                          }
+                        }
+                      }
+                    ]
+                  }
+                ]
+              },
+              "circuit_breakers": {
+                "thresholds": [
+                  {
+                    "priority": "HIGH",
+                    "max_connections": 100000,
+                    "max_pending_requests": 100000,
+                    "max_requests": 60000000,
+                    "max_retries": 50,
+                    "track_remaining": true
+                  },
+                  {
+                    "max_connections": 100000,
+                    "max_pending_requests": 100000,
+                    "max_requests": 60000000,
+                    "max_retries": 50,
+                    "track_remaining": true
+                  }
+                ]
+              },
+              "typed_extension_protocol_options": {
+                "envoy.extensions.upstreams.http.v3.HttpProtocolOptions": {
+                  "@type": "type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions",
+                  "explicit_http_config": {
+                    "http2_protocol_options": {}
+                  }
+                }
+              },
+              "upstream_connection_options": {
+                "tcp_keepalive": {
+                  "keepalive_probes": 3,
+                  "keepalive_time": 30,
+                  "keepalive_interval": 5
+                }
+              }
+            },
+            {
+              "name": "envoy-admin",
+              "alt_stat_name": "projectcontour_envoy-admin_9001",
+              "type": "STATIC",
+              "connect_timeout": "0.250s",
+              "load_assignment": {
+                "cluster_name": "envoy-admin",
+                "endpoints": [
+                  {
+                    "lb_endpoints": [
+                      {
+                        "endpoint": {
+                          "address": {
+                            "pipe": {
+                              "path": "/admin/admin.sock",
+                              "mode": 420
+                            }
+                          }
+                        }
+                      }
+                    ]
+                  }
+                ]
+              }
+            },
+            {
+              "name": "envoy-local",
+              "alt_stat_name": "projectcontour_envoy-local",
+              "type": "EDS",
+              "eds_cluster_config": {
+                "eds_config": {
+                  "api_config_source": {
+                    "api_type": "GRPC",
+                    "transport_api_version": "V3",
+                    "grpc_services": [
+                      {
+                        "envoy_grpc": {
+                          "cluster_name": "contour",
+                          "authority": "contour"
+                        }
+                      }
+                    ]
+                  },
+                  "resource_api_version": "V3"
+                },
+                "service_name": "envoy-local"
+              }
+            }
+          ]
+        },
+        "default_regex_engine": {
+          "name": "envoy.regex_engines.google_re2",
+          "typed_config": {
+            "@type": "type.googleapis.com/envoy.extensions.regex_engines.v3.GoogleRE2"
+          }
+        },
+        "dynamic_resources": {
+          "lds_config": {
+            "api_config_source": {
+              "api_type": "GRPC",
+              "transport_api_version": "V3",
+              "grpc_services": [
+                {
+                  "envoy_grpc": {
+                    "cluster_name": "contour",
+                    "authority": "contour"
+                  }
+                }
+              ]
+            },
+            "resource_api_version": "V3"
+          },
+          "cds_config": {
+            "api_config_source": {
+              "api_type": "GRPC",
+              "transport_api_version": "V3",
+              "grpc_services": [
+                {
+                  "envoy_grpc": {
+                    "cluster_name": "contour",
+                    "authority": "contour"
+                  }
+                }
+              ]
+            },
+            "resource_api_version": "V3"
+          }
+        },
+        "layered_runtime": {
+          "layers": [
+            {
+              "name": "dynamic",
+              "rtds_layer": {
+                "name": "dynamic",
+                "rtds_config": {
+                  "api_config_source": {
+                    "api_type": "GRPC",
+                    "transport_api_version": "V3",
+                    "grpc_services": [
+                      {
+                        "envoy_grpc": {
+                          "cluster_name": "contour",
+                          "authority": "contour"
+                        }
+                      }
+                    ]
+                  },
+                  "resource_api_version": "V3"
+                }
+              }
+            },
+            {
+              "name": "admin",
+              "admin_layer": {}
+            }
+          ]
+        },
+        "admin": {
+          "access_log": [
+            {
+              "name": "envoy.access_loggers.file",
+              "typed_config": {
+                "@type": "type.googleapis.com/envoy.extensions.access_loggers.file.v3.FileAccessLog",
+                "path": "/dev/null"
+              }
+            }
+          ],
+          "address": {
+            "pipe": {
+              "path": "/admin/admin.sock",
+              "mode": 420
+            }
+          }
+        },
+        "node": {
+          "locality": {
+            "region": "us-east-1",
+            "zone": "us-east-1a"
+          }
+        },
+        "cluster_manager": {
+          "local_cluster_name": "envoy-local"
+        }
       }`,
 		},
 	}
@@ -20,6 +20,7 @@ import (
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_cluster_aggregate_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
 	envoy_upstream_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -34,6 +35,10 @@ import (
 	"github.com/projectcontour/contour/internal/xds"
 )
 
+// SubsetMetadataNamespace is the endpoint metadata namespace
+// that Envoy subset load balancing matches against.
+const SubsetMetadataNamespace = "envoy.lb"
+
 func clusterDefaults() *envoy_config_cluster_v3.Cluster {
 	return &envoy_config_cluster_v3.Cluster{
 		ConnectTimeout: durationpb.New(2 * time.Second),
@@ -57,8 +62,21 @@ func Cluster(c *dag.Cluster) *envoy_config_cluster_v3.Cluster {
 		cluster.PerConnectionBufferLimitBytes = protobuf.UInt32OrNil(*c.PerConnectionBufferLimitBytes)
 	}
 
-	switch len(service.ExternalName) {
-	case 0:
+	switch {
+	case len(service.Endpoints) > 0:
+		// Backend endpoints are static, unless some of them
+		// need to be resolved in DNS.
+		clusterDiscoveryType := ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_STATIC)
+		for _, e := range service.Endpoints {
+			if net.ParseIP(e.Address) == nil {
+				clusterDiscoveryType = ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_STRICT_DNS)
+				break
+			}
+		}
+
+		cluster.ClusterDiscoveryType = clusterDiscoveryType
+		cluster.LoadAssignment = BackendClusterLoadAssignment(service)
+	case len(service.ExternalName) == 0:
 		// external name not set, cluster will be discovered via EDS
 		cluster.ClusterDiscoveryType = ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS)
 		cluster.EdsClusterConfig = edsconfig("contour", service)
@@ -80,6 +98,8 @@ func Cluster(c *dag.Cluster) *envoy_config_cluster_v3.Cluster {
 	}
 
 	applyCircuitBreakers(cluster, service.CircuitBreakers)
+	applyOutlierDetection(cluster, c.OutlierDetectionPolicy)
+	applySubsetLoadBalancing(cluster, c.SubsetSelector)
 
 	httpVersion := HTTPVersionAuto
 	switch c.Protocol {
@@ -135,6 +155,30 @@ func Cluster(c *dag.Cluster) *envoy_config_cluster_v3.Cluster {
 	return cluster
 }
 
+// AggregateCluster builds an aggregate envoy_config_cluster_v3.Cluster
+// that fails over between the clusters of the given dag.AggregateCluster
+// in priority order.
+func AggregateCluster(c *dag.AggregateCluster) *envoy_config_cluster_v3.Cluster {
+	clusters := make([]string, 0, len(c.Clusters))
+	for _, cluster := range c.Clusters {
+		clusters = append(clusters, envoy.Clustername(cluster))
+	}
+
+	return &envoy_config_cluster_v3.Cluster{
+		Name:           envoy.AggregateClusterName(c),
+		ConnectTimeout: durationpb.New(2 * time.Second),
+		LbPolicy:       envoy_config_cluster_v3.Cluster_CLUSTER_PROVIDED,
+		ClusterDiscoveryType: &envoy_config_cluster_v3.Cluster_ClusterType{
+			ClusterType: &envoy_config_cluster_v3.Cluster_CustomClusterType{
+				Name: "envoy.clusters.aggregate",
+				TypedConfig: protobuf.MustMarshalAny(&envoy_cluster_aggregate_v3.ClusterConfig{
+					Clusters: clusters,
+				}),
+			},
+		},
+	}
+}
+
 // ExtensionCluster builds a envoy_config_cluster_v3.Cluster struct for the given extension service.
 func ExtensionCluster(ext *dag.ExtensionCluster) *envoy_config_cluster_v3.Cluster {
 	cluster := clusterDefaults()
@@ -185,6 +229,7 @@ func ExtensionCluster(ext *dag.ExtensionCluster) *envoy_config_cluster_v3.Cluste
 	cluster.TypedExtensionProtocolOptions = protocolOptions(http2Version, ext.ClusterTimeoutPolicy.IdleConnectionTimeout, nil)
 
 	applyCircuitBreakers(cluster, ext.CircuitBreakers)
+	applyOutlierDetection(cluster, ext.OutlierDetectionPolicy)
 
 	return cluster
 }
@@ -207,6 +252,51 @@ func applyCircuitBreakers(cluster *envoy_config_cluster_v3.Cluster, settings dag
 	}
 }
 
+func applyOutlierDetection(cluster *envoy_config_cluster_v3.Cluster, policy *dag.OutlierDetectionPolicy) {
+	if policy == nil {
+		return
+	}
+
+	od := &envoy_config_cluster_v3.OutlierDetection{
+		BaseEjectionTime:   durationpb.New(policy.BaseEjectionTime),
+		MaxEjectionPercent: wrapperspb.UInt32(policy.MaxEjectionPercent),
+		// Only the consecutive error detectors are configurable, so
+		// keep the success rate detector from ejecting endpoints.
+		EnforcingSuccessRate: wrapperspb.UInt32(0),
+	}
+
+	if policy.ConsecutiveServerErrors > 0 {
+		od.Consecutive_5Xx = wrapperspb.UInt32(policy.ConsecutiveServerErrors)
+	} else {
+		od.EnforcingConsecutive_5Xx = wrapperspb.UInt32(0)
+	}
+
+	// Envoy tracks consecutive gateway failures but does
+	// not enforce ejections for them by default.
+	if policy.ConsecutiveGatewayErrors > 0 {
+		od.ConsecutiveGatewayFailure = wrapperspb.UInt32(policy.ConsecutiveGatewayErrors)
+		od.EnforcingConsecutiveGatewayFailure = wrapperspb.UInt32(100)
+	}
+
+	cluster.OutlierDetection = od
+}
+
+func applySubsetLoadBalancing(cluster *envoy_config_cluster_v3.Cluster, selector map[string]string) {
+	if len(selector) == 0 {
+		return
+	}
+
+	// Clusters are named by their subset keys, so only routes that
+	// select a subset use this cluster and there is no need to fall
+	// back to other endpoints.
+	cluster.LbSubsetConfig = &envoy_config_cluster_v3.Cluster_LbSubsetConfig{
+		FallbackPolicy: envoy_config_cluster_v3.Cluster_LbSubsetConfig_NO_FALLBACK,
+		SubsetSelectors: []*envoy_config_cluster_v3.Cluster_LbSubsetConfig_LbSubsetSelector{{
+			Keys: dag.SubsetKeys(selector),
+		}},
+	}
+}
+
 // DNSNameCluster builds a envoy_config_cluster_v3.Cluster for the given *dag.DNSNameCluster.
 func DNSNameCluster(c *dag.DNSNameCluster) *envoy_config_cluster_v3.Cluster {
 	cluster := clusterDefaults()
@@ -279,6 +369,27 @@ func ClusterCommonLBConfig() *envoy_config_cluster_v3.Cluster_CommonLbConfig {
 	}
 }
 
+// ZoneAwareRouting enables Envoy zone aware routing on an EDS cluster.
+// Clusters using a hash based load balancer are left unchanged since
+// Envoy only supports zone aware routing with the other policies.
+func ZoneAwareRouting(cluster *envoy_config_cluster_v3.Cluster, minClusterSize *uint32) {
+	if cluster.GetType() != envoy_config_cluster_v3.Cluster_EDS || cluster.LbPolicy == envoy_config_cluster_v3.Cluster_RING_HASH {
+		return
+	}
+
+	zoneAware := &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{}
+	if minClusterSize != nil {
+		zoneAware.MinClusterSize = wrapperspb.UInt64(uint64(*minClusterSize))
+	}
+
+	if cluster.CommonLbConfig == nil {
+		cluster.CommonLbConfig = ClusterCommonLBConfig()
+	}
+	cluster.CommonLbConfig.LocalityConfigSpecifier = &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+		ZoneAwareLbConfig: zoneAware,
+	}
+}
+
 // ConfigSource returns a *envoy_config_core_v3.ConfigSource for cluster.
 func ConfigSource(cluster string) *envoy_config_core_v3.ConfigSource {
 	return &envoy_config_core_v3.ConfigSource{
@@ -782,6 +782,58 @@ func TestCluster(t *testing.T) {
 				},
 			},
 		},
+		"outlier detection": {
+			cluster: &dag.Cluster{
+				Upstream: service(s1),
+				OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{
+					ConsecutiveServerErrors:  5,
+					ConsecutiveGatewayErrors: 3,
+					BaseEjectionTime:         30 * time.Second,
+					MaxEjectionPercent:       10,
+				},
+			},
+			want: &envoy_config_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/9aa2f90d69",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_config_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				OutlierDetection: &envoy_config_cluster_v3.OutlierDetection{
+					Consecutive_5Xx:                    wrapperspb.UInt32(5),
+					ConsecutiveGatewayFailure:          wrapperspb.UInt32(3),
+					EnforcingConsecutiveGatewayFailure: wrapperspb.UInt32(100),
+					EnforcingSuccessRate:               wrapperspb.UInt32(0),
+					BaseEjectionTime:                   durationpb.New(30 * time.Second),
+					MaxEjectionPercent:                 wrapperspb.UInt32(10),
+				},
+			},
+		},
+		"outlier detection without server errors": {
+			cluster: &dag.Cluster{
+				Upstream: service(s1),
+				OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{
+					BaseEjectionTime:   time.Minute,
+					MaxEjectionPercent: 50,
+				},
+			},
+			want: &envoy_config_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/50b2d7c21f",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_config_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				OutlierDetection: &envoy_config_cluster_v3.OutlierDetection{
+					EnforcingConsecutive_5Xx: wrapperspb.UInt32(0),
+					EnforcingSuccessRate:     wrapperspb.UInt32(0),
+					BaseEjectionTime:         durationpb.New(time.Minute),
+					MaxEjectionPercent:       wrapperspb.UInt32(50),
+				},
+			},
+		},
 		"cluster with per connection buffer limit bytes set": {
 			cluster: &dag.Cluster{
 				Upstream:                      service(s1),
@@ -1198,6 +1250,59 @@ func TestClustername(t *testing.T) {
 		cluster: cluster1,
 		want:    "default/backend/80/50abc1400c",
 	})
+
+	// The global client certificate is the same for every cluster, so
+	// only a certificate set by the service changes the name.
+	cluster1.ClientCertificate = &dag.Secret{
+		Object: &core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "clientcert",
+				Namespace: "default",
+			},
+		},
+	}
+	run(t, "global client certificate", testcase{
+		cluster: cluster1,
+		want:    "default/backend/80/50abc1400c",
+	})
+
+	cluster1.ServiceClientCertificate = true
+	run(t, "service client certificate", testcase{
+		cluster: cluster1,
+		want:    "default/backend/80/492f65f3f1",
+	})
+
+	outlierDetection := func(serverErrors, gatewayErrors uint32) *dag.Cluster {
+		return &dag.Cluster{
+			Upstream: &dag.Service{
+				Weighted: dag.WeightedService{
+					Weight:           1,
+					ServiceName:      "backend",
+					ServiceNamespace: "default",
+					ServicePort: core_v1.ServicePort{
+						Name:     "http",
+						Protocol: "TCP",
+						Port:     80,
+					},
+				},
+			},
+			OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{
+				ConsecutiveServerErrors:  serverErrors,
+				ConsecutiveGatewayErrors: gatewayErrors,
+				BaseEjectionTime:         30 * time.Second,
+				MaxEjectionPercent:       10,
+			},
+		}
+	}
+
+	// Concatenating the error counts without a separator would
+	// give both policies the same name.
+	t.Run("outlier detection error counts are not ambiguous", func(t *testing.T) {
+		assert.NotEqual(t,
+			envoy.Clustername(outlierDetection(1, 15)),
+			envoy.Clustername(outlierDetection(11, 5)),
+		)
+	})
 }
 
 func TestLBPolicy(t *testing.T) {
@@ -1234,6 +1339,60 @@ func TestClusterCommonLBConfig(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestZoneAwareRouting(t *testing.T) {
+	tests := map[string]struct {
+		cluster        *envoy_config_cluster_v3.Cluster
+		minClusterSize *uint32
+		want           *envoy_config_cluster_v3.Cluster_CommonLbConfig
+	}{
+		"eds cluster": {
+			cluster: &envoy_config_cluster_v3.Cluster{
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+			},
+			want: &envoy_config_cluster_v3.Cluster_CommonLbConfig{
+				HealthyPanicThreshold: &envoy_type_v3.Percent{},
+				LocalityConfigSpecifier: &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+					ZoneAwareLbConfig: &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{},
+				},
+			},
+		},
+		"eds cluster with min cluster size": {
+			cluster: &envoy_config_cluster_v3.Cluster{
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+			},
+			minClusterSize: ptr.To(uint32(3)),
+			want: &envoy_config_cluster_v3.Cluster_CommonLbConfig{
+				HealthyPanicThreshold: &envoy_type_v3.Percent{},
+				LocalityConfigSpecifier: &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+					ZoneAwareLbConfig: &envoy_config_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{
+						MinClusterSize: wrapperspb.UInt64(3),
+					},
+				},
+			},
+		},
+		"ring hash cluster": {
+			cluster: &envoy_config_cluster_v3.Cluster{
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_EDS),
+				LbPolicy:             envoy_config_cluster_v3.Cluster_RING_HASH,
+			},
+			want: nil,
+		},
+		"strict dns cluster": {
+			cluster: &envoy_config_cluster_v3.Cluster{
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_STRICT_DNS),
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ZoneAwareRouting(tc.cluster, tc.minClusterSize)
+			protobuf.ExpectEqual(t, tc.want, tc.cluster.CommonLbConfig)
+		})
+	}
+}
+
 func service(s *core_v1.Service, protocols ...string) *dag.Service {
 	protocol := ""
 	if len(protocols) > 0 {
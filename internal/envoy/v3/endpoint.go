@@ -89,3 +89,20 @@ func ExternalNameClusterLoadAssignment(service *dag.Service) *envoy_config_endpo
 	}
 	return cla
 }
+
+// BackendClusterLoadAssignment creates a *envoy_config_endpoint_v3.ClusterLoadAssignment pointing to the
+// static endpoints of a Backend.
+func BackendClusterLoadAssignment(service *dag.Service) *envoy_config_endpoint_v3.ClusterLoadAssignment {
+	addrs := make([]*envoy_config_core_v3.Address, 0, len(service.Endpoints))
+	for _, e := range service.Endpoints {
+		addrs = append(addrs, SocketAddress(e.Address, int(e.Port)))
+	}
+
+	return ClusterLoadAssignment(
+		xds.ClusterLoadAssignmentName(
+			types.NamespacedName{Name: service.Weighted.ServiceName, Namespace: service.Weighted.ServiceNamespace},
+			service.Weighted.ServicePort.Name,
+		),
+		addrs...,
+	)
+}
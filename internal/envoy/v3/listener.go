@@ -14,31 +14,45 @@
 package v3
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 
+	xds_core_v3 "github.com/cncf/xds/go/xds/core/v3"
+	xds_type_matcher_v3 "github.com/cncf/xds/go/xds/type/matcher/v3"
 	envoy_config_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	envoy_config_common_mutation_rules_v3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_compression_brotli_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
 	envoy_compression_gzip_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	envoy_compression_zstd_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/zstd/compressor/v3"
+	envoy_filter_http_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
 	envoy_filter_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_filter_http_cors_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	envoy_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_filter_http_ext_proc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	envoy_filter_http_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	envoy_filter_http_grpc_stats_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
 	envoy_filter_http_grpc_web_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	envoy_filter_http_header_mutation_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	envoy_filter_http_jwt_authn_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	envoy_filter_http_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	envoy_filter_http_lua_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_filter_http_oauth2_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/oauth2/v3"
 	envoy_filter_http_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoy_filter_http_router_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	envoy_filter_http_stateful_session_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
 	envoy_filter_listener_proxy_protocol_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/proxy_protocol/v3"
 	envoy_filter_listener_tls_inspector_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	envoy_filter_network_http_connection_manager_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_filter_network_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoy_filter_udp_udp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -154,17 +168,54 @@ func Listener(name, address string, port int, perConnectionBufferLimitBytes *uin
 	return l
 }
 
+// UDPListener returns a new envoy_config_listener_v3.Listener for the supplied address and
+// port that proxies UDP datagrams using the supplied UDP listener filter.
+func UDPListener(name, address string, port int, so *SocketOptions, lf *envoy_config_listener_v3.ListenerFilter) *envoy_config_listener_v3.Listener {
+	return &envoy_config_listener_v3.Listener{
+		Name:              name,
+		Address:           UDPSocketAddress(address, port),
+		ListenerFilters:   []*envoy_config_listener_v3.ListenerFilter{lf},
+		SocketOptions:     so.Build(),
+		UdpListenerConfig: &envoy_config_listener_v3.UdpListenerConfig{},
+	}
+}
+
+// QUICListener returns a new envoy_config_listener_v3.Listener for the supplied
+// address and port that accepts HTTP/3 connections over QUIC. Filter chains
+// are added by the caller.
+func QUICListener(name, address string, port int, so *SocketOptions) *envoy_config_listener_v3.Listener {
+	return &envoy_config_listener_v3.Listener{
+		Name:          name,
+		Address:       UDPSocketAddress(address, port),
+		SocketOptions: so.Build(),
+		UdpListenerConfig: &envoy_config_listener_v3.UdpListenerConfig{
+			QuicOptions: &envoy_config_listener_v3.QuicProtocolOptions{},
+			DownstreamSocketConfig: &envoy_config_core_v3.UdpSocketConfig{
+				PreferGro: wrapperspb.Bool(true),
+			},
+		},
+	}
+}
+
 const (
-	CORSFilterName            string = "envoy.filters.http.cors"
-	LocalRateLimitFilterName  string = "envoy.filters.http.local_ratelimit"
-	GlobalRateLimitFilterName string = "envoy.filters.http.ratelimit"
-	RBACFilterName            string = "envoy.filters.http.rbac"
-	ExtAuthzFilterName        string = "envoy.filters.http.ext_authz"
-	JWTAuthnFilterName        string = "envoy.filters.http.jwt_authn"
-	LuaFilterName             string = "envoy.filters.http.lua"
-	CompressorFilterName      string = "envoy.filters.http.compressor"
-	GRPCWebFilterName         string = "envoy.filters.http.grpc_web"
-	GRPCStatsFilterName       string = "envoy.filters.http.grpc_stats"
+	CORSFilterName             string = "envoy.filters.http.cors"
+	LocalRateLimitFilterName   string = "envoy.filters.http.local_ratelimit"
+	GlobalRateLimitFilterName  string = "envoy.filters.http.ratelimit"
+	RBACFilterName             string = "envoy.filters.http.rbac"
+	ExtAuthzFilterName         string = "envoy.filters.http.ext_authz"
+	ExtProcFilterName          string = "envoy.filters.http.ext_proc"
+	JWTAuthnFilterName         string = "envoy.filters.http.jwt_authn"
+	LuaFilterName              string = "envoy.filters.http.lua"
+	OAuth2FilterName           string = "envoy.filters.http.oauth2"
+	BasicAuthFilterName        string = "envoy.filters.http.basic_auth"
+	FaultFilterName            string = "envoy.filters.http.fault"
+	CompressorFilterName       string = "envoy.filters.http.compressor"
+	CompressorBrotliFilterName string = "envoy.filters.http.compressor.brotli"
+	CompressorZstdFilterName   string = "envoy.filters.http.compressor.zstd"
+	GRPCWebFilterName          string = "envoy.filters.http.grpc_web"
+	GRPCStatsFilterName        string = "envoy.filters.http.grpc_stats"
+	StatefulSessionFilterName  string = "envoy.filters.http.stateful_session"
+	HeaderMutationFilterName   string = "envoy.filters.http.header_mutation"
 )
 
 type httpConnectionManagerBuilder struct {
@@ -188,6 +239,8 @@ type httpConnectionManagerBuilder struct {
 	maxRequestsPerConnection      *uint32
 	http2MaxConcurrentStreams     *uint32
 	enableWebsockets              bool
+	compression                   *contour_v1alpha1.EnvoyCompression
+	compressors                   bool
 }
 
 func (b *httpConnectionManagerBuilder) EnableWebsockets(enable bool) *httpConnectionManagerBuilder {
@@ -304,38 +357,101 @@ func (b *httpConnectionManagerBuilder) HTTP2MaxConcurrentStreams(http2MaxConcurr
 	return b
 }
 
-func (b *httpConnectionManagerBuilder) DefaultFilters() *httpConnectionManagerBuilder {
-	// Add a default set of ordered http filters.
-	// The names are not required to match anything and are
-	// identified by the TypeURL of each filter.
-	b.filters = append(b.filters,
-		&envoy_filter_network_http_connection_manager_v3.HttpFilter{
-			Name: CompressorFilterName,
+// Compression sets the response compression settings used by the
+// compressor filters that DefaultFilters adds. If nil, responses are
+// compressed with gzip only.
+func (b *httpConnectionManagerBuilder) Compression(compression *contour_v1alpha1.EnvoyCompression) *httpConnectionManagerBuilder {
+	b.compression = compression
+	return b
+}
+
+// defaultCompressionContentTypes is the list of content types compressed
+// when no content types are configured.
+var defaultCompressionContentTypes = []string{
+	// Default content-types https://github.com/envoyproxy/envoy/blob/e74999dbdb12aa4d6b7a5d62d51731ea86bf72be/source/extensions/filters/http/compressor/compressor_filter.cc#L35-L38
+	"text/html", "text/plain", "text/css", "application/javascript", "application/x-javascript",
+	"text/javascript", "text/x-javascript", "text/ecmascript", "text/js", "text/jscript",
+	"text/x-js", "application/ecmascript", "application/x-json", "application/xml",
+	"application/json", "image/svg+xml", "text/xml", "application/xhtml+xml",
+	// Additional content-types for grpc-web https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md#protocol-differences-vs-grpc-over-http2
+	"application/grpc-web", "application/grpc-web+proto", "application/grpc-web+json", "application/grpc-web+thrift",
+	"application/grpc-web-text", "application/grpc-web-text+proto", "application/grpc-web-text+thrift",
+}
+
+// compressorFilters returns the compressor HTTP filters for the given
+// compression settings, one per algorithm in order of preference.
+func compressorFilters(compression *contour_v1alpha1.EnvoyCompression) []*envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	algorithms := []contour_v1alpha1.CompressionAlgorithm{contour_v1alpha1.GzipCompression}
+	contentTypes := defaultCompressionContentTypes
+	var minContentLength *wrapperspb.UInt32Value
+
+	if compression != nil {
+		if len(compression.Algorithms) > 0 {
+			algorithms = compression.Algorithms
+		}
+		if len(compression.ContentTypes) > 0 {
+			contentTypes = compression.ContentTypes
+		}
+		if compression.MinContentLength != nil {
+			minContentLength = wrapperspb.UInt32(*compression.MinContentLength)
+		}
+	}
+
+	var filters []*envoy_filter_network_http_connection_manager_v3.HttpFilter
+	for _, algorithm := range algorithms {
+		var name string
+		var library *envoy_config_core_v3.TypedExtensionConfig
+
+		switch algorithm {
+		case contour_v1alpha1.GzipCompression:
+			name = CompressorFilterName
+			library = &envoy_config_core_v3.TypedExtensionConfig{
+				Name:        "gzip",
+				TypedConfig: protobuf.MustMarshalAny(&envoy_compression_gzip_compressor_v3.Gzip{}),
+			}
+		case contour_v1alpha1.BrotliCompression:
+			name = CompressorBrotliFilterName
+			library = &envoy_config_core_v3.TypedExtensionConfig{
+				Name:        "brotli",
+				TypedConfig: protobuf.MustMarshalAny(&envoy_compression_brotli_compressor_v3.Brotli{}),
+			}
+		case contour_v1alpha1.ZstdCompression:
+			name = CompressorZstdFilterName
+			library = &envoy_config_core_v3.TypedExtensionConfig{
+				Name:        "zstd",
+				TypedConfig: protobuf.MustMarshalAny(&envoy_compression_zstd_compressor_v3.Zstd{}),
+			}
+		default:
+			continue
+		}
+
+		filters = append(filters, &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: name,
 			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
 				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_compressor_v3.Compressor{
-					CompressorLibrary: &envoy_config_core_v3.TypedExtensionConfig{
-						Name: "gzip",
-						TypedConfig: protobuf.MustMarshalAny(
-							&envoy_compression_gzip_compressor_v3.Gzip{},
-						),
-					},
+					CompressorLibrary: library,
 					ResponseDirectionConfig: &envoy_filter_http_compressor_v3.Compressor_ResponseDirectionConfig{
 						CommonConfig: &envoy_filter_http_compressor_v3.Compressor_CommonDirectionConfig{
-							ContentType: []string{
-								// Default content-types https://github.com/envoyproxy/envoy/blob/e74999dbdb12aa4d6b7a5d62d51731ea86bf72be/source/extensions/filters/http/compressor/compressor_filter.cc#L35-L38
-								"text/html", "text/plain", "text/css", "application/javascript", "application/x-javascript",
-								"text/javascript", "text/x-javascript", "text/ecmascript", "text/js", "text/jscript",
-								"text/x-js", "application/ecmascript", "application/x-json", "application/xml",
-								"application/json", "image/svg+xml", "text/xml", "application/xhtml+xml",
-								// Additional content-types for grpc-web https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md#protocol-differences-vs-grpc-over-http2
-								"application/grpc-web", "application/grpc-web+proto", "application/grpc-web+json", "application/grpc-web+thrift",
-								"application/grpc-web-text", "application/grpc-web-text+proto", "application/grpc-web-text+thrift",
-							},
+							MinContentLength: minContentLength,
+							ContentType:      contentTypes,
 						},
 					},
 				}),
 			},
-		},
+		})
+	}
+
+	return filters
+}
+
+func (b *httpConnectionManagerBuilder) DefaultFilters() *httpConnectionManagerBuilder {
+	// Add a default set of ordered http filters.
+	// The names are not required to match anything and are
+	// identified by the TypeURL of each filter.
+	// The compressor filters go first and are added by Get, once
+	// the compression settings are known.
+	b.compressors = true
+	b.filters = append(b.filters,
 		&envoy_filter_network_http_connection_manager_v3.HttpFilter{
 			Name: GRPCWebFilterName,
 			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
@@ -389,6 +505,26 @@ func (b *httpConnectionManagerBuilder) DefaultFilters() *httpConnectionManagerBu
 				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_rbac_v3.RBAC{}),
 			},
 		},
+		&envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: StatefulSessionFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(
+					// since no session state is defined here, the filter is disabled
+					// globally but can be enabled on a per-route basis.
+					&envoy_filter_http_stateful_session_v3.StatefulSession{},
+				),
+			},
+		},
+		&envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: FaultFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(
+					// since no faults are defined here, the filter is disabled
+					// globally but can be enabled on a per-route basis.
+					&envoy_filter_http_fault_v3.HTTPFault{},
+				),
+			},
+		},
 		&envoy_filter_network_http_connection_manager_v3.HttpFilter{
 			Name: "router",
 			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
@@ -521,6 +657,10 @@ func (b *httpConnectionManagerBuilder) Get() *envoy_config_listener_v3.Filter {
 		DelayedCloseTimeout: envoy.Timeout(b.delayedCloseTimeout),
 	}
 
+	if b.compressors {
+		cm.HttpFilters = append(compressorFilters(b.compression), b.filters...)
+	}
+
 	// Max connection duration is infinite/disabled by default in Envoy, so if the timeout setting
 	// indicates to either disable or use default, don't pass a value at all. Note that unlike other
 	// Envoy timeouts, explicitly passing a 0 here *would not* disable the timeout; it needs to be
@@ -562,6 +702,10 @@ func (b *httpConnectionManagerBuilder) Get() *envoy_config_listener_v3.Filter {
 		}
 	}
 
+	if b.codec == HTTPVersion3 {
+		cm.Http3ProtocolOptions = &envoy_config_core_v3.Http3ProtocolOptions{}
+	}
+
 	if b.enableWebsockets {
 		cm.UpgradeConfigs = append(cm.UpgradeConfigs,
 			&envoy_filter_network_http_connection_manager_v3.HttpConnectionManager_UpgradeConfig{
@@ -671,6 +815,46 @@ func TCPProxy(statPrefix string, proxy *dag.TCPProxy, accesslogger []*envoy_conf
 	}
 }
 
+// UDPProxyFilterName is the name of Envoy's UDP proxy listener filter.
+const UDPProxyFilterName string = "envoy.filters.udp_listener.udp_proxy"
+
+// UDPProxy creates a new UDP proxy listener filter.
+func UDPProxy(statPrefix string, proxy *dag.UDPProxy, accesslogger []*envoy_config_accesslog_v3.AccessLog) *envoy_config_listener_v3.ListenerFilter {
+	// The UDP proxy filter only supports a single upstream
+	// cluster per listener, which is selected by a matcher
+	// that always falls through to its on_no_match action.
+	var clusterName string
+	if len(proxy.Clusters) > 0 {
+		clusterName = envoy.Clustername(proxy.Clusters[0])
+	}
+
+	udpProxy := &envoy_filter_udp_udp_proxy_v3.UdpProxyConfig{
+		StatPrefix: statPrefix,
+		AccessLog:  accesslogger,
+		RouteSpecifier: &envoy_filter_udp_udp_proxy_v3.UdpProxyConfig_Matcher{
+			Matcher: &xds_type_matcher_v3.Matcher{
+				OnNoMatch: &xds_type_matcher_v3.Matcher_OnMatch{
+					OnMatch: &xds_type_matcher_v3.Matcher_OnMatch_Action{
+						Action: &xds_core_v3.TypedExtensionConfig{
+							Name: "route",
+							TypedConfig: protobuf.MustMarshalAny(&envoy_filter_udp_udp_proxy_v3.Route{
+								Cluster: clusterName,
+							}),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &envoy_config_listener_v3.ListenerFilter{
+		Name: UDPProxyFilterName,
+		ConfigType: &envoy_config_listener_v3.ListenerFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(udpProxy),
+		},
+	}
+}
+
 // UnixSocketAddress creates a new Unix Socket envoy_config_core_v3.Address.
 func UnixSocketAddress(address string) *envoy_config_core_v3.Address {
 	return &envoy_config_core_v3.Address{
@@ -683,6 +867,13 @@ func UnixSocketAddress(address string) *envoy_config_core_v3.Address {
 	}
 }
 
+// UDPSocketAddress creates a new UDP envoy_config_core_v3.Address.
+func UDPSocketAddress(address string, port int) *envoy_config_core_v3.Address {
+	addr := SocketAddress(address, port)
+	addr.GetSocketAddress().Protocol = envoy_config_core_v3.SocketAddress_UDP
+	return addr
+}
+
 // SocketAddress creates a new TCP envoy_config_core_v3.Address.
 func SocketAddress(address string, port int) *envoy_config_core_v3.Address {
 	if address == "::" {
@@ -826,6 +1017,182 @@ func FilterExternalAuthz(externalAuthorization *dag.ExternalAuthorization) *envo
 	}
 }
 
+// FilterExternalProcessing returns an `ext_proc` filter configured with the
+// requested parameters.
+func FilterExternalProcessing(externalProcessing *dag.ExternalProcessing) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	if externalProcessing == nil {
+		return nil
+	}
+
+	procConfig := envoy_filter_http_ext_proc_v3.ExternalProcessor{
+		// The processing stream lives as long as the client
+		// request, so the gRPC timeout is left unset and the
+		// response timeout applies to each message instead.
+		GrpcService:      GrpcService(externalProcessing.ProcessingService.Name, externalProcessing.ProcessingService.SNI, timeout.DefaultSetting()),
+		FailureModeAllow: externalProcessing.FailOpen,
+		MessageTimeout:   envoy.Timeout(externalProcessing.ResponseTimeout),
+		ProcessingMode:   processingMode(externalProcessing.ProcessingMode),
+	}
+
+	return &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: ExtProcFilterName,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&procConfig),
+		},
+	}
+}
+
+// processingMode converts a DAG external processing mode to the
+// Envoy representation. Unset fields map to the Envoy defaults.
+func processingMode(pm *dag.ProcessingMode) *envoy_filter_http_ext_proc_v3.ProcessingMode {
+	if pm == nil {
+		return nil
+	}
+
+	headerMode := func(mode dag.HeaderSendMode) envoy_filter_http_ext_proc_v3.ProcessingMode_HeaderSendMode {
+		switch mode {
+		case dag.HeaderSendModeSend:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_SEND
+		case dag.HeaderSendModeSkip:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_SKIP
+		default:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_DEFAULT
+		}
+	}
+	bodyMode := func(mode dag.BodySendMode) envoy_filter_http_ext_proc_v3.ProcessingMode_BodySendMode {
+		switch mode {
+		case dag.BodySendModeStreamed:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_STREAMED
+		case dag.BodySendModeBuffered:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_BUFFERED
+		case dag.BodySendModeBufferedPartial:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_BUFFERED_PARTIAL
+		default:
+			return envoy_filter_http_ext_proc_v3.ProcessingMode_NONE
+		}
+	}
+
+	return &envoy_filter_http_ext_proc_v3.ProcessingMode{
+		RequestHeaderMode:  headerMode(pm.RequestHeaderMode),
+		ResponseHeaderMode: headerMode(pm.ResponseHeaderMode),
+		RequestBodyMode:    bodyMode(pm.RequestBodyMode),
+		ResponseBodyMode:   bodyMode(pm.ResponseBodyMode),
+	}
+}
+
+// FilterOAuth2 returns an `oauth2` filter configured with the
+// requested parameters.
+func FilterOAuth2(oauth2 *dag.OAuth2) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	if oauth2 == nil {
+		return nil
+	}
+
+	exactPath := func(path string) *envoy_matcher_v3.PathMatcher {
+		return &envoy_matcher_v3.PathMatcher{
+			Rule: &envoy_matcher_v3.PathMatcher_Path{
+				Path: &envoy_matcher_v3.StringMatcher{
+					MatchPattern: &envoy_matcher_v3.StringMatcher_Exact{
+						Exact: path,
+					},
+				},
+			},
+		}
+	}
+
+	sdsSecret := func(key string) *envoy_transport_socket_tls_v3.SdsSecretConfig {
+		return &envoy_transport_socket_tls_v3.SdsSecretConfig{
+			Name:      envoy.OAuth2SecretName(oauth2.ClientSecret, key),
+			SdsConfig: ConfigSource("contour"),
+		}
+	}
+
+	oauth2Config := envoy_filter_http_oauth2_v3.OAuth2{
+		Config: &envoy_filter_http_oauth2_v3.OAuth2Config{
+			TokenEndpoint: &envoy_config_core_v3.HttpUri{
+				Uri: oauth2.TokenEndpoint,
+				HttpUpstreamType: &envoy_config_core_v3.HttpUri_Cluster{
+					Cluster: envoy.DNSNameClusterName(&oauth2.TokenEndpointCluster),
+				},
+				Timeout: durationpb.New(oauth2.TokenEndpointTimeout),
+			},
+			AuthorizationEndpoint: oauth2.AuthorizationEndpoint,
+			Credentials: &envoy_filter_http_oauth2_v3.OAuth2Credentials{
+				ClientId:    oauth2.ClientID,
+				TokenSecret: sdsSecret(dag.OAuth2ClientSecretKey),
+				TokenFormation: &envoy_filter_http_oauth2_v3.OAuth2Credentials_HmacSecret{
+					HmacSecret: sdsSecret(dag.OAuth2HMACSecretKey),
+				},
+			},
+			// OAuth2 is only permitted on virtual hosts
+			// that terminate TLS.
+			RedirectUri:         "https://%REQ(:authority)%" + oauth2.RedirectPath,
+			RedirectPathMatcher: exactPath(oauth2.RedirectPath),
+			SignoutPath:         exactPath(oauth2.SignoutPath),
+			ForwardBearerToken:  oauth2.ForwardBearerToken,
+			PassThroughMatcher:  headerMatcher(oauth2.PassThroughMatchers),
+			AuthScopes:          oauth2.Scopes,
+		},
+	}
+
+	return &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: OAuth2FilterName,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&oauth2Config),
+		},
+	}
+}
+
+// BasicAuthConfigName returns the name of the `basic_auth` filter,
+// and of its extension config, that authenticates requests to the
+// given virtual host.
+func BasicAuthConfigName(vhost string) string {
+	return BasicAuthFilterName + "/" + vhost
+}
+
+// BasicAuthOverrideConfigName returns the name of the `basic_auth`
+// filter, and of its extension config, that authenticates requests
+// to routes overriding the htpasswd users with the given Secret.
+func BasicAuthOverrideConfigName(secret *dag.Secret) string {
+	return BasicAuthFilterName + "/" + secret.Namespace() + "/" + secret.Name()
+}
+
+// FilterBasicAuth returns a `basic_auth` filter whose config is
+// fetched from Contour over ECDS, so that the htpasswd users never
+// appear in the listener config. A disabled filter only runs for
+// routes that explicitly enable it.
+func FilterBasicAuth(name string, disabled bool) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	return &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: name,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_ConfigDiscovery{
+			ConfigDiscovery: &envoy_config_core_v3.ExtensionConfigSource{
+				ConfigSource: ConfigSource("contour"),
+				TypeUrls:     []string{"type.googleapis.com/envoy.extensions.filters.http.basic_auth.v3.BasicAuth"},
+			},
+		},
+		Disabled: disabled,
+	}
+}
+
+// BasicAuthExtensionConfig returns the named `basic_auth` filter
+// config that authenticates requests against the htpasswd users in
+// the given Secret.
+func BasicAuthExtensionConfig(name string, secret *dag.Secret) *envoy_config_core_v3.TypedExtensionConfig {
+	// Envoy splits users on "\n" only, so normalize htpasswd
+	// files written with CRLF line endings.
+	users := bytes.ReplaceAll(secret.Object.Data[dag.BasicAuthKey], []byte("\r\n"), []byte("\n"))
+
+	return &envoy_config_core_v3.TypedExtensionConfig{
+		Name: name,
+		TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_basic_auth_v3.BasicAuth{
+			Users: &envoy_config_core_v3.DataSource{
+				Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+					InlineBytes: users,
+				},
+			},
+		}),
+	}
+}
+
 // FilterJWTAuthN returns a `jwt_authn` filter configured with the
 // requested parameters.
 func FilterJWTAuthN(jwtProviders []dag.JWTProvider) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
@@ -910,6 +1277,54 @@ func FilterChainTLS(domain string, downstream *envoy_transport_socket_tls_v3.Dow
 	return fc
 }
 
+// FilterChainQUIC returns a QUIC enabled envoy_config_listener_v3.FilterChain.
+func FilterChainQUIC(domain string, downstream *envoy_transport_socket_tls_v3.DownstreamTlsContext, filters []*envoy_config_listener_v3.Filter) *envoy_config_listener_v3.FilterChain {
+	fc := &envoy_config_listener_v3.FilterChain{
+		Filters:         filters,
+		TransportSocket: DownstreamQUICTransportSocket(downstream),
+	}
+
+	// As with TLS filter chains, a wildcard domain matches
+	// any QUIC connection to this listener.
+	if domain == "*" {
+		fc.FilterChainMatch = &envoy_config_listener_v3.FilterChainMatch{
+			TransportProtocol: "quic",
+		}
+	} else {
+		fc.FilterChainMatch = &envoy_config_listener_v3.FilterChainMatch{
+			ServerNames: []string{domain},
+		}
+	}
+
+	return fc
+}
+
+// FilterAltSvc returns an HTTP filter that advertises an HTTP/3
+// endpoint on the given port to clients with an alt-svc response
+// header, unless the upstream already set one.
+func FilterAltSvc(port uint32) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	return &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: HeaderMutationFilterName,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_header_mutation_v3.HeaderMutation{
+				Mutations: &envoy_filter_http_header_mutation_v3.Mutations{
+					ResponseMutations: []*envoy_config_common_mutation_rules_v3.HeaderMutation{{
+						Action: &envoy_config_common_mutation_rules_v3.HeaderMutation_Append{
+							Append: &envoy_config_core_v3.HeaderValueOption{
+								Header: &envoy_config_core_v3.HeaderValue{
+									Key:   "alt-svc",
+									Value: fmt.Sprintf(`h3=":%d"; ma=86400`, port),
+								},
+								AppendAction: envoy_config_core_v3.HeaderValueOption_ADD_IF_ABSENT,
+							},
+						},
+					}},
+				},
+			}),
+		},
+	}
+}
+
 // FilterChainTLSFallback returns a TLS enabled envoy_config_listener_v3.FilterChain configured for FallbackCertificate.
 func FilterChainTLSFallback(downstream *envoy_transport_socket_tls_v3.DownstreamTlsContext, filters []*envoy_config_listener_v3.Filter) *envoy_config_listener_v3.FilterChain {
 	fc := &envoy_config_listener_v3.FilterChain{
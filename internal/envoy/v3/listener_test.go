@@ -20,21 +20,28 @@ import (
 	envoy_config_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_compression_brotli_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
 	envoy_compression_gzip_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	envoy_compression_zstd_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/zstd/compressor/v3"
+	envoy_filter_http_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
 	envoy_filter_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_filter_http_cors_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	envoy_filter_http_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	envoy_filter_http_grpc_stats_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
 	envoy_filter_http_grpc_web_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	envoy_filter_http_header_mutation_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	envoy_filter_http_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	envoy_filter_http_lua_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoy_filter_http_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoy_filter_http_router_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	envoy_filter_http_stateful_session_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
 	envoy_filter_network_http_connection_manager_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_filter_network_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -640,6 +647,16 @@ func TestHTTPConnectionManager(t *testing.T) {
 			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
 				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_rbac_v3.RBAC{}),
 			},
+		}, {
+			Name: StatefulSessionFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_stateful_session_v3.StatefulSession{}),
+			},
+		}, {
+			Name: FaultFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_fault_v3.HTTPFault{}),
+			},
 		}, {
 			Name: "router",
 			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
@@ -1717,6 +1734,118 @@ func TestFilterChainTLS_Match(t *testing.T) {
 	}
 }
 
+func TestFilterChainQUIC_Match(t *testing.T) {
+	tests := map[string]struct {
+		domain string
+		want   *envoy_config_listener_v3.FilterChainMatch
+	}{
+		"SNI": {
+			domain: "projectcontour.io",
+			want: &envoy_config_listener_v3.FilterChainMatch{
+				ServerNames: []string{"projectcontour.io"},
+			},
+		},
+		"No SNI": {
+			domain: "*",
+			want: &envoy_config_listener_v3.FilterChainMatch{
+				TransportProtocol: "quic",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FilterChainQUIC(tc.domain, &envoy_transport_socket_tls_v3.DownstreamTlsContext{}, nil)
+			protobuf.ExpectEqual(t, tc.want, got.FilterChainMatch)
+			assert.Equal(t, "envoy.transport_sockets.quic", got.TransportSocket.Name)
+		})
+	}
+}
+
+func TestQUICListener(t *testing.T) {
+	got := QUICListener("ingress_https_quic", "0.0.0.0", 8443, NewSocketOptions())
+	want := &envoy_config_listener_v3.Listener{
+		Name:    "ingress_https_quic",
+		Address: UDPSocketAddress("0.0.0.0", 8443),
+		UdpListenerConfig: &envoy_config_listener_v3.UdpListenerConfig{
+			QuicOptions: &envoy_config_listener_v3.QuicProtocolOptions{},
+			DownstreamSocketConfig: &envoy_config_core_v3.UdpSocketConfig{
+				PreferGro: wrapperspb.Bool(true),
+			},
+		},
+	}
+	protobuf.ExpectEqual(t, want, got)
+}
+
+func TestHTTPConnectionManagerHTTP3(t *testing.T) {
+	hcm := &envoy_filter_network_http_connection_manager_v3.HttpConnectionManager{}
+
+	got := HTTPConnectionManagerBuilder().
+		RouteConfigName("default/kuard").
+		MetricsPrefix("default/kuard").
+		Codec(HTTPVersion3).
+		DefaultFilters().
+		Get()
+	require.NoError(t, got.GetTypedConfig().UnmarshalTo(hcm))
+	assert.Equal(t, HTTPVersion3, hcm.CodecType)
+	assert.NotNil(t, hcm.Http3ProtocolOptions)
+
+	got = HTTPConnectionManagerBuilder().
+		RouteConfigName("default/kuard").
+		MetricsPrefix("default/kuard").
+		DefaultFilters().
+		Get()
+	require.NoError(t, got.GetTypedConfig().UnmarshalTo(hcm))
+	assert.Nil(t, hcm.Http3ProtocolOptions)
+}
+
+func TestFilterAltSvc(t *testing.T) {
+	got := FilterAltSvc(443)
+	assert.Equal(t, HeaderMutationFilterName, got.Name)
+
+	hm := &envoy_filter_http_header_mutation_v3.HeaderMutation{}
+	require.NoError(t, got.GetTypedConfig().UnmarshalTo(hm))
+	require.Len(t, hm.Mutations.ResponseMutations, 1)
+
+	opt := hm.Mutations.ResponseMutations[0].GetAppend()
+	assert.Equal(t, "alt-svc", opt.Header.Key)
+	assert.Equal(t, `h3=":443"; ma=86400`, opt.Header.Value)
+	assert.Equal(t, envoy_config_core_v3.HeaderValueOption_ADD_IF_ABSENT, opt.AppendAction)
+}
+
+func TestFilterBasicAuth(t *testing.T) {
+	got := FilterBasicAuth("envoy.filters.http.basic_auth/default/admins", true)
+	assert.Equal(t, "envoy.filters.http.basic_auth/default/admins", got.Name)
+	assert.True(t, got.Disabled)
+	assert.Nil(t, got.GetTypedConfig())
+	assert.Equal(t, []string{"type.googleapis.com/envoy.extensions.filters.http.basic_auth.v3.BasicAuth"}, got.GetConfigDiscovery().TypeUrls)
+	protobuf.ExpectEqual(t, ConfigSource("contour"), got.GetConfigDiscovery().ConfigSource)
+}
+
+func TestBasicAuthExtensionConfig(t *testing.T) {
+	secret := &dag.Secret{
+		Object: &core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "admins",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				dag.BasicAuthKey: []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\r\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\r\n"),
+			},
+		},
+	}
+
+	name := BasicAuthOverrideConfigName(secret)
+	assert.Equal(t, "envoy.filters.http.basic_auth/default/admins", name)
+
+	got := BasicAuthExtensionConfig(name, secret)
+	assert.Equal(t, name, got.Name)
+
+	ba := &envoy_filter_http_basic_auth_v3.BasicAuth{}
+	require.NoError(t, got.GetTypedConfig().UnmarshalTo(ba))
+	assert.Equal(t, "alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n", string(ba.Users.GetInlineBytes()))
+}
+
 // TestBuilderValidation tests that validation checks that
 // DefaultFilters adds the required HTTP connection manager filters.
 func TestBuilderValidation(t *testing.T) {
@@ -1748,6 +1877,80 @@ func TestBuilderValidation(t *testing.T) {
 	require.Errorf(t, badBuilder.Validate(), "Adding a filter after the Router filter should fail")
 }
 
+func TestCompressorFilters(t *testing.T) {
+	compressor := func(name, library string, config proto.Message, minContentLength *wrapperspb.UInt32Value, contentTypes []string) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+		return &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: name,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_compressor_v3.Compressor{
+					CompressorLibrary: &envoy_config_core_v3.TypedExtensionConfig{
+						Name:        library,
+						TypedConfig: protobuf.MustMarshalAny(config),
+					},
+					ResponseDirectionConfig: &envoy_filter_http_compressor_v3.Compressor_ResponseDirectionConfig{
+						CommonConfig: &envoy_filter_http_compressor_v3.Compressor_CommonDirectionConfig{
+							MinContentLength: minContentLength,
+							ContentType:      contentTypes,
+						},
+					},
+				}),
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		compression *contour_v1alpha1.EnvoyCompression
+		want        []*envoy_filter_network_http_connection_manager_v3.HttpFilter
+	}{
+		"default": {
+			compression: nil,
+			want: []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
+				compressor(CompressorFilterName, "gzip", &envoy_compression_gzip_compressor_v3.Gzip{}, nil, compressorContentTypes),
+			},
+		},
+		"empty settings": {
+			compression: &contour_v1alpha1.EnvoyCompression{},
+			want: []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
+				compressor(CompressorFilterName, "gzip", &envoy_compression_gzip_compressor_v3.Gzip{}, nil, compressorContentTypes),
+			},
+		},
+		"all algorithms in preference order": {
+			compression: &contour_v1alpha1.EnvoyCompression{
+				Algorithms: []contour_v1alpha1.CompressionAlgorithm{
+					contour_v1alpha1.ZstdCompression,
+					contour_v1alpha1.BrotliCompression,
+					contour_v1alpha1.GzipCompression,
+				},
+				MinContentLength: ptr.To(uint32(1024)),
+				ContentTypes:     []string{"text/html", "application/json"},
+			},
+			want: []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
+				compressor(CompressorZstdFilterName, "zstd", &envoy_compression_zstd_compressor_v3.Zstd{}, wrapperspb.UInt32(1024), []string{"text/html", "application/json"}),
+				compressor(CompressorBrotliFilterName, "brotli", &envoy_compression_brotli_compressor_v3.Brotli{}, wrapperspb.UInt32(1024), []string{"text/html", "application/json"}),
+				compressor(CompressorFilterName, "gzip", &envoy_compression_gzip_compressor_v3.Gzip{}, wrapperspb.UInt32(1024), []string{"text/html", "application/json"}),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Compression takes effect regardless of whether it
+			// is set before or after the default filters.
+			for _, b := range []*httpConnectionManagerBuilder{
+				HTTPConnectionManagerBuilder().Compression(tc.compression).DefaultFilters(),
+				HTTPConnectionManagerBuilder().DefaultFilters().Compression(tc.compression),
+			} {
+				hcm := &envoy_filter_network_http_connection_manager_v3.HttpConnectionManager{}
+				require.NoError(t, b.Get().GetTypedConfig().UnmarshalTo(hcm))
+
+				got := hcm.HttpFilters
+				protobuf.ExpectEqual(t, tc.want, got[:len(tc.want)])
+				assert.Equal(t, GRPCWebFilterName, got[len(tc.want)].Name)
+			}
+		})
+	}
+}
+
 func TestAddFilter(t *testing.T) {
 	routerFilter := &envoy_filter_network_http_connection_manager_v3.HttpFilter{
 		Name: "router",
@@ -1797,6 +2000,18 @@ func TestAddFilter(t *testing.T) {
 			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_rbac_v3.RBAC{}),
 		},
 	}
+	statefulSessionFilter := &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: StatefulSessionFilterName,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_stateful_session_v3.StatefulSession{}),
+		},
+	}
+	faultFilter := &envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		Name: FaultFilterName,
+		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_fault_v3.HTTPFault{}),
+		},
+	}
 
 	localRateLimitFilter := &envoy_filter_network_http_connection_manager_v3.HttpFilter{
 		Name: LocalRateLimitFilterName,
@@ -1809,25 +2024,6 @@ func TestAddFilter(t *testing.T) {
 		},
 	}
 
-	compressFilter := &envoy_filter_network_http_connection_manager_v3.HttpFilter{
-		Name: CompressorFilterName,
-		ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
-			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_compressor_v3.Compressor{
-				CompressorLibrary: &envoy_config_core_v3.TypedExtensionConfig{
-					Name: "gzip",
-					TypedConfig: protobuf.MustMarshalAny(
-						&envoy_compression_gzip_compressor_v3.Gzip{},
-					),
-				},
-				ResponseDirectionConfig: &envoy_filter_http_compressor_v3.Compressor_ResponseDirectionConfig{
-					CommonConfig: &envoy_filter_http_compressor_v3.Compressor_CommonDirectionConfig{
-						ContentType: compressorContentTypes,
-					},
-				},
-			}),
-		},
-	}
-
 	tests := map[string]struct {
 		builder *httpConnectionManagerBuilder
 		add     *envoy_filter_network_http_connection_manager_v3.HttpFilter
@@ -1863,13 +2059,14 @@ func TestAddFilter(t *testing.T) {
 			builder: HTTPConnectionManagerBuilder().DefaultFilters(),
 			add:     authzFilter(),
 			want: []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
-				compressFilter,
 				grpcWebFilter,
 				grpcStatsFilter,
 				corsFilter,
 				localRateLimitFilter,
 				luaFilter,
 				rbacFilter,
+				statefulSessionFilter,
+				faultFilter,
 				authzFilter(),
 				routerFilter,
 			},
@@ -1882,13 +2079,14 @@ func TestAddFilter(t *testing.T) {
 				PackAsBytes:         true,
 			}),
 			want: []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
-				compressFilter,
 				grpcWebFilter,
 				grpcStatsFilter,
 				corsFilter,
 				localRateLimitFilter,
 				luaFilter,
 				rbacFilter,
+				statefulSessionFilter,
+				faultFilter,
 				authzFilter("ext-auth-server.com", &dag.AuthorizationServerBufferSettings{
 					MaxRequestBytes:     10,
 					AllowPartialMessage: true,
@@ -24,13 +24,21 @@ import (
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_common_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	envoy_filter_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_filter_http_cors_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	envoy_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_filter_http_ext_proc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	envoy_filter_http_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	envoy_filter_http_jwt_authn_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	envoy_filter_http_lua_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoy_filter_http_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoy_filter_http_stateful_session_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
+	envoy_stateful_session_cookie_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/stateful_session/cookie/v3"
+	envoy_stateful_session_header_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/stateful_session/header/v3"
 	envoy_internal_redirect_previous_routes_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/internal_redirect/previous_routes/v3"
 	envoy_internal_redirect_safe_cross_scheme_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/internal_redirect/safe_cross_scheme/v3"
+	envoy_type_http_v3 "github.com/envoyproxy/go-control-plane/envoy/type/http/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -131,6 +139,10 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool) *envoy_confi
 			route.TypedPerFilterConfig["envoy.filters.http.ext_authz"] = routeAuthzContext(dagRoute.AuthContext)
 		}
 
+		if dagRoute.BasicAuthDisabled {
+			route.TypedPerFilterConfig[BasicAuthConfigName(vhostName)] = routeFilterDisabled()
+		}
+
 		route.Action = routeDirectResponse(dagRoute.DirectResponse)
 	case dagRoute.Redirect != nil:
 		// TODO request/response headers?
@@ -165,6 +177,36 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool) *envoy_confi
 			route.TypedPerFilterConfig[ExtAuthzFilterName] = routeAuthzContext(dagRoute.AuthContext)
 		}
 
+		// Disable response compression for this route.
+		if dagRoute.CompressionDisabled {
+			for _, name := range []string{CompressorFilterName, CompressorBrotliFilterName, CompressorZstdFilterName} {
+				route.TypedPerFilterConfig[name] = routeCompressionDisabled()
+			}
+		}
+
+		// Apply per-route external processing policy modifications.
+		if dagRoute.ExternalProcessingDisabled {
+			route.TypedPerFilterConfig[ExtProcFilterName] = routeExtProcDisabled()
+		} else if dagRoute.ExternalProcessingMode != nil {
+			route.TypedPerFilterConfig[ExtProcFilterName] = routeExtProcMode(dagRoute.ExternalProcessingMode)
+		}
+
+		// Apply per-route basic authentication modifications.
+		// BasicAuthPerRoute would inline the htpasswd users, so
+		// overrides instead swap the virtual host filter for the
+		// disabled filter holding the route's users.
+		if dagRoute.BasicAuthDisabled {
+			route.TypedPerFilterConfig[BasicAuthConfigName(vhostName)] = routeFilterDisabled()
+		} else if dagRoute.BasicAuthSecret != nil {
+			route.TypedPerFilterConfig[BasicAuthConfigName(vhostName)] = routeFilterDisabled()
+			route.TypedPerFilterConfig[BasicAuthOverrideConfigName(dagRoute.BasicAuthSecret)] = routeFilterEnabled()
+		}
+
+		// Apply per-route fault injection policy.
+		if dagRoute.FaultInjectionPolicy != nil {
+			route.TypedPerFilterConfig[FaultFilterName] = protobuf.MustMarshalAny(faultInjection(dagRoute.FaultInjectionPolicy))
+		}
+
 		// If JWT verification is enabled, add per-route filter
 		// config referencing a requirement in the main filter
 		// config.
@@ -181,6 +223,20 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool) *envoy_confi
 			)
 		}
 
+		// If session persistence is enabled, add per-route
+		// stateful session config.
+		if dagRoute.SessionPersistence != nil {
+			route.TypedPerFilterConfig[StatefulSessionFilterName] = protobuf.MustMarshalAny(
+				statefulSessionPerRoute(dagRoute.SessionPersistence),
+			)
+		}
+
+		// Apply per-route CORS policy, overriding the virtual
+		// host policy if there is one.
+		if dagRoute.CORSPolicy != nil {
+			route.TypedPerFilterConfig[CORSFilterName] = protobuf.MustMarshalAny(corsPolicy(dagRoute.CORSPolicy))
+		}
+
 		// Remove empty map if no per-filter config was added.
 		if len(route.TypedPerFilterConfig) == 0 {
 			route.TypedPerFilterConfig = nil
@@ -190,6 +246,45 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool) *envoy_confi
 	return route
 }
 
+// statefulSessionPerRoute returns a per-route config that enables
+// the stateful session filter using the given session persistence.
+func statefulSessionPerRoute(sp *dag.SessionPersistence) *envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute {
+	var sessionState *envoy_config_core_v3.TypedExtensionConfig
+
+	switch sp.Type {
+	case dag.SessionPersistenceHeader:
+		sessionState = &envoy_config_core_v3.TypedExtensionConfig{
+			Name: "envoy.http.stateful_session.header",
+			TypedConfig: protobuf.MustMarshalAny(&envoy_stateful_session_header_v3.HeaderBasedSessionState{
+				Name: sp.Name,
+			}),
+		}
+	default:
+		cookie := &envoy_type_http_v3.Cookie{
+			Name: sp.Name,
+			Path: "/",
+		}
+		if sp.CookieTTL > 0 {
+			cookie.Ttl = durationpb.New(sp.CookieTTL)
+		}
+
+		sessionState = &envoy_config_core_v3.TypedExtensionConfig{
+			Name: "envoy.http.stateful_session.cookie",
+			TypedConfig: protobuf.MustMarshalAny(&envoy_stateful_session_cookie_v3.CookieBasedSessionState{
+				Cookie: cookie,
+			}),
+		}
+	}
+
+	return &envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute{
+		Override: &envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute_StatefulSession{
+			StatefulSession: &envoy_filter_http_stateful_session_v3.StatefulSession{
+				SessionState: sessionState,
+			},
+		},
+	}
+}
+
 // routeAuthzDisabled returns a per-route config to disable authorization.
 func routeAuthzDisabled() *anypb.Any {
 	return protobuf.MustMarshalAny(
@@ -215,6 +310,97 @@ func routeAuthzContext(settings map[string]string) *anypb.Any {
 	)
 }
 
+// routeCompressionDisabled returns a per-route config to disable response compression.
+func routeCompressionDisabled() *anypb.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_filter_http_compressor_v3.CompressorPerRoute{
+			Override: &envoy_filter_http_compressor_v3.CompressorPerRoute_Disabled{
+				Disabled: true,
+			},
+		},
+	)
+}
+
+// routeFilterDisabled returns a per-route config that disables the
+// filter it is keyed by.
+func routeFilterDisabled() *anypb.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_config_route_v3.FilterConfig{
+			Disabled: true,
+		},
+	)
+}
+
+// routeFilterEnabled returns a per-route config that enables the
+// filter it is keyed by, even if the filter is disabled by default.
+// Envoy treats an empty config as an explicit enable.
+func routeFilterEnabled() *anypb.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_config_route_v3.FilterConfig{
+			Config: &anypb.Any{},
+		},
+	)
+}
+
+// faultInjection returns the fault filter config that injects the
+// delays and aborts of the given policy.
+func faultInjection(fp *dag.FaultInjectionPolicy) *envoy_filter_http_fault_v3.HTTPFault {
+	fault := &envoy_filter_http_fault_v3.HTTPFault{
+		Headers: headerMatcher(fp.HeaderMatchConditions),
+	}
+
+	if fp.Delay != nil {
+		fault.Delay = &envoy_filter_common_fault_v3.FaultDelay{
+			FaultDelaySecifier: &envoy_filter_common_fault_v3.FaultDelay_FixedDelay{
+				FixedDelay: durationpb.New(fp.Delay.Duration),
+			},
+			Percentage: &envoy_type_v3.FractionalPercent{
+				Numerator:   fp.Delay.Percentage,
+				Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+			},
+		}
+	}
+
+	if fp.Abort != nil {
+		fault.Abort = &envoy_filter_http_fault_v3.FaultAbort{
+			ErrorType: &envoy_filter_http_fault_v3.FaultAbort_HttpStatus{
+				HttpStatus: fp.Abort.StatusCode,
+			},
+			Percentage: &envoy_type_v3.FractionalPercent{
+				Numerator:   fp.Abort.Percentage,
+				Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+			},
+		}
+	}
+
+	return fault
+}
+
+// routeExtProcDisabled returns a per-route config to disable external processing.
+func routeExtProcDisabled() *anypb.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_filter_http_ext_proc_v3.ExtProcPerRoute{
+			Override: &envoy_filter_http_ext_proc_v3.ExtProcPerRoute_Disabled{
+				Disabled: true,
+			},
+		},
+	)
+}
+
+// routeExtProcMode returns a per-route config to override the
+// external processing mode.
+func routeExtProcMode(pm *dag.ProcessingMode) *anypb.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_filter_http_ext_proc_v3.ExtProcPerRoute{
+			Override: &envoy_filter_http_ext_proc_v3.ExtProcPerRoute_Overrides{
+				Overrides: &envoy_filter_http_ext_proc_v3.ExtProcOverrides{
+					ProcessingMode: processingMode(pm),
+				},
+			},
+		},
+	)
+}
+
 func ipFilterConfig(allow bool, rules []dag.IPFilterRule) *envoy_filter_http_rbac_v3.RBACPerRoute {
 	action := envoy_config_rbac_v3.RBAC_ALLOW
 	if !allow {
@@ -395,7 +581,8 @@ func routeRedirect(redirect *dag.Redirect) *envoy_config_route_v3.Route_Redirect
 
 // routeRoute creates a *envoy_config_route_v3.Route_Route for the services supplied.
 // If len(services) is greater than one, the route's action will be a
-// weighted cluster.
+// weighted cluster, unless the route fails over between them using an
+// aggregate cluster.
 func routeRoute(r *dag.Route) *envoy_config_route_v3.Route_Route {
 	ra := envoy_config_route_v3.RouteAction{
 		RetryPolicy:            retryPolicy(r),
@@ -446,11 +633,17 @@ func routeRoute(r *dag.Route) *envoy_config_route_v3.Route_Route {
 		)
 	}
 
-	if envoy.SingleSimpleCluster(r) {
+	switch {
+	case r.AggregateCluster != nil:
+		ra.ClusterSpecifier = &envoy_config_route_v3.RouteAction_Cluster{
+			Cluster: envoy.AggregateClusterName(r.AggregateCluster),
+		}
+	case envoy.SingleSimpleCluster(r):
 		ra.ClusterSpecifier = &envoy_config_route_v3.RouteAction_Cluster{
 			Cluster: envoy.Clustername(r.Clusters[0]),
 		}
-	} else {
+		ra.MetadataMatch = subsetMetadataMatch(r.Clusters[0].SubsetSelector)
+	default:
 		ra.ClusterSpecifier = &envoy_config_route_v3.RouteAction_WeightedClusters{
 			WeightedClusters: weightedClusters(r),
 		}
@@ -517,16 +710,36 @@ func mirrorPolicy(r *dag.Route) []*envoy_config_route_v3.RouteAction_RequestMirr
 		mirrorPolicies = append(mirrorPolicies, &envoy_config_route_v3.RouteAction_RequestMirrorPolicy{
 			Cluster: envoy.Clustername(mp.Cluster),
 			RuntimeFraction: &envoy_config_core_v3.RuntimeFractionalPercent{
-				DefaultValue: &envoy_type_v3.FractionalPercent{
-					Numerator:   uint32(mp.Weight),
-					Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
-				},
+				DefaultValue: mirrorFractionalPercent(mp),
 			},
 		})
 	}
 	return mirrorPolicies
 }
 
+// mirrorFractionalPercent converts the fraction of requests to mirror
+// into an Envoy FractionalPercent. Envoy only supports a fixed set of
+// denominators, so any other denominator is scaled to parts per million.
+func mirrorFractionalPercent(mp *dag.MirrorPolicy) *envoy_type_v3.FractionalPercent {
+	switch mp.Denominator {
+	case 0, 100:
+		return &envoy_type_v3.FractionalPercent{
+			Numerator:   uint32(mp.Weight),
+			Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+		}
+	case 10000:
+		return &envoy_type_v3.FractionalPercent{
+			Numerator:   uint32(mp.Weight),
+			Denominator: envoy_type_v3.FractionalPercent_TEN_THOUSAND,
+		}
+	default:
+		return &envoy_type_v3.FractionalPercent{
+			Numerator:   uint32(mp.Weight * 1_000_000 / mp.Denominator),
+			Denominator: envoy_type_v3.FractionalPercent_MILLION,
+		}
+	}
+}
+
 func retryPolicy(r *dag.Route) *envoy_config_route_v3.RetryPolicy {
 	if r.RetryPolicy == nil {
 		return nil
@@ -543,6 +756,11 @@ func retryPolicy(r *dag.Route) *envoy_config_route_v3.RetryPolicy {
 		rp.NumRetries = wrapperspb.UInt32(r.RetryPolicy.NumRetries)
 	}
 	rp.PerTryTimeout = envoy.Timeout(r.RetryPolicy.PerTryTimeout)
+	if r.RetryPolicy.BackOffBaseInterval > 0 {
+		rp.RetryBackOff = &envoy_config_route_v3.RetryPolicy_RetryBackOff{
+			BaseInterval: durationpb.New(r.RetryPolicy.BackOffBaseInterval),
+		}
+	}
 
 	return rp
 }
@@ -626,8 +844,9 @@ func weightedClusters(route *dag.Route) *envoy_config_route_v3.WeightedCluster {
 		total += cluster.Weight
 
 		c := &envoy_config_route_v3.WeightedCluster_ClusterWeight{
-			Name:   envoy.Clustername(cluster),
-			Weight: wrapperspb.UInt32(cluster.Weight),
+			Name:          envoy.Clustername(cluster),
+			Weight:        wrapperspb.UInt32(cluster.Weight),
+			MetadataMatch: subsetMetadataMatch(cluster.SubsetSelector),
 		}
 		if cluster.RequestHeadersPolicy != nil {
 			c.RequestHeadersToAdd = append(headerValueList(cluster.RequestHeadersPolicy.Set, false), headerValueList(cluster.RequestHeadersPolicy.Add, true)...)
@@ -662,6 +881,28 @@ func weightedClusters(route *dag.Route) *envoy_config_route_v3.WeightedCluster {
 	return &wc
 }
 
+// subsetMetadataMatch returns the metadata that endpoints must have
+// to be selected by the given subset selector, or nil if the selector
+// is empty.
+func subsetMetadataMatch(selector map[string]string) *envoy_config_core_v3.Metadata {
+	if len(selector) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]*structpb.Value, len(selector))
+	for k, v := range selector {
+		fields[k] = structpb.NewStringValue(v)
+	}
+
+	return &envoy_config_core_v3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			SubsetMetadataNamespace: {
+				Fields: fields,
+			},
+		},
+	}
+}
+
 // VirtualHost creates a new route.VirtualHost.
 func VirtualHost(hostname string, routes ...*envoy_config_route_v3.Route) *envoy_config_route_v3.VirtualHost {
 	return &envoy_config_route_v3.VirtualHost{
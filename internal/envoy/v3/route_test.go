@@ -21,10 +21,17 @@ import (
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_common_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	envoy_filter_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_filter_http_cors_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	envoy_filter_http_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	envoy_filter_http_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoy_filter_http_stateful_session_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
+	envoy_stateful_session_cookie_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/stateful_session/cookie/v3"
+	envoy_stateful_session_header_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/stateful_session/header/v3"
 	envoy_internal_redirect_previous_routes_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/internal_redirect/previous_routes/v3"
 	envoy_internal_redirect_safe_cross_scheme_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/internal_redirect/safe_cross_scheme/v3"
+	envoy_type_http_v3 "github.com/envoyproxy/go-control-plane/envoy/type/http/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +39,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/projectcontour/contour/internal/dag"
@@ -322,6 +330,30 @@ func TestRouteRoute(t *testing.T) {
 				},
 			},
 		},
+		"retry with backoff": {
+			route: &dag.Route{
+				RetryPolicy: &dag.RetryPolicy{
+					RetryOn:             "reset,connect-failure,refused-stream",
+					NumRetries:          3,
+					BackOffBaseInterval: 100 * time.Millisecond,
+				},
+				Clusters: []*dag.Cluster{c1},
+			},
+			want: &envoy_config_route_v3.Route_Route{
+				Route: &envoy_config_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{
+						Cluster: "default/kuard/8080/da39a3ee5e",
+					},
+					RetryPolicy: &envoy_config_route_v3.RetryPolicy{
+						RetryOn:    "reset,connect-failure,refused-stream",
+						NumRetries: wrapperspb.UInt32(3),
+						RetryBackOff: &envoy_config_route_v3.RetryPolicy_RetryBackOff{
+							BaseInterval: durationpb.New(100 * time.Millisecond),
+						},
+					},
+				},
+			},
+		},
 		"timeout 90s": {
 			route: &dag.Route{
 				TimeoutPolicy: dag.RouteTimeoutPolicy{
@@ -794,6 +826,68 @@ func TestRouteRoute(t *testing.T) {
 				},
 			},
 		},
+		"mirrors with fractions": {
+			route: &dag.Route{
+				Clusters: []*dag.Cluster{c1},
+				MirrorPolicies: []*dag.MirrorPolicy{
+					{
+						Cluster: &dag.Cluster{
+							Upstream: &dag.Service{
+								Weighted: dag.WeightedService{
+									Weight:           1,
+									ServiceName:      s1.Name,
+									ServiceNamespace: s1.Namespace,
+									ServicePort:      s1.Spec.Ports[0],
+								},
+							},
+						},
+						Weight:      25,
+						Denominator: 10000,
+					},
+					{
+						Cluster: &dag.Cluster{
+							Upstream: &dag.Service{
+								Weighted: dag.WeightedService{
+									Weight:           1,
+									ServiceName:      s2.Name,
+									ServiceNamespace: s2.Namespace,
+									ServicePort:      s2.Spec.Ports[0],
+								},
+							},
+						},
+						Weight:      1,
+						Denominator: 3,
+					},
+				},
+			},
+			want: &envoy_config_route_v3.Route_Route{
+				Route: &envoy_config_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{
+						Cluster: "default/kuard/8080/da39a3ee5e",
+					},
+					RequestMirrorPolicies: []*envoy_config_route_v3.RouteAction_RequestMirrorPolicy{
+						{
+							Cluster: "default/kuard/8080/da39a3ee5e",
+							RuntimeFraction: &envoy_config_core_v3.RuntimeFractionalPercent{
+								DefaultValue: &envoy_type_v3.FractionalPercent{
+									Numerator:   25,
+									Denominator: envoy_type_v3.FractionalPercent_TEN_THOUSAND,
+								},
+							},
+						},
+						{
+							Cluster: "default/kuard2/8080/da39a3ee5e",
+							RuntimeFraction: &envoy_config_core_v3.RuntimeFractionalPercent{
+								DefaultValue: &envoy_type_v3.FractionalPercent{
+									Numerator:   333333,
+									Denominator: envoy_type_v3.FractionalPercent_MILLION,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"prefix rewrite": {
 			route: &dag.Route{
 				Clusters:          []*dag.Cluster{c1},
@@ -1068,6 +1162,366 @@ func TestBuildRouteWithDirectResponse(t *testing.T) {
 	}
 }
 
+func TestBuildRouteWithSessionPersistence(t *testing.T) {
+	tests := map[string]struct {
+		sessionPersistence *dag.SessionPersistence
+		want               map[string]*anypb.Any
+	}{
+		"no session persistence": {
+			sessionPersistence: nil,
+			want:               nil,
+		},
+		"session cookie": {
+			sessionPersistence: &dag.SessionPersistence{
+				Type: dag.SessionPersistenceCookie,
+				Name: "session",
+			},
+			want: map[string]*anypb.Any{
+				StatefulSessionFilterName: protobuf.MustMarshalAny(&envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute{
+					Override: &envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute_StatefulSession{
+						StatefulSession: &envoy_filter_http_stateful_session_v3.StatefulSession{
+							SessionState: &envoy_config_core_v3.TypedExtensionConfig{
+								Name: "envoy.http.stateful_session.cookie",
+								TypedConfig: protobuf.MustMarshalAny(&envoy_stateful_session_cookie_v3.CookieBasedSessionState{
+									Cookie: &envoy_type_http_v3.Cookie{
+										Name: "session",
+										Path: "/",
+									},
+								}),
+							},
+						},
+					},
+				}),
+			},
+		},
+		"permanent cookie": {
+			sessionPersistence: &dag.SessionPersistence{
+				Type:      dag.SessionPersistenceCookie,
+				Name:      "session",
+				CookieTTL: time.Hour,
+			},
+			want: map[string]*anypb.Any{
+				StatefulSessionFilterName: protobuf.MustMarshalAny(&envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute{
+					Override: &envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute_StatefulSession{
+						StatefulSession: &envoy_filter_http_stateful_session_v3.StatefulSession{
+							SessionState: &envoy_config_core_v3.TypedExtensionConfig{
+								Name: "envoy.http.stateful_session.cookie",
+								TypedConfig: protobuf.MustMarshalAny(&envoy_stateful_session_cookie_v3.CookieBasedSessionState{
+									Cookie: &envoy_type_http_v3.Cookie{
+										Name: "session",
+										Path: "/",
+										Ttl:  durationpb.New(time.Hour),
+									},
+								}),
+							},
+						},
+					},
+				}),
+			},
+		},
+		"header": {
+			sessionPersistence: &dag.SessionPersistence{
+				Type: dag.SessionPersistenceHeader,
+				Name: "x-session",
+			},
+			want: map[string]*anypb.Any{
+				StatefulSessionFilterName: protobuf.MustMarshalAny(&envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute{
+					Override: &envoy_filter_http_stateful_session_v3.StatefulSessionPerRoute_StatefulSession{
+						StatefulSession: &envoy_filter_http_stateful_session_v3.StatefulSession{
+							SessionState: &envoy_config_core_v3.TypedExtensionConfig{
+								Name: "envoy.http.stateful_session.header",
+								TypedConfig: protobuf.MustMarshalAny(&envoy_stateful_session_header_v3.HeaderBasedSessionState{
+									Name: "x-session",
+								}),
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dagRoute := &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix:          "/",
+					PrefixMatchType: dag.PrefixMatchString,
+				},
+				Clusters: []*dag.Cluster{{
+					Upstream: &dag.Service{
+						Weighted: dag.WeightedService{
+							Weight:           1,
+							ServiceName:      "kuard",
+							ServiceNamespace: "default",
+							ServicePort: core_v1.ServicePort{
+								Port: 8080,
+							},
+						},
+					},
+				}},
+				SessionPersistence: tc.sessionPersistence,
+			}
+
+			got := buildRoute(dagRoute, "example", false)
+			protobuf.ExpectEqual(t, tc.want, got.TypedPerFilterConfig)
+		})
+	}
+}
+
+func TestBuildRouteWithCORSPolicy(t *testing.T) {
+	tests := map[string]struct {
+		corsPolicy *dag.CORSPolicy
+		want       map[string]*anypb.Any
+	}{
+		"no cors policy": {
+			corsPolicy: nil,
+			want:       nil,
+		},
+		"cors policy": {
+			corsPolicy: &dag.CORSPolicy{
+				AllowOrigin:  []dag.CORSAllowOriginMatch{{Type: dag.CORSAllowOriginMatchExact, Value: "https://example.com"}},
+				AllowMethods: []string{"GET", "POST"},
+				MaxAge:       timeout.DurationSetting(10 * time.Minute),
+			},
+			want: map[string]*anypb.Any{
+				CORSFilterName: protobuf.MustMarshalAny(&envoy_filter_http_cors_v3.CorsPolicy{
+					AllowOriginStringMatch: []*envoy_matcher_v3.StringMatcher{
+						{
+							MatchPattern: &envoy_matcher_v3.StringMatcher_Exact{
+								Exact: "https://example.com",
+							},
+							IgnoreCase: true,
+						},
+					},
+					AllowCredentials:          wrapperspb.Bool(false),
+					AllowPrivateNetworkAccess: wrapperspb.Bool(false),
+					AllowMethods:              "GET,POST",
+					MaxAge:                    "600",
+				}),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dagRoute := &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix:          "/",
+					PrefixMatchType: dag.PrefixMatchString,
+				},
+				Clusters: []*dag.Cluster{{
+					Upstream: &dag.Service{
+						Weighted: dag.WeightedService{
+							Weight:           1,
+							ServiceName:      "kuard",
+							ServiceNamespace: "default",
+							ServicePort: core_v1.ServicePort{
+								Port: 8080,
+							},
+						},
+					},
+				}},
+				CORSPolicy: tc.corsPolicy,
+			}
+
+			got := buildRoute(dagRoute, "example", false)
+			protobuf.ExpectEqual(t, tc.want, got.TypedPerFilterConfig)
+		})
+	}
+}
+
+func TestBuildRouteWithCompressionDisabled(t *testing.T) {
+	disabled := protobuf.MustMarshalAny(&envoy_filter_http_compressor_v3.CompressorPerRoute{
+		Override: &envoy_filter_http_compressor_v3.CompressorPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+
+	tests := map[string]struct {
+		compressionDisabled bool
+		want                map[string]*anypb.Any
+	}{
+		"compression enabled": {
+			compressionDisabled: false,
+			want:                nil,
+		},
+		"compression disabled": {
+			compressionDisabled: true,
+			want: map[string]*anypb.Any{
+				CompressorFilterName:       disabled,
+				CompressorBrotliFilterName: disabled,
+				CompressorZstdFilterName:   disabled,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dagRoute := &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix:          "/",
+					PrefixMatchType: dag.PrefixMatchString,
+				},
+				Clusters: []*dag.Cluster{{
+					Upstream: &dag.Service{
+						Weighted: dag.WeightedService{
+							Weight:           1,
+							ServiceName:      "kuard",
+							ServiceNamespace: "default",
+							ServicePort: core_v1.ServicePort{
+								Port: 8080,
+							},
+						},
+					},
+				}},
+				CompressionDisabled: tc.compressionDisabled,
+			}
+
+			got := buildRoute(dagRoute, "example", false)
+			protobuf.ExpectEqual(t, tc.want, got.TypedPerFilterConfig)
+		})
+	}
+}
+
+func TestBuildRouteWithBasicAuth(t *testing.T) {
+	secret := &dag.Secret{
+		Object: &core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "admins",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				dag.BasicAuthKey: []byte("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		disabled bool
+		secret   *dag.Secret
+		want     map[string]*anypb.Any
+	}{
+		"no override": {
+			want: nil,
+		},
+		"basic auth disabled": {
+			disabled: true,
+			want: map[string]*anypb.Any{
+				"envoy.filters.http.basic_auth/example": protobuf.MustMarshalAny(&envoy_config_route_v3.FilterConfig{
+					Disabled: true,
+				}),
+			},
+		},
+		"users overridden": {
+			secret: secret,
+			want: map[string]*anypb.Any{
+				"envoy.filters.http.basic_auth/example": protobuf.MustMarshalAny(&envoy_config_route_v3.FilterConfig{
+					Disabled: true,
+				}),
+				"envoy.filters.http.basic_auth/default/admins": protobuf.MustMarshalAny(&envoy_config_route_v3.FilterConfig{
+					Config: &anypb.Any{},
+				}),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dagRoute := &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix:          "/",
+					PrefixMatchType: dag.PrefixMatchString,
+				},
+				Clusters: []*dag.Cluster{{
+					Upstream: &dag.Service{
+						Weighted: dag.WeightedService{
+							Weight:           1,
+							ServiceName:      "kuard",
+							ServiceNamespace: "default",
+							ServicePort: core_v1.ServicePort{
+								Port: 8080,
+							},
+						},
+					},
+				}},
+				BasicAuthDisabled: tc.disabled,
+				BasicAuthSecret:   tc.secret,
+			}
+
+			got := buildRoute(dagRoute, "example", false)
+			protobuf.ExpectEqual(t, tc.want, got.TypedPerFilterConfig)
+		})
+	}
+}
+
+func TestBuildRouteWithFaultInjection(t *testing.T) {
+	dagRoute := &dag.Route{
+		PathMatchCondition: &dag.PrefixMatchCondition{
+			Prefix:          "/",
+			PrefixMatchType: dag.PrefixMatchString,
+		},
+		Clusters: []*dag.Cluster{{
+			Upstream: &dag.Service{
+				Weighted: dag.WeightedService{
+					Weight:           1,
+					ServiceName:      "kuard",
+					ServiceNamespace: "default",
+					ServicePort: core_v1.ServicePort{
+						Port: 8080,
+					},
+				},
+			},
+		}},
+		FaultInjectionPolicy: &dag.FaultInjectionPolicy{
+			Delay: &dag.FaultDelay{
+				Duration:   2 * time.Second,
+				Percentage: 50,
+			},
+			Abort: &dag.FaultAbort{
+				StatusCode: 503,
+				Percentage: 10,
+			},
+			HeaderMatchConditions: []dag.HeaderMatchCondition{{
+				Name:      "x-fault",
+				MatchType: dag.HeaderMatchTypePresent,
+			}},
+		},
+	}
+
+	want := map[string]*anypb.Any{
+		FaultFilterName: protobuf.MustMarshalAny(&envoy_filter_http_fault_v3.HTTPFault{
+			Delay: &envoy_filter_common_fault_v3.FaultDelay{
+				FaultDelaySecifier: &envoy_filter_common_fault_v3.FaultDelay_FixedDelay{
+					FixedDelay: durationpb.New(2 * time.Second),
+				},
+				Percentage: &envoy_type_v3.FractionalPercent{
+					Numerator:   50,
+					Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+				},
+			},
+			Abort: &envoy_filter_http_fault_v3.FaultAbort{
+				ErrorType: &envoy_filter_http_fault_v3.FaultAbort_HttpStatus{
+					HttpStatus: 503,
+				},
+				Percentage: &envoy_type_v3.FractionalPercent{
+					Numerator:   10,
+					Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+				},
+			},
+			Headers: []*envoy_config_route_v3.HeaderMatcher{{
+				Name: "x-fault",
+				HeaderMatchSpecifier: &envoy_config_route_v3.HeaderMatcher_PresentMatch{
+					PresentMatch: true,
+				},
+			}},
+		}),
+	}
+
+	got := buildRoute(dagRoute, "example", false)
+	protobuf.ExpectEqual(t, want, got.TypedPerFilterConfig)
+}
+
 func TestWeightedClusters(t *testing.T) {
 	tests := map[string]struct {
 		route *dag.Route
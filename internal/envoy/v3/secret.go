@@ -41,3 +41,20 @@ func Secret(s *dag.Secret) *envoy_transport_socket_tls_v3.Secret {
 		},
 	}
 }
+
+// OAuth2Secret creates a new envoy_transport_socket_tls_v3.Secret from
+// the given key of a dag.Secret holding OAuth2 client credentials.
+func OAuth2Secret(s *dag.Secret, key string) *envoy_transport_socket_tls_v3.Secret {
+	return &envoy_transport_socket_tls_v3.Secret{
+		Name: envoy.OAuth2SecretName(s, key),
+		Type: &envoy_transport_socket_tls_v3.Secret_GenericSecret{
+			GenericSecret: &envoy_transport_socket_tls_v3.GenericSecret{
+				Secret: &envoy_config_core_v3.DataSource{
+					Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+						InlineBytes: s.Data()[key],
+					},
+				},
+			},
+		},
+	}
+}
@@ -117,3 +117,33 @@ func TestSecretname(t *testing.T) {
 		})
 	}
 }
+
+func TestOAuth2Secret(t *testing.T) {
+	secret := &dag.Secret{
+		Object: &core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "oauth2",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				dag.OAuth2ClientSecretKey: []byte("client"),
+				dag.OAuth2HMACSecretKey:   []byte("hmac"),
+			},
+		},
+	}
+
+	want := &envoy_transport_socket_tls_v3.Secret{
+		Name: "default/oauth2/hmac-secret",
+		Type: &envoy_transport_socket_tls_v3.Secret_GenericSecret{
+			GenericSecret: &envoy_transport_socket_tls_v3.GenericSecret{
+				Secret: &envoy_config_core_v3.DataSource{
+					Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+						InlineBytes: []byte("hmac"),
+					},
+				},
+			},
+		},
+	}
+
+	protobuf.ExpectEqual(t, want, OAuth2Secret(secret, dag.OAuth2HMACSecretKey))
+}
@@ -15,6 +15,7 @@ package v3
 
 import (
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_transport_socket_quic_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 
 	"github.com/projectcontour/contour/internal/protobuf"
@@ -39,3 +40,15 @@ func DownstreamTLSTransportSocket(tls *envoy_transport_socket_tls_v3.DownstreamT
 		},
 	}
 }
+
+// DownstreamQUICTransportSocket returns a QUIC transport socket using the DownstreamTlsContext provided.
+func DownstreamQUICTransportSocket(tls *envoy_transport_socket_tls_v3.DownstreamTlsContext) *envoy_config_core_v3.TransportSocket {
+	return &envoy_config_core_v3.TransportSocket{
+		Name: "envoy.transport_sockets.quic",
+		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_transport_socket_quic_v3.QuicDownstreamTransport{
+				DownstreamTlsContext: tls,
+			}),
+		},
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_transport_socket_quic_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -84,3 +85,21 @@ func TestDownstreamTLSTransportSocket(t *testing.T) {
 		})
 	}
 }
+
+func TestDownstreamQUICTransportSocket(t *testing.T) {
+	ctxt := &envoy_transport_socket_tls_v3.DownstreamTlsContext{
+		CommonTlsContext: &envoy_transport_socket_tls_v3.CommonTlsContext{
+			AlpnProtocols: []string{"h3"},
+		},
+	}
+	want := &envoy_config_core_v3.TransportSocket{
+		Name: "envoy.transport_sockets.quic",
+		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_transport_socket_quic_v3.QuicDownstreamTransport{
+				DownstreamTlsContext: ctxt,
+			}),
+		},
+	}
+
+	protobuf.ExpectEqual(t, want, DownstreamQUICTransportSocket(ctxt))
+}
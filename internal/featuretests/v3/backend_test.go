@@ -0,0 +1,312 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+)
+
+// enableBackends enables the processing of Backends, which share the
+// enableExternalNameService setting with ExternalName Services.
+func enableBackends(b *dag.Builder) {
+	for _, p := range b.Processors {
+		switch p := p.(type) {
+		case *dag.BackendProcessor:
+			p.EnableExternalNameService = true
+		case *dag.HTTPProxyProcessor:
+			p.EnableExternalNameService = true
+		case *dag.GatewayAPIProcessor:
+			p.EnableExternalNameService = true
+		}
+	}
+}
+
+func TestBackend(t *testing.T) {
+	rh, c, done := setup(t, enableBackends)
+	defer done()
+
+	b1 := &contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "192.168.0.10"},
+				{Address: "192.168.0.11", Port: 8080},
+			},
+		},
+	}
+	rh.OnAdd(b1)
+
+	p1 := fixture.NewProxy("backend").
+		WithFQDN("backend.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "legacy",
+					Kind: "Backend",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("backend.projectcontour.io",
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/legacy/80/754a08ddf8"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(p1).IsValid()
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			DefaultCluster(&envoy_config_cluster_v3.Cluster{
+				Name:                 "default/legacy/80/754a08ddf8",
+				ClusterDiscoveryType: envoy_v3.ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_STATIC),
+				AltStatName:          "default_legacy_80",
+				LoadAssignment: &envoy_config_endpoint_v3.ClusterLoadAssignment{
+					ClusterName: "default/legacy",
+					Endpoints: envoy_v3.Endpoints(
+						envoy_v3.SocketAddress("192.168.0.10", 80),
+						envoy_v3.SocketAddress("192.168.0.11", 8080),
+					),
+				},
+			}),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// A hostname endpoint is resolved by Envoy.
+	b2 := &contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "legacy.example.com"},
+			},
+		},
+	}
+	rh.OnUpdate(b1, b2)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			externalNameCluster("default/legacy/80/754a08ddf8", "default/legacy", "default_legacy_80", "legacy.example.com", 80),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// Invalid Backends are rejected.
+	b3 := &contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "localhost"},
+			},
+		},
+	}
+	rh.OnUpdate(b2, b3)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p1).HasError(contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference",
+		`Spec.Routes unresolved service reference: backend "default/legacy" endpoint 0 is invalid: address "localhost" points to localhost, this is not allowed`)
+
+	// So are references to missing Backends.
+	rh.OnDelete(b3)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p1).HasError(contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference",
+		`Spec.Routes unresolved service reference: backend "default/legacy" not found`)
+}
+
+func TestBackendTLSProtocol(t *testing.T) {
+	rh, c, done := setup(t, enableBackends)
+	defer done()
+
+	caSecret := featuretests.CASecret(t, "cacert", &featuretests.CACertificate)
+	rh.OnAdd(caSecret)
+
+	rh.OnAdd(&contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "192.168.0.10"},
+			},
+			TLS: &contour_v1alpha1.BackendTLS{
+				UpstreamValidation: &contour_v1.UpstreamValidation{
+					CACertificate: caSecret.Name,
+					SubjectName:   "legacy.example.com",
+				},
+			},
+		},
+	})
+
+	// The Backend's TLS settings are used without setting the protocol.
+	p1 := fixture.NewProxy("backend").
+		WithFQDN("backend.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "legacy",
+					Kind: "Backend",
+					Port: 443,
+				}},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Status(p1).IsValid()
+
+	// A route protocol that does not use TLS is rejected, rather
+	// than sending traffic that is not verified.
+	p2 := fixture.NewProxy("backend").
+		WithFQDN("backend.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name:     "legacy",
+					Kind:     "Backend",
+					Port:     443,
+					Protocol: ptr.To("h2c"),
+				}},
+			}},
+		})
+	rh.OnUpdate(p1, p2)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p2).HasError(contour_v1.ConditionTypeServiceError, "UnsupportedProtocol",
+		`Backend "legacy" verifies its endpoints over TLS, protocol "h2c" is not supported`)
+}
+
+func TestBackendNotEnabled(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(&contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "192.168.0.10"},
+			},
+		},
+	})
+
+	p1 := fixture.NewProxy("backend").
+		WithFQDN("backend.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "legacy",
+					Kind: "Backend",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p1).HasError(contour_v1.ConditionTypeServiceError, "ServiceUnresolvedReference",
+		`Spec.Routes unresolved service reference: default/legacy is a Backend, these are not currently enabled. See the config.enableExternalNameService config file setting`)
+}
+
+func TestBackendHTTPRoute(t *testing.T) {
+	rh, c, done := setup(t, enableBackends)
+	defer done()
+
+	rh.OnAdd(gc)
+	rh.OnAdd(gateway)
+
+	rh.OnAdd(&contour_v1alpha1.Backend{
+		ObjectMeta: fixture.ObjectMeta("default/legacy"),
+		Spec: contour_v1alpha1.BackendSpec{
+			Endpoints: []contour_v1alpha1.BackendEndpoint{
+				{Address: "192.168.0.10"},
+			},
+		},
+	})
+
+	rh.OnAdd(&gatewayapi_v1.HTTPRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Hostnames: []gatewayapi_v1.Hostname{
+				"test.projectcontour.io",
+			},
+			Rules: []gatewayapi_v1.HTTPRouteRule{{
+				Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1.PathMatchPathPrefix, "/"),
+				BackendRefs: []gatewayapi_v1.HTTPBackendRef{{
+					BackendRef: gatewayapi_v1.BackendRef{
+						BackendObjectReference: gatewayapi_v1.BackendObjectReference{
+							Group: ptr.To(gatewayapi_v1.Group("projectcontour.io")),
+							Kind:  ptr.To(gatewayapi_v1.Kind("Backend")),
+							Name:  "legacy",
+							Port:  ptr.To(gatewayapi_v1.PortNumber(80)),
+						},
+					},
+				}},
+			}},
+		},
+	})
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			DefaultCluster(&envoy_config_cluster_v3.Cluster{
+				Name:                 "default/legacy/80/754a08ddf8",
+				ClusterDiscoveryType: envoy_v3.ClusterDiscoveryType(envoy_config_cluster_v3.Cluster_STATIC),
+				AltStatName:          "default_legacy_80",
+				LoadAssignment: &envoy_config_endpoint_v3.ClusterLoadAssignment{
+					ClusterName: "default/legacy",
+					Endpoints: envoy_v3.Endpoints(
+						envoy_v3.SocketAddress("192.168.0.10", 80),
+					),
+				},
+			}),
+		),
+		TypeUrl: clusterType,
+	})
+}
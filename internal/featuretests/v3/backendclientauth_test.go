@@ -237,3 +237,72 @@ func TestBackendClientAuthenticationWithExtensionService(t *testing.T) {
 		TypeUrl:   clusterType,
 	})
 }
+
+func TestBackendClientAuthenticationPerServiceWithHTTPProxy(t *testing.T) {
+	rh, c, done := setup(t, proxyClientCertificateOpt(t))
+	defer done()
+
+	globalSecret := featuretests.TLSSecret(t, "envoyclientsecret", &featuretests.ClientCertificate)
+	serviceSecret := featuretests.TLSSecret(t, "otherNs/serviceclientsecret", &featuretests.ClientCertificate)
+	caSecret := featuretests.CASecret(t, "backendcacert", &featuretests.CACertificate)
+	rh.OnAdd(globalSecret)
+	rh.OnAdd(serviceSecret)
+	rh.OnAdd(caSecret)
+
+	svc := fixture.NewService("backend").
+		WithPorts(core_v1.ServicePort{Name: "http", Port: 443})
+	rh.OnAdd(svc)
+
+	proxy := fixture.NewProxy("authenticated").WithSpec(
+		contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "www.example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name:     svc.Name,
+					Port:     443,
+					Protocol: ptr.To("tls"),
+					UpstreamValidation: &contour_v1.UpstreamValidation{
+						CACertificate: caSecret.Name,
+						SubjectName:   "subjname",
+					},
+					ClientCertificate: "otherNs/serviceclientsecret",
+				}},
+			}},
+		})
+	rh.OnAdd(proxy)
+
+	// The client certificate Secret is in another namespace and has not been delegated.
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(proxy).HasError(contour_v1.ConditionTypeTLSError, "ClientCertificateNotDelegated",
+		`service.ClientCertificate Secret "otherNs/serviceclientsecret" is not configured for certificate delegation`)
+
+	rh.OnAdd(&contour_v1.TLSCertificateDelegation{
+		ObjectMeta: fixture.ObjectMeta("otherNs/delegate-clientcert"),
+		Spec: contour_v1.TLSCertificateDelegationSpec{
+			Delegations: []contour_v1.CertificateDelegation{{
+				SecretName:       "serviceclientsecret",
+				TargetNamespaces: []string{"default"},
+			}},
+		},
+	})
+
+	// The Service's client certificate takes precedence over the global one.
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			tlsCluster(cluster("default/backend/443/545d789e7e", "default/backend/http", "default_backend_443"), caSecret, "subjname", "", serviceSecret, nil),
+		),
+		TypeUrl: clusterType,
+	}).Status(proxy).IsValid()
+
+	// A missing client certificate Secret invalidates the route.
+	rh.OnDelete(serviceSecret)
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(proxy).HasError(contour_v1.ConditionTypeTLSError, "SecretNotValid",
+		`service.ClientCertificate Secret "otherNs/serviceclientsecret" is invalid: Secret not found`)
+}
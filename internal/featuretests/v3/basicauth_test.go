@@ -0,0 +1,297 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"path"
+	"testing"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/protobuf"
+)
+
+const (
+	// SHA-1 of "password".
+	basicAuthUsers  = "alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"
+	basicAuthAdmins = "root:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\r\n"
+)
+
+func basicAuthConfig(name, users string) *envoy_config_core_v3.TypedExtensionConfig {
+	return &envoy_config_core_v3.TypedExtensionConfig{
+		Name: name,
+		TypedConfig: protobuf.MustMarshalAny(&envoy_filter_http_basic_auth_v3.BasicAuth{
+			Users: &envoy_config_core_v3.DataSource{
+				Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+					InlineBytes: []byte(users),
+				},
+			},
+		}),
+	}
+}
+
+func basicAuthBasic(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "basicauth.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+	p.Spec.VirtualHost.BasicAuth = &contour_v1.BasicAuth{SecretName: "htpasswd"}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType, "ingress_https").Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						basicAuthFilterFor(fqdn),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+		),
+	}).Status(p).IsValid()
+
+	// The htpasswd users are only served over ECDS.
+	c.Request(extensionConfigType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: extensionConfigType,
+		Resources: resources(t,
+			basicAuthConfig("envoy.filters.http.basic_auth/"+fqdn, basicAuthUsers),
+		),
+	})
+}
+
+func basicAuthRoutePolicies(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "basicauth.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Conditions:      matchconditions(prefixMatchCondition("/public")),
+				Services:        []contour_v1.Service{{Name: "app-server", Port: 80}},
+				BasicAuthPolicy: &contour_v1.BasicAuthPolicy{Disabled: true},
+			}, {
+				Conditions:      matchconditions(prefixMatchCondition("/admin")),
+				Services:        []contour_v1.Service{{Name: "app-server", Port: 80}},
+				BasicAuthPolicy: &contour_v1.BasicAuthPolicy{SecretName: "admins"},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/default")),
+				Services:   []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+	p.Spec.VirtualHost.BasicAuth = &contour_v1.BasicAuth{SecretName: "htpasswd"}
+
+	rh.OnAdd(p)
+
+	c.Request(routeType, path.Join("https", fqdn)).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration(
+				path.Join("https", fqdn),
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/default"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/public"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: withFilterConfig("envoy.filters.http.basic_auth/"+fqdn,
+							&envoy_config_route_v3.FilterConfig{
+								Disabled: true,
+							}),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/admin"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: map[string]*anypb.Any{
+							"envoy.filters.http.basic_auth/" + fqdn: protobuf.MustMarshalAny(&envoy_config_route_v3.FilterConfig{
+								Disabled: true,
+							}),
+							"envoy.filters.http.basic_auth/default/admins": protobuf.MustMarshalAny(&envoy_config_route_v3.FilterConfig{
+								Config: &anypb.Any{},
+							}),
+						},
+					},
+				),
+			),
+		),
+	}).Status(p).IsValid()
+
+	c.Request(listenerType, "ingress_https").Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						basicAuthFilterFor(fqdn, "envoy.filters.http.basic_auth/default/admins"),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+		),
+	})
+
+	// CRLF line endings are normalized for Envoy.
+	c.Request(extensionConfigType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: extensionConfigType,
+		Resources: resources(t,
+			basicAuthConfig("envoy.filters.http.basic_auth/"+fqdn, basicAuthUsers),
+			basicAuthConfig("envoy.filters.http.basic_auth/default/admins", "root:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"),
+		),
+	})
+}
+
+func basicAuthWithoutTLS(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("basicauth.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+	p.Spec.VirtualHost.BasicAuth = &contour_v1.BasicAuth{SecretName: "htpasswd"}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeBasicAuthError, "BasicAuthNotPermitted",
+		"Spec.VirtualHost.BasicAuth can only be defined for root HTTPProxies that terminate TLS")
+}
+
+func basicAuthMalformedSecret(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	rh.OnAdd(&core_v1.Secret{
+		ObjectMeta: fixture.ObjectMeta("malformed"),
+		Type:       core_v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"auth": []byte("alice:$apr1$salt$hash\n"),
+		},
+	})
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN("basicauth.projectcontour.io").
+		WithCertificate("certificate").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+	p.Spec.VirtualHost.BasicAuth = &contour_v1.BasicAuth{SecretName: "malformed"}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeBasicAuthError, "SecretNotValid",
+		`Spec.VirtualHost.BasicAuth.SecretName Secret "default/malformed" is invalid: invalid htpasswd entries: line 1: unsupported password hash, only {SHA} is supported`)
+}
+
+func basicAuthRouteSecretWithoutVirtualHost(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("basicauth.projectcontour.io").
+		WithCertificate("certificate").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services:        []contour_v1.Service{{Name: "app-server", Port: 80}},
+				BasicAuthPolicy: &contour_v1.BasicAuthPolicy{SecretName: "admins"},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeBasicAuthError, "BasicAuthNotEnabled",
+		"route.basicAuthPolicy.secretName requires basic authentication to be enabled on the root HTTPProxy")
+}
+
+func TestBasicAuth(t *testing.T) {
+	subtests := map[string]func(*testing.T, ResourceEventHandlerWrapper, *Contour){
+		"Basic":                       basicAuthBasic,
+		"RoutePolicies":               basicAuthRoutePolicies,
+		"WithoutTLS":                  basicAuthWithoutTLS,
+		"MalformedSecret":             basicAuthMalformedSecret,
+		"RouteSecretWithoutBasicAuth": basicAuthRouteSecretWithoutVirtualHost,
+	}
+
+	for n, f := range subtests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			rh, c, done := setup(t)
+			defer done()
+
+			// Add common test fixtures.
+			rh.OnAdd(fixture.NewService("app-server").
+				WithPorts(core_v1.ServicePort{Port: 80}))
+
+			rh.OnAdd(featuretests.Endpoints("default", "app-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 80)),
+			}))
+
+			rh.OnAdd(&core_v1.Secret{
+				ObjectMeta: fixture.ObjectMeta("htpasswd"),
+				Type:       core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"auth": []byte(basicAuthUsers),
+				},
+			})
+
+			rh.OnAdd(&core_v1.Secret{
+				ObjectMeta: fixture.ObjectMeta("admins"),
+				Type:       core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"auth": []byte(basicAuthAdmins),
+				},
+			})
+
+			rh.OnAdd(featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate))
+
+			f(t, rh, c)
+		})
+	}
+}
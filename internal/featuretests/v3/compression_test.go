@@ -0,0 +1,140 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/protobuf"
+	"github.com/projectcontour/contour/internal/timeout"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
+)
+
+func TestCompressionListenerConfig(t *testing.T) {
+	compression := &contour_v1alpha1.EnvoyCompression{
+		Algorithms: []contour_v1alpha1.CompressionAlgorithm{
+			contour_v1alpha1.BrotliCompression,
+			contour_v1alpha1.GzipCompression,
+		},
+		MinContentLength: ptr.To(uint32(1024)),
+		ContentTypes:     []string{"text/html", "application/json"},
+	}
+
+	rh, c, done := setup(t, func(conf *xdscache_v3.ListenerConfig) {
+		conf.Compression = compression
+	})
+	defer done()
+
+	rh.OnAdd(fixture.NewService("backend").
+		WithPorts(core_v1.ServicePort{Name: "http", Port: 80}))
+
+	rh.OnAdd(fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{
+			Fqdn: "kuard.example.com",
+		},
+		Routes: []contour_v1.Route{{
+			Services: []contour_v1.Service{{
+				Name: "backend",
+				Port: 80,
+			}},
+		}},
+	}))
+
+	httpListener := defaultHTTPListener()
+	httpListener.FilterChains = envoy_v3.FilterChains(envoy_v3.HTTPConnectionManagerBuilder().
+		RouteConfigName("ingress_http").
+		MetricsPrefix("ingress_http").
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo)).
+		RequestTimeout(timeout.DurationSetting(0)).
+		Compression(compression).
+		DefaultFilters().
+		Get())
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			httpListener,
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
+
+func TestCompressionDisabledOnRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("backend").
+		WithPorts(core_v1.ServicePort{Name: "http", Port: 80}))
+
+	p := fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{
+			Fqdn: "kuard.example.com",
+		},
+		Routes: []contour_v1.Route{{
+			Conditions: matchconditions(prefixMatchCondition("/")),
+			Services: []contour_v1.Service{{
+				Name: "backend",
+				Port: 80,
+			}},
+		}, {
+			Conditions:         matchconditions(prefixMatchCondition("/stream")),
+			DisableCompression: true,
+			Services: []contour_v1.Service{{
+				Name: "backend",
+				Port: 80,
+			}},
+		}},
+	})
+	rh.OnAdd(p)
+
+	disabled := protobuf.MustMarshalAny(&envoy_filter_http_compressor_v3.CompressorPerRoute{
+		Override: &envoy_filter_http_compressor_v3.CompressorPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("kuard.example.com",
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/stream"),
+						Action: routeCluster("default/backend/80/da39a3ee5e"),
+						TypedPerFilterConfig: map[string]*anypb.Any{
+							envoy_v3.CompressorFilterName:       disabled,
+							envoy_v3.CompressorBrotliFilterName: disabled,
+							envoy_v3.CompressorZstdFilterName:   disabled,
+						},
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/backend/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(p).IsValid()
+}
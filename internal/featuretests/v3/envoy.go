@@ -25,7 +25,9 @@ import (
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_filter_http_ext_proc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
 	envoy_filter_http_jwt_authn_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	envoy_filter_http_oauth2_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/oauth2/v3"
 	envoy_filter_network_http_connection_manager_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_filter_network_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -553,6 +555,60 @@ func authzFilterFor(
 		Get()
 }
 
+func extProcFilterFor(
+	vhost string,
+	extProc *envoy_filter_http_ext_proc_v3.ExternalProcessor,
+) *envoy_config_listener_v3.Filter {
+	return envoy_v3.HTTPConnectionManagerBuilder().
+		AddFilter(envoy_v3.FilterMisdirectedRequests(vhost)).
+		DefaultFilters().
+		AddFilter(&envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: envoy_v3.ExtProcFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(extProc),
+			},
+		}).
+		RouteConfigName(path.Join("https", vhost)).
+		MetricsPrefix(xdscache_v3.ENVOY_HTTPS_LISTENER).
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo)).
+		Get()
+}
+
+func oauth2FilterFor(
+	vhost string,
+	oauth2 *envoy_filter_http_oauth2_v3.OAuth2,
+) *envoy_config_listener_v3.Filter {
+	return envoy_v3.HTTPConnectionManagerBuilder().
+		AddFilter(envoy_v3.FilterMisdirectedRequests(vhost)).
+		DefaultFilters().
+		AddFilter(&envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: envoy_v3.OAuth2FilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(oauth2),
+			},
+		}).
+		RouteConfigName(path.Join("https", vhost)).
+		MetricsPrefix(xdscache_v3.ENVOY_HTTPS_LISTENER).
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo)).
+		Get()
+}
+
+func basicAuthFilterFor(vhost string, overrides ...string) *envoy_config_listener_v3.Filter {
+	b := envoy_v3.HTTPConnectionManagerBuilder().
+		AddFilter(envoy_v3.FilterMisdirectedRequests(vhost)).
+		DefaultFilters().
+		AddFilter(envoy_v3.FilterBasicAuth(envoy_v3.BasicAuthConfigName(vhost), false))
+
+	for _, name := range overrides {
+		b.AddFilter(envoy_v3.FilterBasicAuth(name, true))
+	}
+
+	return b.RouteConfigName(path.Join("https", vhost)).
+		MetricsPrefix(xdscache_v3.ENVOY_HTTPS_LISTENER).
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo)).
+		Get()
+}
+
 func jwtAuthnFilterFor(
 	vhost string,
 	jwt *envoy_filter_http_jwt_authn_v3.JwtAuthentication,
@@ -23,6 +23,7 @@ import (
 	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
@@ -693,6 +694,66 @@ func overrideExtGlobalCircuitBreakers(t *testing.T, rh ResourceEventHandlerWrapp
 	})
 }
 
+func extOutlierDetection(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	rh.OnAdd(&contour_v1alpha1.ExtensionService{
+		ObjectMeta: fixture.ObjectMeta("ns/ext"),
+		Spec: contour_v1alpha1.ExtensionServiceSpec{
+			Services: []contour_v1alpha1.ExtensionServiceTarget{
+				{Name: "svc1", Port: 8081},
+				{Name: "svc2", Port: 8082},
+			},
+			OutlierDetectionPolicy: &contour_v1.OutlierDetectionPolicy{
+				ConsecutiveGatewayErrors: ptr.To(uint32(3)),
+				BaseEjectionTime:         "1m",
+			},
+		},
+	})
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: clusterType,
+		Resources: resources(t,
+			DefaultCluster(
+				h2cCluster(cluster("extension/ns/ext", "extension/ns/ext", "extension_ns_ext")),
+				&envoy_config_cluster_v3.Cluster{
+					TransportSocket: envoy_v3.UpstreamTLSTransportSocket(
+						&envoy_transport_socket_tls_v3.UpstreamTlsContext{
+							CommonTlsContext: &envoy_transport_socket_tls_v3.CommonTlsContext{
+								AlpnProtocols: []string{"h2"},
+							},
+						},
+					),
+					OutlierDetection: &envoy_config_cluster_v3.OutlierDetection{
+						Consecutive_5Xx:                    wrapperspb.UInt32(5),
+						ConsecutiveGatewayFailure:          wrapperspb.UInt32(3),
+						EnforcingConsecutiveGatewayFailure: wrapperspb.UInt32(100),
+						EnforcingSuccessRate:               wrapperspb.UInt32(0),
+						BaseEjectionTime:                   durationpb.New(time.Minute),
+						MaxEjectionPercent:                 wrapperspb.UInt32(10),
+					},
+				},
+			),
+		),
+	})
+}
+
+func extInvalidOutlierDetection(_ *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	rh.OnAdd(&contour_v1alpha1.ExtensionService{
+		ObjectMeta: fixture.ObjectMeta("ns/ext"),
+		Spec: contour_v1alpha1.ExtensionServiceSpec{
+			Services: []contour_v1alpha1.ExtensionServiceTarget{
+				{Name: "svc1", Port: 8081},
+			},
+			OutlierDetectionPolicy: &contour_v1.OutlierDetectionPolicy{
+				MaxEjectionPercent: ptr.To(uint32(200)),
+			},
+		},
+	})
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: clusterType,
+	})
+}
+
 func TestExtensionService(t *testing.T) {
 	subtests := map[string]func(*testing.T, ResourceEventHandlerWrapper, *Contour){
 		"Basic":                         extBasic,
@@ -707,6 +768,8 @@ func TestExtensionService(t *testing.T) {
 		"CircuitBreakers":               extCircuitBreakers,
 		"GlobalCircuitBreakers":         extGlobalCircuitBreakers,
 		"OverrideGlobalCircuitBreakers": overrideExtGlobalCircuitBreakers,
+		"OutlierDetection":              extOutlierDetection,
+		"InvalidOutlierDetection":       extInvalidOutlierDetection,
 	}
 
 	for n, f := range subtests {
@@ -0,0 +1,324 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_ext_proc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func extProcGrpcService(name string) *envoy_config_core_v3.GrpcService {
+	return &envoy_config_core_v3.GrpcService{
+		TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+			EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+				ClusterName: name,
+				Authority:   "extension.proc.extension",
+			},
+		},
+	}
+}
+
+func extProcBasic(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "extproc.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithExternalProcessing(contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Namespace: "proc",
+				Name:      "extension",
+			},
+			ResponseTimeout: "500ms",
+			FailOpen:        true,
+			ProcessingMode: &contour_v1.ProcessingMode{
+				ResponseHeaderMode: contour_v1.HeaderSendModeSkip,
+				RequestBodyMode:    contour_v1.BodySendModeBuffered,
+			},
+		}).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			defaultHTTPListener(),
+
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						extProcFilterFor(
+							fqdn,
+							&envoy_filter_http_ext_proc_v3.ExternalProcessor{
+								GrpcService:      extProcGrpcService("extension/proc/extension"),
+								FailureModeAllow: true,
+								MessageTimeout:   durationpb.New(500 * time.Millisecond),
+								ProcessingMode: &envoy_filter_http_ext_proc_v3.ProcessingMode{
+									RequestHeaderMode:  envoy_filter_http_ext_proc_v3.ProcessingMode_DEFAULT,
+									ResponseHeaderMode: envoy_filter_http_ext_proc_v3.ProcessingMode_SKIP,
+									RequestBodyMode:    envoy_filter_http_ext_proc_v3.ProcessingMode_BUFFERED,
+									ResponseBodyMode:   envoy_filter_http_ext_proc_v3.ProcessingMode_NONE,
+								},
+							},
+						),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+
+			statsListener()),
+	}).Status(p).IsValid()
+}
+
+func extProcRoutePolicy(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "extproc.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithExternalProcessing(contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Namespace: "proc",
+				Name:      "extension",
+			},
+		}).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Conditions:               matchconditions(prefixMatchCondition("/disabled")),
+				Services:                 []contour_v1.Service{{Name: "app-server", Port: 80}},
+				ExternalProcessingPolicy: &contour_v1.ExternalProcessingPolicy{Disabled: true},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/upload")),
+				Services:   []contour_v1.Service{{Name: "app-server", Port: 80}},
+				ExternalProcessingPolicy: &contour_v1.ExternalProcessingPolicy{
+					ProcessingMode: &contour_v1.ProcessingMode{
+						RequestBodyMode: contour_v1.BodySendModeStreamed,
+					},
+				},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/default")),
+				Services:   []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	disabledConfig := withFilterConfig(envoy_v3.ExtProcFilterName,
+		&envoy_filter_http_ext_proc_v3.ExtProcPerRoute{
+			Override: &envoy_filter_http_ext_proc_v3.ExtProcPerRoute_Disabled{
+				Disabled: true,
+			},
+		})
+
+	streamedConfig := withFilterConfig(envoy_v3.ExtProcFilterName,
+		&envoy_filter_http_ext_proc_v3.ExtProcPerRoute{
+			Override: &envoy_filter_http_ext_proc_v3.ExtProcPerRoute_Overrides{
+				Overrides: &envoy_filter_http_ext_proc_v3.ExtProcOverrides{
+					ProcessingMode: &envoy_filter_http_ext_proc_v3.ProcessingMode{
+						RequestBodyMode: envoy_filter_http_ext_proc_v3.ProcessingMode_STREAMED,
+					},
+				},
+			},
+		})
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration(
+				path.Join("https", fqdn),
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_config_route_v3.Route{
+						Match:                routePrefix("/disabled"),
+						Action:               routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: disabledConfig,
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/default"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+					&envoy_config_route_v3.Route{
+						Match:                routePrefix("/upload"),
+						Action:               routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: streamedConfig,
+					},
+				),
+			),
+			envoy_v3.RouteConfiguration(
+				"ingress_http",
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/disabled"),
+						Action: withRedirect(),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/default"),
+						Action: withRedirect(),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/upload"),
+						Action: withRedirect(),
+					},
+				),
+			),
+		),
+	}).Status(p).IsValid()
+}
+
+func extProcInvalidReference(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("extproc.projectcontour.io").
+		WithCertificate("certificate").
+		WithExternalProcessing(contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Namespace: "proc",
+				Name:      "missing",
+			},
+		}).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeExternalProcessingError, "ExtensionServiceNotFound",
+		`Spec.Virtualhost.ExternalProcessing.extensionRef extension service "proc/missing" not found`)
+}
+
+func extProcWithoutTLS(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("extproc.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+	p.Spec.VirtualHost.ExternalProcessing = &contour_v1.ExternalProcessing{
+		ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+			Namespace: "proc",
+			Name:      "extension",
+		},
+	}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeExternalProcessingError, "ExternalProcessingNotPermitted",
+		"Spec.VirtualHost.ExternalProcessing can only be defined for root HTTPProxies that terminate TLS")
+}
+
+func extProcFallbackIncompat(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("extproc.projectcontour.io").
+		WithCertificate("certificate").
+		WithExternalProcessing(contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Namespace: "proc",
+				Name:      "extension",
+			},
+		}).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	p.Spec.VirtualHost.TLS.EnableFallbackCertificate = true
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures", "Spec.Virtualhost.TLS fallback & external processing are incompatible")
+}
+
+func TestExternalProcessing(t *testing.T) {
+	subtests := map[string]func(*testing.T, ResourceEventHandlerWrapper, *Contour){
+		"Basic":            extProcBasic,
+		"RoutePolicy":      extProcRoutePolicy,
+		"MissingExtension": extProcInvalidReference,
+		"WithoutTLS":       extProcWithoutTLS,
+		"FallbackIncompat": extProcFallbackIncompat,
+	}
+
+	for n, f := range subtests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			rh, c, done := setup(t)
+			defer done()
+
+			// Add common test fixtures.
+
+			rh.OnAdd(fixture.NewService("proc/proc-server").
+				WithPorts(core_v1.ServicePort{Port: 8081}))
+
+			rh.OnAdd(featuretests.Endpoints("proc", "proc-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 8081)),
+			}))
+
+			rh.OnAdd(&contour_v1alpha1.ExtensionService{
+				ObjectMeta: fixture.ObjectMeta("proc/extension"),
+				Spec: contour_v1alpha1.ExtensionServiceSpec{
+					Services: []contour_v1alpha1.ExtensionServiceTarget{
+						{Name: "proc-server", Port: 8081},
+					},
+				},
+			})
+
+			rh.OnAdd(fixture.NewService("app-server").
+				WithPorts(core_v1.ServicePort{Port: 80}))
+
+			rh.OnAdd(featuretests.Endpoints("default", "app-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 80)),
+			}))
+
+			rh.OnAdd(featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate))
+			f(t, rh, c)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_cluster_aggregate_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/protobuf"
+)
+
+func aggregateCluster(name string, clusters ...string) *envoy_config_cluster_v3.Cluster {
+	return &envoy_config_cluster_v3.Cluster{
+		Name:           name,
+		ConnectTimeout: durationpb.New(2 * time.Second),
+		LbPolicy:       envoy_config_cluster_v3.Cluster_CLUSTER_PROVIDED,
+		ClusterDiscoveryType: &envoy_config_cluster_v3.Cluster_ClusterType{
+			ClusterType: &envoy_config_cluster_v3.Cluster_CustomClusterType{
+				Name: "envoy.clusters.aggregate",
+				TypedConfig: protobuf.MustMarshalAny(&envoy_cluster_aggregate_v3.ClusterConfig{
+					Clusters: clusters,
+				}),
+			},
+		},
+	}
+}
+
+func TestFailover(t *testing.T) {
+	rh, c, done := setup(t, enableExternalNameService(t))
+	defer done()
+
+	rh.OnAdd(fixture.NewService("app").
+		WithPorts(core_v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+
+	rh.OnAdd(fixture.NewService("dr").
+		WithSpec(core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{{
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+			}},
+			ExternalName: "app.dr.example.com",
+			Type:         core_v1.ServiceTypeExternalName,
+		}))
+
+	// The DR service is listed first, but has a lower priority.
+	p1 := fixture.NewProxy("failover").
+		WithFQDN("failover.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{
+					{Name: "dr", Port: 80, Priority: 1},
+					{Name: "app", Port: 80},
+				},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("failover.projectcontour.io",
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/app/failover/e426d085fe"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(p1).IsValid()
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			cluster("default/app/80/da39a3ee5e", "default/app", "default_app_80"),
+			aggregateCluster("default/app/failover/e426d085fe",
+				"default/app/80/da39a3ee5e",
+				"default/dr/80/7e04fc469e",
+			),
+			externalNameCluster("default/dr/80/7e04fc469e", "default/dr", "default_dr_80", "app.dr.example.com", 80),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// Services of a route can not share a priority.
+	p2 := fixture.NewProxy("failover").
+		WithFQDN("failover.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{
+					{Name: "dr", Port: 80, Priority: 1},
+					{Name: "app", Port: 80, Priority: 1},
+				},
+			}},
+		})
+	rh.OnUpdate(p1, p2)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p2).HasError(contour_v1.ConditionTypeServiceError, "PriorityInvalid",
+		`services "dr" and "app" have the same priority 1`)
+}
@@ -0,0 +1,106 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_fault_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func TestFaultInjection(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("backend").
+		WithPorts(core_v1.ServicePort{Name: "http", Port: 80}))
+
+	p := fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{Fqdn: "example.com"},
+		Routes: []contour_v1.Route{{
+			Conditions: matchconditions(prefixMatchCondition("/faulty")),
+			Services:   []contour_v1.Service{{Name: "backend", Port: 80}},
+			FaultInjectionPolicy: &contour_v1.FaultInjectionPolicy{
+				Abort: &contour_v1.FaultAbort{
+					StatusCode: 503,
+					Percentage: ptr.To(int32(25)),
+				},
+			},
+		}, {
+			Services: []contour_v1.Service{{Name: "backend", Port: 80}},
+		}},
+	})
+	rh.OnAdd(p)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("example.com",
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/faulty"),
+						Action: routeCluster("default/backend/80/da39a3ee5e"),
+						TypedPerFilterConfig: withFilterConfig(envoy_v3.FaultFilterName,
+							&envoy_filter_http_fault_v3.HTTPFault{
+								Abort: &envoy_filter_http_fault_v3.FaultAbort{
+									ErrorType: &envoy_filter_http_fault_v3.FaultAbort_HttpStatus{
+										HttpStatus: 503,
+									},
+									Percentage: &envoy_type_v3.FractionalPercent{
+										Numerator:   25,
+										Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+									},
+								},
+							}),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/backend/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(p).IsValid()
+
+	// An invalid policy rejects the HTTPProxy.
+	p2 := fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{Fqdn: "example.com"},
+		Routes: []contour_v1.Route{{
+			Services: []contour_v1.Service{{Name: "backend", Port: 80}},
+			FaultInjectionPolicy: &contour_v1.FaultInjectionPolicy{
+				Delay: &contour_v1.FaultDelay{
+					Duration: "0s",
+				},
+			},
+		}},
+	})
+	rh.OnUpdate(p, p2)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(p2).HasError(contour_v1.ConditionTypeRouteError, "FaultInjectionPolicyInvalid",
+		`route.faultInjectionPolicy is invalid: invalid delay duration "0s", must be greater than zero`)
+}
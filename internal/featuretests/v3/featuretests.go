@@ -28,6 +28,7 @@ import (
 	envoy_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	envoy_service_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	envoy_service_extension_v3 "github.com/envoyproxy/go-control-plane/envoy/service/extension/v3"
 	envoy_service_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
 	envoy_service_route_v3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	envoy_service_secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
@@ -61,11 +62,12 @@ import (
 )
 
 const (
-	endpointType = resource.EndpointType // nolint:varcheck,deadcode
-	clusterType  = resource.ClusterType
-	routeType    = resource.RouteType
-	listenerType = resource.ListenerType
-	secretType   = resource.SecretType
+	endpointType        = resource.EndpointType // nolint:varcheck,deadcode
+	clusterType         = resource.ClusterType
+	routeType           = resource.RouteType
+	listenerType        = resource.ListenerType
+	secretType          = resource.SecretType
+	extensionConfigType = resource.ExtensionConfigType
 )
 
 // ResourceEventHandlerWrapper wraps the ResourceEventHandler interface from client-go/tools/cache.
@@ -99,6 +101,7 @@ func setup(t *testing.T, opts ...any) (ResourceEventHandlerWrapper, *Contour, fu
 			0,
 		),
 		&xdscache_v3.SecretCache{},
+		&xdscache_v3.ExtensionConfigCache{},
 		&xdscache_v3.RouteCache{},
 		&xdscache_v3.ClusterCache{},
 		et,
@@ -132,6 +135,7 @@ func setup(t *testing.T, opts ...any) (ResourceEventHandlerWrapper, *Contour, fu
 			&dag.ExtensionServiceProcessor{
 				FieldLogger: log.WithField("context", "ExtensionServiceProcessor"),
 			},
+			&dag.BackendProcessor{},
 			&dag.HTTPProxyProcessor{},
 			&dag.GatewayAPIProcessor{
 				FieldLogger: log.WithField("context", "GatewayAPIProcessor"),
@@ -321,7 +325,7 @@ func (s *StatusResult) Equals(want contour_v1.HTTPProxyStatus) *Contour {
 	// We should never get an error fetching the status for an
 	// object, so make it fatal if we do.
 	if s.Err != nil {
-		s.T.Fatalf(s.Err.Error())
+		s.T.Fatal(s.Err)
 	}
 
 	assert.Equal(s.T, want, *s.Have)
@@ -336,7 +340,7 @@ func (s *StatusResult) Like(want contour_v1.HTTPProxyStatus) *Contour {
 	// We should never get an error fetching the status for an
 	// object, so make it fatal if we do.
 	if s.Err != nil {
-		s.T.Fatalf(s.Err.Error())
+		s.T.Fatal(s.Err)
 	}
 
 	if len(want.CurrentStatus) > 0 {
@@ -446,6 +450,11 @@ func (c *Contour) Request(typeurl string, names ...string) *Response {
 		stl, err := lds.StreamListeners(ctx)
 		require.NoError(c, err)
 		st = stl
+	case extensionConfigType:
+		ecds := envoy_service_extension_v3.NewExtensionConfigDiscoveryServiceClient(c.ClientConn)
+		stx, err := ecds.StreamExtensionConfigs(ctx)
+		require.NoError(c, err)
+		st = stx
 	case endpointType:
 		eds := envoy_service_endpoint_v3.NewEndpointDiscoveryServiceClient(c.ClientConn)
 		ste, err := eds.StreamEndpoints(ctx)
@@ -0,0 +1,291 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+	"time"
+
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_ext_proc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	envoy_filter_network_http_connection_manager_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/protobuf"
+	"github.com/projectcontour/contour/internal/timeout"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
+)
+
+func globalExtProcFilter() *envoy_filter_http_ext_proc_v3.ExternalProcessor {
+	return &envoy_filter_http_ext_proc_v3.ExternalProcessor{
+		GrpcService:    extProcGrpcService("extension/proc/extension"),
+		MessageTimeout: durationpb.New(500 * time.Millisecond),
+	}
+}
+
+func globalExternalProcessingFilterExists(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("foo.com").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	httpListener := defaultHTTPListener()
+
+	// replace the default filter chains with an HCM that includes the global
+	// ext_proc filter.
+	httpListener.FilterChains = envoy_v3.FilterChains(getGlobalExtProcHCM())
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			httpListener,
+			statsListener()),
+	}).Status(p).IsValid()
+}
+
+func globalExternalProcessingFilterExistsTLS(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "foo.com"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	httpListener := defaultHTTPListener()
+	httpListener.FilterChains = envoy_v3.FilterChains(getGlobalExtProcHCM())
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			httpListener,
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						extProcFilterFor(fqdn, globalExtProcFilter()),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+			statsListener()),
+	}).Status(p).IsValid()
+}
+
+func globalExternalProcessingWithTLSOverride(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "foo.com"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithExternalProcessing(contour_v1.ExternalProcessing{
+			ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+				Namespace: "proc",
+				Name:      "extension",
+			},
+			ResponseTimeout: "2s",
+			FailOpen:        true,
+		}).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	httpListener := defaultHTTPListener()
+	httpListener.FilterChains = envoy_v3.FilterChains(getGlobalExtProcHCM())
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			httpListener,
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						extProcFilterFor(fqdn, &envoy_filter_http_ext_proc_v3.ExternalProcessor{
+							GrpcService:      extProcGrpcService("extension/proc/extension"),
+							FailureModeAllow: true,
+							MessageTimeout:   durationpb.New(2 * time.Second),
+						}),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+			statsListener()),
+	}).Status(p).IsValid()
+}
+
+func globalExternalProcessingWithRouteDisabled(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "foo.com"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Conditions:               matchconditions(prefixMatchCondition("/disabled")),
+				Services:                 []contour_v1.Service{{Name: "app-server", Port: 80}},
+				ExternalProcessingPolicy: &contour_v1.ExternalProcessingPolicy{Disabled: true},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/default")),
+				Services:   []contour_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	rh.OnAdd(p)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration(
+				"ingress_http",
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/disabled"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: withFilterConfig(envoy_v3.ExtProcFilterName,
+							&envoy_filter_http_ext_proc_v3.ExtProcPerRoute{
+								Override: &envoy_filter_http_ext_proc_v3.ExtProcPerRoute_Disabled{
+									Disabled: true,
+								},
+							}),
+					},
+					&envoy_config_route_v3.Route{
+						Match:  routePrefix("/default"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+	}).Status(p).IsValid()
+}
+
+func TestGlobalExternalProcessing(t *testing.T) {
+	subtests := map[string]func(*testing.T, ResourceEventHandlerWrapper, *Contour){
+		// Default ext_proc on non TLS host.
+		"GlobalExternalProcessingFilterExists": globalExternalProcessingFilterExists,
+		// Default ext_proc on non TLS and TLS hosts.
+		"GlobalExternalProcessingFilterExistsTLS": globalExternalProcessingFilterExistsTLS,
+		// ext_proc override on TLS host.
+		"GlobalExternalProcessingWithTLSOverride": globalExternalProcessingWithTLSOverride,
+		// ext_proc disabled on a non TLS route.
+		"GlobalExternalProcessingWithRouteDisabled": globalExternalProcessingWithRouteDisabled,
+	}
+
+	for n, f := range subtests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			rh, c, done := setup(t,
+				func(cfg *xdscache_v3.ListenerConfig) {
+					cfg.GlobalExternalProcessingConfig = &xdscache_v3.GlobalExternalProcessingConfig{
+						ExtensionServiceConfig: xdscache_v3.ExtensionServiceConfig{
+							ExtensionService: k8s.NamespacedNameFrom("proc/extension"),
+							Timeout:          timeout.DurationSetting(500 * time.Millisecond),
+							SNI:              "extension.proc.extension",
+						},
+					}
+				},
+				func(b *dag.Builder) {
+					for _, processor := range b.Processors {
+						if httpProxyProcessor, ok := processor.(*dag.HTTPProxyProcessor); ok {
+							httpProxyProcessor.GlobalExternalProcessing = &contour_v1.ExternalProcessing{
+								ExtensionServiceRef: contour_v1.ExtensionServiceReference{
+									Name:      "extension",
+									Namespace: "proc",
+								},
+								ResponseTimeout: "500ms",
+							}
+						}
+					}
+				})
+			defer done()
+
+			// Add common test fixtures.
+			rh.OnAdd(fixture.NewService("proc/proc-server").
+				WithPorts(core_v1.ServicePort{Port: 8081}))
+
+			rh.OnAdd(featuretests.Endpoints("proc", "proc-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 8081)),
+			}))
+
+			rh.OnAdd(&contour_v1alpha1.ExtensionService{
+				ObjectMeta: fixture.ObjectMeta("proc/extension"),
+				Spec: contour_v1alpha1.ExtensionServiceSpec{
+					Services: []contour_v1alpha1.ExtensionServiceTarget{
+						{Name: "proc-server", Port: 8081},
+					},
+				},
+			})
+
+			rh.OnAdd(fixture.NewService("app-server").
+				WithPorts(core_v1.ServicePort{Port: 80}))
+
+			rh.OnAdd(featuretests.Endpoints("default", "app-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 80)),
+			}))
+
+			rh.OnAdd(featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate))
+
+			f(t, rh, c)
+		})
+	}
+}
+
+// getGlobalExtProcHCM returns a HTTP Connection Manager with Global External Processing configured.
+func getGlobalExtProcHCM() *envoy_config_listener_v3.Filter {
+	return envoy_v3.HTTPConnectionManagerBuilder().
+		RouteConfigName("ingress_http").
+		MetricsPrefix("ingress_http").
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo)).
+		DefaultFilters().
+		AddFilter(&envoy_filter_network_http_connection_manager_v3.HttpFilter{
+			Name: envoy_v3.ExtProcFilterName,
+			ConfigType: &envoy_filter_network_http_connection_manager_v3.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(globalExtProcFilter()),
+			},
+		}).
+		Get()
+}
@@ -0,0 +1,128 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"path"
+	"testing"
+
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
+)
+
+func TestHTTP3Listener(t *testing.T) {
+	rh, c, done := setup(t, func(conf *xdscache_v3.ListenerConfig) {
+		conf.HTTP3 = &contour_v1alpha1.EnvoyHTTP3{
+			Enabled:        true,
+			AdvertisedPort: ptr.To(uint32(8443)),
+		}
+	})
+	defer done()
+
+	sec1 := featuretests.TLSSecret(t, "secret", &featuretests.ServerCertificate)
+	rh.OnAdd(sec1)
+
+	rh.OnAdd(fixture.NewService("backend").
+		WithPorts(core_v1.ServicePort{Name: "http", Port: 80}))
+
+	p := fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{
+			Fqdn: "kuard.example.com",
+			TLS: &contour_v1.TLS{
+				SecretName: sec1.Name,
+			},
+		},
+		Routes: []contour_v1.Route{{
+			Services: []contour_v1.Service{{
+				Name: "backend",
+				Port: 80,
+			}},
+		}},
+	})
+	rh.OnAdd(p)
+
+	cm := envoy_v3.HTTPConnectionManagerBuilder().
+		AddFilter(envoy_v3.FilterMisdirectedRequests("kuard.example.com")).
+		DefaultFilters().
+		AddFilter(envoy_v3.FilterAltSvc(8443)).
+		RouteConfigName(path.Join("https", "kuard.example.com")).
+		MetricsPrefix(xdscache_v3.ENVOY_HTTPS_LISTENER).
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo))
+
+	httpsFilter := cm.Get()
+	quicFilter := cm.Codec(envoy_v3.HTTPVersion3).Get()
+
+	quicListener := envoy_v3.QUICListener("ingress_https_quic", "0.0.0.0", 8443, envoy_v3.NewSocketOptions())
+	quicListener.FilterChains = []*envoy_config_listener_v3.FilterChain{
+		envoy_v3.FilterChainQUIC(
+			"kuard.example.com",
+			envoy_v3.DownstreamTLSContext(
+				&dag.Secret{Object: sec1},
+				envoy_transport_socket_tls_v3.TlsParameters_TLSv1_3,
+				envoy_transport_socket_tls_v3.TlsParameters_TLSv1_3,
+				nil,
+				nil,
+				"h3"),
+			envoy_v3.Filters(quicFilter),
+		),
+	}
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			defaultHTTPListener(),
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls("kuard.example.com", sec1, httpsFilter, nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+			quicListener,
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	}).Status(p).IsValid()
+
+	// Without TLS there is no HTTP/3 listener.
+	p2 := fixture.NewProxy("simple").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{
+			Fqdn: "kuard.example.com",
+		},
+		Routes: p.Spec.Routes,
+	})
+	rh.OnUpdate(p, p2)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			defaultHTTPListener(),
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
@@ -0,0 +1,257 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_filter_http_oauth2_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/oauth2/v3"
+	envoy_transport_socket_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	core_v1 "k8s.io/api/core/v1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func oauth2Proxy(oauth2 *contour_v1.OAuth2) *contour_v1.HTTPProxy {
+	return fixture.NewProxy("proxy").WithSpec(contour_v1.HTTPProxySpec{
+		VirtualHost: &contour_v1.VirtualHost{
+			Fqdn: "oauth2.projectcontour.io",
+			TLS: &contour_v1.TLS{
+				SecretName: "certificate",
+			},
+			OAuth2: oauth2,
+		},
+		Routes: []contour_v1.Route{{
+			Services: []contour_v1.Service{{Name: "app-server", Port: 80}},
+		}},
+	})
+}
+
+func oauth2PathMatcher(path string) *envoy_matcher_v3.PathMatcher {
+	return &envoy_matcher_v3.PathMatcher{
+		Rule: &envoy_matcher_v3.PathMatcher_Path{
+			Path: &envoy_matcher_v3.StringMatcher{
+				MatchPattern: &envoy_matcher_v3.StringMatcher_Exact{
+					Exact: path,
+				},
+			},
+		},
+	}
+}
+
+func oauth2SdsSecret(name string) *envoy_transport_socket_tls_v3.SdsSecretConfig {
+	return &envoy_transport_socket_tls_v3.SdsSecretConfig{
+		Name:      name,
+		SdsConfig: envoy_v3.ConfigSource("contour"),
+	}
+}
+
+func oauth2Basic(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	const fqdn = "oauth2.projectcontour.io"
+
+	p := oauth2Proxy(&contour_v1.OAuth2{
+		TokenEndpoint:         "https://idp.example.com/oauth2/token",
+		TokenEndpointTimeout:  "3s",
+		AuthorizationEndpoint: "https://idp.example.com/oauth2/authorize",
+		ClientID:              "contour",
+		ClientSecretRef:       "oauth2",
+		Scopes:                []string{"openid", "email"},
+		ForwardBearerToken:    true,
+		PassThroughMatchers: []contour_v1.HeaderMatchCondition{{
+			Name:    "x-api-key",
+			Present: true,
+		}},
+	})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType, "ingress_https").Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			&envoy_config_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{
+					filterchaintls(fqdn, featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate),
+						oauth2FilterFor(fqdn, &envoy_filter_http_oauth2_v3.OAuth2{
+							Config: &envoy_filter_http_oauth2_v3.OAuth2Config{
+								TokenEndpoint: &envoy_config_core_v3.HttpUri{
+									Uri: "https://idp.example.com/oauth2/token",
+									HttpUpstreamType: &envoy_config_core_v3.HttpUri_Cluster{
+										Cluster: "dnsname/https/idp.example.com",
+									},
+									Timeout: durationpb.New(3 * time.Second),
+								},
+								AuthorizationEndpoint: "https://idp.example.com/oauth2/authorize",
+								Credentials: &envoy_filter_http_oauth2_v3.OAuth2Credentials{
+									ClientId:    "contour",
+									TokenSecret: oauth2SdsSecret("default/oauth2/client-secret"),
+									TokenFormation: &envoy_filter_http_oauth2_v3.OAuth2Credentials_HmacSecret{
+										HmacSecret: oauth2SdsSecret("default/oauth2/hmac-secret"),
+									},
+								},
+								RedirectUri:         "https://%REQ(:authority)%/oauth2/callback",
+								RedirectPathMatcher: oauth2PathMatcher("/oauth2/callback"),
+								SignoutPath:         oauth2PathMatcher("/oauth2/signout"),
+								ForwardBearerToken:  true,
+								PassThroughMatcher: []*envoy_config_route_v3.HeaderMatcher{{
+									Name: "x-api-key",
+									HeaderMatchSpecifier: &envoy_config_route_v3.HeaderMatcher_PresentMatch{
+										PresentMatch: true,
+									},
+								}},
+								AuthScopes: []string{"openid", "email"},
+							},
+						}),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+		),
+	}).Status(p).IsValid()
+
+	c.Request(secretType, "default/oauth2/client-secret", "default/oauth2/hmac-secret").Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl: secretType,
+		Resources: resources(t,
+			&envoy_transport_socket_tls_v3.Secret{
+				Name: "default/oauth2/client-secret",
+				Type: &envoy_transport_socket_tls_v3.Secret_GenericSecret{
+					GenericSecret: &envoy_transport_socket_tls_v3.GenericSecret{
+						Secret: &envoy_config_core_v3.DataSource{
+							Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+								InlineBytes: []byte("client"),
+							},
+						},
+					},
+				},
+			},
+			&envoy_transport_socket_tls_v3.Secret{
+				Name: "default/oauth2/hmac-secret",
+				Type: &envoy_transport_socket_tls_v3.Secret_GenericSecret{
+					GenericSecret: &envoy_transport_socket_tls_v3.GenericSecret{
+						Secret: &envoy_config_core_v3.DataSource{
+							Specifier: &envoy_config_core_v3.DataSource_InlineBytes{
+								InlineBytes: []byte("hmac"),
+							},
+						},
+					},
+				},
+			},
+		),
+	})
+}
+
+func oauth2WithoutTLS(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := oauth2Proxy(&contour_v1.OAuth2{
+		TokenEndpoint:         "https://idp.example.com/oauth2/token",
+		AuthorizationEndpoint: "https://idp.example.com/oauth2/authorize",
+		ClientID:              "contour",
+		ClientSecretRef:       "oauth2",
+	})
+	p.Spec.VirtualHost.TLS = nil
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeOAuth2Error, "OAuth2NotPermitted",
+		"Spec.VirtualHost.OAuth2 can only be defined for root HTTPProxies that terminate TLS")
+}
+
+func oauth2MissingSecret(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := oauth2Proxy(&contour_v1.OAuth2{
+		TokenEndpoint:         "https://idp.example.com/oauth2/token",
+		AuthorizationEndpoint: "https://idp.example.com/oauth2/authorize",
+		ClientID:              "contour",
+		ClientSecretRef:       "missing",
+	})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeOAuth2Error, "ClientSecretNotValid",
+		`Spec.VirtualHost.OAuth2.ClientSecretRef Secret "default/missing" is invalid: Secret not found`)
+}
+
+func oauth2InvalidTokenEndpoint(t *testing.T, rh ResourceEventHandlerWrapper, c *Contour) {
+	p := oauth2Proxy(&contour_v1.OAuth2{
+		TokenEndpoint:         "ftp://idp.example.com/oauth2/token",
+		AuthorizationEndpoint: "https://idp.example.com/oauth2/authorize",
+		ClientID:              "contour",
+		ClientSecretRef:       "oauth2",
+	})
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_v1.ConditionTypeOAuth2Error, "TokenEndpointSchemeInvalid",
+		`Spec.VirtualHost.OAuth2.TokenEndpoint has invalid scheme "ftp", must be http or https`)
+}
+
+func TestOAuth2(t *testing.T) {
+	subtests := map[string]func(*testing.T, ResourceEventHandlerWrapper, *Contour){
+		"Basic":                oauth2Basic,
+		"WithoutTLS":           oauth2WithoutTLS,
+		"MissingSecret":        oauth2MissingSecret,
+		"InvalidTokenEndpoint": oauth2InvalidTokenEndpoint,
+	}
+
+	for n, f := range subtests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			rh, c, done := setup(t)
+			defer done()
+
+			// Add common test fixtures.
+			rh.OnAdd(fixture.NewService("app-server").
+				WithPorts(core_v1.ServicePort{Port: 80}))
+
+			rh.OnAdd(featuretests.Endpoints("default", "app-server", core_v1.EndpointSubset{
+				Addresses: featuretests.Addresses("192.168.183.21"),
+				Ports:     featuretests.Ports(featuretests.Port("", 80)),
+			}))
+
+			rh.OnAdd(&core_v1.Secret{
+				ObjectMeta: fixture.ObjectMeta("oauth2"),
+				Type:       core_v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"client-secret": []byte("client"),
+					"hmac-secret":   []byte("hmac"),
+				},
+			})
+
+			rh.OnAdd(featuretests.TLSSecret(t, "certificate", &featuretests.ServerCertificate))
+
+			f(t, rh, c)
+		})
+	}
+}
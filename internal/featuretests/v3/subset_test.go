@@ -0,0 +1,148 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+)
+
+func enableSubsetLoadBalancing(b *dag.Builder) {
+	for _, p := range b.Processors {
+		if p, ok := p.(*dag.HTTPProxyProcessor); ok {
+			p.EnableSubsetLoadBalancing = true
+		}
+	}
+}
+
+func TestSubsetLoadBalancing(t *testing.T) {
+	rh, c, done := setup(t, enableSubsetLoadBalancing)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("app").
+		WithPorts(core_v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+
+	p1 := fixture.NewProxy("subset").
+		WithFQDN("subset.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "app",
+					Port: 80,
+					Subset: &contour_v1.SubsetSelector{
+						MatchLabels: map[string]string{"version": "v2"},
+					},
+				}},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Request(routeType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("subset.projectcontour.io",
+					&envoy_config_route_v3.Route{
+						Match: routePrefix("/"),
+						Action: routeCluster("default/app/80/c692273deb", func(r *envoy_config_route_v3.Route_Route) {
+							r.Route.MetadataMatch = &envoy_config_core_v3.Metadata{
+								FilterMetadata: map[string]*structpb.Struct{
+									"envoy.lb": {
+										Fields: map[string]*structpb.Value{
+											"version": structpb.NewStringValue("v2"),
+										},
+									},
+								},
+							}
+						}),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(p1).IsValid()
+
+	subsetCluster := cluster("default/app/80/c692273deb", "default/app", "default_app_80")
+	subsetCluster.LbSubsetConfig = &envoy_config_cluster_v3.Cluster_LbSubsetConfig{
+		FallbackPolicy: envoy_config_cluster_v3.Cluster_LbSubsetConfig_NO_FALLBACK,
+		SubsetSelectors: []*envoy_config_cluster_v3.Cluster_LbSubsetConfig_LbSubsetSelector{{
+			Keys: []string{"version"},
+		}},
+	}
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t, subsetCluster),
+		TypeUrl:   clusterType,
+	})
+
+	// An empty selector is rejected.
+	p2 := fixture.NewProxy("subset").
+		WithFQDN("subset.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name:   "app",
+					Port:   80,
+					Subset: &contour_v1.SubsetSelector{},
+				}},
+			}},
+		})
+	rh.OnUpdate(p1, p2)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p2).HasError(contour_v1.ConditionTypeServiceError, "SubsetNotValid",
+		`service "app": subset.matchLabels must not be empty`)
+}
+
+func TestSubsetLoadBalancingNotEnabled(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("app").
+		WithPorts(core_v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+
+	p1 := fixture.NewProxy("subset").
+		WithFQDN("subset.projectcontour.io").
+		WithSpec(contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "app",
+					Port: 80,
+					Subset: &contour_v1.SubsetSelector{
+						MatchLabels: map[string]string{"version": "v2"},
+					},
+				}},
+			}},
+		})
+	rh.OnAdd(p1)
+
+	c.Request(clusterType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: nil,
+		TypeUrl:   clusterType,
+	}).Status(p1).HasError(contour_v1.ConditionTypeServiceError, "SubsetLoadBalancingNotEnabled",
+		`service "app": subset load balancing is not enabled`)
+}
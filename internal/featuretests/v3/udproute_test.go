@@ -0,0 +1,210 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	xds_core_v3 "github.com/cncf/xds/go/xds/core/v3"
+	xds_type_matcher_v3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_filter_udp_udp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+	"github.com/projectcontour/contour/internal/protobuf"
+)
+
+func udpproxy(statPrefix, cluster string) *envoy_config_listener_v3.ListenerFilter {
+	return &envoy_config_listener_v3.ListenerFilter{
+		Name: envoy_v3.UDPProxyFilterName,
+		ConfigType: &envoy_config_listener_v3.ListenerFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_filter_udp_udp_proxy_v3.UdpProxyConfig{
+				StatPrefix: statPrefix,
+				AccessLog:  envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil, contour_v1alpha1.LogLevelInfo),
+				RouteSpecifier: &envoy_filter_udp_udp_proxy_v3.UdpProxyConfig_Matcher{
+					Matcher: &xds_type_matcher_v3.Matcher{
+						OnNoMatch: &xds_type_matcher_v3.Matcher_OnMatch{
+							OnMatch: &xds_type_matcher_v3.Matcher_OnMatch_Action{
+								Action: &xds_core_v3.TypedExtensionConfig{
+									Name: "route",
+									TypedConfig: protobuf.MustMarshalAny(&envoy_filter_udp_udp_proxy_v3.Route{
+										Cluster: cluster,
+									}),
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+	}
+}
+
+func udpListener(name string, port int, cluster string) *envoy_config_listener_v3.Listener {
+	return &envoy_config_listener_v3.Listener{
+		Name:              name,
+		Address:           envoy_v3.UDPSocketAddress("0.0.0.0", port),
+		ListenerFilters:   []*envoy_config_listener_v3.ListenerFilter{udpproxy(name, cluster)},
+		UdpListenerConfig: &envoy_config_listener_v3.UdpListenerConfig{},
+	}
+}
+
+func TestUDPRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	svc1 := fixture.NewService("backend-1").
+		WithPorts(core_v1.ServicePort{Port: 53, Protocol: core_v1.ProtocolUDP, TargetPort: intstr.FromInt(5353)})
+
+	rh.OnAdd(svc1)
+
+	rh.OnAdd(&gatewayapi_v1.GatewayClass{
+		TypeMeta:   meta_v1.TypeMeta{},
+		ObjectMeta: fixture.ObjectMeta("test-gc"),
+		Spec: gatewayapi_v1.GatewayClassSpec{
+			ControllerName: "projectcontour.io/contour",
+		},
+		Status: gatewayapi_v1.GatewayClassStatus{
+			Conditions: []meta_v1.Condition{
+				{
+					Type:   string(gatewayapi_v1.GatewayClassConditionStatusAccepted),
+					Status: meta_v1.ConditionTrue,
+				},
+			},
+		},
+	})
+
+	gateway := &gatewayapi_v1.Gateway{
+		ObjectMeta: fixture.ObjectMeta("projectcontour/contour"),
+		Spec: gatewayapi_v1.GatewaySpec{
+			Listeners: []gatewayapi_v1.Listener{{
+				Name:     "udp-1",
+				Port:     10000,
+				Protocol: gatewayapi_v1.UDPProtocolType,
+				AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+					Namespaces: &gatewayapi_v1.RouteNamespaces{
+						From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	}
+	rh.OnAdd(gateway)
+
+	route1 := &gatewayapi_v1alpha2.UDPRoute{
+		ObjectMeta: fixture.ObjectMeta("udproute-1"),
+		Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1.ParentReference{
+					{
+						Namespace:   ptr.To(gatewayapi_v1.Namespace("projectcontour")),
+						Name:        gatewayapi_v1.ObjectName("contour"),
+						SectionName: ptr.To(gatewayapi_v1.SectionName("udp-1")),
+					},
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+				BackendRefs: gatewayapi.TLSRouteBackendRef("backend-1", 53, nil),
+			}},
+		},
+	}
+	rh.OnAdd(route1)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			statsListener(),
+			udpListener("udp-10000", 18000, "default/backend-1/53/e9a6f622e3"),
+		),
+		TypeUrl: listenerType,
+	})
+
+	// check that there is no route config
+	require.Empty(t, c.Request(routeType).Resources)
+
+	// A TCP listener on the same port as the UDP listener is
+	// programmed independently.
+	svc2 := fixture.NewService("backend-2").
+		WithPorts(core_v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)})
+	rh.OnAdd(svc2)
+
+	gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayapi_v1.Listener{
+		Name:     "tcp-1",
+		Port:     10000,
+		Protocol: gatewayapi_v1.TCPProtocolType,
+		AllowedRoutes: &gatewayapi_v1.AllowedRoutes{
+			Namespaces: &gatewayapi_v1.RouteNamespaces{
+				From: ptr.To(gatewayapi_v1.NamespacesFromAll),
+			},
+		},
+	})
+	rh.OnUpdate(gateway, gateway)
+
+	route2 := &gatewayapi_v1alpha2.TCPRoute{
+		ObjectMeta: fixture.ObjectMeta("tcproute-1"),
+		Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1.ParentReference{
+					{
+						Namespace:   ptr.To(gatewayapi_v1.Namespace("projectcontour")),
+						Name:        gatewayapi_v1.ObjectName("contour"),
+						SectionName: ptr.To(gatewayapi_v1.SectionName("tcp-1")),
+					},
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+				BackendRefs: gatewayapi.TLSRouteBackendRef("backend-2", 80, nil),
+			}},
+		},
+	}
+	rh.OnAdd(route2)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			statsListener(),
+			&envoy_config_listener_v3.Listener{
+				Name:    "tcp-10000",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 18000),
+				FilterChains: []*envoy_config_listener_v3.FilterChain{{
+					Filters: envoy_v3.Filters(
+						tcpproxy("tcp-10000", "default/backend-2/80/da39a3ee5e"),
+					),
+				}},
+				SocketOptions: envoy_v3.NewSocketOptions().TCPKeepalive().Build(),
+			},
+			udpListener("udp-10000", 18000, "default/backend-1/53/e9a6f622e3"),
+		),
+		TypeUrl: listenerType,
+	})
+
+	rh.OnDelete(route1)
+	rh.OnDelete(route2)
+
+	c.Request(listenerType).Equals(&envoy_service_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
@@ -101,13 +101,13 @@ func ValidateListeners(listeners []gatewayapi_v1.Listener) ValidateListenersResu
 
 		// Check for a supported protocol.
 		switch listener.Protocol {
-		case gatewayapi_v1.HTTPProtocolType, gatewayapi_v1.HTTPSProtocolType, gatewayapi_v1.TLSProtocolType, gatewayapi_v1.TCPProtocolType, ContourHTTPSProtocolType:
+		case gatewayapi_v1.HTTPProtocolType, gatewayapi_v1.HTTPSProtocolType, gatewayapi_v1.TLSProtocolType, gatewayapi_v1.TCPProtocolType, gatewayapi_v1.UDPProtocolType, ContourHTTPSProtocolType:
 		default:
 			result.InvalidListenerConditions[listener.Name] = meta_v1.Condition{
 				Type:    string(gatewayapi_v1.ListenerConditionAccepted),
 				Status:  meta_v1.ConditionFalse,
 				Reason:  string(gatewayapi_v1.ListenerReasonUnsupportedProtocol),
-				Message: fmt.Sprintf("Listener protocol %q is unsupported, must be one of HTTP, HTTPS, TLS, TCP or projectcontour.io/https", listener.Protocol),
+				Message: fmt.Sprintf("Listener protocol %q is unsupported, must be one of HTTP, HTTPS, TLS, TCP, UDP or projectcontour.io/https", listener.Protocol),
 			}
 			continue
 		}
@@ -120,6 +120,13 @@ func ValidateListeners(listeners []gatewayapi_v1.Listener) ValidateListenersResu
 			for j := range i {
 				otherListener := listeners[j]
 
+				// Listeners using UDP can share a port number with Listeners
+				// using TCP-based protocols, since they use a different
+				// transport protocol.
+				if (listener.Protocol == gatewayapi_v1.UDPProtocolType) != (otherListener.Protocol == gatewayapi_v1.UDPProtocolType) {
+					continue
+				}
+
 				if listener.Port != otherListener.Port {
 					// Port ranges 57536-58558 and 58559-59581 both map to container ports
 					// 1024-2046, since we can't listen on ports 1-1023 in the Envoy container.
@@ -155,6 +162,11 @@ func ValidateListeners(listeners []gatewayapi_v1.Listener) ValidateListenersResu
 						result.InvalidListenerConditions[listener.Name] = conflictedCondition(gatewayapi_v1.ListenerReasonProtocolConflict, "All Listener protocols for a given port must be compatible")
 						return true
 					}
+				case listener.Protocol == gatewayapi_v1.UDPProtocolType:
+					// UDP Listeners don't have hostnames, so there can
+					// only be a single one per port.
+					result.InvalidListenerConditions[listener.Name] = conflictedCondition(gatewayapi_v1.ListenerReasonProtocolConflict, "Only one UDP Listener can be defined for a given port")
+					return true
 				}
 
 				// Hostname conflict
@@ -180,6 +192,8 @@ func ValidateListeners(listeners []gatewayapi_v1.Listener) ValidateListenersResu
 			protocol = "https"
 		case gatewayapi_v1.TCPProtocolType:
 			protocol = "tcp"
+		case gatewayapi_v1.UDPProtocolType:
+			protocol = "udp"
 		}
 		envoyListenerName := fmt.Sprintf("%s-%d", protocol, listener.Port)
 
@@ -525,6 +525,74 @@ func TestValidateListeners(t *testing.T) {
 		assert.Len(t, res.ListenerNames, 3)
 	})
 
+	t.Run("UDP listeners share ports with TCP-based listeners", func(t *testing.T) {
+		listeners := []gatewayapi_v1.Listener{
+			{
+				Name:     "tcp-1",
+				Protocol: gatewayapi_v1.TCPProtocolType,
+				Port:     10000,
+			},
+			{
+				Name:     "udp-1",
+				Protocol: gatewayapi_v1.UDPProtocolType,
+				Port:     10000,
+			},
+			{
+				Name:     "udp-2",
+				Protocol: gatewayapi_v1.UDPProtocolType,
+				Port:     80,
+			},
+			{
+				Name:     "http",
+				Protocol: gatewayapi_v1.HTTPProtocolType,
+				Port:     80,
+			},
+		}
+
+		res := ValidateListeners(listeners)
+		assert.Empty(t, res.InvalidListenerConditions)
+		assert.ElementsMatch(t, res.Ports, []ListenerPort{
+			{Name: "tcp-10000", Port: 10000, ContainerPort: 18000, Protocol: "tcp"},
+			{Name: "udp-10000", Port: 10000, ContainerPort: 18000, Protocol: "udp"},
+			{Name: "udp-80", Port: 80, ContainerPort: 8080, Protocol: "udp"},
+			{Name: "http-80", Port: 80, ContainerPort: 8080, Protocol: "http"},
+		})
+		assert.Equal(t, map[string]string{
+			"tcp-1": "tcp-10000",
+			"udp-1": "udp-10000",
+			"udp-2": "udp-80",
+			"http":  "http-80",
+		}, res.ListenerNames)
+	})
+
+	t.Run("Two UDP listeners on the same port", func(t *testing.T) {
+		listeners := []gatewayapi_v1.Listener{
+			{
+				Name:     "udp-1",
+				Protocol: gatewayapi_v1.UDPProtocolType,
+				Port:     10000,
+			},
+			{
+				Name:     "udp-2",
+				Protocol: gatewayapi_v1.UDPProtocolType,
+				Port:     10000,
+			},
+		}
+
+		res := ValidateListeners(listeners)
+		assert.ElementsMatch(t, res.Ports, []ListenerPort{
+			{Name: "udp-10000", Port: 10000, ContainerPort: 18000, Protocol: "udp"},
+		})
+		assert.Equal(t, map[gatewayapi_v1.SectionName]meta_v1.Condition{
+			"udp-2": {
+				Type:    string(gatewayapi_v1.ListenerConditionConflicted),
+				Status:  meta_v1.ConditionTrue,
+				Reason:  string(gatewayapi_v1.ListenerReasonProtocolConflict),
+				Message: "Only one UDP Listener can be defined for a given port",
+			},
+		}, res.InvalidListenerConditions)
+	})
+
 	t.Run("Listeners with various edge-case port numbers", func(t *testing.T) {
 		listeners := []gatewayapi_v1.Listener{
 			{
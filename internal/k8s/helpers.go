@@ -28,6 +28,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -61,6 +62,13 @@ func isStatusEqual(objA, objB any) bool {
 				return true
 			}
 		}
+	case *contour_v1alpha1.Backend:
+		if b, ok := objB.(*contour_v1alpha1.Backend); ok {
+			if cmp.Equal(a.Status, b.Status,
+				cmpopts.IgnoreFields(contour_v1.Condition{}, "LastTransitionTime")) {
+				return true
+			}
+		}
 	case *gatewayapi_v1.GatewayClass:
 		if b, ok := objB.(*gatewayapi_v1.GatewayClass); ok {
 			if cmp.Equal(a.Status, b.Status,
@@ -110,6 +118,7 @@ func IsObjectEqual(oldObj, newObj client.Object) (bool, error) {
 	// Status/annotations/labels changes are ignored.
 	// Generation is implemented in CRDs, Ingress and IngressClass.
 	case *contour_v1alpha1.ExtensionService,
+		*contour_v1alpha1.Backend,
 		*contour_v1.TLSCertificateDelegation:
 		return isGenerationEqual(oldObj, newObj), nil
 
@@ -120,7 +129,9 @@ func IsObjectEqual(oldObj, newObj client.Object) (bool, error) {
 		*gatewayapi_v1alpha2.TLSRoute,
 		*gatewayapi_v1.GRPCRoute,
 		*gatewayapi_v1alpha2.TCPRoute,
-		*gatewayapi_v1alpha3.BackendTLSPolicy:
+		*gatewayapi_v1alpha2.UDPRoute,
+		*gatewayapi_v1alpha3.BackendTLSPolicy,
+		*gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
 		return isGenerationEqual(oldObj, newObj), nil
 
 	// Slow path: compare the content of the objects.
@@ -202,6 +202,7 @@ func TestIsEqualForGeneration(t *testing.T) {
 	run(t, &networking_v1.Ingress{})
 	run(t, &contour_v1.HTTPProxy{})
 	run(t, &contour_v1alpha1.ExtensionService{})
+	run(t, &contour_v1alpha1.Backend{})
 	run(t, &contour_v1.TLSCertificateDelegation{})
 	run(t, &gatewayapi_v1.GatewayClass{})
 	run(t, &gatewayapi_v1.Gateway{})
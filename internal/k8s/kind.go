@@ -16,6 +16,7 @@ package k8s
 import (
 	core_v1 "k8s.io/api/core/v1"
 	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -23,6 +24,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -59,6 +61,8 @@ func KindOf(obj any) string {
 			return "TLSRoute"
 		case *gatewayapi_v1alpha2.TCPRoute:
 			return "TCPRoute"
+		case *gatewayapi_v1alpha2.UDPRoute:
+			return "UDPRoute"
 		case *gatewayapi_v1.Gateway:
 			return "Gateway"
 		case *gatewayapi_v1.GatewayClass:
@@ -67,10 +71,14 @@ func KindOf(obj any) string {
 			return "ReferenceGrant"
 		case *gatewayapi_v1alpha3.BackendTLSPolicy:
 			return "BackendTLSPolicy"
+		case *gatewayapi_x_v1alpha1.XBackendTrafficPolicy:
+			return "XBackendTrafficPolicy"
 		case *contour_v1.TLSCertificateDelegation:
 			return "TLSCertificateDelegation"
 		case *contour_v1alpha1.ExtensionService:
 			return "ExtensionService"
+		case *contour_v1alpha1.Backend:
+			return "Backend"
 		case *contour_v1alpha1.ContourConfiguration:
 			return "ContourConfiguration"
 		case *contour_v1alpha1.ContourDeployment:
@@ -79,6 +87,8 @@ func KindOf(obj any) string {
 			return "Namespace"
 		case *unstructured.Unstructured:
 			return obj.GetKind()
+		case *meta_v1.PartialObjectMetadata:
+			return obj.Kind
 		default:
 			return ""
 		}
@@ -101,7 +111,7 @@ func VersionOf(obj any) string {
 			return networking_v1.SchemeGroupVersion.String()
 		case *contour_v1.HTTPProxy, *contour_v1.TLSCertificateDelegation:
 			return contour_v1.GroupVersion.String()
-		case *contour_v1alpha1.ExtensionService:
+		case *contour_v1alpha1.ExtensionService, *contour_v1alpha1.Backend:
 			return contour_v1alpha1.GroupVersion.String()
 		case *unstructured.Unstructured:
 			return obj.GetAPIVersion()
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	core_v1 "k8s.io/api/core/v1"
 	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -43,6 +44,7 @@ func TestKindOf(t *testing.T) {
 		{"HTTPProxy", &contour_v1.HTTPProxy{}},
 		{"TLSCertificateDelegation", &contour_v1.TLSCertificateDelegation{}},
 		{"ExtensionService", &contour_v1alpha1.ExtensionService{}},
+		{"Backend", &contour_v1alpha1.Backend{}},
 		{"ContourConfiguration", &contour_v1alpha1.ContourConfiguration{}},
 		{"ContourDeployment", &contour_v1alpha1.ContourDeployment{}},
 		{"GRPCRoute", &gatewayapi_v1.GRPCRoute{}},
@@ -61,6 +63,11 @@ func TestKindOf(t *testing.T) {
 				},
 			},
 		},
+		{
+			"Pod", &meta_v1.PartialObjectMetadata{
+				TypeMeta: meta_v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -80,6 +87,7 @@ func TestVersionOf(t *testing.T) {
 		{"projectcontour.io/v1", &contour_v1.HTTPProxy{}},
 		{"projectcontour.io/v1", &contour_v1.TLSCertificateDelegation{}},
 		{"projectcontour.io/v1alpha1", &contour_v1alpha1.ExtensionService{}},
+		{"projectcontour.io/v1alpha1", &contour_v1alpha1.Backend{}},
 		{
 			"test.projectcontour.io/v1", &unstructured.Unstructured{
 				Object: map[string]any{
@@ -16,13 +16,16 @@ package k8s
 // +kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;list;watch
 // +kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses/status,verbs=create;get;update
 
-// +kubebuilder:rbac:groups="projectcontour.io",resources=httpproxies;tlscertificatedelegations;extensionservices;contourconfigurations,verbs=get;list;watch
-// +kubebuilder:rbac:groups="projectcontour.io",resources=httpproxies/status;extensionservices/status;contourconfigurations/status,verbs=create;get;update
+// +kubebuilder:rbac:groups="projectcontour.io",resources=httpproxies;tlscertificatedelegations;extensionservices;backends;contourconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups="projectcontour.io",resources=httpproxies/status;extensionservices/status;backends/status;contourconfigurations/status,verbs=create;get;update
 
-// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses;gateways;httproutes;tlsroutes;grpcroutes;tcproutes;referencegrants;backendtlspolicies,verbs=get;list;watch
-// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status;grpcroutes/status;tcproutes/status;backendtlspolicies/status,verbs=update
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses;gateways;httproutes;tlsroutes;grpcroutes;tcproutes;udproutes;referencegrants;backendtlspolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status;grpcroutes/status;tcproutes/status;udproutes/status;backendtlspolicies/status,verbs=update
 
-// +kubebuilder:rbac:groups="",resources=secrets;endpoints;services;namespaces;configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="gateway.networking.x-k8s.io",resources=xbackendtrafficpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="gateway.networking.x-k8s.io",resources=xbackendtrafficpolicies/status,verbs=update
+
+// +kubebuilder:rbac:groups="",resources=secrets;endpoints;services;namespaces;configmaps;pods,verbs=get;list;watch
 
 // Add RBAC policy to support leader election.
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;get;update,namespace=projectcontour
@@ -30,3 +33,6 @@ package k8s
 
 // Add RBAC policy for endpoint slices
 // +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=list;get;watch
+
+// Add RBAC policy for nodes, used by topology aware routing.
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
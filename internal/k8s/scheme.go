@@ -20,6 +20,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -38,6 +39,7 @@ func NewContourScheme() (*runtime.Scheme, error) {
 		gatewayapi_v1alpha3.Install,
 		gatewayapi_v1beta1.Install,
 		gatewayapi_v1.Install,
+		gatewayapi_x_v1alpha1.Install,
 	}
 
 	if err := b.AddToScheme(s); err != nil {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -199,11 +200,16 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Validate listener ports and hostnames to get
 	// the ports to program.
 	for _, listenerPort := range gatewayapi.ValidateListeners(gateway.Spec.Listeners).Ports {
-		contourModel.Spec.NetworkPublishing.Envoy.Ports = append(contourModel.Spec.NetworkPublishing.Envoy.Ports, model.Port{
+		port := model.Port{
 			Name:          listenerPort.Name,
 			ServicePort:   listenerPort.Port,
 			ContainerPort: listenerPort.ContainerPort,
-		})
+		}
+		if listenerPort.Protocol == "udp" {
+			port.Protocol = core_v1.ProtocolUDP
+		}
+
+		contourModel.Spec.NetworkPublishing.Envoy.Ports = append(contourModel.Spec.NetworkPublishing.Envoy.Ports, port)
 	}
 
 	gatewayClassParams, err := r.getGatewayClassParams(ctx, gatewayClass)
@@ -95,7 +95,7 @@ func TestGatewayReconcile(t *testing.T) {
 		}
 	}
 
-	makeGatewayWithAddrs := func(addrs []gatewayapi_v1.GatewayAddress) *gatewayapi_v1.Gateway {
+	makeGatewayWithAddrs := func(addrs []gatewayapi_v1.GatewaySpecAddress) *gatewayapi_v1.Gateway {
 		gtw := makeGateway()
 		gtw.Spec.Addresses = addrs
 		return gtw
@@ -220,7 +220,7 @@ func TestGatewayReconcile(t *testing.T) {
 		},
 		"A gateway with one IP address results in an Envoy service with loadBalancerIP set to that IP address": {
 			gatewayClass: reconcilableGatewayClass("gatewayclass-1", controller),
-			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewayAddress{
+			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.IPAddressType),
 					Value: "172.18.255.207",
@@ -234,7 +234,7 @@ func TestGatewayReconcile(t *testing.T) {
 		},
 		"A gateway with two IP addresses results in an Envoy service with loadBalancerIP set to the first IP address": {
 			gatewayClass: reconcilableGatewayClass("gatewayclass-1", controller),
-			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewayAddress{
+			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.IPAddressType),
 					Value: "172.18.255.207",
@@ -251,7 +251,7 @@ func TestGatewayReconcile(t *testing.T) {
 		},
 		"A gateway with one Hostname address results in an Envoy service with loadBalancerIP set to that hostname": {
 			gatewayClass: reconcilableGatewayClass("gatewayclass-1", controller),
-			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewayAddress{
+			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.HostnameAddressType),
 					Value: "projectcontour.io",
@@ -264,7 +264,7 @@ func TestGatewayReconcile(t *testing.T) {
 		},
 		"A gateway with two Hostname addresses results in an Envoy service with loadBalancerIP set to the first hostname": {
 			gatewayClass: reconcilableGatewayClass("gatewayclass-1", controller),
-			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewayAddress{
+			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.HostnameAddressType),
 					Value: "projectcontour.io",
@@ -281,7 +281,7 @@ func TestGatewayReconcile(t *testing.T) {
 		},
 		"A gateway with one custom address type results in an Envoy service with no loadBalancerIP": {
 			gatewayClass: reconcilableGatewayClass("gatewayclass-1", controller),
-			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewayAddress{
+			gateway: makeGatewayWithAddrs([]gatewayapi_v1.GatewaySpecAddress{
 				{
 					Type:  ptr.To(gatewayapi_v1.AddressType("acme.io/CustomAddressType")),
 					Value: "custom-address-types-are-not-supported",
@@ -492,7 +492,7 @@ func TestGatewayReconcile(t *testing.T) {
 					Protocol: gatewayapi_v1.HTTPProtocolType,
 					Port:     81,
 				},
-				// listener-4 will be ignored because it's an unsupported protocol
+				// listener-4 uses UDP, so its port will use the UDP protocol
 				{
 					Name:     "listener-4",
 					Protocol: gatewayapi_v1.UDPProtocolType,
@@ -528,7 +528,7 @@ func TestGatewayReconcile(t *testing.T) {
 				}
 				require.NoError(t, r.client.Get(context.Background(), keyFor(envoyService), envoyService))
 
-				require.Len(t, envoyService.Spec.Ports, 4)
+				require.Len(t, envoyService.Spec.Ports, 5)
 				assert.Contains(t, envoyService.Spec.Ports, core_v1.ServicePort{
 					Name:       "http-80",
 					Protocol:   core_v1.ProtocolTCP,
@@ -541,6 +541,12 @@ func TestGatewayReconcile(t *testing.T) {
 					Port:       81,
 					TargetPort: intstr.IntOrString{IntVal: 8081},
 				})
+				assert.Contains(t, envoyService.Spec.Ports, core_v1.ServicePort{
+					Name:       "udp-82",
+					Protocol:   core_v1.ProtocolUDP,
+					Port:       82,
+					TargetPort: intstr.IntOrString{IntVal: 8082},
+				})
 				assert.Contains(t, envoyService.Spec.Ports, core_v1.ServicePort{
 					Name:       "https-443",
 					Protocol:   core_v1.ProtocolTCP,
@@ -577,7 +583,7 @@ func TestGatewayReconcile(t *testing.T) {
 				// listener-4 will be ignored because it's an unsupported protocol
 				{
 					Name:     "listener-4",
-					Protocol: gatewayapi_v1.UDPProtocolType,
+					Protocol: gatewayapi_v1.ProtocolType("SCTP"),
 					Port:     82,
 				},
 			}),
@@ -1276,9 +1282,9 @@ func TestGatewayReconcile(t *testing.T) {
 				Spec: gatewayapi_v1.GatewaySpec{
 					GatewayClassName: gatewayapi_v1.ObjectName("gatewayclass-1"),
 					Infrastructure: &gatewayapi_v1.GatewayInfrastructure{
-						Labels: map[gatewayapi_v1.AnnotationKey]gatewayapi_v1.AnnotationValue{
-							gatewayapi_v1.AnnotationKey("projectcontour.io/label-1"): gatewayapi_v1.AnnotationValue("label-value-1"),
-							gatewayapi_v1.AnnotationKey("projectcontour.io/label-2"): gatewayapi_v1.AnnotationValue("label-value-2"),
+						Labels: map[gatewayapi_v1.LabelKey]gatewayapi_v1.LabelValue{
+							gatewayapi_v1.LabelKey("projectcontour.io/label-1"): gatewayapi_v1.LabelValue("label-value-1"),
+							gatewayapi_v1.LabelKey("projectcontour.io/label-2"): gatewayapi_v1.LabelValue("label-value-2"),
 						},
 						Annotations: map[gatewayapi_v1.AnnotationKey]gatewayapi_v1.AnnotationValue{
 							gatewayapi_v1.AnnotationKey("projectcontour.io/annotation-1"): gatewayapi_v1.AnnotationValue("annotation-value-1"),
@@ -135,6 +135,34 @@ func (c *Contour) EnvoyTolerationsExist() bool {
 	return false
 }
 
+// EnvoyHTTP3Enabled returns true if the runtime settings enable
+// HTTP/3 listeners for Envoy.
+func (c *Contour) EnvoyHTTP3Enabled() bool {
+	if c.Spec.RuntimeSettings != nil &&
+		c.Spec.RuntimeSettings.Envoy != nil &&
+		c.Spec.RuntimeSettings.Envoy.Listener != nil &&
+		c.Spec.RuntimeSettings.Envoy.Listener.HTTP3 != nil &&
+		c.Spec.RuntimeSettings.Envoy.Listener.HTTP3.Enabled {
+		return true
+	}
+
+	return false
+}
+
+// EnvoyTopologyAwareRoutingEnabled returns true if the runtime settings
+// enable topology aware routing for Envoy.
+func (c *Contour) EnvoyTopologyAwareRoutingEnabled() bool {
+	if c.Spec.RuntimeSettings != nil &&
+		c.Spec.RuntimeSettings.Envoy != nil &&
+		c.Spec.RuntimeSettings.Envoy.Cluster != nil &&
+		c.Spec.RuntimeSettings.Envoy.Cluster.TopologyAwareRouting != nil &&
+		c.Spec.RuntimeSettings.Envoy.Cluster.TopologyAwareRouting.Enabled {
+		return true
+	}
+
+	return false
+}
+
 func (c *Contour) WatchAllNamespaces() bool {
 	return c.Spec.WatchNamespaces == nil || len(c.Spec.WatchNamespaces) == 0
 }
@@ -612,6 +640,9 @@ type Port struct {
 	ServicePort int32
 	// ContainerPort is the port to expose on the Envoy container(s).
 	ContainerPort int32
+	// Protocol is the network protocol of the port. If unspecified,
+	// defaults to TCP.
+	Protocol core_v1.Protocol
 	// NodePort is the network port number to expose for the NodePort Service.
 	// If unspecified, a port number will be assigned from the cluster's
 	// nodeport service range, i.e. --service-node-port-range flag
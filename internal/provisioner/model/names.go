@@ -77,7 +77,9 @@ func (c *Contour) ContourRBACNames() RBACNames {
 // the Envoy daemonset.
 func (c *Contour) EnvoyRBACNames() RBACNames {
 	return RBACNames{
-		ServiceAccount: "envoy-" + c.Name,
+		ServiceAccount:     "envoy-" + c.Name,
+		ClusterRole:        fmt.Sprintf("envoy-%s-%s", c.Namespace, c.Name),
+		ClusterRoleBinding: fmt.Sprintf("envoy-%s-%s", c.Namespace, c.Name),
 	}
 }
 
@@ -43,6 +43,8 @@ const (
 	envoyNsEnvVar = "CONTOUR_NAMESPACE"
 	// envoyPodEnvVar is the name of the Envoy pod name environment variable.
 	envoyPodEnvVar = "ENVOY_POD_NAME"
+	// envoyLocalityNodeEnvVar is the name of the Envoy node name environment variable.
+	envoyLocalityNodeEnvVar = "ENVOY_LOCALITY_NODE"
 	// envoyCertsVolName is the name of the contour certificates volume.
 	envoyCertsVolName = "envoycert"
 	// envoyCertsVolMntDir is the directory name of the Envoy certificates volume.
@@ -55,6 +57,10 @@ const (
 	envoyAdminVolName = "envoy-admin"
 	// envoyAdminVolMntDir is the directory name of the Envoy admin volume.
 	envoyAdminVolMntDir = "admin"
+	// envoyTokenVolName is the name of the Envoy service account token volume.
+	envoyTokenVolName = "envoy-token"
+	// envoyTokenVolMntDir is the directory of the Envoy service account token volume.
+	envoyTokenVolMntDir = "/var/run/secrets/kubernetes.io/serviceaccount"
 	// envoyCfgFileName is the name of the Envoy configuration file.
 	envoyCfgFileName = "envoy.json"
 	// xdsResourceVersion is the version of the Envoy xdS resource types.
@@ -325,12 +331,68 @@ func desiredContainers(contour *model.Contour, contourImage, envoyImage string)
 		},
 	}
 
+	// With topology aware routing, the init container reads the locality
+	// of its Node from the API server, so it is the only container that
+	// gets a service account token.
+	if contour.EnvoyTopologyAwareRoutingEnabled() {
+		initContainers[0].Env = append(initContainers[0].Env, core_v1.EnvVar{
+			Name: envoyLocalityNodeEnvVar,
+			ValueFrom: &core_v1.EnvVarSource{
+				FieldRef: &core_v1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "spec.nodeName",
+				},
+			},
+		})
+		initContainers[0].VolumeMounts = append(initContainers[0].VolumeMounts, core_v1.VolumeMount{
+			Name:      envoyTokenVolName,
+			MountPath: envoyTokenVolMntDir,
+			ReadOnly:  true,
+		})
+	}
+
 	for j := range containers {
 		containers[j].VolumeMounts = append(containers[j].VolumeMounts, contour.Spec.EnvoyExtraVolumeMounts...)
 	}
 	return initContainers, containers
 }
 
+// envoyTokenVolumes returns the service account token volume of the Envoy
+// pod when the init container needs to read its Node.
+func envoyTokenVolumes(contour *model.Contour) []core_v1.Volume {
+	if !contour.EnvoyTopologyAwareRoutingEnabled() {
+		return nil
+	}
+
+	return []core_v1.Volume{{
+		Name: envoyTokenVolName,
+		VolumeSource: core_v1.VolumeSource{
+			Projected: &core_v1.ProjectedVolumeSource{
+				DefaultMode: ptr.To(int32(420)),
+				Sources: []core_v1.VolumeProjection{
+					{
+						ServiceAccountToken: &core_v1.ServiceAccountTokenProjection{
+							ExpirationSeconds: ptr.To(int64(3607)),
+							Path:              "token",
+						},
+					},
+					{
+						ConfigMap: &core_v1.ConfigMapProjection{
+							LocalObjectReference: core_v1.LocalObjectReference{
+								Name: "kube-root-ca.crt",
+							},
+							Items: []core_v1.KeyToPath{{
+								Key:  "ca.crt",
+								Path: "ca.crt",
+							}},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
 // DesiredDaemonSet returns the desired DaemonSet for the provided contour using
 // contourImage as the shutdown-manager/envoy-initconfig container images and
 // envoyImage as Envoy's container image.
@@ -392,6 +454,7 @@ func DesiredDaemonSet(contour *model.Contour, contourImage, envoyImage string) *
 		},
 	}
 
+	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, envoyTokenVolumes(contour)...)
 	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, contour.Spec.EnvoyExtraVolumes...)
 
 	if contour.EnvoyNodeSelectorExists() {
@@ -477,6 +540,7 @@ func desiredDeployment(contour *model.Contour, contourImage, envoyImage string)
 		},
 	}
 
+	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, envoyTokenVolumes(contour)...)
 	deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, contour.Spec.EnvoyExtraVolumes...)
 
 	if contour.EnvoyNodeSelectorExists() {
@@ -439,3 +439,58 @@ func TestEnvoyCustomPorts(t *testing.T) {
 	container := checkDaemonSetHasContainer(t, ds, EnvoyContainerName, true)
 	checkContainerHasReadinessPort(t, container, 8020)
 }
+
+func TestEnvoyTopologyAwareRouting(t *testing.T) {
+	name := "envoy-topology-aware-routing"
+	cntr := model.Default(fmt.Sprintf("%s-ns", name), name)
+
+	testContourImage := "ghcr.io/projectcontour/contour:test"
+	testEnvoyImage := "docker.io/envoyproxy/envoy:test"
+	ds := DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.Name == envoyTokenVolName {
+			t.Errorf("daemonset has unexpected volume %q", v.Name)
+		}
+	}
+
+	cntr.Spec.RuntimeSettings = &contour_v1alpha1.ContourConfigurationSpec{
+		Envoy: &contour_v1alpha1.EnvoyConfig{
+			Cluster: &contour_v1alpha1.ClusterParameters{
+				TopologyAwareRouting: &contour_v1alpha1.TopologyAwareRouting{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	checkDaemonSetHasEnvVar(t, ds, envoyInitContainerName, envoyLocalityNodeEnvVar)
+
+	var hasVol bool
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.Name == envoyTokenVolName {
+			hasVol = true
+		}
+	}
+	if !hasVol {
+		t.Errorf("daemonset is missing volume %q", envoyTokenVolName)
+	}
+
+	// Only the init container gets the service account token.
+	for _, c := range ds.Spec.Template.Spec.Containers {
+		for _, v := range c.VolumeMounts {
+			if v.Name == envoyTokenVolName {
+				t.Errorf("container %q has unexpected volume mount %q", c.Name, v.Name)
+			}
+		}
+	}
+	initContainer := ds.Spec.Template.Spec.InitContainers[0]
+	expected := core_v1.VolumeMount{
+		Name:      envoyTokenVolName,
+		MountPath: envoyTokenVolMntDir,
+		ReadOnly:  true,
+	}
+	if !apiequality.Semantic.DeepEqual(initContainer.VolumeMounts[len(initContainer.VolumeMounts)-1], expected) {
+		t.Errorf("init container is missing volume mount %q", envoyTokenVolName)
+	}
+}
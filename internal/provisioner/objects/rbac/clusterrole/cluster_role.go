@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 
+	core_v1 "k8s.io/api/core/v1"
 	rbac_v1 "k8s.io/api/rbac/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,6 +65,39 @@ func desiredClusterRole(name string, contour *model.Contour, clusterScopedResour
 	return role
 }
 
+// EnsureEnvoyClusterRole ensures a ClusterRole resource exists with the provided
+// name for Envoy to read the Node it runs on.
+func EnsureEnvoyClusterRole(ctx context.Context, cli client.Client, name string, contour *model.Contour) error {
+	desired := desiredEnvoyClusterRole(name, contour)
+
+	// Enclose contour.
+	updater := func(ctx context.Context, cli client.Client, current, desired *rbac_v1.ClusterRole) error {
+		return updateClusterRoleIfNeeded(ctx, cli, contour, current, desired)
+	}
+
+	return objects.EnsureObject(ctx, cli, desired, updater, &rbac_v1.ClusterRole{})
+}
+
+// desiredEnvoyClusterRole constructs an instance of the desired ClusterRole resource
+// for Envoy with the provided name and contour namespace/name for the owning contour
+// labels. The Envoy bootstrap reads the topology labels of its Node to set the
+// Envoy locality.
+func desiredEnvoyClusterRole(name string, contour *model.Contour) *rbac_v1.ClusterRole {
+	return &rbac_v1.ClusterRole{
+		TypeMeta: meta_v1.TypeMeta{
+			Kind: "Role",
+		},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        name,
+			Labels:      contour.CommonLabels(),
+			Annotations: contour.CommonAnnotations(),
+		},
+		Rules: []rbac_v1.PolicyRule{
+			util.PolicyRuleFor(core_v1.GroupName, []string{"get"}, "nodes"),
+		},
+	}
+}
+
 // updateClusterRoleIfNeeded updates a ClusterRole resource if current does not match desired,
 // using contour to verify the existence of owner labels.
 func updateClusterRoleIfNeeded(ctx context.Context, cli client.Client, contour *model.Contour, current, desired *rbac_v1.ClusterRole) error {
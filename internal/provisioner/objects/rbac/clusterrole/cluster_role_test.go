@@ -53,7 +53,8 @@ func clusterRoleRulesContainOnlyClusterScopeRules(cr *rbac_v1.ClusterRole) bool
 	for _, r := range cr.Rules {
 		if !slices.Contains(r.Resources, "gatewayclasses") &&
 			!slices.Contains(r.Resources, "gatewayclasses/status") &&
-			!slices.Contains(r.Resources, "namespaces") {
+			!slices.Contains(r.Resources, "namespaces") &&
+			!slices.Contains(r.Resources, "nodes") {
 			return false
 		}
 	}
@@ -95,6 +96,27 @@ func TestDesiredClusterRole(t *testing.T) {
 	}
 }
 
+func TestDesiredEnvoyClusterRole(t *testing.T) {
+	name := "test-envoy-cr"
+	cntr := model.Default(fmt.Sprintf("%s-ns", name), name)
+	cr := desiredEnvoyClusterRole(name, cntr)
+	checkClusterRoleName(t, cr, name)
+	ownerLabels := map[string]string{
+		model.ContourOwningGatewayNameLabel:    cntr.Name,
+		model.GatewayAPIOwningGatewayNameLabel: cntr.Name,
+	}
+	checkClusterRoleLabels(t, cr, ownerLabels)
+
+	expected := []rbac_v1.PolicyRule{{
+		Verbs:     []string{"get"},
+		APIGroups: []string{""},
+		Resources: []string{"nodes"},
+	}}
+	if !apiequality.Semantic.DeepEqual(cr.Rules, expected) {
+		t.Errorf("cluster role has unexpected rules: %v", diff.ObjectReflectDiff(cr.Rules, expected))
+	}
+}
+
 func TestDesiredClusterRoleFilterResources(t *testing.T) {
 	filterNamespacedGatewayResources := func(policyRules []rbac_v1.PolicyRule) [][]string {
 		gatewayResources := [][]string{}
@@ -167,7 +189,7 @@ func TestDesiredClusterRoleFilterResources(t *testing.T) {
 		},
 		{
 			description:               "disable both gateway and contour features",
-			disabledFeatures:          []contour_v1.Feature{"grpcroutes", "tlsroutes", "extensionservices", "backendtlspolicies"},
+			disabledFeatures:          []contour_v1.Feature{"grpcroutes", "tlsroutes", "extensionservices", "backendtlspolicies", "xbackendtrafficpolicies"},
 			clusterScopedResourceOnly: false,
 			expectedGateway: [][]string{
 				removeFromStringArray(util.GatewayGroupNamespacedResource, "tlsroutes", "grpcroutes", "backendtlspolicies"),
@@ -112,6 +112,24 @@ func ensureEnvoyRBAC(ctx context.Context, cli client.Client, contour *model.Cont
 	if err := serviceaccount.EnsureServiceAccount(ctx, cli, names.ServiceAccount, contour); err != nil {
 		return fmt.Errorf("failed to ensure service account %s/%s: %w", contour.Namespace, names.ServiceAccount, err)
 	}
+
+	// Topology aware routing needs Envoy to read the locality of its Node.
+	if !contour.EnvoyTopologyAwareRoutingEnabled() {
+		if err := objects.EnsureObjectDeleted(ctx, cli, &rbac_v1.ClusterRoleBinding{ObjectMeta: meta_v1.ObjectMeta{Name: names.ClusterRoleBinding}}, contour); err != nil {
+			return fmt.Errorf("failed to delete cluster role binding %s: %w", names.ClusterRoleBinding, err)
+		}
+		if err := objects.EnsureObjectDeleted(ctx, cli, &rbac_v1.ClusterRole{ObjectMeta: meta_v1.ObjectMeta{Name: names.ClusterRole}}, contour); err != nil {
+			return fmt.Errorf("failed to delete cluster role %s: %w", names.ClusterRole, err)
+		}
+		return nil
+	}
+
+	if err := clusterrole.EnsureEnvoyClusterRole(ctx, cli, names.ClusterRole, contour); err != nil {
+		return fmt.Errorf("failed to ensure cluster role %s: %w", names.ClusterRole, err)
+	}
+	if err := clusterrolebinding.EnsureClusterRoleBinding(ctx, cli, names.ClusterRoleBinding, names.ClusterRole, names.ServiceAccount, contour); err != nil {
+		return fmt.Errorf("failed to ensure cluster role binding %s: %w", names.ClusterRoleBinding, err)
+	}
 	return nil
 }
 
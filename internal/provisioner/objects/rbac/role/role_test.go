@@ -167,7 +167,7 @@ func TestDesiredRoleFilterResources(t *testing.T) {
 		},
 		{
 			description:      "disable both gateway and contour features",
-			disabledFeatures: []contour_v1.Feature{"grpcroutes", "tlsroutes", "backendtlspolicies", "extensionservices"},
+			disabledFeatures: []contour_v1.Feature{"grpcroutes", "tlsroutes", "backendtlspolicies", "xbackendtrafficpolicies", "extensionservices"},
 			expectedGateway: [][]string{
 				removeFromStringArray(util.GatewayGroupNamespacedResource, "tlsroutes", "grpcroutes", "backendtlspolicies"),
 				removeFromStringArray(util.GatewayGroupNamespacedResourceStatus, "tlsroutes/status", "grpcroutes/status", "backendtlspolicies/status"),
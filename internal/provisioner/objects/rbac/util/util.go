@@ -21,6 +21,7 @@ import (
 	networking_v1 "k8s.io/api/networking/v1"
 	rbac_v1 "k8s.io/api/rbac/v1"
 	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/projectcontour/contour/internal/provisioner/model"
@@ -30,10 +31,12 @@ import (
 const contourV1GroupName = "projectcontour.io"
 
 var (
-	GatewayGroupNamespacedResource       = []string{"gateways", "httproutes", "tlsroutes", "grpcroutes", "tcproutes", "referencegrants", "backendtlspolicies"}
-	GatewayGroupNamespacedResourceStatus = []string{"gateways/status", "httproutes/status", "tlsroutes/status", "grpcroutes/status", "tcproutes/status", "backendtlspolicies/status"}
-	ContourGroupNamespacedResource       = []string{"httpproxies", "tlscertificatedelegations", "extensionservices", "contourconfigurations"}
-	ContourGroupNamespacedResourceStatus = []string{"httpproxies/status", "extensionservices/status", "contourconfigurations/status"}
+	GatewayGroupNamespacedResource        = []string{"gateways", "httproutes", "tlsroutes", "grpcroutes", "tcproutes", "udproutes", "referencegrants", "backendtlspolicies"}
+	GatewayGroupNamespacedResourceStatus  = []string{"gateways/status", "httproutes/status", "tlsroutes/status", "grpcroutes/status", "tcproutes/status", "udproutes/status", "backendtlspolicies/status"}
+	GatewayXGroupNamespacedResource       = []string{"xbackendtrafficpolicies"}
+	GatewayXGroupNamespacedResourceStatus = []string{"xbackendtrafficpolicies/status"}
+	ContourGroupNamespacedResource        = []string{"httpproxies", "tlscertificatedelegations", "extensionservices", "backends", "contourconfigurations"}
+	ContourGroupNamespacedResourceStatus  = []string{"httpproxies/status", "extensionservices/status", "backends/status", "contourconfigurations/status"}
 )
 
 var (
@@ -57,7 +60,7 @@ func PolicyRuleFor(apiGroup string, verbs []string, resources ...string) rbac_v1
 func NamespacedResourcePolicyRules(resourcesToSkip []contour_v1.Feature) []rbac_v1.PolicyRule {
 	return []rbac_v1.PolicyRule{
 		// Core Contour-watched resources.
-		PolicyRuleFor(core_v1.GroupName, getListWatch, "secrets", "endpoints", "services", "configmaps"),
+		PolicyRuleFor(core_v1.GroupName, getListWatch, "secrets", "endpoints", "services", "configmaps", "pods"),
 
 		// Discovery Contour-watched resources.
 		PolicyRuleFor(discovery_v1.GroupName, getListWatch, "endpointslices"),
@@ -66,6 +69,8 @@ func NamespacedResourcePolicyRules(resourcesToSkip []contour_v1.Feature) []rbac_
 		// Note, ReferenceGrant does not currently have a .status field so it's omitted from the status rule.
 		PolicyRuleFor(gatewayapi_v1.GroupName, getListWatch, filterResources(resourcesToSkip, GatewayGroupNamespacedResource...)...),
 		PolicyRuleFor(gatewayapi_v1.GroupName, update, filterResources(resourcesToSkip, GatewayGroupNamespacedResourceStatus...)...),
+		PolicyRuleFor(gatewayapi_x_v1alpha1.GroupName, getListWatch, filterResources(resourcesToSkip, GatewayXGroupNamespacedResource...)...),
+		PolicyRuleFor(gatewayapi_x_v1alpha1.GroupName, update, filterResources(resourcesToSkip, GatewayXGroupNamespacedResourceStatus...)...),
 
 		// Ingress resources.
 		PolicyRuleFor(networking_v1.GroupName, getListWatch, "ingresses"),
@@ -87,6 +92,9 @@ func ClusterScopedResourcePolicyRules() []rbac_v1.PolicyRule {
 
 		// Namespaces
 		PolicyRuleFor(core_v1.GroupName, getListWatch, "namespaces"),
+
+		// Nodes, used by topology aware routing.
+		PolicyRuleFor(core_v1.GroupName, getListWatch, "nodes"),
 	}
 }
 
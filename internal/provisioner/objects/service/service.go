@@ -183,12 +183,28 @@ func DesiredEnvoyService(contour *model.Contour) *core_v1.Service {
 	var ports []core_v1.ServicePort
 
 	for _, port := range contour.Spec.NetworkPublishing.Envoy.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = core_v1.ProtocolTCP
+		}
+
 		ports = append(ports, core_v1.ServicePort{
 			Name:       port.Name,
-			Protocol:   core_v1.ProtocolTCP,
+			Protocol:   protocol,
 			Port:       port.ServicePort,
 			TargetPort: intstr.IntOrString{IntVal: port.ContainerPort},
 		})
+
+		// HTTP/3 listeners share the port number of their HTTPS
+		// listener, but over UDP.
+		if contour.EnvoyHTTP3Enabled() && protocol == core_v1.ProtocolTCP && isHTTPSPort(port.Name) {
+			ports = append(ports, core_v1.ServicePort{
+				Name:       port.Name + "-quic",
+				Protocol:   core_v1.ProtocolUDP,
+				Port:       port.ServicePort,
+				TargetPort: intstr.IntOrString{IntVal: port.ContainerPort},
+			})
+		}
 	}
 
 	svc := &core_v1.Service{
@@ -278,7 +294,7 @@ func DesiredEnvoyService(contour *model.Contour) *core_v1.Service {
 				continue
 			}
 			for i, q := range svc.Spec.Ports {
-				if q.Name == p.Name {
+				if q.Name == p.Name || q.Name == p.Name+"-quic" {
 					svc.Spec.Ports[i].NodePort = p.NodePort
 				}
 			}
@@ -348,6 +364,12 @@ func updateEnvoyServiceIfNeeded(ctx context.Context, cli client.Client, contour
 	return nil
 }
 
+// isHTTPSPort returns true if the named Envoy port serves HTTPS,
+// either the default "https" port or a Gateway listener port.
+func isHTTPSPort(name string) bool {
+	return name == "https" || strings.HasPrefix(name, "https-")
+}
+
 // isELB returns true if params is an AWS Classic ELB.
 func isELB(params *model.ProviderLoadBalancerParameters) bool {
 	return params.Type == model.AWSLoadBalancerProvider &&
@@ -21,6 +21,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/provisioner/model"
 	"github.com/projectcontour/contour/internal/provisioner/objects"
 )
@@ -195,6 +196,27 @@ func TestDesiredEnvoyService(t *testing.T) {
 	checkServiceHasPortName(t, svc, "https")
 	checkServiceHasPortProtocol(t, svc, core_v1.ProtocolTCP)
 
+	// Enabling HTTP/3 adds a UDP port alongside the HTTPS port.
+	cntr.Spec.RuntimeSettings = &contour_v1alpha1.ContourConfigurationSpec{
+		Envoy: &contour_v1alpha1.EnvoyConfig{
+			Listener: &contour_v1alpha1.EnvoyListenerConfig{
+				HTTP3: &contour_v1alpha1.EnvoyHTTP3{Enabled: true},
+			},
+		},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasPortName(t, svc, "https-quic")
+	checkServiceHasPortProtocol(t, svc, core_v1.ProtocolUDP)
+	for _, p := range svc.Spec.Ports {
+		if p.Name == "https-quic" && (p.Port != EnvoyServiceHTTPSPort || p.NodePort != 30444) {
+			t.Errorf("service port %q has unexpected port %d or nodeport %d", p.Name, p.Port, p.NodePort)
+		}
+	}
+	if len(svc.Spec.Ports) != 3 {
+		t.Errorf("expected 3 service ports, got %d", len(svc.Spec.Ports))
+	}
+	cntr.Spec.RuntimeSettings = nil
+
 	cntr.Spec.NetworkPublishing.Envoy.Type = model.ClusterIPServicePublishingType
 	cntr.Spec.NetworkPublishing.Envoy.IPFamilyPolicy = core_v1.IPFamilyPolicyRequireDualStack
 	svc = DesiredEnvoyService(cntr)
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
@@ -443,6 +444,13 @@ func (s secretSorter) Len() int           { return len(s) }
 func (s secretSorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s secretSorter) Less(i, j int) bool { return s[i].Name < s[j].Name }
 
+// Sorts the given extension config values by name.
+type extensionConfigSorter []*envoy_config_core_v3.TypedExtensionConfig
+
+func (s extensionConfigSorter) Len() int           { return len(s) }
+func (s extensionConfigSorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s extensionConfigSorter) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
 // For returns a sort.Interface object that can be used to sort the
 // given value. It returns nil if there is no sorter for the type of
 // value.
@@ -450,6 +458,8 @@ func For(v any) sort.Interface {
 	switch v := v.(type) {
 	case []*envoy_transport_socket_tls_v3.Secret:
 		return secretSorter(v)
+	case []*envoy_config_core_v3.TypedExtensionConfig:
+		return extensionConfigSorter(v)
 	case []*envoy_config_route_v3.RouteConfiguration:
 		return routeConfigurationSorter(v)
 	case []*envoy_config_route_v3.VirtualHost:
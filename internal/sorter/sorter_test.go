@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
@@ -551,6 +552,14 @@ func TestSortSecrets(t *testing.T) {
 	shuffleAndCheckSort(t, want)
 }
 
+func TestSortExtensionConfigs(t *testing.T) {
+	want := []*envoy_config_core_v3.TypedExtensionConfig{
+		{Name: "first"},
+		{Name: "second"},
+	}
+	shuffleAndCheckSort(t, want)
+}
+
 func TestSortHeaderMatchConditions(t *testing.T) {
 	want := []dag.HeaderMatchCondition{
 		// Note that if the header names are the same, we
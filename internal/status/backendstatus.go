@@ -0,0 +1,97 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"fmt"
+	"time"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/k8s"
+)
+
+// BackendCacheEntry holds status updates for a particular Backend
+type BackendCacheEntry struct {
+	ConditionCache
+
+	Name           types.NamespacedName
+	Generation     int64
+	TransitionTime meta_v1.Time
+}
+
+var _ CacheEntry = &BackendCacheEntry{}
+
+func (e *BackendCacheEntry) AsStatusUpdate() k8s.StatusUpdate {
+	m := k8s.StatusMutatorFunc(func(obj client.Object) client.Object {
+		o, ok := obj.(*contour_v1alpha1.Backend)
+		if !ok {
+			panic(fmt.Sprintf("unsupported %T object %q in status mutator", obj, e.Name))
+		}
+
+		backend := o.DeepCopy()
+
+		for condType, cond := range e.Conditions {
+			cond.ObservedGeneration = e.Generation
+			cond.LastTransitionTime = e.TransitionTime
+
+			currCond := backend.Status.GetConditionFor(string(condType))
+			if currCond == nil {
+				backend.Status.Conditions = append(backend.Status.Conditions, *cond)
+				continue
+			}
+
+			// Don't update the condition if our observation is stale.
+			if currCond.ObservedGeneration > cond.ObservedGeneration {
+				continue
+			}
+
+			cond.DeepCopyInto(currCond)
+		}
+
+		return backend
+	})
+
+	return k8s.StatusUpdate{
+		NamespacedName: e.Name,
+		Resource:       &contour_v1alpha1.Backend{},
+		Mutator:        m,
+	}
+}
+
+// BackendAccessor returns a pointer to a shared status cache entry
+// for the given Backend object. If no such entry exists, a new entry
+// is added. When the caller finishes with the cache entry, it must
+// call the returned function to release the entry back to the cache.
+func BackendAccessor(c *Cache, backend *contour_v1alpha1.Backend) (*BackendCacheEntry, func()) {
+	entry := c.Get(backend)
+	if entry == nil {
+		entry = &BackendCacheEntry{
+			Name:           k8s.NamespacedNameOf(backend),
+			Generation:     backend.GetGeneration(),
+			TransitionTime: meta_v1.NewTime(time.Now()),
+		}
+
+		// Populate the cache with the new entry
+		c.Put(backend, entry)
+	}
+
+	entry = c.Get(backend)
+	return entry.(*BackendCacheEntry), func() {
+		c.Put(backend, entry)
+	}
+}
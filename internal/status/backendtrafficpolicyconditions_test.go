@@ -0,0 +1,136 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+	"github.com/projectcontour/contour/internal/k8s"
+)
+
+func TestBackendTrafficPolicyAddCondition(t *testing.T) {
+	backendTrafficPolicyUpdate := BackendTrafficPolicyStatusUpdate{
+		FullName:   k8s.NamespacedNameFrom("test/test"),
+		Generation: 7,
+	}
+
+	ancestorRef := gatewayapi.GatewayParentRef("projectcontour", "contour")
+
+	basUpdate := backendTrafficPolicyUpdate.StatusUpdateFor(ancestorRef)
+
+	basUpdate.AddCondition(gatewayapi_v1alpha2.PolicyConditionAccepted, meta_v1.ConditionTrue, gatewayapi_v1alpha2.PolicyReasonAccepted, "Valid XBackendTrafficPolicy")
+
+	require.Len(t, backendTrafficPolicyUpdate.ConditionsForAncestorRef(ancestorRef), 1)
+	got := backendTrafficPolicyUpdate.ConditionsForAncestorRef(ancestorRef)[0]
+
+	assert.EqualValues(t, gatewayapi_v1alpha2.PolicyConditionAccepted, got.Type)
+	assert.EqualValues(t, meta_v1.ConditionTrue, got.Status)
+	assert.EqualValues(t, gatewayapi_v1alpha2.PolicyReasonAccepted, got.Reason)
+	assert.EqualValues(t, "Valid XBackendTrafficPolicy", got.Message)
+	assert.EqualValues(t, 7, got.ObservedGeneration)
+}
+
+func TestBackendTrafficPolicyMutate(t *testing.T) {
+	testTransitionTime := meta_v1.NewTime(time.Now())
+	var testGeneration int64 = 7
+
+	bsu := BackendTrafficPolicyStatusUpdate{
+		FullName:       k8s.NamespacedNameFrom("test/test"),
+		Generation:     testGeneration,
+		TransitionTime: testTransitionTime,
+		PolicyAncestorStatuses: []*gatewayapi_v1alpha2.PolicyAncestorStatus{
+			{
+				AncestorRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				Conditions: []meta_v1.Condition{
+					{
+						Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+						Status:  contour_v1.ConditionTrue,
+						Reason:  string(gatewayapi_v1alpha2.PolicyReasonAccepted),
+						Message: "Accepted XBackendTrafficPolicy",
+					},
+				},
+			},
+		},
+	}
+
+	blp := &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Status: gatewayapi_v1alpha2.PolicyStatus{
+			Ancestors: []gatewayapi_v1alpha2.PolicyAncestorStatus{
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("externalgateway", "some-gateway"),
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:  contour_v1.ConditionTrue,
+							Reason:  string(gatewayapi_v1alpha2.PolicyReasonAccepted),
+							Message: "This was added by some other gateway and should not be removed.",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	wantBackendTrafficPolicy := &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Status: gatewayapi_v1alpha2.PolicyStatus{
+			Ancestors: []gatewayapi_v1alpha2.PolicyAncestorStatus{
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					Conditions: []meta_v1.Condition{
+						{
+							ObservedGeneration: testGeneration,
+							LastTransitionTime: testTransitionTime,
+							Type:               string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:             contour_v1.ConditionTrue,
+							Reason:             string(gatewayapi_v1alpha2.PolicyReasonAccepted),
+							Message:            "Accepted XBackendTrafficPolicy",
+						},
+					},
+				},
+				{
+					AncestorRef: gatewayapi.GatewayParentRef("externalgateway", "some-gateway"),
+					Conditions: []meta_v1.Condition{
+						{
+							Type:    string(gatewayapi_v1alpha2.PolicyConditionAccepted),
+							Status:  contour_v1.ConditionTrue,
+							Reason:  string(gatewayapi_v1alpha2.PolicyReasonAccepted),
+							Message: "This was added by some other gateway and should not be removed.",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	blp, ok := bsu.Mutate(blp).(*gatewayapi_x_v1alpha1.XBackendTrafficPolicy)
+	require.True(t, ok)
+	assert.Equal(t, wantBackendTrafficPolicy, blp, 1)
+}
@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayapi_v1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayapi_x_v1alpha1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 
 	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/projectcontour/contour/internal/k8s"
@@ -39,13 +40,14 @@ const ValidCondition ConditionType = "Valid"
 // NewCache creates a new Cache for holding status updates.
 func NewCache(gateway types.NamespacedName, gatewayController gatewayapi_v1.GatewayController) Cache {
 	return Cache{
-		gatewayRef:              gateway,
-		gatewayController:       gatewayController,
-		proxyUpdates:            make(map[types.NamespacedName]*ProxyUpdate),
-		gatewayUpdates:          make(map[types.NamespacedName]*GatewayStatusUpdate),
-		routeUpdates:            make(map[types.NamespacedName]*RouteStatusUpdate),
-		backendTLSPolicyUpdates: make(map[types.NamespacedName]*BackendTLSPolicyStatusUpdate),
-		entries:                 make(map[string]map[types.NamespacedName]CacheEntry),
+		gatewayRef:                  gateway,
+		gatewayController:           gatewayController,
+		proxyUpdates:                make(map[types.NamespacedName]*ProxyUpdate),
+		gatewayUpdates:              make(map[types.NamespacedName]*GatewayStatusUpdate),
+		routeUpdates:                make(map[types.NamespacedName]*RouteStatusUpdate),
+		backendTLSPolicyUpdates:     make(map[types.NamespacedName]*BackendTLSPolicyStatusUpdate),
+		backendTrafficPolicyUpdates: make(map[types.NamespacedName]*BackendTrafficPolicyStatusUpdate),
+		entries:                     make(map[string]map[types.NamespacedName]CacheEntry),
 	}
 }
 
@@ -61,10 +63,11 @@ type Cache struct {
 	gatewayRef        types.NamespacedName
 	gatewayController gatewayapi_v1.GatewayController
 
-	proxyUpdates            map[types.NamespacedName]*ProxyUpdate
-	gatewayUpdates          map[types.NamespacedName]*GatewayStatusUpdate
-	routeUpdates            map[types.NamespacedName]*RouteStatusUpdate
-	backendTLSPolicyUpdates map[types.NamespacedName]*BackendTLSPolicyStatusUpdate
+	proxyUpdates                map[types.NamespacedName]*ProxyUpdate
+	gatewayUpdates              map[types.NamespacedName]*GatewayStatusUpdate
+	routeUpdates                map[types.NamespacedName]*RouteStatusUpdate
+	backendTLSPolicyUpdates     map[types.NamespacedName]*BackendTLSPolicyStatusUpdate
+	backendTrafficPolicyUpdates map[types.NamespacedName]*BackendTrafficPolicyStatusUpdate
 
 	// Map of cache entry maps, keyed on Kind.
 	entries map[string]map[types.NamespacedName]CacheEntry
@@ -110,6 +113,16 @@ func (c *Cache) GetStatusUpdates() []k8s.StatusUpdate {
 		flattened = append(flattened, update)
 	}
 
+	for fullname, backendTrafficPolicyUpdate := range c.backendTrafficPolicyUpdates {
+		update := k8s.StatusUpdate{
+			NamespacedName: fullname,
+			Resource:       &gatewayapi_x_v1alpha1.XBackendTrafficPolicy{},
+			Mutator:        backendTrafficPolicyUpdate,
+		}
+
+		flattened = append(flattened, update)
+	}
+
 	for fullname, pu := range c.proxyUpdates {
 		update := k8s.StatusUpdate{
 			NamespacedName: fullname,
@@ -188,6 +201,15 @@ func (c *Cache) GetBackendTLSPolicyUpdates() []*BackendTLSPolicyStatusUpdate {
 	return allUpdates
 }
 
+// GetBackendTrafficPolicyUpdates gets the underlying BackendTrafficPolicyConditionsUpdate objects from the cache.
+func (c *Cache) GetBackendTrafficPolicyUpdates() []*BackendTrafficPolicyStatusUpdate {
+	var allUpdates []*BackendTrafficPolicyStatusUpdate
+	for _, conditionsUpdate := range c.backendTrafficPolicyUpdates {
+		allUpdates = append(allUpdates, conditionsUpdate)
+	}
+	return allUpdates
+}
+
 // GatewayStatusAccessor returns a GatewayStatusUpdate that allows a client to build up a list of
 // status changes as well as a function to commit the change back to the cache when everything
 // is done. The commit function pattern is used so that the GatewayStatusUpdate does not need
@@ -286,3 +308,24 @@ func (c *Cache) BackendTLSPolicyConditionsAccessor(nsName types.NamespacedName,
 		c.backendTLSPolicyUpdates[pu.FullName] = pu
 	}
 }
+
+// BackendTrafficPolicyConditionsAccessor returns a BackendTrafficPolicyStatusUpdate that allows a client
+// to build up a list of metav1.Conditions as well as a function to commit the change back to the
+// cache when everything is done. The commit function pattern is used so that the
+// BackendTrafficPolicyStatusUpdate does not need to know anything the cache internals.
+func (c *Cache) BackendTrafficPolicyConditionsAccessor(nsName types.NamespacedName, generation int64) (*BackendTrafficPolicyStatusUpdate, func()) {
+	pu := &BackendTrafficPolicyStatusUpdate{
+		FullName:          nsName,
+		GatewayRef:        c.gatewayRef,
+		GatewayController: c.gatewayController,
+		Generation:        generation,
+		TransitionTime:    meta_v1.NewTime(time.Now()),
+	}
+
+	return pu, func() {
+		if len(pu.PolicyAncestorStatuses) == 0 {
+			return
+		}
+		c.backendTrafficPolicyUpdates[pu.FullName] = pu
+	}
+}
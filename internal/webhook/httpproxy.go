@@ -0,0 +1,176 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook contains the admission webhooks hosted by Contour.
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	admission_v1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/status"
+)
+
+// HTTPProxyPath is the path the HTTPProxy validating webhook is served on.
+const HTTPProxyPath = "/validate-httpproxy"
+
+// Simulator builds the DAG that would result from a change to the
+// cached objects, without making the change.
+type Simulator interface {
+	// Simulate returns the DAG built from the cached objects and
+	// the DAG built with obj added to them.
+	Simulate(ctx context.Context, obj any) (current, simulated *dag.DAG, err error)
+}
+
+// HTTPProxyValidator is an admission handler that rejects changes to an
+// HTTPProxy that would make it, or another HTTPProxy that is valid,
+// invalid.
+//
+// Errors that are resolved by creating the objects an HTTPProxy refers
+// to, such as a missing Service, Secret or included HTTPProxy, do not
+// cause a rejection since the objects may be created in any order.
+// They are returned as warnings instead.
+type HTTPProxyValidator struct {
+	Simulator Simulator
+	Decoder   admission.Decoder
+
+	logrus.FieldLogger
+}
+
+// unresolvedReferenceReasons are the reasons of the errors set on an
+// HTTPProxy when an object it refers to does not exist yet.
+var unresolvedReferenceReasons = map[string]bool{
+	contour_v1.ConditionTypeOrphanedError: true,
+	"IncludeNotFound":                     true,
+	"ServiceUnresolvedReference":          true,
+	"ExtensionServiceNotFound":            true,
+	"SecretNotValid":                      true,
+	"DelegationNotPermitted":              true,
+	"CACertificateNotDelegated":           true,
+	"ClientCertificateNotDelegated":       true,
+	"FallbackNotDelegated":                true,
+	"RemoteJWKSCACertificateNotDelegated": true,
+}
+
+// Handle implements admission.Handler.
+func (v *HTTPProxyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	proxy := &contour_v1.HTTPProxy{}
+	if err := v.Decoder.Decode(req, proxy); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Changes that leave the spec as it is, such as removing a
+	// finalizer from an HTTPProxy being deleted, are always allowed.
+	if proxy.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+	if req.Operation == admission_v1.Update {
+		old := &contour_v1.HTTPProxy{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(old.Spec, proxy.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	// The status is for Contour to set.
+	proxy.Status = contour_v1.HTTPProxyStatus{}
+
+	current, simulated, err := v.Simulator.Simulate(ctx, proxy)
+	if err != nil {
+		v.WithError(err).Error("unable to simulate HTTPProxy change")
+		return admission.Errored(http.StatusServiceUnavailable, err)
+	}
+
+	// invalid records the HTTPProxies made invalid by errors
+	// other than unresolved references before the change.
+	invalid := map[types.NamespacedName]bool{}
+	for _, pu := range current.StatusCache.GetProxyUpdates() {
+		if errs, _ := proxyErrors(pu); len(errs) > 0 {
+			invalid[pu.Fullname] = true
+		}
+	}
+
+	updates := simulated.StatusCache.GetProxyUpdates()
+	slices.SortFunc(updates, func(a, b *status.ProxyUpdate) int {
+		return cmp.Or(
+			cmp.Compare(a.Fullname.Namespace, b.Fullname.Namespace),
+			cmp.Compare(a.Fullname.Name, b.Fullname.Name),
+		)
+	})
+
+	name := k8s.NamespacedNameOf(proxy)
+
+	var denials, warnings []string
+	for _, pu := range updates {
+		errs, unresolved := proxyErrors(pu)
+
+		switch {
+		case pu.Fullname == name:
+			warnings = append(warnings, formatErrors(pu.Fullname, unresolved)...)
+		case invalid[pu.Fullname]:
+			// The change is not the cause of the errors
+			// of an HTTPProxy that was already invalid.
+			continue
+		}
+
+		denials = append(denials, formatErrors(pu.Fullname, errs)...)
+	}
+
+	if len(denials) > 0 {
+		return admission.Denied(strings.Join(denials, "; ")).WithWarnings(warnings...)
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// proxyErrors returns the errors of the Valid condition of pu, split
+// into unresolved reference errors and the others.
+func proxyErrors(pu *status.ProxyUpdate) (errs, unresolved []contour_v1.SubCondition) {
+	cond, ok := pu.Conditions[status.ValidCondition]
+	if !ok {
+		return nil, nil
+	}
+
+	for _, e := range cond.Errors {
+		if unresolvedReferenceReasons[e.Reason] {
+			unresolved = append(unresolved, e)
+		} else {
+			errs = append(errs, e)
+		}
+	}
+
+	return errs, unresolved
+}
+
+func formatErrors(name types.NamespacedName, errs []contour_v1.SubCondition) []string {
+	var msgs []string
+	for _, e := range errs {
+		msgs = append(msgs, fmt.Sprintf("HTTPProxy %s: %s: %s", name, e.Reason, e.Message))
+	}
+	return msgs
+}
@@ -0,0 +1,229 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admission_v1 "k8s.io/api/admission/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	contour_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+)
+
+type builderSimulator struct {
+	builder *dag.Builder
+}
+
+func (b *builderSimulator) Simulate(_ context.Context, obj any) (*dag.DAG, *dag.DAG, error) {
+	return b.builder.Simulate(), b.builder.Simulate(obj), nil
+}
+
+func TestHTTPProxyValidator(t *testing.T) {
+	service := &core_v1.Service{
+		ObjectMeta: fixture.ObjectMeta("default/kuard"),
+		Spec: core_v1.ServiceSpec{
+			Ports: []core_v1.ServicePort{{Name: "http", Protocol: "TCP", Port: 8080}},
+		},
+	}
+
+	root := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/root"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Includes: []contour_v1.Include{{
+				Name: "child",
+			}},
+		},
+	}
+
+	child := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/child"),
+		Spec: contour_v1.HTTPProxySpec{
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	// invalid already claims the fqdn used by invalid2.
+	invalid := &contour_v1.HTTPProxy{
+		ObjectMeta: fixture.ObjectMeta("default/invalid"),
+		Spec: contour_v1.HTTPProxySpec{
+			VirtualHost: &contour_v1.VirtualHost{
+				Fqdn: "invalid.example.com",
+			},
+			Routes: []contour_v1.Route{{
+				Services: []contour_v1.Service{{
+					Name: "kuard",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+	invalid2 := invalid.DeepCopy()
+	invalid2.Name = "invalid2"
+
+	duplicate := child.DeepCopy()
+	duplicate.Name = "duplicate"
+	duplicate.Spec.VirtualHost = &contour_v1.VirtualHost{
+		Fqdn: "example.com",
+	}
+
+	includesRoot := child.DeepCopy()
+	includesRoot.Spec.Routes = nil
+	includesRoot.Spec.Includes = []contour_v1.Include{{
+		Name: "root",
+	}}
+
+	cycle := child.DeepCopy()
+	cycle.Spec.Routes = nil
+	cycle.Spec.Includes = []contour_v1.Include{{
+		Name: "child",
+	}}
+
+	missingService := child.DeepCopy()
+	missingService.Name = "missing-service"
+	missingService.Spec.VirtualHost = &contour_v1.VirtualHost{
+		Fqdn: "missing.example.com",
+	}
+	missingService.Spec.Routes[0].Services[0].Name = "missing"
+
+	other := invalid.DeepCopy()
+	other.Name = "other"
+	other.Spec.VirtualHost.Fqdn = "new.example.com"
+
+	relabeled := invalid.DeepCopy()
+	relabeled.Labels = map[string]string{"app": "kuard"}
+
+	tests := map[string]struct {
+		operation    admission_v1.Operation
+		obj, oldObj  *contour_v1.HTTPProxy
+		wantAllowed  bool
+		wantMessage  string
+		wantWarnings []string
+	}{
+		"duplicate fqdn": {
+			operation:   admission_v1.Create,
+			obj:         duplicate,
+			wantAllowed: false,
+			wantMessage: `HTTPProxy default/duplicate: DuplicateVhost: fqdn "example.com" is used in multiple HTTPProxies: default/duplicate, default/root; ` +
+				`HTTPProxy default/root: DuplicateVhost: fqdn "example.com" is used in multiple HTTPProxies: default/duplicate, default/root`,
+		},
+		"include of a root": {
+			operation:   admission_v1.Update,
+			obj:         includesRoot,
+			oldObj:      child,
+			wantAllowed: false,
+			wantMessage: `HTTPProxy default/child: RootIncludesRoot: root httpproxy cannot include another root httpproxy (default/root)`,
+		},
+		"include cycle": {
+			operation:   admission_v1.Update,
+			obj:         cycle,
+			oldObj:      child,
+			wantAllowed: false,
+			wantMessage: `HTTPProxy default/child: IncludeCreatesCycle: include creates an include cycle: default/root -> default/child -> default/child`,
+		},
+		"missing service is a warning": {
+			operation:   admission_v1.Create,
+			obj:         missingService,
+			wantAllowed: true,
+			wantWarnings: []string{
+				`HTTPProxy default/missing-service: ServiceUnresolvedReference: Spec.Routes unresolved service reference: service "default/missing" not found`,
+			},
+		},
+		"already invalid proxies do not cause a rejection": {
+			operation:   admission_v1.Create,
+			obj:         other,
+			wantAllowed: true,
+		},
+		"unchanged spec": {
+			operation:   admission_v1.Update,
+			obj:         relabeled,
+			oldObj:      invalid,
+			wantAllowed: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			log := fixture.NewTestLogger(t)
+			builder := &dag.Builder{
+				Source: dag.KubernetesCache{
+					FieldLogger: log,
+				},
+				Processors: []dag.Processor{
+					&dag.ListenerProcessor{},
+					&dag.HTTPProxyProcessor{},
+				},
+			}
+			for _, obj := range []any{service, root, child, invalid, invalid2} {
+				builder.Source.Insert(obj)
+			}
+
+			scheme, err := k8s.NewContourScheme()
+			require.NoError(t, err)
+
+			v := &HTTPProxyValidator{
+				Simulator:   &builderSimulator{builder: builder},
+				Decoder:     admission.NewDecoder(scheme),
+				FieldLogger: log,
+			}
+
+			req := admission.Request{
+				AdmissionRequest: admission_v1.AdmissionRequest{
+					Operation: tc.operation,
+					Object:    rawExtension(t, tc.obj),
+				},
+			}
+			if tc.oldObj != nil {
+				req.OldObject = rawExtension(t, tc.oldObj)
+			}
+
+			resp := v.Handle(context.Background(), req)
+			assert.Equal(t, tc.wantAllowed, resp.Allowed)
+			if !tc.wantAllowed {
+				assert.Equal(t, tc.wantMessage, resp.Result.Message)
+			}
+			assert.Equal(t, tc.wantWarnings, resp.Warnings)
+		})
+	}
+}
+
+func rawExtension(t *testing.T, proxy *contour_v1.HTTPProxy) runtime.RawExtension {
+	proxy = proxy.DeepCopy()
+	proxy.TypeMeta = meta_v1.TypeMeta{
+		APIVersion: contour_v1.GroupVersion.String(),
+		Kind:       "HTTPProxy",
+	}
+
+	data, err := json.Marshal(proxy)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: data}
+}
@@ -21,6 +21,7 @@ import (
 	envoy_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	envoy_service_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	envoy_service_extension_v3 "github.com/envoyproxy/go-control-plane/envoy/service/extension/v3"
 	envoy_service_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
 	envoy_service_route_v3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	envoy_service_runtime_v3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
@@ -67,6 +68,7 @@ type contourServer struct {
 	envoy_service_cluster_v3.UnimplementedClusterDiscoveryServiceServer
 	envoy_service_listener_v3.UnimplementedListenerDiscoveryServiceServer
 	envoy_service_runtime_v3.UnimplementedRuntimeDiscoveryServiceServer
+	envoy_service_extension_v3.UnimplementedExtensionConfigDiscoveryServiceServer
 
 	logrus.FieldLogger
 	resources   map[string]xds.Resource
@@ -186,3 +188,7 @@ func (s *contourServer) StreamSecrets(srv envoy_service_secret_v3.SecretDiscover
 func (s *contourServer) StreamRuntime(srv envoy_service_runtime_v3.RuntimeDiscoveryService_StreamRuntimeServer) error {
 	return s.stream(srv)
 }
+
+func (s *contourServer) StreamExtensionConfigs(srv envoy_service_extension_v3.ExtensionConfigDiscoveryService_StreamExtensionConfigsServer) error {
+	return s.stream(srv)
+}
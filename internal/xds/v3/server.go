@@ -17,6 +17,7 @@ import (
 	envoy_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	envoy_service_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	envoy_service_extension_v3 "github.com/envoyproxy/go-control-plane/envoy/service/extension/v3"
 	envoy_service_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
 	envoy_service_route_v3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	envoy_service_runtime_v3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
@@ -33,6 +34,7 @@ type Server interface {
 	envoy_service_discovery_v3.AggregatedDiscoveryServiceServer
 	envoy_service_secret_v3.SecretDiscoveryServiceServer
 	envoy_service_runtime_v3.RuntimeDiscoveryServiceServer
+	envoy_service_extension_v3.ExtensionConfigDiscoveryServiceServer
 }
 
 // RegisterServer registers the given xDS protocol Server with the gRPC
@@ -46,4 +48,5 @@ func RegisterServer(srv Server, g *grpc.Server) {
 	envoy_service_listener_v3.RegisterListenerDiscoveryServiceServer(g, srv)
 	envoy_service_route_v3.RegisterRouteDiscoveryServiceServer(g, srv)
 	envoy_service_runtime_v3.RegisterRuntimeDiscoveryServiceServer(g, srv)
+	envoy_service_extension_v3.RegisterExtensionConfigDiscoveryServiceServer(g, srv)
 }
@@ -21,6 +21,7 @@ import (
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"google.golang.org/protobuf/proto"
 
+	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/contour"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/envoy"
@@ -31,6 +32,10 @@ import (
 
 // ClusterCache manages the contents of the gRPC CDS cache.
 type ClusterCache struct {
+	// TopologyAwareRouting enables zone aware routing on
+	// EDS clusters if set and enabled.
+	TopologyAwareRouting *contour_v1alpha1.TopologyAwareRouting
+
 	mu     sync.Mutex
 	values map[string]*envoy_config_cluster_v3.Cluster
 	contour.Cond
@@ -93,6 +98,19 @@ func (c *ClusterCache) OnChange(root *dag.DAG) {
 		}
 	}
 
+	if tar := c.TopologyAwareRouting; tar != nil && tar.Enabled {
+		for _, cluster := range clusters {
+			envoy_v3.ZoneAwareRouting(cluster, tar.MinClusterSize)
+		}
+	}
+
+	for _, cluster := range root.GetAggregateClusters() {
+		name := envoy.AggregateClusterName(cluster)
+		if _, ok := clusters[name]; !ok {
+			clusters[name] = envoy_v3.AggregateCluster(cluster)
+		}
+	}
+
 	for _, cluster := range root.GetDNSNameClusters() {
 		name := envoy.DNSNameClusterName(cluster)
 		if _, ok := clusters[name]; !ok {
@@ -15,15 +15,20 @@ package v3
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	core_v1 "k8s.io/api/core/v1"
 	discovery_v1 "k8s.io/api/discovery/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
@@ -39,10 +44,26 @@ import (
 // resources by matching the given service port to the given discovery_v1.EndpointSlice.
 // endpointSliceMap may be nil, in which case, the result is also nil.
 func (c *EndpointSliceCache) RecalculateEndpoints(port, healthPort core_v1.ServicePort, endpointSliceMap map[string]*discovery_v1.EndpointSlice) []*LoadBalancingEndpoint {
+	lb, _ := c.recalculateEndpoints(port, healthPort, endpointSliceMap, nil)
+	return lb
+}
+
+// recalculateEndpoints is like RecalculateEndpoints, but also returns the
+// locality of each endpoint at the same index when topology aware routing
+// is enabled. The Pod labels named by subsetKeys are added to each endpoint
+// as metadata for subset load balancing.
+func (c *EndpointSliceCache) recalculateEndpoints(port, healthPort core_v1.ServicePort, endpointSliceMap map[string]*discovery_v1.EndpointSlice, subsetKeys []string) ([]*LoadBalancingEndpoint, []*envoy_config_core_v3.Locality) {
 	var lb []*LoadBalancingEndpoint
+	var localities []*envoy_config_core_v3.Locality
 	uniqueEndpoints := make(map[string]struct{}, 0)
 	var healthCheckPort int32
 
+	// An empty protocol defaults to TCP.
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = core_v1.ProtocolTCP
+	}
+
 	for _, endpointSlice := range endpointSliceMap {
 		sort.Slice(endpointSlice.Endpoints, func(i, j int) bool {
 			return endpointSlice.Endpoints[i].Addresses[0] < endpointSlice.Endpoints[j].Addresses[0]
@@ -66,7 +87,7 @@ func (c *EndpointSliceCache) RecalculateEndpoints(port, healthPort core_v1.Servi
 					continue
 				}
 
-				if *endpointPort.Protocol != core_v1.ProtocolTCP {
+				if *endpointPort.Protocol != protocol {
 					continue
 				}
 
@@ -98,8 +119,16 @@ func (c *EndpointSliceCache) RecalculateEndpoints(port, healthPort core_v1.Servi
 				// Hence, we need to ensure that the endpoints we add to []*LoadBalancingEndpoint aren't duplicated.
 				endpointKey := fmt.Sprintf("%s:%d", endpoint.Addresses[0], *endpointPort.Port)
 				if _, exists := uniqueEndpoints[endpointKey]; !exists {
-					lb = append(lb, envoy_v3.LBEndpoint(addr))
+					lbEndpoint := envoy_v3.LBEndpoint(addr)
+					if len(subsetKeys) > 0 {
+						lbEndpoint.Metadata = c.endpointMetadata(endpoint, subsetKeys)
+					}
+					lb = append(lb, lbEndpoint)
 					uniqueEndpoints[endpointKey] = struct{}{}
+
+					if c.topologyAware {
+						localities = append(localities, c.endpointLocality(endpoint))
+					}
 				}
 			}
 		}
@@ -111,7 +140,91 @@ func (c *EndpointSliceCache) RecalculateEndpoints(port, healthPort core_v1.Servi
 		}
 	}
 
-	return lb
+	return lb, localities
+}
+
+// endpointLocality returns the locality of the given endpoint, or nil if
+// it is not known. The zone is taken from the endpoint itself, falling back
+// to the zone of the node it is running on. The region is always taken from
+// the node.
+//
+// Topology hints are ignored. Envoy zone aware routing balances traffic
+// between zones from the number of hosts in each locality, so the locality
+// must be the zone the endpoint actually runs in.
+func (c *EndpointSliceCache) endpointLocality(endpoint discovery_v1.Endpoint) *envoy_config_core_v3.Locality {
+	var region, zone string
+
+	if endpoint.NodeName != nil {
+		if node, ok := c.nodes[*endpoint.NodeName]; ok {
+			region, zone = node.Region, node.Zone
+		}
+	}
+
+	if endpoint.Zone != nil && *endpoint.Zone != "" {
+		zone = *endpoint.Zone
+	}
+
+	if region == "" && zone == "" {
+		return nil
+	}
+
+	return &envoy_config_core_v3.Locality{
+		Region: region,
+		Zone:   zone,
+	}
+}
+
+// localPortName returns the name of the TCP port of the given Envoy
+// EndpointSlices that sorts first. The Envoy Service exposes a port per
+// listener, so the local cluster is pinned to a single named port to
+// count each Envoy instance only once.
+func localPortName(endpointSlices map[string]*discovery_v1.EndpointSlice) string {
+	var names []string
+	for _, endpointSlice := range endpointSlices {
+		for _, port := range endpointSlice.Ports {
+			if port.Name != nil && port.Protocol != nil && *port.Protocol == core_v1.ProtocolTCP {
+				names = append(names, *port.Name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	return slices.Min(names)
+}
+
+// endpointMetadata returns the subset load balancing metadata of the given
+// endpoint, which holds the labels named by subsetKeys of the Pod the
+// endpoint refers to. It returns nil if the Pod is not known or has none of
+// the labels.
+func (c *EndpointSliceCache) endpointMetadata(endpoint discovery_v1.Endpoint, subsetKeys []string) *envoy_config_core_v3.Metadata {
+	ref := endpoint.TargetRef
+	if ref == nil || ref.Kind != "Pod" {
+		return nil
+	}
+
+	labels := c.pods[types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}]
+
+	fields := map[string]*structpb.Value{}
+	for _, k := range subsetKeys {
+		if v, ok := labels[k]; ok {
+			fields[k] = structpb.NewStringValue(v)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &envoy_config_core_v3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			envoy_v3.SubsetMetadataNamespace: {
+				Fields: fields,
+			},
+		},
+	}
 }
 
 // EndpointSliceCache is a cache of EndpointSlice and ServiceCluster objects.
@@ -132,6 +245,21 @@ type EndpointSliceCache struct {
 	// the Inner map is a map[k,v] where k is the endpoint slice name and v is the
 	// endpoint slice itself.
 	endpointSlices map[types.NamespacedName]map[string]*discovery_v1.EndpointSlice
+
+	// topologyAware is true when endpoints should be grouped
+	// by the locality of the node they are running on.
+	topologyAware bool
+
+	// Cache of Node localities, indexed by Node name.
+	nodes map[string]*envoy_config_core_v3.Locality
+
+	// All the current ServiceClusters. These all become stale
+	// when the locality of a Node changes.
+	clusters []*dag.ServiceCluster
+
+	// Cache of Pod labels, indexed by Pod namespaced name.
+	// Only populated when subset load balancing is enabled.
+	pods map[types.NamespacedName]map[string]string
 }
 
 // Recalculate regenerates all the ClusterLoadAssignments from the
@@ -157,11 +285,17 @@ func (c *EndpointSliceCache) Recalculate() map[string]*envoy_config_endpoint_v3.
 			Policy:      nil,
 		}
 
+		if c.topologyAware {
+			cla.Endpoints = c.localityEndpoints(cluster)
+			assignments[cla.ClusterName] = &cla
+			continue
+		}
+
 		// Look up each service, and if we have endpointSlice for that service,
 		// attach them as a new LocalityEndpoints resource.
 		for _, w := range cluster.Services {
 			n := types.NamespacedName{Namespace: w.ServiceNamespace, Name: w.ServiceName}
-			if lb := c.RecalculateEndpoints(w.ServicePort, w.HealthPort, c.endpointSlices[n]); lb != nil {
+			if lb, _ := c.recalculateEndpoints(w.ServicePort, w.HealthPort, c.endpointSlices[n], cluster.SubsetKeys); lb != nil {
 				// Append the new set of endpoints. Users are allowed to set the load
 				// balancing weight to 0, which we reflect to Envoy as nil in order to
 				// assign no load to that locality.
@@ -182,6 +316,61 @@ func (c *EndpointSliceCache) Recalculate() map[string]*envoy_config_endpoint_v3.
 	return assignments
 }
 
+// localityEndpoints returns the endpoints of all the services in the given
+// ServiceCluster, grouped into one LocalityEndpoints resource per locality.
+// The load balancing weight of each locality is the sum of the weights of
+// the services that have endpoints in it. Endpoints with an unknown
+// locality are grouped together without a locality.
+func (c *EndpointSliceCache) localityEndpoints(cluster *dag.ServiceCluster) []*LocalityEndpoints {
+	groups := map[string]*LocalityEndpoints{}
+	weights := map[string]uint32{}
+
+	for _, w := range cluster.Services {
+		n := types.NamespacedName{Namespace: w.ServiceNamespace, Name: w.ServiceName}
+
+		port := w.ServicePort
+		if cluster.ClusterName == envoy_v3.LocalClusterName {
+			port.Name = localPortName(c.endpointSlices[n])
+		}
+
+		lb, localities := c.recalculateEndpoints(port, w.HealthPort, c.endpointSlices[n], cluster.SubsetKeys)
+
+		seen := map[string]bool{}
+		for i := range lb {
+			key := localities[i].GetRegion() + "/" + localities[i].GetZone()
+
+			group, ok := groups[key]
+			if !ok {
+				group = &LocalityEndpoints{Locality: localities[i]}
+				groups[key] = group
+			}
+			group.LbEndpoints = append(group.LbEndpoints, lb[i])
+
+			if !seen[key] {
+				weights[key] += w.Weight
+				seen[key] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var endpoints []*LocalityEndpoints
+	for _, k := range keys {
+		// Users are allowed to set the load balancing weight to 0, which
+		// we reflect to Envoy as nil in order to assign no load to that
+		// locality.
+		groups[k].LoadBalancingWeight = protobuf.UInt32OrNil(weights[k])
+		endpoints = append(endpoints, groups[k])
+	}
+
+	return endpoints
+}
+
 // SetClusters replaces the cache of ServiceCluster resources. All
 // the added clusters will be marked stale.
 func (c *EndpointSliceCache) SetClusters(clusters []*dag.ServiceCluster) error {
@@ -218,6 +407,7 @@ func (c *EndpointSliceCache) SetClusters(clusters []*dag.ServiceCluster) error {
 	}
 
 	c.stale = clusters
+	c.clusters = clusters
 	c.services = serviceIndex
 
 	return nil
@@ -268,6 +458,86 @@ func (c *EndpointSliceCache) DeleteEndpointSlice(endpointSlice *discovery_v1.End
 	return false
 }
 
+// UpdateNode adds the locality of node to the cache, or replaces it if it
+// is already cached. If topology aware routing is enabled and the locality
+// has changed, all ServiceClusters become stale. Returns a boolean
+// indicating whether any ServiceClusters became stale or not.
+func (c *EndpointSliceCache) UpdateNode(node *core_v1.Node) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	locality := &envoy_config_core_v3.Locality{
+		Region: node.Labels[core_v1.LabelTopologyRegion],
+		Zone:   node.Labels[core_v1.LabelTopologyZone],
+	}
+
+	if existing, ok := c.nodes[node.Name]; ok && proto.Equal(existing, locality) {
+		return false
+	}
+	c.nodes[node.Name] = locality
+
+	return c.markAllStale()
+}
+
+// DeleteNode deletes the locality of node from the cache. If topology aware
+// routing is enabled, all ServiceClusters become stale. Returns a boolean
+// indicating whether any ServiceClusters became stale or not.
+func (c *EndpointSliceCache) DeleteNode(node *core_v1.Node) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.nodes[node.Name]; !ok {
+		return false
+	}
+	delete(c.nodes, node.Name)
+
+	return c.markAllStale()
+}
+
+func (c *EndpointSliceCache) markAllStale() bool {
+	if !c.topologyAware || len(c.clusters) == 0 {
+		return false
+	}
+
+	c.stale = append(c.stale, c.clusters...)
+	return true
+}
+
+// UpdatePod adds the labels of pod to the cache, or replaces them if they
+// are already cached. If the labels have changed, all ServiceClusters that
+// use subset load balancing become stale. Returns a boolean indicating
+// whether any ServiceClusters became stale or not.
+func (c *EndpointSliceCache) UpdatePod(pod *meta_v1.PartialObjectMetadata) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	if existing, ok := c.pods[name]; ok && maps.Equal(existing, pod.Labels) {
+		return false
+	}
+	c.pods[name] = maps.Clone(pod.Labels)
+
+	stale := false
+	for _, cluster := range c.clusters {
+		if len(cluster.SubsetKeys) > 0 {
+			c.stale = append(c.stale, cluster)
+			stale = true
+		}
+	}
+
+	return stale
+}
+
+// DeletePod deletes the labels of pod from the cache. The endpoints of
+// a deleted Pod are removed by EndpointSlice updates, so no ServiceClusters
+// become stale.
+func (c *EndpointSliceCache) DeletePod(pod *meta_v1.PartialObjectMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pods, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+}
+
 // NewEndpointSliceTranslator allocates a new endpointsSlice translator.
 func NewEndpointSliceTranslator(log logrus.FieldLogger) *EndpointSliceTranslator {
 	return &EndpointSliceTranslator{
@@ -278,6 +548,8 @@ func NewEndpointSliceTranslator(log logrus.FieldLogger) *EndpointSliceTranslator
 			stale:          nil,
 			services:       map[types.NamespacedName][]*dag.ServiceCluster{},
 			endpointSlices: map[types.NamespacedName]map[string]*discovery_v1.EndpointSlice{},
+			nodes:          map[string]*envoy_config_core_v3.Locality{},
+			pods:           map[types.NamespacedName]map[string]string{},
 		},
 	}
 }
@@ -293,10 +565,34 @@ type EndpointSliceTranslator struct {
 
 	cache EndpointSliceCache
 
+	// localCluster is the ServiceCluster for the Envoy Service
+	// itself, which Envoy uses as its local cluster for zone aware
+	// routing. It is nil unless topology aware routing is enabled.
+	localCluster *dag.ServiceCluster
+
 	mu      sync.Mutex // Protects entries.
 	entries map[string]*envoy_config_endpoint_v3.ClusterLoadAssignment
 }
 
+// EnableTopologyAwareRouting groups endpoints by the locality of the node
+// they are running on, and publishes the endpoints of envoyService as the
+// ClusterLoadAssignment for Envoy's local cluster.
+func (e *EndpointSliceTranslator) EnableTopologyAwareRouting(envoyService types.NamespacedName) {
+	e.cache.mu.Lock()
+	e.cache.topologyAware = true
+	e.cache.mu.Unlock()
+
+	e.localCluster = &dag.ServiceCluster{
+		ClusterName: envoy_v3.LocalClusterName,
+		Services: []dag.WeightedService{{
+			Weight:           1,
+			ServiceName:      envoyService.Name,
+			ServiceNamespace: envoyService.Namespace,
+			ServicePort:      core_v1.ServicePort{Protocol: core_v1.ProtocolTCP},
+		}},
+	}
+}
+
 // Merge combines the given entries with the existing entries in the
 // EndpointSliceTranslator. If the same key exists in both maps, an existing entry
 // is replaced.
@@ -312,20 +608,31 @@ func (e *EndpointSliceTranslator) Merge(entries map[string]*envoy_config_endpoin
 // OnChange observes DAG rebuild events.
 func (e *EndpointSliceTranslator) OnChange(root *dag.DAG) {
 	clusters := []*dag.ServiceCluster{}
-	names := map[string]bool{}
+	names := map[string]*dag.ServiceCluster{}
 
 	for _, svc := range root.GetServiceClusters() {
 		if err := svc.Validate(); err != nil {
 			e.WithError(err).Errorf("dropping invalid service cluster %q", svc.ClusterName)
-		} else if _, ok := names[svc.ClusterName]; ok {
+		} else if existing, ok := names[svc.ClusterName]; ok {
 			e.Debugf("dropping service cluster with duplicate name %q", svc.ClusterName)
+
+			// Clusters with different subsets share the same endpoints,
+			// so the endpoints need the metadata for all of them.
+			existing.SubsetKeys = append(existing.SubsetKeys, svc.SubsetKeys...)
+			slices.Sort(existing.SubsetKeys)
+			existing.SubsetKeys = slices.Compact(existing.SubsetKeys)
 		} else {
 			e.Debugf("added ServiceCluster %q from DAG", svc.ClusterName)
-			clusters = append(clusters, svc.DeepCopy())
-			names[svc.ClusterName] = true
+			c := svc.DeepCopy()
+			clusters = append(clusters, c)
+			names[svc.ClusterName] = c
 		}
 	}
 
+	if _, ok := names[envoy_v3.LocalClusterName]; e.localCluster != nil && !ok {
+		clusters = append(clusters, e.localCluster.DeepCopy())
+	}
+
 	// Update the cache with the new clusters.
 	if err := e.cache.SetClusters(clusters); err != nil {
 		e.WithError(err).Error("failed to cache service clusters")
@@ -372,6 +679,28 @@ func (e *EndpointSliceTranslator) OnAdd(obj any, _ bool) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *core_v1.Node:
+		if !e.cache.UpdateNode(obj) {
+			return
+		}
+
+		e.WithField("node", obj.Name).Debug("Node locality changed, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *meta_v1.PartialObjectMetadata:
+		if !e.cache.UpdatePod(obj) {
+			return
+		}
+
+		e.WithField("pod", k8s.NamespacedNameOf(obj)).Debug("Pod labels changed, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
 	default:
 		e.Errorf("OnAdd unexpected type %T: %#v", obj, obj)
 	}
@@ -409,6 +738,28 @@ func (e *EndpointSliceTranslator) OnUpdate(oldObj, newObj any) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *core_v1.Node:
+		if !e.cache.UpdateNode(newObj) {
+			return
+		}
+
+		e.WithField("node", newObj.Name).Debug("Node locality changed, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *meta_v1.PartialObjectMetadata:
+		if !e.cache.UpdatePod(newObj) {
+			return
+		}
+
+		e.WithField("pod", k8s.NamespacedNameOf(newObj)).Debug("Pod labels changed, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
 	default:
 		e.Errorf("OnUpdate unexpected type %T: %#v", newObj, newObj)
 	}
@@ -427,6 +778,19 @@ func (e *EndpointSliceTranslator) OnDelete(obj any) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *core_v1.Node:
+		if !e.cache.DeleteNode(obj) {
+			return
+		}
+
+		e.WithField("node", obj.Name).Debug("Node was deleted, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *meta_v1.PartialObjectMetadata:
+		e.cache.DeletePod(obj)
 	case cache.DeletedFinalStateUnknown:
 		e.OnDelete(obj.Obj) // recurse into ourselves with the tombstoned value
 	default:
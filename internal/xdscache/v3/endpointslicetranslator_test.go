@@ -16,11 +16,16 @@ package v3
 import (
 	"testing"
 
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	core_v1 "k8s.io/api/core/v1"
 	discovery_v1 "k8s.io/api/discovery/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	"github.com/projectcontour/contour/internal/dag"
@@ -1198,3 +1203,306 @@ func TestEndpointSliceTranslatorDefaultWeightedService(t *testing.T) {
 
 	protobuf.ExpectEqual(t, want, endpointSliceTranslator.Contents())
 }
+
+func TestEndpointSliceTranslatorTopologyAwareRouting(t *testing.T) {
+	endpointSliceTranslator := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+	endpointSliceTranslator.EnableTopologyAwareRouting(types.NamespacedName{Namespace: "projectcontour", Name: "envoy"})
+
+	// An empty DAG only contains the local cluster.
+	endpointSliceTranslator.OnChange(&dag.DAG{})
+
+	clusters := []*dag.ServiceCluster{
+		{
+			ClusterName: "default/weighted",
+			Services: []dag.WeightedService{
+				{
+					Weight:           1,
+					ServiceName:      "weight1",
+					ServiceNamespace: "default",
+					ServicePort:      core_v1.ServicePort{},
+				},
+				{
+					Weight:           2,
+					ServiceName:      "weight2",
+					ServiceNamespace: "default",
+					ServicePort:      core_v1.ServicePort{},
+				},
+			},
+		},
+		endpointSliceTranslator.localCluster,
+	}
+
+	require.NoError(t, endpointSliceTranslator.cache.SetClusters(clusters))
+
+	ports := []discovery_v1.EndpointPort{
+		{
+			Port:     ptr.To[int32](8080),
+			Protocol: ptr.To[core_v1.Protocol]("TCP"),
+		},
+	}
+
+	endpointSliceTranslator.OnAdd(&core_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node-a",
+			Labels: map[string]string{
+				core_v1.LabelTopologyRegion: "us-east-1",
+				core_v1.LabelTopologyZone:   "us-east-1a",
+			},
+		},
+	}, false)
+	endpointSliceTranslator.OnAdd(&core_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node-b",
+			Labels: map[string]string{
+				core_v1.LabelTopologyRegion: "us-east-1",
+				core_v1.LabelTopologyZone:   "us-east-1b",
+			},
+		},
+	}, false)
+
+	endpointSliceTranslator.OnAdd(endpointSlice("default", "weight1-eps-fs23r", "weight1", discovery_v1.AddressTypeIPv4, []discovery_v1.Endpoint{
+		{
+			Addresses: []string{"192.168.183.24"},
+			NodeName:  ptr.To("node-a"),
+		},
+		{
+			Addresses: []string{"192.168.183.25"},
+			NodeName:  ptr.To("node-b"),
+		},
+		{
+			// The endpoint zone takes precedence over the node zone.
+			Addresses: []string{"192.168.183.26"},
+			NodeName:  ptr.To("node-b"),
+			Zone:      ptr.To("us-east-1c"),
+		},
+	}, ports), false)
+	endpointSliceTranslator.OnAdd(endpointSlice("default", "weight2-eps-sdf9f", "weight2", discovery_v1.AddressTypeIPv4, []discovery_v1.Endpoint{
+		{
+			Addresses: []string{"192.168.183.27"},
+			NodeName:  ptr.To("node-a"),
+		},
+		{
+			// Endpoints on unknown nodes have no locality.
+			Addresses: []string{"192.168.183.28"},
+			NodeName:  ptr.To("node-c"),
+		},
+		{
+			// Topology hints do not change the endpoint zone.
+			Addresses: []string{"192.168.183.29"},
+			NodeName:  ptr.To("node-b"),
+			Hints: &discovery_v1.EndpointHints{
+				ForZones: []discovery_v1.ForZone{{Name: "us-east-1a"}},
+			},
+		},
+	}, ports), false)
+
+	// The local cluster only uses the first named port of the
+	// Envoy Service, so each Envoy instance is counted once.
+	endpointSliceTranslator.OnAdd(endpointSlice("projectcontour", "envoy-eps-ab12c", "envoy", discovery_v1.AddressTypeIPv4, []discovery_v1.Endpoint{
+		{
+			Addresses: []string{"10.0.0.1"},
+			NodeName:  ptr.To("node-a"),
+		},
+	}, []discovery_v1.EndpointPort{
+		{
+			Name:     ptr.To("https"),
+			Port:     ptr.To[int32](8443),
+			Protocol: ptr.To[core_v1.Protocol]("TCP"),
+		},
+		{
+			Name:     ptr.To("http"),
+			Port:     ptr.To[int32](8080),
+			Protocol: ptr.To[core_v1.Protocol]("TCP"),
+		},
+	}), false)
+
+	want := []proto.Message{
+		&envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/weighted",
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.28", 8080)),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(2),
+				},
+				{
+					Locality: &envoy_config_core_v3.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.24", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.27", 8080)),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(3),
+				},
+				{
+					Locality: &envoy_config_core_v3.Locality{Region: "us-east-1", Zone: "us-east-1b"},
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.25", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.29", 8080)),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(3),
+				},
+				{
+					Locality: &envoy_config_core_v3.Locality{Region: "us-east-1", Zone: "us-east-1c"},
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("192.168.183.26", 8080)),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(1),
+				},
+			},
+		},
+		&envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: envoy_v3.LocalClusterName,
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+				{
+					Locality: &envoy_config_core_v3.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080)),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(1),
+				},
+			},
+		},
+	}
+
+	protobuf.ExpectEqual(t, want, endpointSliceTranslator.Contents())
+
+	// Moving a node to another zone recalculates its endpoints.
+	endpointSliceTranslator.OnUpdate(&core_v1.Node{}, &core_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node-a",
+			Labels: map[string]string{
+				core_v1.LabelTopologyRegion: "us-east-1",
+				core_v1.LabelTopologyZone:   "us-east-1b",
+			},
+		},
+	})
+
+	want[1] = &envoy_config_endpoint_v3.ClusterLoadAssignment{
+		ClusterName: envoy_v3.LocalClusterName,
+		Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+			{
+				Locality: &envoy_config_core_v3.Locality{Region: "us-east-1", Zone: "us-east-1b"},
+				LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+					envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080)),
+				},
+				LoadBalancingWeight: wrapperspb.UInt32(1),
+			},
+		},
+	}
+
+	protobuf.ExpectEqual(t, want[1:], endpointSliceTranslator.Query([]string{envoy_v3.LocalClusterName}))
+}
+
+func TestEndpointSliceTranslatorSubsetLoadBalancing(t *testing.T) {
+	endpointSliceTranslator := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+
+	clusters := []*dag.ServiceCluster{
+		{
+			ClusterName: "default/simple",
+			Services: []dag.WeightedService{
+				{
+					Weight:           1,
+					ServiceName:      "simple",
+					ServiceNamespace: "default",
+					ServicePort:      core_v1.ServicePort{},
+				},
+			},
+			SubsetKeys: []string{"track", "version"},
+		},
+	}
+
+	require.NoError(t, endpointSliceTranslator.cache.SetClusters(clusters))
+
+	pod := func(name string, labels map[string]string) *meta_v1.PartialObjectMetadata {
+		return &meta_v1.PartialObjectMetadata{
+			TypeMeta: meta_v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    labels,
+			},
+		}
+	}
+
+	endpointSliceTranslator.OnAdd(pod("simple-a", map[string]string{"app": "simple", "version": "v1"}), false)
+	endpointSliceTranslator.OnAdd(pod("simple-b", map[string]string{"app": "simple", "version": "v2", "track": "canary"}), false)
+
+	endpointSliceTranslator.OnAdd(endpointSlice("default", "simple-eps-fs23r", "simple", discovery_v1.AddressTypeIPv4, []discovery_v1.Endpoint{
+		{
+			Addresses: []string{"192.168.183.24"},
+			TargetRef: &core_v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "simple-a"},
+		},
+		{
+			Addresses: []string{"192.168.183.25"},
+			TargetRef: &core_v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "simple-b"},
+		},
+		{
+			// Endpoints without a known Pod have no metadata.
+			Addresses: []string{"192.168.183.26"},
+		},
+	}, []discovery_v1.EndpointPort{
+		{
+			Port:     ptr.To[int32](8080),
+			Protocol: ptr.To[core_v1.Protocol]("TCP"),
+		},
+	}), false)
+
+	lbEndpoint := func(address string, labels map[string]string) *envoy_config_endpoint_v3.LbEndpoint {
+		lb := envoy_v3.LBEndpoint(envoy_v3.SocketAddress(address, 8080))
+		if len(labels) > 0 {
+			fields := map[string]*structpb.Value{}
+			for k, v := range labels {
+				fields[k] = structpb.NewStringValue(v)
+			}
+			lb.Metadata = &envoy_config_core_v3.Metadata{
+				FilterMetadata: map[string]*structpb.Struct{
+					"envoy.lb": {Fields: fields},
+				},
+			}
+		}
+		return lb
+	}
+
+	want := []proto.Message{
+		&envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/simple",
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						lbEndpoint("192.168.183.24", map[string]string{"version": "v1"}),
+						lbEndpoint("192.168.183.25", map[string]string{"version": "v2", "track": "canary"}),
+						lbEndpoint("192.168.183.26", nil),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(1),
+				},
+			},
+		},
+	}
+
+	protobuf.ExpectEqual(t, want, endpointSliceTranslator.Contents())
+
+	// Changing the labels of a Pod recalculates its endpoints.
+	endpointSliceTranslator.OnUpdate(
+		pod("simple-a", map[string]string{"app": "simple", "version": "v1"}),
+		pod("simple-a", map[string]string{"app": "simple", "version": "v3"}),
+	)
+
+	want = []proto.Message{
+		&envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/simple",
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{
+						lbEndpoint("192.168.183.24", map[string]string{"version": "v3"}),
+						lbEndpoint("192.168.183.25", map[string]string{"version": "v2", "track": "canary"}),
+						lbEndpoint("192.168.183.26", nil),
+					},
+					LoadBalancingWeight: wrapperspb.UInt32(1),
+				},
+			},
+		},
+	}
+
+	protobuf.ExpectEqual(t, want, endpointSliceTranslator.Contents())
+}
@@ -50,6 +50,12 @@ func RecalculateEndpoints(port, healthPort core_v1.ServicePort, eps *core_v1.End
 	var lb []*LoadBalancingEndpoint
 	var healthCheckPort int32
 
+	// An empty protocol defaults to TCP.
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = core_v1.ProtocolTCP
+	}
+
 	for _, s := range eps.Subsets {
 		// Skip subsets without ready addresses.
 		if len(s.Addresses) < 1 {
@@ -57,8 +63,9 @@ func RecalculateEndpoints(port, healthPort core_v1.ServicePort, eps *core_v1.End
 		}
 
 		for _, endpointPort := range s.Ports {
-			if endpointPort.Protocol != core_v1.ProtocolTCP {
-				// NOTE: we only support "TCP", which is the default.
+			if endpointPort.Protocol != protocol {
+				// NOTE: we only support "TCP", which is the default,
+				// and "UDP" for UDPRoutes.
 				continue
 			}
 
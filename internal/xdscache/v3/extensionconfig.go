@@ -0,0 +1,122 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"sort"
+	"sync"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/projectcontour/contour/internal/contour"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/protobuf"
+	"github.com/projectcontour/contour/internal/sorter"
+)
+
+// ExtensionConfigCache manages the contents of the gRPC ECDS cache.
+// It holds the HTTP filter configs that must not be inlined into
+// listeners or routes, such as basic authentication users.
+type ExtensionConfigCache struct {
+	mu     sync.Mutex
+	values map[string]*envoy_config_core_v3.TypedExtensionConfig
+	contour.Cond
+}
+
+// Update replaces the contents of the cache with the supplied map.
+func (c *ExtensionConfigCache) Update(v map[string]*envoy_config_core_v3.TypedExtensionConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values = v
+	c.Cond.Notify()
+}
+
+// Contents returns a copy of the cache's contents.
+func (c *ExtensionConfigCache) Contents() []proto.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var values []*envoy_config_core_v3.TypedExtensionConfig
+	for _, v := range c.values {
+		values = append(values, v)
+	}
+	sort.Stable(sorter.For(values))
+	return protobuf.AsMessages(values)
+}
+
+func (c *ExtensionConfigCache) Query(names []string) []proto.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var values []*envoy_config_core_v3.TypedExtensionConfig
+	for _, n := range names {
+		v, ok := c.values[n]
+		if !ok {
+			continue
+		}
+		values = append(values, v)
+	}
+	sort.Stable(sorter.For(values))
+	return protobuf.AsMessages(values)
+}
+
+func (*ExtensionConfigCache) TypeURL() string { return resource.ExtensionConfigType }
+
+func (c *ExtensionConfigCache) OnChange(root *dag.DAG) {
+	configs := map[string]*envoy_config_core_v3.TypedExtensionConfig{}
+
+	for _, l := range root.Listeners {
+		for _, vh := range l.SecureVirtualHosts {
+			if vh.BasicAuthSecret == nil {
+				continue
+			}
+
+			name := envoy_v3.BasicAuthConfigName(vh.VirtualHost.Name)
+			configs[name] = envoy_v3.BasicAuthExtensionConfig(name, vh.BasicAuthSecret)
+
+			for _, secret := range basicAuthOverrideSecrets(vh) {
+				name := envoy_v3.BasicAuthOverrideConfigName(secret)
+				configs[name] = envoy_v3.BasicAuthExtensionConfig(name, secret)
+			}
+		}
+	}
+
+	c.Update(configs)
+}
+
+// basicAuthOverrideSecrets returns the distinct Secrets that routes of
+// the given virtual host use to override its htpasswd users, sorted
+// by name.
+func basicAuthOverrideSecrets(vh *dag.SecureVirtualHost) []*dag.Secret {
+	secrets := map[string]*dag.Secret{}
+	for _, route := range vh.Routes {
+		if route.BasicAuthSecret != nil {
+			secrets[envoy_v3.BasicAuthOverrideConfigName(route.BasicAuthSecret)] = route.BasicAuthSecret
+		}
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := make([]*dag.Secret, 0, len(names))
+	for _, name := range names {
+		res = append(res, secrets[name])
+	}
+	return res
+}
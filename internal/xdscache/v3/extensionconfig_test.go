@@ -0,0 +1,90 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/projectcontour/contour/internal/protobuf"
+)
+
+func TestExtensionConfigCacheContents(t *testing.T) {
+	tests := map[string]struct {
+		contents map[string]*envoy_config_core_v3.TypedExtensionConfig
+		want     []proto.Message
+	}{
+		"empty": {
+			contents: nil,
+			want:     nil,
+		},
+		"sorted by name": {
+			contents: map[string]*envoy_config_core_v3.TypedExtensionConfig{
+				"b": {Name: "b"},
+				"a": {Name: "a"},
+			},
+			want: []proto.Message{
+				&envoy_config_core_v3.TypedExtensionConfig{Name: "a"},
+				&envoy_config_core_v3.TypedExtensionConfig{Name: "b"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c ExtensionConfigCache
+			c.Update(tc.contents)
+			protobuf.ExpectEqual(t, tc.want, c.Contents())
+		})
+	}
+}
+
+func TestExtensionConfigCacheQuery(t *testing.T) {
+	contents := map[string]*envoy_config_core_v3.TypedExtensionConfig{
+		"a": {Name: "a"},
+		"b": {Name: "b"},
+	}
+
+	tests := map[string]struct {
+		query []string
+		want  []proto.Message
+	}{
+		"exact match": {
+			query: []string{"a"},
+			want: []proto.Message{
+				&envoy_config_core_v3.TypedExtensionConfig{Name: "a"},
+			},
+		},
+		"partial match": {
+			query: []string{"b", "c"},
+			want: []proto.Message{
+				&envoy_config_core_v3.TypedExtensionConfig{Name: "b"},
+			},
+		},
+		"no match": {
+			query: []string{"c"},
+			want:  nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c ExtensionConfigCache
+			c.Update(contents)
+			protobuf.ExpectEqual(t, tc.want, c.Query(tc.query))
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	contour_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/contour"
@@ -116,6 +117,14 @@ type ListenerConfig struct {
 	// ServerHeaderTransformation defines the action to be applied to the Server header on the response path.
 	ServerHeaderTransformation contour_v1alpha1.ServerHeaderTransformationType
 
+	// Compression defines the response compression settings for HTTP listeners.
+	// If nil, responses are compressed with gzip using Envoy's default content types.
+	Compression *contour_v1alpha1.EnvoyCompression
+
+	// HTTP3 optionally configures HTTP/3 (QUIC) listeners to be added
+	// alongside each HTTPS listener.
+	HTTP3 *contour_v1alpha1.EnvoyHTTP3
+
 	// XffNumTrustedHops sets the number of additional ingress proxy hops from the
 	// right side of the x-forwarded-for HTTP header to trust.
 	XffNumTrustedHops uint32
@@ -144,6 +153,10 @@ type ListenerConfig struct {
 	// used.
 	GlobalExternalAuthConfig *GlobalExternalAuthConfig
 
+	// GlobalExternalProcessingConfig optionally configures the global external processing Service to be
+	// used.
+	GlobalExternalProcessingConfig *GlobalExternalProcessingConfig
+
 	// TracingConfig optionally configures the tracing collector Service to be
 	// used.
 	TracingConfig *TracingConfig
@@ -201,6 +214,12 @@ type GlobalExternalAuthConfig struct {
 	WithRequestBody *dag.AuthorizationServerBufferSettings
 }
 
+type GlobalExternalProcessingConfig struct {
+	ExtensionServiceConfig
+	FailOpen       bool
+	ProcessingMode *dag.ProcessingMode
+}
+
 // httpAccessLog returns the access log for the HTTP (non TLS)
 // listener or DEFAULT_HTTP_ACCESS_LOG if not configured.
 func (lvc *ListenerConfig) httpAccessLog() string {
@@ -275,6 +294,12 @@ func (lvc *ListenerConfig) maxTLSVersion() envoy_transport_socket_tls_v3.TlsPara
 	return envoy_transport_socket_tls_v3.TlsParameters_TLSv1_3
 }
 
+// http3Enabled returns true if HTTP/3 listeners should be
+// added alongside HTTPS listeners.
+func (lvc *ListenerConfig) http3Enabled() bool {
+	return lvc.HTTP3 != nil && lvc.HTTP3.Enabled
+}
+
 // ListenerCache manages the contents of the gRPC LDS cache.
 type ListenerCache struct {
 	mu           sync.Mutex
@@ -371,7 +396,27 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 		socketOptions = socketOptions.TOS(cfg.SocketOptions.TOS).TrafficClass(cfg.SocketOptions.TrafficClass)
 	}
 
+	// UDP sockets don't support TCP keepalives.
+	udpSocketOptions := envoy_v3.NewSocketOptions()
+	if cfg.SocketOptions != nil {
+		udpSocketOptions = udpSocketOptions.TOS(cfg.SocketOptions.TOS).TrafficClass(cfg.SocketOptions.TrafficClass)
+	}
+
 	for _, listener := range root.Listeners {
+		// A Listener-level UDPProxy proxies all datagrams
+		// received on the Listener port.
+		if listener.UDPProxy != nil {
+			listeners[listener.Name] = envoy_v3.UDPListener(
+				listener.Name,
+				listener.Address,
+				listener.Port,
+				udpSocketOptions,
+				envoy_v3.UDPProxy(listener.Name, listener.UDPProxy, cfg.newInsecureAccessLog()),
+			)
+
+			continue
+		}
+
 		// A Listener-level TCPProxy proxies all traffic for
 		// the Listener port, i.e. no filter chain match.
 		if listener.TCPProxy != nil {
@@ -394,6 +439,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 		if len(listener.VirtualHosts) > 0 {
 			cm := envoy_v3.HTTPConnectionManagerBuilder().
 				Codec(envoy_v3.CodecForVersions(cfg.DefaultHTTPVersions...)).
+				Compression(cfg.Compression).
 				DefaultFilters().
 				RouteConfigName(httpRouteConfigName(listener)).
 				MetricsPrefix(listener.Name).
@@ -411,6 +457,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 				MaxRequestsPerConnection(cfg.MaxRequestsPerConnection).
 				HTTP2MaxConcurrentStreams(cfg.HTTP2MaxConcurrentStreams).
 				AddFilter(httpGlobalExternalAuthConfig(cfg.GlobalExternalAuthConfig)).
+				AddFilter(httpGlobalExternalProcessingConfig(cfg.GlobalExternalProcessingConfig)).
 				Tracing(envoy_v3.TracingConfig(envoyTracingConfig(cfg.TracingConfig))).
 				AddFilter(envoy_v3.GlobalRateLimitFilter(envoyGlobalRateLimitConfig(cfg.RateLimitConfig))).
 				EnableWebsockets(listener.EnableWebsockets).
@@ -439,11 +486,24 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 				socketOptions,
 				secureProxyProtocol(cfg.UseProxyProto),
 			)
+
+			// If HTTP/3 is enabled, add a QUIC listener on the same
+			// port to which we will attach a filter chain per vhost
+			// that terminates TLS.
+			if cfg.http3Enabled() {
+				listeners[quicListenerName(listener.Name)] = envoy_v3.QUICListener(
+					quicListenerName(listener.Name),
+					listener.Address,
+					listener.Port,
+					udpSocketOptions,
+				)
+			}
 		}
 
 		for _, vh := range listener.SecureVirtualHosts {
 			var alpnProtos []string
 			var filters []*envoy_config_listener_v3.Filter
+			var quicFilters []*envoy_config_listener_v3.Filter
 
 			var forwardClientCertificate *dag.ClientCertificateDetails
 			if vh.DownstreamValidation != nil {
@@ -467,9 +527,18 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 				cm := envoy_v3.HTTPConnectionManagerBuilder().
 					Codec(envoy_v3.CodecForVersions(cfg.DefaultHTTPVersions...)).
 					AddFilter(envoy_v3.FilterMisdirectedRequests(vh.VirtualHost.Name)).
+					Compression(cfg.Compression).
 					DefaultFilters().
-					AddFilter(envoy_v3.FilterJWTAuthN(vh.JWTProviders)).
-					AddFilter(authzFilter).
+					AddFilter(envoy_v3.FilterOAuth2(vh.OAuth2)).
+					AddFilter(envoy_v3.FilterJWTAuthN(vh.JWTProviders))
+
+				for _, f := range basicAuthFilters(vh) {
+					cm.AddFilter(f)
+				}
+
+				cm.AddFilter(authzFilter).
+					AddFilter(envoy_v3.FilterExternalProcessing(vh.ExternalProcessing)).
+					AddFilter(altSvcFilter(cfg.HTTP3, listener)).
 					RouteConfigName(httpsRouteConfigName(listener, vh.VirtualHost.Name)).
 					MetricsPrefix(listener.Name).
 					AccessLoggers(cfg.newSecureAccessLog()).
@@ -488,12 +557,15 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 					ForwardClientCertificate(forwardClientCertificate).
 					MaxRequestsPerConnection(cfg.MaxRequestsPerConnection).
 					HTTP2MaxConcurrentStreams(cfg.HTTP2MaxConcurrentStreams).
-					EnableWebsockets(listener.EnableWebsockets).
-					Get()
+					EnableWebsockets(listener.EnableWebsockets)
 
-				filters = envoy_v3.Filters(cm)
+				filters = envoy_v3.Filters(cm.Get())
 
 				alpnProtos = envoy_v3.ProtoNamesForVersions(cfg.DefaultHTTPVersions...)
+
+				if cfg.http3Enabled() {
+					quicFilters = envoy_v3.Filters(cm.Codec(envoy_v3.HTTPVersion3).Get())
+				}
 			} else {
 				filters = envoy_v3.Filters(envoy_v3.TCPProxy(listener.Name, vh.TCPProxy, cfg.newSecureAccessLog()))
 
@@ -526,6 +598,21 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 
 			listeners[listener.Name].FilterChains = append(listeners[listener.Name].FilterChains, envoy_v3.FilterChainTLS(vh.VirtualHost.Name, downstreamTLS, filters))
 
+			// Serve the same vhost over HTTP/3. QUIC requires TLS 1.3,
+			// so the configured TLS versions are not applied here.
+			if len(quicFilters) > 0 && vh.Secret != nil {
+				quicTLS := envoy_v3.DownstreamTLSContext(
+					vh.Secret,
+					envoy_transport_socket_tls_v3.TlsParameters_TLSv1_3,
+					envoy_transport_socket_tls_v3.TlsParameters_TLSv1_3,
+					nil,
+					vh.DownstreamValidation,
+					"h3")
+
+				quicListener := listeners[quicListenerName(listener.Name)]
+				quicListener.FilterChains = append(quicListener.FilterChains, envoy_v3.FilterChainQUIC(vh.VirtualHost.Name, quicTLS, quicFilters))
+			}
+
 			// If this VirtualHost has enabled the fallback certificate then set a default
 			// FilterChain which will allow routes with this vhost to accept non-SNI TLS requests.
 			// Note that we don't add the misdirected requests filter on this chain because at this
@@ -549,8 +636,10 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 				}
 
 				cm := envoy_v3.HTTPConnectionManagerBuilder().
+					Compression(cfg.Compression).
 					DefaultFilters().
 					AddFilter(authzFilter).
+					AddFilter(envoy_v3.FilterExternalProcessing(vh.ExternalProcessing)).
 					RouteConfigName(fallbackCertRouteConfigName(listener)).
 					MetricsPrefix(listener.Name).
 					AccessLoggers(cfg.newSecureAccessLog()).
@@ -587,6 +676,16 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 			// to ensure that the LDS entries are identical.
 			sort.Stable(sorter.For(listener.FilterChains))
 		}
+
+		// Likewise for the QUIC listener, which has no filter chains
+		// if all the vhosts bound to the https listener use TLS passthrough.
+		if quic := listeners[quicListenerName(listener.Name)]; quic != nil {
+			if len(quic.FilterChains) == 0 {
+				delete(listeners, quic.Name)
+			} else {
+				sort.Stable(sorter.For(quic.FilterChains))
+			}
+		}
 	}
 
 	// support more params of envoy listener
@@ -605,6 +704,45 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 	c.Update(listeners)
 }
 
+// quicListenerName returns the name of the HTTP/3 listener
+// paired with the named HTTPS listener.
+func quicListenerName(name string) string {
+	return name + "_quic"
+}
+
+// basicAuthFilters returns the `basic_auth` filters of the given
+// virtual host: the filter of the virtual host itself, followed by
+// a disabled filter per Secret that routes override it with.
+func basicAuthFilters(vh *dag.SecureVirtualHost) []*envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	if vh.BasicAuthSecret == nil {
+		return nil
+	}
+
+	filters := []*envoy_filter_network_http_connection_manager_v3.HttpFilter{
+		envoy_v3.FilterBasicAuth(envoy_v3.BasicAuthConfigName(vh.VirtualHost.Name), false),
+	}
+	for _, secret := range basicAuthOverrideSecrets(vh) {
+		filters = append(filters, envoy_v3.FilterBasicAuth(envoy_v3.BasicAuthOverrideConfigName(secret), true))
+	}
+
+	return filters
+}
+
+// altSvcFilter returns the filter advertising the HTTP/3 listener
+// paired with listener. Gateway listeners advertise the port of
+// the Gateway listener, since each one is exposed on its own port.
+func altSvcFilter(config *contour_v1alpha1.EnvoyHTTP3, listener *dag.Listener) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	if listener.ServicePort > 0 {
+		return envoy_v3.FilterAltSvc(uint32(listener.ServicePort))
+	}
+
+	return envoy_v3.FilterAltSvc(ptr.Deref(config.AdvertisedPort, 443))
+}
+
 func httpGlobalExternalAuthConfig(config *GlobalExternalAuthConfig) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
 	if config == nil {
 		return nil
@@ -621,6 +759,22 @@ func httpGlobalExternalAuthConfig(config *GlobalExternalAuthConfig) *envoy_filte
 	})
 }
 
+func httpGlobalExternalProcessingConfig(config *GlobalExternalProcessingConfig) *envoy_filter_network_http_connection_manager_v3.HttpFilter {
+	if config == nil {
+		return nil
+	}
+
+	return envoy_v3.FilterExternalProcessing(&dag.ExternalProcessing{
+		ProcessingService: &dag.ExtensionCluster{
+			Name: dag.ExtensionClusterName(config.ExtensionServiceConfig.ExtensionService),
+			SNI:  config.ExtensionServiceConfig.SNI,
+		},
+		ResponseTimeout: config.ExtensionServiceConfig.Timeout,
+		FailOpen:        config.FailOpen,
+		ProcessingMode:  config.ProcessingMode,
+	})
+}
+
 func envoyGlobalRateLimitConfig(config *RateLimitConfig) *envoy_v3.GlobalRateLimitConfig {
 	if config == nil {
 		return nil
@@ -3246,6 +3246,46 @@ func TestListenerVisit(t *testing.T) {
 	}
 }
 
+func TestAltSvcFilter(t *testing.T) {
+	tests := map[string]struct {
+		config   *contour_v1alpha1.EnvoyHTTP3
+		listener *dag.Listener
+		want     *envoy_filter_network_http_connection_manager_v3.HttpFilter
+	}{
+		"HTTP/3 disabled": {
+			config:   &contour_v1alpha1.EnvoyHTTP3{},
+			listener: &dag.Listener{Name: ENVOY_HTTPS_LISTENER, Port: 8443},
+			want:     nil,
+		},
+		"default advertised port": {
+			config:   &contour_v1alpha1.EnvoyHTTP3{Enabled: true},
+			listener: &dag.Listener{Name: ENVOY_HTTPS_LISTENER, Port: 8443},
+			want:     envoy_v3.FilterAltSvc(443),
+		},
+		"configured advertised port": {
+			config:   &contour_v1alpha1.EnvoyHTTP3{Enabled: true, AdvertisedPort: ptr.To(uint32(8443))},
+			listener: &dag.Listener{Name: ENVOY_HTTPS_LISTENER, Port: 8443},
+			want:     envoy_v3.FilterAltSvc(8443),
+		},
+		"Gateway listener advertises its own port": {
+			config:   &contour_v1alpha1.EnvoyHTTP3{Enabled: true, AdvertisedPort: ptr.To(uint32(8443))},
+			listener: &dag.Listener{Name: "https-9443", Port: 9443, ServicePort: 9443},
+			want:     envoy_v3.FilterAltSvc(9443),
+		},
+		"Gateway listener on a privileged port": {
+			config:   &contour_v1alpha1.EnvoyHTTP3{Enabled: true},
+			listener: &dag.Listener{Name: "https-444", Port: 8444, ServicePort: 444},
+			want:     envoy_v3.FilterAltSvc(444),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.want, altSvcFilter(tc.config, tc.listener))
+		})
+	}
+}
+
 func transportSocket(secretName string, tlsMinProtoVersion, tlsMaxProtoVersion envoy_transport_socket_tls_v3.TlsParameters_TlsProtocol, cipherSuites []string, alpnprotos ...string) *envoy_config_core_v3.TransportSocket {
 	secret := &dag.Secret{
 		Object: &core_v1.Secret{
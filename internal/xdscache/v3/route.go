@@ -115,7 +115,7 @@ func (c *RouteCache) OnChange(root *dag.DAG) {
 				for _, route := range vhost.Routes {
 					routes = append(routes, route)
 				}
-				sortRoutes(routes)
+				SortRoutes(routes)
 
 				routeConfigs[routeConfigName].VirtualHosts = append(routeConfigs[routeConfigName].VirtualHosts,
 					envoy_v3.VirtualHostAndRoutes(vhost, routes, false),
@@ -140,7 +140,7 @@ func (c *RouteCache) OnChange(root *dag.DAG) {
 				for _, route := range vhost.Routes {
 					routes = append(routes, route)
 				}
-				sortRoutes(routes)
+				SortRoutes(routes)
 
 				routeConfigs[routeConfigName].VirtualHosts = append(routeConfigs[routeConfigName].VirtualHosts,
 					envoy_v3.VirtualHostAndRoutes(&vhost.VirtualHost, routes, true))
@@ -169,7 +169,8 @@ func (c *RouteCache) OnChange(root *dag.DAG) {
 	c.Update(routeConfigs)
 }
 
-// sortRoutes sorts the given Route slice in place. Routes are ordered
+// SortRoutes sorts the given Route slice in place, along with the
+// header and query param match conditions of each route. Routes are ordered
 // first by path match type, path match value via string comparison and
 // then by the header and query param match conditions.
 // We sort dag.Route objects before converting to Envoy types to ensure
@@ -180,7 +181,7 @@ func (c *RouteCache) OnChange(root *dag.DAG) {
 // Contour types instead ensures we can sort from most to least specific
 // route match regardless of the underlying Envoy type that is used to
 // implement the match.
-func sortRoutes(routes []*dag.Route) {
+func SortRoutes(routes []*dag.Route) {
 	for _, r := range routes {
 		sort.Stable(sorter.For(r.HeaderMatchConditions))
 		sort.Stable(sorter.For(r.QueryParamMatchConditions))
@@ -3964,7 +3964,7 @@ func TestSortLongestRouteFirst(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			got := append([]*dag.Route{}, tc.routes...) // shallow copy
-			sortRoutes(got)
+			SortRoutes(got)
 			assert.Equal(t, tc.want, got)
 		})
 	}
@@ -105,5 +105,14 @@ func (c *SecretCache) OnChange(root *dag.DAG) {
 		}
 	}
 
+	for _, secret := range root.GetOAuth2Secrets() {
+		for _, key := range []string{dag.OAuth2ClientSecretKey, dag.OAuth2HMACSecretKey} {
+			name := envoy.OAuth2SecretName(secret, key)
+			if _, ok := secrets[name]; !ok {
+				secrets[name] = envoy_v3.OAuth2Secret(secret, key)
+			}
+		}
+	}
+
 	c.Update(secrets)
 }
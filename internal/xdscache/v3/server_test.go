@@ -22,6 +22,7 @@ import (
 	envoy_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	envoy_service_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	envoy_service_extension_v3 "github.com/envoyproxy/go-control-plane/envoy/service/extension/v3"
 	envoy_service_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
 	envoy_service_route_v3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	envoy_service_runtime_v3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
@@ -221,6 +222,16 @@ func TestGRPC(t *testing.T) {
 			checkrecv(t, stream)                    // check we receive one notification
 			checktimeout(t, stream)                 // check that the second receive times out
 		},
+		"StreamExtensionConfigs": func(t *testing.T, cc *grpc.ClientConn) {
+			ecds := envoy_service_extension_v3.NewExtensionConfigDiscoveryServiceClient(cc)
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			stream, err := ecds.StreamExtensionConfigs(ctx)
+			require.NoError(t, err)
+			sendreq(t, stream, resource.ExtensionConfigType) // send initial notification
+			checkrecv(t, stream)                             // check we receive one notification
+			checktimeout(t, stream)                          // check that the second receive times out
+		},
 		"StreamRuntime": func(t *testing.T, cc *grpc.ClientConn) {
 			rtds := envoy_service_runtime_v3.NewRuntimeDiscoveryServiceClient(cc)
 			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
@@ -242,6 +253,7 @@ func TestGRPC(t *testing.T) {
 			resources := []xdscache.ResourceCache{
 				&ListenerCache{},
 				&SecretCache{},
+				&ExtensionConfigCache{},
 				&RouteCache{},
 				&ClusterCache{},
 				est,
@@ -447,6 +447,34 @@ type ClusterParameters struct {
 
 	// UpstreamTLS contains the TLS policy parameters for upstream connections
 	UpstreamTLS ProtocolParameters `yaml:"upstream-tls,omitempty"`
+
+	// TopologyAwareRouting configures Envoy to prefer upstream
+	// endpoints in its own zone.
+	TopologyAwareRouting TopologyAwareRoutingParameters `yaml:"topology-aware-routing,omitempty"`
+
+	// SubsetLoadBalancing allows HTTPProxy services to select a
+	// subset of their endpoints by Pod label.
+	SubsetLoadBalancing SubsetLoadBalancingParameters `yaml:"subset-load-balancing,omitempty"`
+}
+
+// TopologyAwareRoutingParameters holds zone aware routing settings.
+type TopologyAwareRoutingParameters struct {
+	// Enabled publishes the locality of each endpoint to Envoy and
+	// enables Envoy zone aware routing.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// MinClusterSize is the minimum number of endpoints an upstream
+	// cluster needs for zone aware routing to be used. Defaults to 6.
+	//
+	// +optional
+	MinClusterSize *uint32 `yaml:"min-cluster-size,omitempty"`
+}
+
+// SubsetLoadBalancingParameters holds subset load balancing settings.
+type SubsetLoadBalancingParameters struct {
+	// Enabled publishes the Pod labels used by HTTPProxy service
+	// subsets as endpoint metadata to Envoy.
+	Enabled bool `yaml:"enabled,omitempty"`
 }
 
 func (p *ClusterParameters) Validate() error {
@@ -528,6 +556,66 @@ type ListenerParameters struct {
 	//
 	// +optional
 	MaxConnectionsPerListener *uint32 `yaml:"max-connections-per-listener,omitempty"`
+
+	// Compression defines the response compression settings applied to
+	// HTTP listeners.
+	//
+	// +optional
+	Compression *CompressionParameters `yaml:"compression,omitempty"`
+
+	// HTTP3 configures HTTP/3 (QUIC) listeners.
+	HTTP3 HTTP3Parameters `yaml:"http3,omitempty"`
+}
+
+// HTTP3Parameters holds HTTP/3 (QUIC) listener settings.
+type HTTP3Parameters struct {
+	// Enabled adds an HTTP/3 listener on UDP for each HTTPS listener.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// AdvertisedPort is the UDP port advertised to clients in the
+	// alt-svc response header. Defaults to 443.
+	//
+	// +optional
+	AdvertisedPort *uint32 `yaml:"advertised-port,omitempty"`
+}
+
+func (h HTTP3Parameters) Validate() error {
+	if h.AdvertisedPort != nil && (*h.AdvertisedPort < 1 || *h.AdvertisedPort > 65535) {
+		return fmt.Errorf("invalid HTTP/3 advertised port %d", *h.AdvertisedPort)
+	}
+
+	return nil
+}
+
+// CompressionParameters holds response compression settings.
+type CompressionParameters struct {
+	// Algorithms is the list of compression algorithms enabled on the
+	// listeners, in order of preference. Valid options are 'gzip',
+	// 'brotli' and 'zstd'. When empty, only gzip is enabled.
+	Algorithms []string `yaml:"algorithms,omitempty"`
+
+	// MinContentLength is the minimum response length, in bytes, for a
+	// response to be compressed.
+	//
+	// +optional
+	MinContentLength *uint32 `yaml:"min-content-length,omitempty"`
+
+	// ContentTypes is the list of response content types eligible for
+	// compression. When empty, Envoy's default content types are used.
+	ContentTypes []string `yaml:"content-types,omitempty"`
+}
+
+func (c *CompressionParameters) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	compression := contour_v1alpha1.EnvoyCompression{}
+	for _, a := range c.Algorithms {
+		compression.Algorithms = append(compression.Algorithms, contour_v1alpha1.CompressionAlgorithm(a))
+	}
+
+	return compression.Validate()
 }
 
 func (p *ListenerParameters) Validate() error {
@@ -559,6 +647,14 @@ func (p *ListenerParameters) Validate() error {
 		return fmt.Errorf("invalid max connections per listener value %q set on listener, minimum value is 1", *p.MaxConnectionsPerListener)
 	}
 
+	if err := p.Compression.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.HTTP3.Validate(); err != nil {
+		return err
+	}
+
 	return p.SocketOptions.Validate()
 }
 
@@ -659,7 +755,7 @@ type Parameters struct {
 	// Contour's default is overwrite.
 	ServerHeaderTransformation ServerHeaderTransformationType `yaml:"serverHeaderTransformation,omitempty"`
 
-	// EnableExternalNameService allows processing of ExternalNameServices
+	// EnableExternalNameService allows processing of ExternalNameServices and Backends
 	// Defaults to disabled for security reasons.
 	// TODO(youngnick): put a link to the issue and CVE here.
 	EnableExternalNameService bool `yaml:"enableExternalNameService,omitempty"`
@@ -702,6 +798,9 @@ type Parameters struct {
 	// GlobalExternalAuthorization optionally holds properties of the global external authorization configuration.
 	GlobalExternalAuthorization GlobalExternalAuthorization `yaml:"globalExtAuth,omitempty"`
 
+	// GlobalExternalProcessing optionally holds properties of the global external processing configuration.
+	GlobalExternalProcessing GlobalExternalProcessing `yaml:"globalExtProc,omitempty"`
+
 	// MetricsParameters holds configurable parameters for Contour and Envoy metrics.
 	Metrics MetricsParameters `yaml:"metrics,omitempty"`
 
@@ -822,6 +921,48 @@ type GlobalAuthorizationPolicy struct {
 	Context map[string]string `yaml:"context,omitempty"`
 }
 
+// GlobalExternalProcessing defines properties of global external processing.
+type GlobalExternalProcessing struct {
+	// ExtensionService identifies the extension service defining the
+	// external processing server, formatted as <namespace>/<name>.
+	ExtensionService string `yaml:"extensionService,omitempty"`
+	// ResponseTimeout configures maximum time to wait for each message from the external processing server.
+	// Timeout durations are expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// The string "infinity" is also a valid input and specifies no timeout.
+	//
+	// +optional
+	ResponseTimeout string `yaml:"responseTimeout,omitempty"`
+	// If FailOpen is true, the client request is forwarded to the upstream service
+	// even if the external processing server fails to respond.
+	//
+	// +optional
+	FailOpen bool `yaml:"failOpen,omitempty"`
+	// ProcessingMode sets which parts of requests and responses are sent to
+	// the external processing server. This mode will be used unless overridden
+	// by individual routes.
+	//
+	// +optional
+	ProcessingMode *GlobalProcessingMode `yaml:"processingMode,omitempty"`
+}
+
+// GlobalProcessingMode sets which parts of requests and responses are sent
+// to the external processing server.
+type GlobalProcessingMode struct {
+	// RequestHeaderMode sets how request headers are sent.
+	// Values are Default, Send or Skip.
+	RequestHeaderMode string `yaml:"requestHeaderMode,omitempty"`
+	// ResponseHeaderMode sets how response headers are sent.
+	// Values are Default, Send or Skip.
+	ResponseHeaderMode string `yaml:"responseHeaderMode,omitempty"`
+	// RequestBodyMode sets how request bodies are sent.
+	// Values are None, Streamed, Buffered or BufferedPartial.
+	RequestBodyMode string `yaml:"requestBodyMode,omitempty"`
+	// ResponseBodyMode sets how response bodies are sent.
+	// Values are None, Streamed, Buffered or BufferedPartial.
+	ResponseBodyMode string `yaml:"responseBodyMode,omitempty"`
+}
+
 // RateLimitService defines properties of a global Rate Limit Service.
 type RateLimitService struct {
 	// ExtensionService identifies the extension service defining the RLS,
@@ -934,6 +1075,37 @@ func (t *Tracing) Validate() error {
 	return nil
 }
 
+// Validate ensures that the processing modes are valid.
+func (g GlobalExternalProcessing) Validate() error {
+	if g.ProcessingMode == nil {
+		return nil
+	}
+
+	for field, mode := range map[string]string{
+		"requestHeaderMode":  g.ProcessingMode.RequestHeaderMode,
+		"responseHeaderMode": g.ProcessingMode.ResponseHeaderMode,
+	} {
+		switch contour_v1.HeaderSendMode(mode) {
+		case "", contour_v1.HeaderSendModeDefault, contour_v1.HeaderSendModeSend, contour_v1.HeaderSendModeSkip:
+		default:
+			return fmt.Errorf("invalid globalExtProc.processingMode.%s %q", field, mode)
+		}
+	}
+
+	for field, mode := range map[string]string{
+		"requestBodyMode":  g.ProcessingMode.RequestBodyMode,
+		"responseBodyMode": g.ProcessingMode.ResponseBodyMode,
+	} {
+		switch contour_v1.BodySendMode(mode) {
+		case "", contour_v1.BodySendModeNone, contour_v1.BodySendModeStreamed, contour_v1.BodySendModeBuffered, contour_v1.BodySendModeBufferedPartial:
+		default:
+			return fmt.Errorf("invalid globalExtProc.processingMode.%s %q", field, mode)
+		}
+	}
+
+	return nil
+}
+
 func (p *MetricsServerParameters) Validate() error {
 	// Check that both certificate and key are provided if either one is provided.
 	if (p.ServerCert != "") != (p.ServerKey != "") {
@@ -1022,6 +1194,10 @@ func (p *Parameters) Validate() error {
 		return err
 	}
 
+	if err := p.GlobalExternalProcessing.Validate(); err != nil {
+		return err
+	}
+
 	if err := p.Cluster.Validate(); err != nil {
 		return err
 	}
@@ -495,6 +495,36 @@ listener:
   max-connections-per-listener: 1
 `)
 
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, &CompressionParameters{
+			Algorithms:       []string{"brotli", "gzip"},
+			MinContentLength: ptr.To(uint32(1024)),
+			ContentTypes:     []string{"text/html", "application/json"},
+		}, conf.Listener.Compression)
+	}, `
+listener:
+  compression:
+    algorithms:
+    - brotli
+    - gzip
+    min-content-length: 1024
+    content-types:
+    - text/html
+    - application/json
+`)
+
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, HTTP3Parameters{
+			Enabled:        true,
+			AdvertisedPort: ptr.To(uint32(443)),
+		}, conf.Listener.HTTP3)
+	}, `
+listener:
+  http3:
+    enabled: true
+    advertised-port: 443
+`)
+
 	check(func(t *testing.T, conf *Parameters) {
 		assert.Equal(t, ptr.To(uint32(1)), conf.Cluster.MaxRequestsPerConnection)
 	}, `
@@ -502,6 +532,26 @@ cluster:
   max-requests-per-connection: 1
 `)
 
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, TopologyAwareRoutingParameters{
+			Enabled:        true,
+			MinClusterSize: ptr.To(uint32(3)),
+		}, conf.Cluster.TopologyAwareRouting)
+	}, `
+cluster:
+  topology-aware-routing:
+    enabled: true
+    min-cluster-size: 3
+`)
+
+	check(func(t *testing.T, conf *Parameters) {
+		assert.True(t, conf.Cluster.SubsetLoadBalancing.Enabled)
+	}, `
+cluster:
+  subset-load-balancing:
+    enabled: true
+`)
+
 	check(func(t *testing.T, conf *Parameters) {
 		assert.Equal(t, uint32(42), conf.Cluster.GlobalCircuitBreakerDefaults.MaxConnections)
 		assert.Equal(t, uint32(43), conf.Cluster.GlobalCircuitBreakerDefaults.MaxPendingRequests)
@@ -643,6 +693,40 @@ func TestListenerValidation(t *testing.T) {
 		MaxConnectionsPerListener: ptr.To(uint32(0)),
 	}
 	require.Error(t, l.Validate())
+
+	l = &ListenerParameters{
+		Compression: &CompressionParameters{
+			Algorithms: []string{"brotli", "gzip"},
+		},
+	}
+	require.NoError(t, l.Validate())
+	l = &ListenerParameters{
+		Compression: &CompressionParameters{
+			Algorithms: []string{"deflate"},
+		},
+	}
+	require.Error(t, l.Validate())
+	l = &ListenerParameters{
+		Compression: &CompressionParameters{
+			Algorithms: []string{"zstd", "zstd"},
+		},
+	}
+	require.Error(t, l.Validate())
+
+	l = &ListenerParameters{
+		HTTP3: HTTP3Parameters{
+			Enabled:        true,
+			AdvertisedPort: ptr.To(uint32(443)),
+		},
+	}
+	require.NoError(t, l.Validate())
+	l = &ListenerParameters{
+		HTTP3: HTTP3Parameters{
+			Enabled:        true,
+			AdvertisedPort: ptr.To(uint32(70000)),
+		},
+	}
+	require.Error(t, l.Validate())
 }
 
 func TestClusterParametersValidation(t *testing.T) {
@@ -747,3 +831,33 @@ func TestTracingConfigValidation(t *testing.T) {
 	}
 	require.Error(t, trace.Validate())
 }
+
+func TestGlobalExternalProcessingValidation(t *testing.T) {
+	extProc := GlobalExternalProcessing{}
+	require.NoError(t, extProc.Validate())
+
+	extProc = GlobalExternalProcessing{
+		ExtensionService: "projectcontour/dlp",
+		ProcessingMode: &GlobalProcessingMode{
+			RequestHeaderMode: "Send",
+			RequestBodyMode:   "Buffered",
+		},
+	}
+	require.NoError(t, extProc.Validate())
+
+	extProc = GlobalExternalProcessing{
+		ExtensionService: "projectcontour/dlp",
+		ProcessingMode: &GlobalProcessingMode{
+			ResponseHeaderMode: "Always",
+		},
+	}
+	require.Error(t, extProc.Validate())
+
+	extProc = GlobalExternalProcessing{
+		ExtensionService: "projectcontour/dlp",
+		ProcessingMode: &GlobalProcessingMode{
+			ResponseBodyMode: "All",
+		},
+	}
+	require.Error(t, extProc.Validate())
+}
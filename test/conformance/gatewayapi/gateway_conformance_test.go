@@ -138,7 +138,8 @@ func TestGatewayConformance(t *testing.T) {
 		// exclude tests we don't want to run using the ExemptFeatures
 		// field.
 		options.EnableAllSupportedFeatures = false
-		options.SupportedFeatures = features.AllFeatures.Delete(append(features.MeshCoreFeatures.UnsortedList(), features.UDPRouteFeatures.UnsortedList()...)...)
+		options.SupportedFeatures = features.SetsToNamesSet(features.AllFeatures).
+			Delete(features.SetsToNamesSet(features.MeshCoreFeatures, features.MeshExtendedFeatures).UnsortedList()...)
 	}
 
 	conformance.RunConformanceWithOptions(t, options)